@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"time"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/prompb"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// toMetrics converts a remote write request to metrics. Series become data
+// points of the metric named after them, with their labels as attributes.
+// Series of counters, according to the metadata of the request, become sums
+// and the others gauges, or exponential histograms for native histograms.
+func toMetrics(req *prompb.WriteRequest) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	metrics := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+
+	metadata := make(map[string]prompb.MetricMetadata, len(req.Metadata))
+	for _, m := range req.Metadata {
+		metadata[m.MetricFamilyName] = m
+	}
+
+	byName := make(map[string]pmetric.Metric)
+	for _, ts := range req.Timeseries {
+		attrs := pcommon.NewMap()
+		var name string
+		for _, l := range ts.Labels {
+			if l.Name == labels.MetricName {
+				name = l.Value
+				continue
+			}
+			attrs.PutStr(l.Name, l.Value)
+		}
+
+		m, ok := byName[name]
+		if !ok {
+			m = newMetric(metrics, name, metadata[name], len(ts.Histograms) > 0)
+			byName[name] = m
+		}
+
+		switch m.Type() {
+		case pmetric.MetricTypeSum:
+			addSamples(m.Sum().DataPoints(), ts.Samples, attrs)
+		case pmetric.MetricTypeGauge:
+			addSamples(m.Gauge().DataPoints(), ts.Samples, attrs)
+		case pmetric.MetricTypeExponentialHistogram:
+			addHistograms(m.ExponentialHistogram().DataPoints(), ts.Histograms, attrs)
+		}
+	}
+	return md
+}
+
+func newMetric(metrics pmetric.MetricSlice, name string, metadata prompb.MetricMetadata, histogram bool) pmetric.Metric {
+	m := metrics.AppendEmpty()
+	m.SetName(name)
+	m.SetDescription(metadata.Help)
+	m.SetUnit(metadata.Unit)
+
+	switch {
+	case histogram:
+		m.SetEmptyExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	case metadata.Type == prompb.MetricMetadata_COUNTER:
+		sum := m.SetEmptySum()
+		sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		sum.SetIsMonotonic(true)
+	default:
+		m.SetEmptyGauge()
+	}
+	return m
+}
+
+func addSamples(dps pmetric.NumberDataPointSlice, samples []prompb.Sample, attrs pcommon.Map) {
+	for _, s := range samples {
+		dp := dps.AppendEmpty()
+		attrs.CopyTo(dp.Attributes())
+		dp.SetTimestamp(timestamp(s.Timestamp))
+		dp.SetDoubleValue(s.Value)
+	}
+}
+
+func addHistograms(dps pmetric.ExponentialHistogramDataPointSlice, histograms []prompb.Histogram, attrs pcommon.Map) {
+	for _, h := range histograms {
+		dp := dps.AppendEmpty()
+		attrs.CopyTo(dp.Attributes())
+		dp.SetTimestamp(timestamp(h.Timestamp))
+		dp.SetScale(h.Schema)
+		dp.SetSum(h.Sum)
+		dp.SetZeroThreshold(h.ZeroThreshold)
+		if h.IsFloatHistogram() {
+			dp.SetCount(uint64(h.GetCountFloat()))
+			dp.SetZeroCount(uint64(h.GetZeroCountFloat()))
+		} else {
+			dp.SetCount(h.GetCountInt())
+			dp.SetZeroCount(h.GetZeroCountInt())
+		}
+		buckets(dp.Positive(), h.PositiveSpans, h.PositiveDeltas, h.PositiveCounts)
+		buckets(dp.Negative(), h.NegativeSpans, h.NegativeDeltas, h.NegativeCounts)
+	}
+}
+
+// buckets sets the buckets of a native histogram, given as delta encoded
+// counts of integer histograms or absolute counts of float histograms, to
+// dest. The upper bound of bucket i is base^i in Prometheus, but base^(i+1) in
+// OpenTelemetry.
+func buckets(dest pmetric.ExponentialHistogramDataPointBuckets, spans []prompb.BucketSpan, deltas []int64, counts []float64) {
+	if len(spans) == 0 {
+		return
+	}
+	dest.SetOffset(spans[0].Offset - 1)
+
+	var raw []uint64
+	var count int64
+	n := 0
+	for i, span := range spans {
+		if i > 0 {
+			for range span.Offset {
+				raw = append(raw, 0)
+			}
+		}
+		for range span.Length {
+			switch {
+			case n < len(deltas):
+				count += deltas[n]
+				raw = append(raw, uint64(count))
+			case n < len(counts):
+				raw = append(raw, uint64(counts[n]))
+			}
+			n++
+		}
+	}
+	dest.BucketCounts().FromRaw(raw)
+}
+
+func timestamp(ms int64) pcommon.Timestamp {
+	return pcommon.NewTimestampFromTime(time.UnixMilli(ms))
+}
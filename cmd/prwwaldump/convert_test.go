@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"testing"
+
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestToMetrics(t *testing.T) {
+	req := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			{
+				Labels:  []prompb.Label{{Name: "__name__", Value: "requests_total"}, {Name: "job", Value: "a"}},
+				Samples: []prompb.Sample{{Value: 1, Timestamp: 1000}, {Value: 2, Timestamp: 2000}},
+			},
+			{
+				Labels:  []prompb.Label{{Name: "__name__", Value: "requests_total"}, {Name: "job", Value: "b"}},
+				Samples: []prompb.Sample{{Value: 3, Timestamp: 1000}},
+			},
+			{
+				Labels:  []prompb.Label{{Name: "__name__", Value: "temperature"}},
+				Samples: []prompb.Sample{{Value: 21.5, Timestamp: 1000}},
+			},
+			{
+				Labels: []prompb.Label{{Name: "__name__", Value: "latency"}},
+				Histograms: []prompb.Histogram{{
+					Count:          &prompb.Histogram_CountInt{CountInt: 7},
+					Sum:            12,
+					Schema:         1,
+					ZeroThreshold:  0.001,
+					ZeroCount:      &prompb.Histogram_ZeroCountInt{ZeroCountInt: 1},
+					PositiveSpans:  []prompb.BucketSpan{{Offset: -1, Length: 2}, {Offset: 1, Length: 1}},
+					PositiveDeltas: []int64{1, 2, -1},
+					NegativeSpans:  []prompb.BucketSpan{{Offset: 2, Length: 1}},
+					NegativeDeltas: []int64{1},
+					Timestamp:      1000,
+				}},
+			},
+		},
+		Metadata: []prompb.MetricMetadata{
+			{MetricFamilyName: "requests_total", Type: prompb.MetricMetadata_COUNTER, Help: "Requests", Unit: "1"},
+		},
+	}
+
+	md := toMetrics(req)
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 3, metrics.Len())
+
+	requests := metrics.At(0)
+	assert.Equal(t, "requests_total", requests.Name())
+	assert.Equal(t, "Requests", requests.Description())
+	require.Equal(t, pmetric.MetricTypeSum, requests.Type())
+	assert.True(t, requests.Sum().IsMonotonic())
+	dps := requests.Sum().DataPoints()
+	require.Equal(t, 3, dps.Len())
+	assert.Equal(t, 2.0, dps.At(1).DoubleValue())
+	assert.Equal(t, int64(2000), dps.At(1).Timestamp().AsTime().UnixMilli())
+	job, _ := dps.At(2).Attributes().Get("job")
+	assert.Equal(t, "b", job.Str())
+	_, ok := dps.At(2).Attributes().Get("__name__")
+	assert.False(t, ok)
+
+	temperature := metrics.At(1)
+	require.Equal(t, pmetric.MetricTypeGauge, temperature.Type())
+	assert.Equal(t, 21.5, temperature.Gauge().DataPoints().At(0).DoubleValue())
+
+	latency := metrics.At(2)
+	require.Equal(t, pmetric.MetricTypeExponentialHistogram, latency.Type())
+	dp := latency.ExponentialHistogram().DataPoints().At(0)
+	assert.Equal(t, uint64(7), dp.Count())
+	assert.Equal(t, 12.0, dp.Sum())
+	assert.Equal(t, int32(1), dp.Scale())
+	assert.Equal(t, uint64(1), dp.ZeroCount())
+	assert.Equal(t, 0.001, dp.ZeroThreshold())
+	assert.Equal(t, int32(-2), dp.Positive().Offset())
+	assert.Equal(t, []uint64{1, 3, 0, 2}, dp.Positive().BucketCounts().AsRaw())
+	assert.Equal(t, int32(1), dp.Negative().Offset())
+	assert.Equal(t, []uint64{1}, dp.Negative().BucketCounts().AsRaw())
+}
@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Command prwwaldump writes the requests pending in the write-ahead-log of the
+// Prometheus remote write exporter to stdout as OTLP JSON, one line per
+// request. It reads the segment files of the WAL without modifying them, so
+// it can also inspect a corrupted WAL.
+//
+// The exporter does not store a read index: it removes the requests it sent
+// from the front of the WAL and sends all requests left in it on start. By
+// default all entries are written, which are the requests the exporter sends
+// on its next start. As the WAL never becomes empty, its last entry may have
+// been sent already if nothing is pending. The -from flag skips the entries
+// before an index, the index of the first entry of a segment is its file
+// name.
+package main // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/prwwaldump"
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/prometheus/prometheus/prompb"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/prwwal"
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [-from index] <wal directory>\n\n", os.Args[0])
+		fmt.Fprintln(flag.CommandLine.Output(), "Writes the requests pending in the WAL as OTLP JSON lines to stdout.")
+		fmt.Fprintln(flag.CommandLine.Output(), "The directory is the configured wal::directory, or the WAL partition of a tenant below it.")
+		fmt.Fprintln(flag.CommandLine.Output())
+		flag.PrintDefaults()
+	}
+	from := flag.Uint64("from", 0, "index of the first entry to write, the entries before it are skipped. All entries are written by default")
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := dump(flag.Arg(0), *from, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// dump writes the requests of the WAL in dir starting at index from to w. The
+// entries before a corruption are written before the corruption is returned.
+func dump(dir string, from uint64, w io.Writer) error {
+	if _, err := os.Stat(filepath.Join(dir, prwwal.Dir)); err == nil {
+		dir = filepath.Join(dir, prwwal.Dir)
+	}
+	segments, err := prwwal.List(dir)
+	if err != nil {
+		return fmt.Errorf("failed to list WAL segments: %w", err)
+	}
+	if len(segments) == 0 {
+		return errors.New("no WAL segments found in " + dir)
+	}
+
+	marshaler := &pmetric.JSONMarshaler{}
+	for _, s := range segments {
+		entries, _, errEntries := s.Entries()
+		for i, entry := range entries {
+			index := s.Index + uint64(i)
+			if index < from {
+				continue
+			}
+			req := new(prompb.WriteRequest)
+			if err := proto.Unmarshal(entry, req); err != nil {
+				return fmt.Errorf("failed to decode WAL entry %d: %w", index, err)
+			}
+			buf, err := marshaler.MarshalMetrics(toMetrics(req))
+			if err != nil {
+				return err
+			}
+			if _, err := w.Write(append(buf, '\n')); err != nil {
+				return err
+			}
+		}
+		if errEntries != nil {
+			return errEntries
+		}
+	}
+	return nil
+}
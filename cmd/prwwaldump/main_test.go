@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/wal"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/prwwal"
+)
+
+func TestDump(t *testing.T) {
+	dir := t.TempDir()
+	log, err := wal.Open(filepath.Join(dir, prwwal.Dir), nil)
+	require.NoError(t, err)
+	for i := 1; i <= 2; i++ {
+		buf, err := proto.Marshal(&prompb.WriteRequest{Timeseries: []prompb.TimeSeries{{
+			Labels:  []prompb.Label{{Name: "__name__", Value: "test_metric"}},
+			Samples: []prompb.Sample{{Value: float64(i), Timestamp: 1000}},
+		}}})
+		require.NoError(t, err)
+		require.NoError(t, log.Write(uint64(i), buf))
+	}
+	require.NoError(t, log.Close())
+
+	var out bytes.Buffer
+	require.NoError(t, dump(dir, 0, &out))
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 2)
+	for i, line := range lines {
+		md, err := (&pmetric.JSONUnmarshaler{}).UnmarshalMetrics([]byte(line))
+		require.NoError(t, err)
+		dp := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Gauge().DataPoints().At(0)
+		assert.Equal(t, float64(i+1), dp.DoubleValue())
+	}
+
+	// entries before from are skipped
+	out.Reset()
+	require.NoError(t, dump(dir, 2, &out))
+	lines = strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 1)
+	md, err := (&pmetric.JSONUnmarshaler{}).UnmarshalMetrics([]byte(lines[0]))
+	require.NoError(t, err)
+	assert.Equal(t, 2.0, md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Gauge().DataPoints().At(0).DoubleValue())
+
+	// the entries before a corruption are dumped
+	segments, err := prwwal.List(filepath.Join(dir, prwwal.Dir))
+	require.NoError(t, err)
+	f, err := os.OpenFile(segments[0].Path, os.O_APPEND|os.O_WRONLY, 0o600)
+	require.NoError(t, err)
+	_, err = f.Write([]byte{100})
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	out.Reset()
+	require.ErrorIs(t, dump(filepath.Join(dir, prwwal.Dir), 0, &out), prwwal.ErrCorrupt)
+	assert.Len(t, strings.Split(strings.TrimSpace(out.String()), "\n"), 2)
+}
@@ -14,6 +14,8 @@ import (
 var (
 	errNoPipelinePriority    = errors.New("No pipelines are defined in the priority list")
 	errInvalidRetryIntervals = errors.New("Retry interval must be positive")
+	errInvalidThresholds     = errors.New("Failure and success thresholds must be at least 1")
+	errInvalidHoldDown       = errors.New("Hold down must not be negative")
 )
 
 type Config struct {
@@ -21,6 +23,10 @@ type Config struct {
 	// in the queue of an unhealthy exporter
 	QueueSettings exporterhelper.QueueBatchConfig `mapstructure:"sending_queue"`
 
+	// LevelQueueSettings configures a sending queue for every priority level. Data is queued for the active
+	// level, and data failing at a level is handed to the queue of the next level
+	LevelQueueSettings exporterhelper.QueueBatchConfig `mapstructure:"level_queue"`
+
 	// PipelinePriority is the list of pipeline level priorities in a 1 - n configuration, multiple pipelines can
 	// sit at a single priority level and will be routed in a fanout. If any pipeline at a level fails, the
 	// level is considered unhealthy
@@ -38,6 +44,18 @@ type Config struct {
 	// MaxRetry is the maximum retries per level, once this limit is hit for a level, even if the next pipeline level fails,
 	// it will not try to recover the level that exceeded the maximum retries
 	MaxRetries int `mapstructure:"max_retries"` // **Deprecated**
+
+	// FailureThreshold is the number of consecutive errors returned by the current priority level before
+	// the connector fails over to the next level. Data that failed is still routed to the lower levels
+	FailureThreshold int `mapstructure:"failure_threshold"`
+
+	// SuccessThreshold is the number of consecutive successful retries of a higher priority level before
+	// the connector switches back to it, avoiding flapping between levels
+	SuccessThreshold int `mapstructure:"success_threshold"`
+
+	// HoldDown is the minimum time the connector stays at a priority level after switching to it before
+	// higher priority levels are retried
+	HoldDown time.Duration `mapstructure:"hold_down"`
 	// prevent unkeyed literal initialization
 	_ struct{}
 }
@@ -50,5 +68,11 @@ func (c *Config) Validate() error {
 	if c.RetryInterval <= 0 {
 		return errInvalidRetryIntervals
 	}
+	if c.FailureThreshold < 1 || c.SuccessThreshold < 1 {
+		return errInvalidThresholds
+	}
+	if c.HoldDown < 0 {
+		return errInvalidHoldDown
+	}
 	return nil
 }
@@ -27,19 +27,23 @@ func TestLoadConfig(t *testing.T) {
 		{
 			id: component.NewIDWithName(metadata.Type, "default"),
 			expected: &Config{
-				QueueSettings: exporterhelper.NewDefaultQueueConfig(),
+				QueueSettings:      exporterhelper.NewDefaultQueueConfig(),
+				LevelQueueSettings: newDefaultLevelQueueConfig(),
 				PipelinePriority: [][]pipeline.ID{
 					{
 						pipeline.NewIDWithName(pipeline.SignalTraces, ""),
 					},
 				},
-				RetryInterval: 10 * time.Minute,
+				RetryInterval:    10 * time.Minute,
+				FailureThreshold: 1,
+				SuccessThreshold: 1,
 			},
 		},
 		{
 			id: component.NewIDWithName(metadata.Type, "full"),
 			expected: &Config{
-				QueueSettings: exporterhelper.NewDefaultQueueConfig(),
+				QueueSettings:      exporterhelper.NewDefaultQueueConfig(),
+				LevelQueueSettings: newDefaultLevelQueueConfig(),
 				PipelinePriority: [][]pipeline.ID{
 					{
 						pipeline.NewIDWithName(pipeline.SignalTraces, "first"),
@@ -55,7 +59,50 @@ func TestLoadConfig(t *testing.T) {
 						pipeline.NewIDWithName(pipeline.SignalTraces, "fourth"),
 					},
 				},
-				RetryInterval: 5 * time.Minute,
+				RetryInterval:    5 * time.Minute,
+				FailureThreshold: 1,
+				SuccessThreshold: 1,
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "thresholds"),
+			expected: &Config{
+				QueueSettings:      exporterhelper.NewDefaultQueueConfig(),
+				LevelQueueSettings: newDefaultLevelQueueConfig(),
+				PipelinePriority: [][]pipeline.ID{
+					{
+						pipeline.NewIDWithName(pipeline.SignalTraces, "first"),
+					},
+					{
+						pipeline.NewIDWithName(pipeline.SignalTraces, "second"),
+					},
+				},
+				RetryInterval:    30 * time.Second,
+				FailureThreshold: 3,
+				SuccessThreshold: 5,
+				HoldDown:         2 * time.Minute,
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "level_queue"),
+			expected: &Config{
+				QueueSettings: exporterhelper.NewDefaultQueueConfig(),
+				LevelQueueSettings: func() exporterhelper.QueueBatchConfig {
+					cfg := exporterhelper.NewDefaultQueueConfig()
+					cfg.QueueSize = 100
+					return cfg
+				}(),
+				PipelinePriority: [][]pipeline.ID{
+					{
+						pipeline.NewIDWithName(pipeline.SignalTraces, "first"),
+					},
+					{
+						pipeline.NewIDWithName(pipeline.SignalTraces, "second"),
+					},
+				},
+				RetryInterval:    10 * time.Minute,
+				FailureThreshold: 1,
+				SuccessThreshold: 1,
 			},
 		},
 	}
@@ -94,6 +141,16 @@ func TestValidateConfig(t *testing.T) {
 			id:   component.NewIDWithName(metadata.Type, "invalid"),
 			err:  errInvalidRetryIntervals,
 		},
+		{
+			name: "invalid failure_threshold",
+			id:   component.NewIDWithName(metadata.Type, "invalid_threshold"),
+			err:  errInvalidThresholds,
+		},
+		{
+			name: "invalid hold_down",
+			id:   component.NewIDWithName(metadata.Type, "invalid_hold_down"),
+			err:  errInvalidHoldDown,
+		},
 	}
 
 	for _, tc := range testcases {
@@ -28,20 +28,30 @@ func NewFactory() connector.Factory {
 
 func createDefaultConfig() component.Config {
 	return &Config{
-		QueueSettings: exporterhelper.NewDefaultQueueConfig(),
-		RetryInterval: 10 * time.Minute,
-		RetryGap:      0,
-		MaxRetries:    0,
+		QueueSettings:      exporterhelper.NewDefaultQueueConfig(),
+		LevelQueueSettings: newDefaultLevelQueueConfig(),
+		RetryInterval:      10 * time.Minute,
+		RetryGap:           0,
+		MaxRetries:         0,
+		FailureThreshold:   1,
+		SuccessThreshold:   1,
 	}
 }
 
+// newDefaultLevelQueueConfig returns the default configuration of the level queues, which are disabled by default
+func newDefaultLevelQueueConfig() exporterhelper.QueueBatchConfig {
+	cfg := exporterhelper.NewDefaultQueueConfig()
+	cfg.Enabled = false
+	return cfg
+}
+
 func createTracesToTraces(
 	ctx context.Context,
 	set connector.Settings,
 	cfg component.Config,
 	traces consumer.Traces,
 ) (connector.Traces, error) {
-	t, err := newTracesToTraces(set, cfg, traces)
+	t, err := newTracesToTraces(ctx, set, cfg, traces)
 	if err != nil {
 		return nil, err
 	}
@@ -78,7 +88,7 @@ func createMetricsToMetrics(
 	cfg component.Config,
 	metrics consumer.Metrics,
 ) (connector.Metrics, error) {
-	t, err := newMetricsToMetrics(set, cfg, metrics)
+	t, err := newMetricsToMetrics(ctx, set, cfg, metrics)
 	if err != nil {
 		return nil, err
 	}
@@ -115,7 +125,7 @@ func createLogsToLogs(
 	cfg component.Config,
 	logs consumer.Logs,
 ) (connector.Logs, error) {
-	t, err := newLogsToLogs(set, cfg, logs)
+	t, err := newLogsToLogs(ctx, set, cfg, logs)
 	if err != nil {
 		return nil, err
 	}
@@ -4,10 +4,19 @@
 package failoverconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/failoverconnector"
 
 import (
+	"context"
 	"errors"
-
+	"fmt"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pipeline"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/failoverconnector/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/failoverconnector/internal/state"
 )
 
@@ -23,20 +32,17 @@ type baseFailoverRouter[C any] struct {
 	cfg       *Config
 	pS        *state.PipelineSelector
 	consumers []C
+	// queues holds the sending queue of each priority level when level_queue is enabled, data failing
+	// at a level is handed to the queue of the next level
+	queues      []C
+	queueStarts []component.Component
 
-	errTryLock  *state.TryLock
 	notifyRetry chan struct{}
 	done        chan struct{}
-}
 
-// getCurrentConsumer returns the consumer for the current healthy level
-func (f *baseFailoverRouter[C]) getCurrentConsumer() (C, int) {
-	var nilConsumer C
-	pl := f.pS.CurrentPipeline()
-	if pl >= len(f.cfg.PipelinePriority) {
-		return nilConsumer, pl
-	}
-	return f.consumers[pl], pl
+	telemetryBuilder *metadata.TelemetryBuilder
+	hostMu           sync.Mutex
+	host             component.Host
 }
 
 // getConsumerAtIndex returns the consumer at a specific index
@@ -44,26 +50,114 @@ func (f *baseFailoverRouter[C]) getConsumerAtIndex(idx int) C {
 	return f.consumers[idx]
 }
 
-// reportConsumerError ensures only one consumer is reporting an error at a time to avoid multiple failovers
+// reportConsumerError reports an error of the consumer at a level, every error counts towards the failure threshold
 func (f *baseFailoverRouter[C]) reportConsumerError(idx int) {
-	f.errTryLock.TryExecute(f.pS.HandleError, idx)
+	f.pS.HandleError(idx)
+}
+
+// Start records the host used to report the component status, starts the level queues and reports the
+// current level
+func (f *baseFailoverRouter[C]) Start(ctx context.Context, host component.Host) error {
+	for _, queue := range f.queueStarts {
+		if err := queue.Start(ctx, host); err != nil {
+			return err
+		}
+	}
+	f.hostMu.Lock()
+	f.host = host
+	f.hostMu.Unlock()
+	f.reportLevel(f.pS.CurrentPipeline())
+	return nil
 }
 
-func (f *baseFailoverRouter[C]) Shutdown() {
+// reportLevel records the active priority level and reports the status of every priority level through
+// componentstatus, every level above the active one is considered unhealthy. The status of a level is
+// reported with the priority_level attribute, followed by the status of the connector
+func (f *baseFailoverRouter[C]) reportLevel(level int) {
+	f.telemetryBuilder.ConnectorFailoverActivePriorityLevel.Record(context.Background(), int64(level))
+
+	f.hostMu.Lock()
+	host := f.host
+	f.hostMu.Unlock()
+	if host == nil {
+		return
+	}
+
+	for idx, pipelines := range f.cfg.PipelinePriority {
+		attrs := levelAttributes(idx, pipelines)
+		if idx < level {
+			componentstatus.ReportStatus(host, componentstatus.NewRecoverableErrorEvent(
+				fmt.Errorf("priority level %d %v is unhealthy", idx, pipelines),
+				componentstatus.WithAttributes(attrs)))
+			continue
+		}
+		componentstatus.ReportStatus(host, componentstatus.NewEvent(componentstatus.StatusOK,
+			componentstatus.WithAttributes(attrs)))
+	}
+
+	switch {
+	case level == 0:
+		componentstatus.ReportStatus(host, componentstatus.NewEvent(componentstatus.StatusOK))
+	case level >= len(f.cfg.PipelinePriority):
+		componentstatus.ReportStatus(host, componentstatus.NewRecoverableErrorEvent(errNoValidPipeline))
+	default:
+		componentstatus.ReportStatus(host, componentstatus.NewRecoverableErrorEvent(
+			fmt.Errorf("priority levels %v are unhealthy, routing to priority level %d %v",
+				f.cfg.PipelinePriority[:level], level, f.cfg.PipelinePriority[level])))
+	}
+}
+
+// levelAttributes returns the attributes of the status events of a priority level
+func levelAttributes(level int, pipelines []pipeline.ID) pcommon.Map {
+	attrs := pcommon.NewMap()
+	attrs.PutInt("priority_level", int64(level))
+	ids := attrs.PutEmptySlice("pipelines")
+	for _, id := range pipelines {
+		ids.AppendEmpty().SetStr(id.String())
+	}
+	return attrs
+}
+
+// Shutdown drains the level queues in priority order, so that data failing at a level can still be
+// handed to the queue of the next level, before stopping the retries
+func (f *baseFailoverRouter[C]) Shutdown(ctx context.Context) error {
+	var errs error
+	for _, queue := range f.queueStarts {
+		errs = errors.Join(errs, queue.Shutdown(ctx))
+	}
 	select {
 	case <-f.done:
 	default:
 		close(f.done)
+		f.telemetryBuilder.Shutdown()
 	}
+	return errs
 }
 
-func newBaseFailoverRouter[C any](provider consumerProvider[C], cfg *Config) (*baseFailoverRouter[C], error) {
+// levelSettings returns the settings of the sending queue of a priority level, each level queue reports
+// its telemetry under its own ID
+func levelSettings(set connector.Settings, level int) exporter.Settings {
+	name := fmt.Sprintf("priority_level=%d", level)
+	if set.ID.Name() != "" {
+		name = set.ID.Name() + "/" + name
+	}
+	return exporter.Settings{
+		ID:                component.NewIDWithName(set.ID.Type(), name),
+		TelemetrySettings: set.TelemetrySettings,
+		BuildInfo:         set.BuildInfo,
+	}
+}
+
+func newBaseFailoverRouter[C any](provider consumerProvider[C], cfg *Config, set component.TelemetrySettings) (*baseFailoverRouter[C], error) {
 	done := make(chan struct{})
 	notifyRetry := make(chan struct{}, 1)
 	pSConstants := state.PSConstants{
-		RetryInterval: cfg.RetryInterval,
-		RetryGap:      cfg.RetryGap,
-		MaxRetries:    cfg.MaxRetries,
+		RetryInterval:    cfg.RetryInterval,
+		RetryGap:         cfg.RetryGap,
+		MaxRetries:       cfg.MaxRetries,
+		FailureThreshold: cfg.FailureThreshold,
+		SuccessThreshold: cfg.SuccessThreshold,
+		HoldDown:         cfg.HoldDown,
 	}
 
 	consumers := make([]C, 0)
@@ -75,15 +169,22 @@ func newBaseFailoverRouter[C any](provider consumerProvider[C], cfg *Config) (*b
 		consumers = append(consumers, baseConsumer)
 	}
 
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(set)
+	if err != nil {
+		return nil, err
+	}
+
 	selector := state.NewPipelineSelector(notifyRetry, done, pSConstants)
-	return &baseFailoverRouter[C]{
-		consumers:   consumers,
-		cfg:         cfg,
-		pS:          selector,
-		errTryLock:  state.NewTryLock(),
-		done:        done,
-		notifyRetry: notifyRetry,
-	}, nil
+	router := &baseFailoverRouter[C]{
+		consumers:        consumers,
+		cfg:              cfg,
+		pS:               selector,
+		done:             done,
+		notifyRetry:      notifyRetry,
+		telemetryBuilder: telemetryBuilder,
+	}
+	selector.OnLevelChange(router.reportLevel)
+	return router, nil
 }
 
 // For Testing
@@ -3,18 +3,25 @@
 
 package failoverconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/failoverconnector"
 import (
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/connector"
 	"go.opentelemetry.io/collector/connector/connectortest"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/failoverconnector/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/failoverconnector/internal/metadatatest"
 )
 
 func TestFailoverRecovery(t *testing.T) {
@@ -131,6 +138,149 @@ func TestFailoverRecovery(t *testing.T) {
 	})
 }
 
+func TestFailoverThresholds(t *testing.T) {
+	var sinkFirst, sinkSecond consumertest.TracesSink
+	tracesFirst := pipeline.NewIDWithName(pipeline.SignalTraces, "traces/first")
+	tracesSecond := pipeline.NewIDWithName(pipeline.SignalTraces, "traces/second")
+
+	cfg := &Config{
+		PipelinePriority: [][]pipeline.ID{{tracesFirst}, {tracesSecond}},
+		RetryInterval:    10 * time.Millisecond,
+		FailureThreshold: 2,
+		SuccessThreshold: 3,
+	}
+
+	router := connector.NewTracesRouter(map[pipeline.ID]consumer.Traces{
+		tracesFirst:  &sinkFirst,
+		tracesSecond: &sinkSecond,
+	})
+
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(t.Context())) })
+
+	conn, err := NewFactory().CreateTracesToTraces(t.Context(),
+		metadatatest.NewSettings(tel), cfg, router.(consumer.Traces))
+	require.NoError(t, err)
+
+	host := &statusRecordingHost{Host: componenttest.NewNopHost()}
+	require.NoError(t, conn.Start(t.Context(), host))
+
+	failoverConnector := conn.(*tracesFailover)
+	tRouter := failoverConnector.failover
+	defer func() {
+		assert.NoError(t, failoverConnector.Shutdown(t.Context()))
+	}()
+
+	tr := sampleTrace()
+	tRouter.ModifyConsumerAtIndex(0, consumertest.NewErr(errTracesConsumer))
+
+	// the data is routed to the next level, but the connector only fails over on the second error
+	require.NoError(t, conn.ConsumeTraces(t.Context(), tr))
+	require.Equal(t, 0, tRouter.TestGetCurrentConsumerIndex())
+	require.Equal(t, 1, sinkSecond.SpanCount())
+
+	require.NoError(t, conn.ConsumeTraces(t.Context(), tr))
+	require.Equal(t, 1, tRouter.TestGetCurrentConsumerIndex())
+	metadatatest.AssertEqualConnectorFailoverActivePriorityLevel(t, tel,
+		[]metricdata.DataPoint[int64]{{Value: 1}},
+		metricdatatest.IgnoreTimestamp())
+	require.Equal(t, componentstatus.StatusRecoverableError, host.lastStatus())
+	require.Equal(t, componentstatus.StatusRecoverableError, host.levelStatus(0))
+	require.Equal(t, componentstatus.StatusOK, host.levelStatus(1))
+
+	tRouter.ModifyConsumerAtIndex(0, &sinkFirst)
+
+	// the connector only switches back after three successful retries
+	require.Eventually(t, func() bool {
+		return consumeTracesAndCheckStable(tRouter, 0, tr)
+	}, 3*time.Second, 5*time.Millisecond)
+	require.GreaterOrEqual(t, sinkFirst.SpanCount(), 3)
+	metadatatest.AssertEqualConnectorFailoverActivePriorityLevel(t, tel,
+		[]metricdata.DataPoint[int64]{{Value: 0}},
+		metricdatatest.IgnoreTimestamp())
+	require.Equal(t, componentstatus.StatusOK, host.lastStatus())
+	require.Equal(t, componentstatus.StatusOK, host.levelStatus(0))
+}
+
+func TestFailoverLevelQueue(t *testing.T) {
+	var sinkFirst, sinkSecond consumertest.TracesSink
+	tracesFirst := pipeline.NewIDWithName(pipeline.SignalTraces, "traces/first")
+	tracesSecond := pipeline.NewIDWithName(pipeline.SignalTraces, "traces/second")
+
+	levelQueue := newDefaultLevelQueueConfig()
+	levelQueue.Enabled = true
+	cfg := &Config{
+		LevelQueueSettings: levelQueue,
+		PipelinePriority:   [][]pipeline.ID{{tracesFirst}, {tracesSecond}},
+		RetryInterval:      time.Minute,
+	}
+
+	router := connector.NewTracesRouter(map[pipeline.ID]consumer.Traces{
+		tracesFirst:  &sinkFirst,
+		tracesSecond: &sinkSecond,
+	})
+
+	conn, err := NewFactory().CreateTracesToTraces(t.Context(),
+		connectortest.NewNopSettings(metadata.Type), cfg, router.(consumer.Traces))
+	require.NoError(t, err)
+	require.NoError(t, conn.Start(t.Context(), componenttest.NewNopHost()))
+
+	failoverConnector := conn.(*tracesFailover)
+	tRouter := failoverConnector.failover
+	defer func() {
+		assert.NoError(t, failoverConnector.Shutdown(t.Context()))
+	}()
+
+	tRouter.ModifyConsumerAtIndex(0, consumertest.NewErr(errTracesConsumer))
+
+	// the data is queued for the first level, and handed to the queue of the second level once it failed
+	require.NoError(t, conn.ConsumeTraces(t.Context(), sampleTrace()))
+	require.Eventually(t, func() bool {
+		return sinkSecond.SpanCount() == 1
+	}, 3*time.Second, 5*time.Millisecond)
+	require.Equal(t, 1, tRouter.TestGetCurrentConsumerIndex())
+
+	// data is queued for the active level
+	require.NoError(t, conn.ConsumeTraces(t.Context(), sampleTrace()))
+	require.Eventually(t, func() bool {
+		return sinkSecond.SpanCount() == 2
+	}, 3*time.Second, 5*time.Millisecond)
+	require.Equal(t, 0, sinkFirst.SpanCount())
+}
+
+type statusRecordingHost struct {
+	component.Host
+	mu     sync.Mutex
+	events []*componentstatus.Event
+}
+
+func (h *statusRecordingHost) Report(ev *componentstatus.Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.events = append(h.events, ev)
+}
+
+func (h *statusRecordingHost) lastStatus() componentstatus.Status {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.events) == 0 {
+		return componentstatus.StatusNone
+	}
+	return h.events[len(h.events)-1].Status()
+}
+
+// levelStatus returns the status last reported for a priority level
+func (h *statusRecordingHost) levelStatus(level int) componentstatus.Status {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for i := len(h.events) - 1; i >= 0; i-- {
+		if v, ok := h.events[i].Attributes().Get("priority_level"); ok && v.Int() == int64(level) {
+			return h.events[i].Status()
+		}
+	}
+	return componentstatus.StatusNone
+}
+
 func resetConsumers(router *tracesRouter, consumers ...consumer.Traces) {
 	for i, sink := range consumers {
 		router.ModifyConsumerAtIndex(i, sink)
@@ -11,29 +11,84 @@ import (
 
 type PipelineSelector struct {
 	currentPipeline   int
+	consecutiveErrors int
+	retrySuccesses    map[int]int
+	lastSwitch        time.Time
 	constants         PSConstants
 	lock              sync.RWMutex
 	retryEnabledToken chan struct{}
 	retryChan         chan<- struct{}
+	onLevelChange     func(int)
 
 	retryCancel CancelManager
 	done        chan struct{}
 }
 
-// HandleError is called when an error is returned on a healthy pipeline
+// HandleError is called when an error is returned on a healthy pipeline. The selector only moves
+// to the next level once FailureThreshold consecutive errors have been reported for the current level.
+// Errors reported concurrently are all counted, but only move the selector by a single level
 func (p *PipelineSelector) HandleError(idx int) {
+	p.lock.Lock()
 	if idx != p.currentPipeline {
+		p.lock.Unlock()
+		return
+	}
+	p.consecutiveErrors++
+	if p.consecutiveErrors < max(p.constants.FailureThreshold, 1) {
+		p.lock.Unlock()
 		return
 	}
-	p.NextStableLevel()
+	level := p.nextStableLevel()
+	p.lock.Unlock()
+	p.notifyLevelChange(level)
 	p.TryEnableRetry()
 }
 
+// HandleSuccess is called when data was consumed by a pipeline level, it resets the consecutive
+// error count if the level is the current healthy level
+func (p *PipelineSelector) HandleSuccess(idx int) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if idx == p.currentPipeline {
+		p.consecutiveErrors = 0
+	}
+}
+
+// HandleRetrySuccess is called when a retry against a higher priority level succeeded. The level is
+// only reset to healthy once SuccessThreshold consecutive retries succeeded, returns true if it was
+func (p *PipelineSelector) HandleRetrySuccess(idx int) bool {
+	p.lock.Lock()
+	p.retrySuccesses[idx]++
+	if p.retrySuccesses[idx] < max(p.constants.SuccessThreshold, 1) {
+		p.lock.Unlock()
+		return false
+	}
+	p.lock.Unlock()
+	p.ResetHealthyPipeline(idx)
+	return true
+}
+
+// HandleRetryError is called when a retry against a higher priority level failed, it resets the
+// consecutive success count of the level
+func (p *PipelineSelector) HandleRetryError(idx int) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	delete(p.retrySuccesses, idx)
+}
+
 // NextStableLevel increments the level to the next in the priority list
 func (p *PipelineSelector) NextStableLevel() {
 	p.lock.Lock()
-	defer p.lock.Unlock()
+	level := p.nextStableLevel()
+	p.lock.Unlock()
+	p.notifyLevelChange(level)
+}
+
+// nextStableLevel must be called with the lock held
+func (p *PipelineSelector) nextStableLevel() int {
 	p.currentPipeline++
+	p.resetCounters()
+	return p.currentPipeline
 }
 
 // TryEnableRetry checks if a retry is already in effect and if not starts the retry goroutine
@@ -59,6 +114,9 @@ func (p *PipelineSelector) LaunchRetry() {
 		for {
 			select {
 			case <-ticker.C:
+				if p.InHoldDown() {
+					continue
+				}
 				select {
 				case p.retryChan <- struct{}{}:
 				default:
@@ -77,6 +135,14 @@ func (p *PipelineSelector) returnRetryToken() {
 	p.retryEnabledToken <- struct{}{}
 }
 
+// InHoldDown returns true while the HoldDown period after the last level change has not passed,
+// no retries against higher priority levels are made during that time
+func (p *PipelineSelector) InHoldDown() bool {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	return p.constants.HoldDown > 0 && time.Since(p.lastSwitch) < p.constants.HoldDown
+}
+
 // CurrentLevel returns the current healthy pipeline level
 func (p *PipelineSelector) CurrentPipeline() int {
 	p.lock.RLock()
@@ -87,11 +153,37 @@ func (p *PipelineSelector) CurrentPipeline() int {
 // ResetHealthyPipeline resets a pipeline level that was successfully retries back to healthy/active
 func (p *PipelineSelector) ResetHealthyPipeline(pipelineIndex int) {
 	p.lock.Lock()
-	defer p.lock.Unlock()
 	if pipelineIndex == 0 {
 		p.retryCancel.Cancel()
 	}
 	p.currentPipeline = pipelineIndex
+	p.resetCounters()
+	p.lock.Unlock()
+	p.notifyLevelChange(pipelineIndex)
+}
+
+// OnLevelChange registers a function that is called with the new level every time the current
+// healthy pipeline level changes
+func (p *PipelineSelector) OnLevelChange(fn func(int)) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.onLevelChange = fn
+}
+
+// resetCounters must be called with the lock held
+func (p *PipelineSelector) resetCounters() {
+	p.consecutiveErrors = 0
+	clear(p.retrySuccesses)
+	p.lastSwitch = time.Now()
+}
+
+func (p *PipelineSelector) notifyLevelChange(level int) {
+	p.lock.RLock()
+	fn := p.onLevelChange
+	p.lock.RUnlock()
+	if fn != nil {
+		fn(level)
+	}
 }
 
 func NewPipelineSelector(retryChan chan<- struct{}, done chan struct{}, consts PSConstants) *PipelineSelector {
@@ -100,6 +192,7 @@ func NewPipelineSelector(retryChan chan<- struct{}, done chan struct{}, consts P
 
 	ps := &PipelineSelector{
 		currentPipeline:   0,
+		retrySuccesses:    make(map[int]int),
 		constants:         consts,
 		retryEnabledToken: retryEnabledToken,
 		retryChan:         retryChan,
@@ -4,6 +4,7 @@
 package state
 
 import (
+	"sync"
 	"testing"
 	"time"
 
@@ -73,3 +74,118 @@ func TestCurrentPipelineWithReset(t *testing.T) {
 		return idx == 0
 	}, 3*time.Second, 5*time.Millisecond)
 }
+
+func TestHandlePipelineErrorWithFailureThreshold(t *testing.T) {
+	done := make(chan struct{})
+	retryChan := make(chan struct{}, 1)
+	constants := PSConstants{
+		RetryInterval:    50 * time.Millisecond,
+		FailureThreshold: 3,
+	}
+	pS := NewPipelineSelector(retryChan, done, constants)
+
+	defer func() {
+		close(done)
+	}()
+
+	pS.HandleError(0)
+	pS.HandleError(0)
+	require.Equal(t, 0, pS.CurrentPipeline())
+
+	// a success resets the consecutive errors
+	pS.HandleSuccess(0)
+	pS.HandleError(0)
+	pS.HandleError(0)
+	require.Equal(t, 0, pS.CurrentPipeline())
+
+	pS.HandleError(0)
+	require.Equal(t, 1, pS.CurrentPipeline())
+}
+
+func TestHandlePipelineErrorConcurrently(t *testing.T) {
+	done := make(chan struct{})
+	retryChan := make(chan struct{}, 1)
+	constants := PSConstants{
+		RetryInterval:    50 * time.Millisecond,
+		FailureThreshold: 10,
+	}
+	pS := NewPipelineSelector(retryChan, done, constants)
+
+	defer func() {
+		close(done)
+	}()
+
+	// every concurrent error is counted, and the threshold being exceeded
+	// only moves the selector by a single level
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			pS.HandleError(0)
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, 1, pS.CurrentPipeline())
+}
+
+func TestHandleRetrySuccessWithSuccessThreshold(t *testing.T) {
+	done := make(chan struct{})
+	retryChan := make(chan struct{}, 1)
+	constants := PSConstants{
+		RetryInterval:    50 * time.Millisecond,
+		SuccessThreshold: 2,
+	}
+	pS := NewPipelineSelector(retryChan, done, constants)
+
+	defer func() {
+		close(done)
+	}()
+
+	var levels []int
+	pS.OnLevelChange(func(level int) {
+		levels = append(levels, level)
+	})
+
+	pS.TestSetCurrentPipeline(2)
+
+	require.False(t, pS.HandleRetrySuccess(0))
+	// a failed retry resets the consecutive successes
+	pS.HandleRetryError(0)
+	require.False(t, pS.HandleRetrySuccess(0))
+	require.Equal(t, 2, pS.CurrentPipeline())
+
+	require.True(t, pS.HandleRetrySuccess(0))
+	require.Equal(t, 0, pS.CurrentPipeline())
+	require.Equal(t, []int{0}, levels)
+}
+
+func TestHoldDown(t *testing.T) {
+	done := make(chan struct{})
+	retryChan := make(chan struct{}, 1)
+	constants := PSConstants{
+		RetryInterval: 10 * time.Millisecond,
+		HoldDown:      time.Hour,
+	}
+	pS := NewPipelineSelector(retryChan, done, constants)
+
+	defer func() {
+		close(done)
+	}()
+
+	require.False(t, pS.InHoldDown())
+
+	pS.HandleError(0)
+	require.Equal(t, 1, pS.CurrentPipeline())
+	require.True(t, pS.InHoldDown())
+
+	// no retries are signaled while in hold down
+	require.Never(t, func() bool {
+		select {
+		case <-retryChan:
+			return true
+		default:
+			return false
+		}
+	}, 100*time.Millisecond, 10*time.Millisecond)
+}
@@ -9,6 +9,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/connector"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.uber.org/zap"
 )
@@ -17,8 +18,8 @@ type logsRouter struct {
 	*baseFailoverRouter[consumer.Logs]
 }
 
-func newLogsRouter(provider consumerProvider[consumer.Logs], cfg *Config) (*logsRouter, error) {
-	failover, err := newBaseFailoverRouter(provider, cfg)
+func newLogsRouter(provider consumerProvider[consumer.Logs], cfg *Config, set component.TelemetrySettings) (*logsRouter, error) {
+	failover, err := newBaseFailoverRouter(provider, cfg, set)
 	if err != nil {
 		return nil, err
 	}
@@ -38,21 +39,63 @@ func (f *logsRouter) Consume(ctx context.Context, ld plog.Logs) error {
 	}
 }
 
-// consumeByHealthyPipeline will consume the logs by the current healthy level
+// consumeByHealthyPipeline will consume the logs by the current healthy level, falling through to
+// the lower priority levels for as long as the consumers return errors
 func (f *logsRouter) consumeByHealthyPipeline(ctx context.Context, ld plog.Logs) error {
-	for {
-		tc, idx := f.getCurrentConsumer()
-		if idx >= len(f.cfg.PipelinePriority) {
-			return errNoValidPipeline
-		}
-
-		if err := tc.ConsumeLogs(ctx, ld); err != nil {
+	if f.queues != nil {
+		return f.enqueue(ctx, f.pS.CurrentPipeline(), ld)
+	}
+	for idx := f.pS.CurrentPipeline(); idx < len(f.cfg.PipelinePriority); idx++ {
+		if err := f.getConsumerAtIndex(idx).ConsumeLogs(ctx, ld); err != nil {
 			f.reportConsumerError(idx)
 			continue
 		}
-
+		f.pS.HandleSuccess(idx)
 		return nil
 	}
+	return errNoValidPipeline
+}
+
+// enqueue hands the logs to the queue of a priority level, or of the active level if it is lower
+func (f *logsRouter) enqueue(ctx context.Context, idx int, ld plog.Logs) error {
+	idx = max(idx, f.pS.CurrentPipeline())
+	if idx >= len(f.queues) {
+		return errNoValidPipeline
+	}
+	return f.queues[idx].ConsumeLogs(ctx, ld)
+}
+
+// consumeLevel consumes the logs dequeued for a priority level, handing them to the queue of the next
+// level on error. Data queued for a level that became unhealthy is handed to the active level instead
+func (f *logsRouter) consumeLevel(ctx context.Context, idx int, ld plog.Logs) error {
+	if idx < f.pS.CurrentPipeline() {
+		return f.enqueue(ctx, idx, ld)
+	}
+	if err := f.getConsumerAtIndex(idx).ConsumeLogs(ctx, ld); err != nil {
+		f.reportConsumerError(idx)
+		return f.enqueue(ctx, idx+1, ld)
+	}
+	f.pS.HandleSuccess(idx)
+	return nil
+}
+
+// newLevelQueues wraps every priority level in its own sending queue
+func (f *logsRouter) newLevelQueues(ctx context.Context, set connector.Settings) error {
+	for idx := range f.consumers {
+		queue, err := exporterhelper.NewLogs(ctx, levelSettings(set, idx), f.cfg,
+			func(ctx context.Context, ld plog.Logs) error {
+				return f.consumeLevel(ctx, idx, ld)
+			},
+			exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
+			exporterhelper.WithQueue(f.cfg.LevelQueueSettings),
+		)
+		if err != nil {
+			return err
+		}
+		f.queues = append(f.queues, queue)
+		f.queueStarts = append(f.queueStarts, queue)
+	}
+	return nil
 }
 
 // sampleRetryConsumers iterates through all unhealthy consumers to re-establish a healthy connection
@@ -62,15 +105,15 @@ func (f *logsRouter) sampleRetryConsumers(ctx context.Context, ld plog.Logs) boo
 		consumer := f.getConsumerAtIndex(i)
 		err := consumer.ConsumeLogs(ctx, ld)
 		if err == nil {
-			f.pS.ResetHealthyPipeline(i)
+			f.pS.HandleRetrySuccess(i)
 			return true
 		}
+		f.pS.HandleRetryError(i)
 	}
 	return false
 }
 
 type logsFailover struct {
-	component.StartFunc
 	component.ShutdownFunc
 
 	config   *Config
@@ -87,24 +130,33 @@ func (f *logsFailover) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
 	return f.failover.Consume(ctx, ld)
 }
 
-func (f *logsFailover) Shutdown(context.Context) error {
+func (f *logsFailover) Start(ctx context.Context, host component.Host) error {
+	return f.failover.Start(ctx, host)
+}
+
+func (f *logsFailover) Shutdown(ctx context.Context) error {
 	if f.failover != nil {
-		f.failover.Shutdown()
+		return f.failover.Shutdown(ctx)
 	}
 	return nil
 }
 
-func newLogsToLogs(set connector.Settings, cfg component.Config, logs consumer.Logs) (connector.Logs, error) {
+func newLogsToLogs(ctx context.Context, set connector.Settings, cfg component.Config, logs consumer.Logs) (connector.Logs, error) {
 	config := cfg.(*Config)
 	lr, ok := logs.(connector.LogsRouterAndConsumer)
 	if !ok {
 		return nil, errors.New("consumer is not of type MetricsRouter")
 	}
 
-	failover, err := newLogsRouter(lr.Consumer, config)
+	failover, err := newLogsRouter(lr.Consumer, config, set.TelemetrySettings)
 	if err != nil {
 		return nil, err
 	}
+	if config.LevelQueueSettings.Enabled {
+		if err := failover.newLevelQueues(ctx, set); err != nil {
+			return nil, err
+		}
+	}
 
 	return &logsFailover{
 		config:   config,
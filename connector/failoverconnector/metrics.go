@@ -9,6 +9,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/connector"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.uber.org/zap"
 )
@@ -17,8 +18,8 @@ type metricsRouter struct {
 	*baseFailoverRouter[consumer.Metrics]
 }
 
-func newMetricsRouter(provider consumerProvider[consumer.Metrics], cfg *Config) (*metricsRouter, error) {
-	failover, err := newBaseFailoverRouter(provider, cfg)
+func newMetricsRouter(provider consumerProvider[consumer.Metrics], cfg *Config, set component.TelemetrySettings) (*metricsRouter, error) {
+	failover, err := newBaseFailoverRouter(provider, cfg, set)
 	if err != nil {
 		return nil, err
 	}
@@ -38,21 +39,63 @@ func (f *metricsRouter) Consume(ctx context.Context, md pmetric.Metrics) error {
 	}
 }
 
-// consumeByHealthyPipeline will consume the metrics by the current healthy level
+// consumeByHealthyPipeline will consume the metrics by the current healthy level, falling through to
+// the lower priority levels for as long as the consumers return errors
 func (f *metricsRouter) consumeByHealthyPipeline(ctx context.Context, md pmetric.Metrics) error {
-	for {
-		tc, idx := f.getCurrentConsumer()
-		if idx >= len(f.cfg.PipelinePriority) {
-			return errNoValidPipeline
-		}
-
-		if err := tc.ConsumeMetrics(ctx, md); err != nil {
+	if f.queues != nil {
+		return f.enqueue(ctx, f.pS.CurrentPipeline(), md)
+	}
+	for idx := f.pS.CurrentPipeline(); idx < len(f.cfg.PipelinePriority); idx++ {
+		if err := f.getConsumerAtIndex(idx).ConsumeMetrics(ctx, md); err != nil {
 			f.reportConsumerError(idx)
 			continue
 		}
-
+		f.pS.HandleSuccess(idx)
 		return nil
 	}
+	return errNoValidPipeline
+}
+
+// enqueue hands the metrics to the queue of a priority level, or of the active level if it is lower
+func (f *metricsRouter) enqueue(ctx context.Context, idx int, md pmetric.Metrics) error {
+	idx = max(idx, f.pS.CurrentPipeline())
+	if idx >= len(f.queues) {
+		return errNoValidPipeline
+	}
+	return f.queues[idx].ConsumeMetrics(ctx, md)
+}
+
+// consumeLevel consumes the metrics dequeued for a priority level, handing them to the queue of the next
+// level on error. Data queued for a level that became unhealthy is handed to the active level instead
+func (f *metricsRouter) consumeLevel(ctx context.Context, idx int, md pmetric.Metrics) error {
+	if idx < f.pS.CurrentPipeline() {
+		return f.enqueue(ctx, idx, md)
+	}
+	if err := f.getConsumerAtIndex(idx).ConsumeMetrics(ctx, md); err != nil {
+		f.reportConsumerError(idx)
+		return f.enqueue(ctx, idx+1, md)
+	}
+	f.pS.HandleSuccess(idx)
+	return nil
+}
+
+// newLevelQueues wraps every priority level in its own sending queue
+func (f *metricsRouter) newLevelQueues(ctx context.Context, set connector.Settings) error {
+	for idx := range f.consumers {
+		queue, err := exporterhelper.NewMetrics(ctx, levelSettings(set, idx), f.cfg,
+			func(ctx context.Context, md pmetric.Metrics) error {
+				return f.consumeLevel(ctx, idx, md)
+			},
+			exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
+			exporterhelper.WithQueue(f.cfg.LevelQueueSettings),
+		)
+		if err != nil {
+			return err
+		}
+		f.queues = append(f.queues, queue)
+		f.queueStarts = append(f.queueStarts, queue)
+	}
+	return nil
 }
 
 // sampleRetryConsumers iterates through all unhealthy consumers to re-establish a healthy connection
@@ -62,15 +105,15 @@ func (f *metricsRouter) sampleRetryConsumers(ctx context.Context, md pmetric.Met
 		consumer := f.getConsumerAtIndex(i)
 		err := consumer.ConsumeMetrics(ctx, md)
 		if err == nil {
-			f.pS.ResetHealthyPipeline(i)
+			f.pS.HandleRetrySuccess(i)
 			return true
 		}
+		f.pS.HandleRetryError(i)
 	}
 	return false
 }
 
 type metricsFailover struct {
-	component.StartFunc
 	component.ShutdownFunc
 
 	config   *Config
@@ -87,24 +130,33 @@ func (f *metricsFailover) ConsumeMetrics(ctx context.Context, md pmetric.Metrics
 	return f.failover.Consume(ctx, md)
 }
 
-func (f *metricsFailover) Shutdown(context.Context) error {
+func (f *metricsFailover) Start(ctx context.Context, host component.Host) error {
+	return f.failover.Start(ctx, host)
+}
+
+func (f *metricsFailover) Shutdown(ctx context.Context) error {
 	if f.failover != nil {
-		f.failover.Shutdown()
+		return f.failover.Shutdown(ctx)
 	}
 	return nil
 }
 
-func newMetricsToMetrics(set connector.Settings, cfg component.Config, metrics consumer.Metrics) (connector.Metrics, error) {
+func newMetricsToMetrics(ctx context.Context, set connector.Settings, cfg component.Config, metrics consumer.Metrics) (connector.Metrics, error) {
 	config := cfg.(*Config)
 	mr, ok := metrics.(connector.MetricsRouterAndConsumer)
 	if !ok {
 		return nil, errors.New("consumer is not of type MetricsRouter")
 	}
 
-	failover, err := newMetricsRouter(mr.Consumer, config)
+	failover, err := newMetricsRouter(mr.Consumer, config, set.TelemetrySettings)
 	if err != nil {
 		return nil, err
 	}
+	if config.LevelQueueSettings.Enabled {
+		if err := failover.newLevelQueues(ctx, set); err != nil {
+			return nil, err
+		}
+	}
 
 	return &metricsFailover{
 		config:   config,
@@ -10,6 +10,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/connector"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap"
 )
@@ -18,8 +19,8 @@ type tracesRouter struct {
 	*baseFailoverRouter[consumer.Traces]
 }
 
-func newTracesRouter(provider consumerProvider[consumer.Traces], cfg *Config) (*tracesRouter, error) {
-	failover, err := newBaseFailoverRouter(provider, cfg)
+func newTracesRouter(provider consumerProvider[consumer.Traces], cfg *Config, set component.TelemetrySettings) (*tracesRouter, error) {
+	failover, err := newBaseFailoverRouter(provider, cfg, set)
 	if err != nil {
 		return nil, err
 	}
@@ -39,21 +40,63 @@ func (f *tracesRouter) Consume(ctx context.Context, td ptrace.Traces) error {
 	}
 }
 
-// consumeByHealthyPipeline will consume the traces by the current healthy level
+// consumeByHealthyPipeline will consume the traces by the current healthy level, falling through to
+// the lower priority levels for as long as the consumers return errors
 func (f *tracesRouter) consumeByHealthyPipeline(ctx context.Context, td ptrace.Traces) error {
-	for {
-		tc, idx := f.getCurrentConsumer()
-		if idx >= len(f.cfg.PipelinePriority) {
-			return errNoValidPipeline
-		}
-
-		if err := tc.ConsumeTraces(ctx, td); err != nil {
+	if f.queues != nil {
+		return f.enqueue(ctx, f.pS.CurrentPipeline(), td)
+	}
+	for idx := f.pS.CurrentPipeline(); idx < len(f.cfg.PipelinePriority); idx++ {
+		if err := f.getConsumerAtIndex(idx).ConsumeTraces(ctx, td); err != nil {
 			f.reportConsumerError(idx)
 			continue
 		}
-
+		f.pS.HandleSuccess(idx)
 		return nil
 	}
+	return errNoValidPipeline
+}
+
+// enqueue hands the traces to the queue of a priority level, or of the active level if it is lower
+func (f *tracesRouter) enqueue(ctx context.Context, idx int, td ptrace.Traces) error {
+	idx = max(idx, f.pS.CurrentPipeline())
+	if idx >= len(f.queues) {
+		return errNoValidPipeline
+	}
+	return f.queues[idx].ConsumeTraces(ctx, td)
+}
+
+// consumeLevel consumes the traces dequeued for a priority level, handing them to the queue of the next
+// level on error. Data queued for a level that became unhealthy is handed to the active level instead
+func (f *tracesRouter) consumeLevel(ctx context.Context, idx int, td ptrace.Traces) error {
+	if idx < f.pS.CurrentPipeline() {
+		return f.enqueue(ctx, idx, td)
+	}
+	if err := f.getConsumerAtIndex(idx).ConsumeTraces(ctx, td); err != nil {
+		f.reportConsumerError(idx)
+		return f.enqueue(ctx, idx+1, td)
+	}
+	f.pS.HandleSuccess(idx)
+	return nil
+}
+
+// newLevelQueues wraps every priority level in its own sending queue
+func (f *tracesRouter) newLevelQueues(ctx context.Context, set connector.Settings) error {
+	for idx := range f.consumers {
+		queue, err := exporterhelper.NewTraces(ctx, levelSettings(set, idx), f.cfg,
+			func(ctx context.Context, td ptrace.Traces) error {
+				return f.consumeLevel(ctx, idx, td)
+			},
+			exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
+			exporterhelper.WithQueue(f.cfg.LevelQueueSettings),
+		)
+		if err != nil {
+			return err
+		}
+		f.queues = append(f.queues, queue)
+		f.queueStarts = append(f.queueStarts, queue)
+	}
+	return nil
 }
 
 // sampleRetryConsumers iterates through all unhealthy consumers to re-establish a healthy connection
@@ -63,15 +106,15 @@ func (f *tracesRouter) sampleRetryConsumers(ctx context.Context, td ptrace.Trace
 		consumer := f.getConsumerAtIndex(i)
 		err := consumer.ConsumeTraces(ctx, td)
 		if err == nil {
-			f.pS.ResetHealthyPipeline(i)
+			f.pS.HandleRetrySuccess(i)
 			return true
 		}
+		f.pS.HandleRetryError(i)
 	}
 	return false
 }
 
 type tracesFailover struct {
-	component.StartFunc
 	component.ShutdownFunc
 
 	config   *Config
@@ -88,24 +131,33 @@ func (f *tracesFailover) ConsumeTraces(ctx context.Context, td ptrace.Traces) er
 	return f.failover.Consume(ctx, td)
 }
 
-func (f *tracesFailover) Shutdown(context.Context) error {
+func (f *tracesFailover) Start(ctx context.Context, host component.Host) error {
+	return f.failover.Start(ctx, host)
+}
+
+func (f *tracesFailover) Shutdown(ctx context.Context) error {
 	if f.failover != nil {
-		f.failover.Shutdown()
+		return f.failover.Shutdown(ctx)
 	}
 	return nil
 }
 
-func newTracesToTraces(set connector.Settings, cfg component.Config, traces consumer.Traces) (connector.Traces, error) {
+func newTracesToTraces(ctx context.Context, set connector.Settings, cfg component.Config, traces consumer.Traces) (connector.Traces, error) {
 	config := cfg.(*Config)
 	tr, ok := traces.(connector.TracesRouterAndConsumer)
 	if !ok {
 		return nil, errors.New("consumer is not of type TracesRouter")
 	}
 
-	failover, err := newTracesRouter(tr.Consumer, config)
+	failover, err := newTracesRouter(tr.Consumer, config, set.TelemetrySettings)
 	if err != nil {
 		return nil, err
 	}
+	if config.LevelQueueSettings.Enabled {
+		if err := failover.newLevelQueues(ctx, set); err != nil {
+			return nil, err
+		}
+	}
 
 	return &tracesFailover{
 		config:   config,
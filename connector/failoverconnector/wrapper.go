@@ -5,6 +5,7 @@ package failoverconnector // import "github.com/open-telemetry/opentelemetry-col
 
 import (
 	"context"
+	"errors"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
@@ -38,9 +39,11 @@ func (w *wrappedTracesConnector) Capabilities() consumer.Capabilities {
 
 func (w *wrappedTracesConnector) Start(ctx context.Context, host component.Host) error {
 	if starter, ok := w.consumer.(component.Component); ok {
-		return starter.Start(ctx, host)
+		if err := starter.Start(ctx, host); err != nil {
+			return err
+		}
 	}
-	return nil
+	return w.failoverCore.Start(ctx, host)
 }
 
 func (w *wrappedMetricsConnector) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
@@ -53,9 +56,11 @@ func (w *wrappedMetricsConnector) Capabilities() consumer.Capabilities {
 
 func (w *wrappedMetricsConnector) Start(ctx context.Context, host component.Host) error {
 	if starter, ok := w.consumer.(component.Component); ok {
-		return starter.Start(ctx, host)
+		if err := starter.Start(ctx, host); err != nil {
+			return err
+		}
 	}
-	return nil
+	return w.failoverCore.Start(ctx, host)
 }
 
 func (w *wrappedLogsConnector) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
@@ -68,9 +73,11 @@ func (w *wrappedLogsConnector) Capabilities() consumer.Capabilities {
 
 func (w *wrappedLogsConnector) Start(ctx context.Context, host component.Host) error {
 	if starter, ok := w.consumer.(component.Component); ok {
-		return starter.Start(ctx, host)
+		if err := starter.Start(ctx, host); err != nil {
+			return err
+		}
 	}
-	return nil
+	return w.failoverCore.Start(ctx, host)
 }
 
 func (w *wrappedTracesConnector) GetFailoverRouter() *tracesRouter {
@@ -90,8 +97,7 @@ func (w *wrappedTracesConnector) Shutdown(ctx context.Context) error {
 	if shutdowner, ok := w.consumer.(interface{ Shutdown(context.Context) error }); ok {
 		err = shutdowner.Shutdown(ctx)
 	}
-	w.failoverCore.failover.Shutdown()
-	return err
+	return errors.Join(err, w.failoverCore.failover.Shutdown(ctx))
 }
 
 func (w *wrappedMetricsConnector) Shutdown(ctx context.Context) error {
@@ -99,8 +105,7 @@ func (w *wrappedMetricsConnector) Shutdown(ctx context.Context) error {
 	if shutdowner, ok := w.consumer.(interface{ Shutdown(context.Context) error }); ok {
 		err = shutdowner.Shutdown(ctx)
 	}
-	w.failoverCore.failover.Shutdown()
-	return err
+	return errors.Join(err, w.failoverCore.failover.Shutdown(ctx))
 }
 
 func (w *wrappedLogsConnector) Shutdown(ctx context.Context) error {
@@ -108,8 +113,7 @@ func (w *wrappedLogsConnector) Shutdown(ctx context.Context) error {
 	if shutdowner, ok := w.consumer.(interface{ Shutdown(context.Context) error }); ok {
 		err = shutdowner.Shutdown(ctx)
 	}
-	w.failoverCore.failover.Shutdown()
-	return err
+	return errors.Join(err, w.failoverCore.failover.Shutdown(ctx))
 }
 
 func newWrappedTracesConnector(consumer consumer.Traces, failoverCore *tracesFailover) *wrappedTracesConnector {
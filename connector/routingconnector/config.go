@@ -18,6 +18,12 @@ var (
 	errNoPipelines            = errors.New("invalid route: no pipelines defined")
 	errUnexpectedConsumer     = errors.New("expected consumer to be a connector router")
 	errNoTableItems           = errors.New("invalid routing table: the routing table is empty")
+	errSplitWithCondition     = errors.New("invalid route: split may not be combined with a context, condition or statement")
+	errSplitInvalidKey        = errors.New(`invalid split: key must be one of "trace_id" or "resource_attribute"`)
+	errSplitNoAttribute       = errors.New(`invalid split: "resource_attribute" key requires an attribute`)
+	errSplitInvalidPercentage = errors.New("invalid split: percentage must be greater than 0 and at most 100")
+	errSplitOverallocated     = errors.New("invalid routing table: split percentages sharing a key add up to more than 100")
+	errSplitTraceIDMetrics    = errors.New(`invalid split: "trace_id" key is not supported for metrics`)
 )
 
 // Config defines configuration for the Routing processor.
@@ -52,7 +58,26 @@ func (c *Config) Validate() error {
 
 	// validate that every route has a value for the routing attribute and has
 	// at least one pipeline
+	allocated := make(map[string]float64)
 	for _, item := range c.Table {
+		if item.Split != nil {
+			if item.Context != "" || item.Statement != "" || item.Condition != "" {
+				return errSplitWithCondition
+			}
+			if err := item.Split.validate(); err != nil {
+				return err
+			}
+			if len(item.Pipelines) == 0 {
+				return errNoPipelines
+			}
+			if !item.Split.Mirror {
+				allocated[item.Split.hashKey()] += item.Split.Percentage
+				if allocated[item.Split.hashKey()] > 100 {
+					return errSplitOverallocated
+				}
+			}
+			continue
+		}
 		if item.Statement == "" && item.Condition == "" {
 			return errNoConditionOrStatement
 		}
@@ -96,6 +121,12 @@ type RoutingTableItem struct {
 	// For all other contexts, 'Statement' or 'Condition' must be provided, and must be a valid OTTL condition.
 	Condition string `mapstructure:"condition"`
 
+	// Split routes a deterministic share of the data to the pipelines instead of
+	// evaluating a condition. 'Split' may not be combined with 'Context',
+	// 'Statement' or 'Condition'.
+	// Optional.
+	Split *SplitConfig `mapstructure:"split"`
+
 	// Pipelines contains the list of pipelines to use when the value from the FromAttribute field
 	// matches this table item. When no pipelines are specified, the ones specified under
 	// DefaultPipelines are used, if any.
@@ -105,3 +136,60 @@ type RoutingTableItem struct {
 	// prevent unkeyed literal initialization
 	_ struct{}
 }
+
+// SplitConfig specifies a weighted split of the data, e.g. to canary a new
+// backend with a small share of the traffic.
+type SplitConfig struct {
+	// Key is the value hashed to decide whether data belongs to the split.
+	// One of "trace_id" or "resource_attribute". "trace_id" is only supported
+	// for traces and logs and is evaluated per span or log record.
+	// "resource_attribute" is evaluated per resource.
+	// Required.
+	Key string `mapstructure:"key"`
+
+	// Attribute is the name of the resource attribute hashed when Key is
+	// "resource_attribute". Resources without the attribute are never
+	// part of the split.
+	Attribute string `mapstructure:"attribute"`
+
+	// Percentage is the share of the hash space routed to the pipelines, with
+	// a precision of two decimal places. Splits that share the same key are
+	// assigned consecutive, non-overlapping ranges of the hash space in table
+	// order, so their percentages may add up to at most 100.
+	// Required.
+	Percentage float64 `mapstructure:"percentage"`
+
+	// Mirror sends a copy of the matching data to the pipelines and continues
+	// routing the original through the rest of the table. Mirrored splits
+	// always select their share from the start of the hash space and do not
+	// count against the percentages of the other splits.
+	// Optional. Default false.
+	Mirror bool `mapstructure:"mirror"`
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// validate checks if the split configuration is valid. It is invoked by
+// Config.Validate, which also checks the percentages across splits.
+func (s *SplitConfig) validate() error {
+	switch s.Key {
+	case splitKeyTraceID:
+	case splitKeyResourceAttribute:
+		if s.Attribute == "" {
+			return errSplitNoAttribute
+		}
+	default:
+		return errSplitInvalidKey
+	}
+	if s.Percentage <= 0 || s.Percentage > 100 {
+		return errSplitInvalidPercentage
+	}
+	return nil
+}
+
+func (s *SplitConfig) hashKey() string {
+	if s.Key == splitKeyResourceAttribute {
+		return s.Key + "/" + s.Attribute
+	}
+	return s.Key
+}
@@ -375,6 +375,141 @@ func TestValidateConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "split provided",
+			config: &Config{
+				Table: []RoutingTableItem{
+					{
+						Split: &SplitConfig{
+							Key:        "trace_id",
+							Percentage: 5,
+						},
+						Pipelines: []pipeline.ID{
+							pipeline.NewIDWithName(pipeline.SignalTraces, "canary"),
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "split with condition",
+			config: &Config{
+				Table: []RoutingTableItem{
+					{
+						Condition: `attributes["attr"] == "acme"`,
+						Split: &SplitConfig{
+							Key:        "trace_id",
+							Percentage: 5,
+						},
+						Pipelines: []pipeline.ID{
+							pipeline.NewIDWithName(pipeline.SignalTraces, "canary"),
+						},
+					},
+				},
+			},
+			error: "invalid route: split may not be combined with a context, condition or statement",
+		},
+		{
+			name: "split with invalid key",
+			config: &Config{
+				Table: []RoutingTableItem{
+					{
+						Split: &SplitConfig{
+							Key:        "span_id",
+							Percentage: 5,
+						},
+						Pipelines: []pipeline.ID{
+							pipeline.NewIDWithName(pipeline.SignalTraces, "canary"),
+						},
+					},
+				},
+			},
+			error: `invalid split: key must be one of "trace_id" or "resource_attribute"`,
+		},
+		{
+			name: "split by resource attribute without attribute",
+			config: &Config{
+				Table: []RoutingTableItem{
+					{
+						Split: &SplitConfig{
+							Key:        "resource_attribute",
+							Percentage: 5,
+						},
+						Pipelines: []pipeline.ID{
+							pipeline.NewIDWithName(pipeline.SignalTraces, "canary"),
+						},
+					},
+				},
+			},
+			error: `invalid split: "resource_attribute" key requires an attribute`,
+		},
+		{
+			name: "split with invalid percentage",
+			config: &Config{
+				Table: []RoutingTableItem{
+					{
+						Split: &SplitConfig{
+							Key:        "trace_id",
+							Percentage: 101,
+						},
+						Pipelines: []pipeline.ID{
+							pipeline.NewIDWithName(pipeline.SignalTraces, "canary"),
+						},
+					},
+				},
+			},
+			error: "invalid split: percentage must be greater than 0 and at most 100",
+		},
+		{
+			name: "split without pipelines",
+			config: &Config{
+				Table: []RoutingTableItem{
+					{
+						Split: &SplitConfig{
+							Key:        "trace_id",
+							Percentage: 5,
+						},
+					},
+				},
+			},
+			error: "invalid route: no pipelines defined",
+		},
+		{
+			name: "splits sharing a key over 100 percent",
+			config: &Config{
+				Table: []RoutingTableItem{
+					{
+						Split: &SplitConfig{
+							Key:        "trace_id",
+							Percentage: 60,
+						},
+						Pipelines: []pipeline.ID{
+							pipeline.NewIDWithName(pipeline.SignalTraces, "a"),
+						},
+					},
+					{
+						Split: &SplitConfig{
+							Key:        "trace_id",
+							Percentage: 60,
+							Mirror:     true,
+						},
+						Pipelines: []pipeline.ID{
+							pipeline.NewIDWithName(pipeline.SignalTraces, "mirror"),
+						},
+					},
+					{
+						Split: &SplitConfig{
+							Key:        "trace_id",
+							Percentage: 50,
+						},
+						Pipelines: []pipeline.ID{
+							pipeline.NewIDWithName(pipeline.SignalTraces, "b"),
+						},
+					},
+				},
+			},
+			error: "invalid routing table: split percentages sharing a key add up to more than 100",
+		},
 	}
 
 	for _, tt := range tests {
@@ -62,7 +62,14 @@ func (*logsConnector) Capabilities() consumer.Capabilities {
 func (c *logsConnector) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
 	groups := make(map[consumer.Logs]plog.Logs)
 	var errs error
-	for i := 0; i < len(c.router.routeSlice) && ld.ResourceLogs().Len() > 0; i++ {
+	// mirrored splits select their share of the data received by the
+	// connector, not of the data left by the routes before them
+	var original plog.Logs
+	if c.router.mirrored {
+		original = plog.NewLogs()
+		ld.CopyTo(original)
+	}
+	for i := 0; i < len(c.router.routeSlice) && (ld.ResourceLogs().Len() > 0 || c.router.mirrored); i++ {
 		route := c.router.routeSlice[i]
 		matchedLogs := plog.NewLogs()
 		switch route.statementContext {
@@ -80,6 +87,12 @@ func (c *logsConnector) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
 					return isMatch
 				},
 			)
+		case "split":
+			if route.split.mirror {
+				splitLogs(original, matchedLogs, route.split)
+			} else {
+				splitLogs(ld, matchedLogs, route.split)
+			}
 		case "log":
 			plogutil.MoveRecordsWithContextIf(ld, matchedLogs,
 				func(rl plog.ResourceLogs, sl plog.ScopeLogs, lr plog.LogRecord) bool {
@@ -106,6 +119,30 @@ func (c *logsConnector) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
 	return errs
 }
 
+// splitLogs moves the data selected by the split from ld to matched. When
+// the split is mirrored the selected data is copied instead.
+func splitLogs(ld, matched plog.Logs, split *splitRoute) {
+	if split.mirror {
+		mirrored := plog.NewLogs()
+		ld.CopyTo(mirrored)
+		ld = mirrored
+	}
+	switch split.key {
+	case splitKeyTraceID:
+		plogutil.MoveRecordsWithContextIf(ld, matched,
+			func(_ plog.ResourceLogs, _ plog.ScopeLogs, lr plog.LogRecord) bool {
+				return split.matchTraceID(lr.TraceID())
+			},
+		)
+	case splitKeyResourceAttribute:
+		plogutil.MoveResourcesIf(ld, matched,
+			func(rl plog.ResourceLogs) bool {
+				return split.matchResource(rl.Resource())
+			},
+		)
+	}
+}
+
 func groupAllLogs(
 	groups map[consumer.Logs]plog.Logs,
 	cons consumer.Logs,
@@ -40,6 +40,12 @@ func newMetricsConnector(
 		return nil, errUnexpectedConsumer
 	}
 
+	for _, item := range cfg.Table {
+		if item.Split != nil && item.Split.Key == splitKeyTraceID {
+			return nil, errSplitTraceIDMetrics
+		}
+	}
+
 	r, err := newRouter(
 		cfg.Table,
 		cfg.DefaultPipelines,
@@ -63,7 +69,14 @@ func (*metricsConnector) Capabilities() consumer.Capabilities {
 func (c *metricsConnector) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
 	groups := make(map[consumer.Metrics]pmetric.Metrics)
 	var errs error
-	for i := 0; i < len(c.router.routeSlice) && md.ResourceMetrics().Len() > 0; i++ {
+	// mirrored splits select their share of the data received by the
+	// connector, not of the data left by the routes before them
+	var original pmetric.Metrics
+	if c.router.mirrored {
+		original = pmetric.NewMetrics()
+		md.CopyTo(original)
+	}
+	for i := 0; i < len(c.router.routeSlice) && (md.ResourceMetrics().Len() > 0 || c.router.mirrored); i++ {
 		route := c.router.routeSlice[i]
 		matchedMetrics := pmetric.NewMetrics()
 		switch route.statementContext {
@@ -81,6 +94,12 @@ func (c *metricsConnector) ConsumeMetrics(ctx context.Context, md pmetric.Metric
 					return isMatch
 				},
 			)
+		case "split":
+			if route.split.mirror {
+				splitMetrics(original, matchedMetrics, route.split)
+			} else {
+				splitMetrics(md, matchedMetrics, route.split)
+			}
 		case "metric":
 			pmetricutil.MoveMetricsWithContextIf(md, matchedMetrics,
 				func(rm pmetric.ResourceMetrics, sm pmetric.ScopeMetrics, m pmetric.Metric) bool {
@@ -116,6 +135,21 @@ func (c *metricsConnector) ConsumeMetrics(ctx context.Context, md pmetric.Metric
 	return errs
 }
 
+// splitMetrics moves the resources selected by the split from md to matched.
+// When the split is mirrored the selected resources are copied instead.
+func splitMetrics(md, matched pmetric.Metrics, split *splitRoute) {
+	if split.mirror {
+		mirrored := pmetric.NewMetrics()
+		md.CopyTo(mirrored)
+		md = mirrored
+	}
+	pmetricutil.MoveResourcesIf(md, matched,
+		func(rm pmetric.ResourceMetrics) bool {
+			return split.matchResource(rm.Resource())
+		},
+	)
+}
+
 func groupAllMetrics(
 	groups map[consumer.Metrics]pmetric.Metrics,
 	cons consumer.Metrics,
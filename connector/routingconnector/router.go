@@ -42,6 +42,8 @@ type router[C any] struct {
 	consumerProvider consumerProvider[C]
 	table            []RoutingTableItem
 	routeSlice       []routingItem[C]
+	// mirrored is set if any of the routes is a mirrored split
+	mirrored bool
 }
 
 // newRouter creates a new router instance with based on type parameters C and K.
@@ -78,12 +80,16 @@ type routingItem[C any] struct {
 	metricStatement    *ottl.Statement[ottlmetric.TransformContext]
 	dataPointStatement *ottl.Statement[ottldatapoint.TransformContext]
 	logStatement       *ottl.Statement[ottllog.TransformContext]
+	split              *splitRoute
 	statementContext   string
 }
 
 func (r *router[C]) buildParsers(table []RoutingTableItem, settings component.TelemetrySettings) error {
 	var buildResource, buildSpan, buildMetric, buildDataPoint, buildLog bool
 	for _, item := range table {
+		if item.Split != nil {
+			continue
+		}
 		switch item.Context {
 		case "", "resource":
 			buildResource = true
@@ -203,7 +209,24 @@ func (r *router[C]) normalizeConditions() {
 
 // registerRouteConsumers registers a consumer for the pipelines configured for each route
 func (r *router[C]) registerRouteConsumers() (err error) {
+	splits := newSplitRoutes(r.table)
 	for _, item := range r.table {
+		if item.Split != nil {
+			// splits are never deduplicated, identical splits select
+			// different ranges of the hash space
+			consumer, err := r.consumerProvider(item.Pipelines...)
+			if err != nil {
+				return fmt.Errorf("%w: %s", errPipelineNotFound, err.Error())
+			}
+			r.routeSlice = append(r.routeSlice, routingItem[C]{
+				consumer:         consumer,
+				split:            splits[0],
+				statementContext: "split",
+			})
+			r.mirrored = r.mirrored || splits[0].mirror
+			splits = splits[1:]
+			continue
+		}
 		route, ok := r.routes[key(item)]
 		if !ok {
 			route.statementContext = item.Context
@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package routingconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector"
+
+import (
+	"hash/fnv"
+	"math"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+const (
+	splitKeyTraceID           = "trace_id"
+	splitKeyResourceAttribute = "resource_attribute"
+
+	// splitBuckets is the size of the hash space, chosen so that a percentage
+	// with two decimal places maps to a whole number of buckets.
+	splitBuckets = 10000
+)
+
+// splitRoute selects data whose hashed key falls into the bucket range
+// [lower, upper) of the hash space.
+type splitRoute struct {
+	key       string
+	attribute string
+	lower     uint64
+	upper     uint64
+	mirror    bool
+}
+
+// newSplitRoutes builds the split routes for the given table in table order.
+// Non-mirrored splits that share a key are assigned consecutive ranges of the
+// hash space, mirrored splits always start at the beginning of it. Mirrored
+// splits select from all data received by the connector, so the ranges
+// consumed by the splits before them don't affect them.
+func newSplitRoutes(table []RoutingTableItem) []*splitRoute {
+	offsets := make(map[string]uint64)
+	routes := make([]*splitRoute, 0, len(table))
+	for _, item := range table {
+		if item.Split == nil {
+			continue
+		}
+		var lower uint64
+		if !item.Split.Mirror {
+			lower = offsets[item.Split.hashKey()]
+		}
+		upper := min(lower+uint64(math.Round(item.Split.Percentage*splitBuckets/100)), splitBuckets)
+		if !item.Split.Mirror {
+			offsets[item.Split.hashKey()] = upper
+		}
+		routes = append(routes, &splitRoute{
+			key:       item.Split.Key,
+			attribute: item.Split.Attribute,
+			lower:     lower,
+			upper:     upper,
+			mirror:    item.Split.Mirror,
+		})
+	}
+	return routes
+}
+
+func (s *splitRoute) matchBytes(b []byte) bool {
+	h := fnv.New64a()
+	_, _ = h.Write(b)
+	bucket := h.Sum64() % splitBuckets
+	return bucket >= s.lower && bucket < s.upper
+}
+
+// matchTraceID reports whether the trace ID belongs to the split. Empty trace
+// IDs never match.
+func (s *splitRoute) matchTraceID(traceID pcommon.TraceID) bool {
+	if traceID.IsEmpty() {
+		return false
+	}
+	return s.matchBytes(traceID[:])
+}
+
+// matchResource reports whether the configured resource attribute belongs to
+// the split. Resources without the attribute never match.
+func (s *splitRoute) matchResource(res pcommon.Resource) bool {
+	v, ok := res.Attributes().Get(s.attribute)
+	if !ok {
+		return false
+	}
+	return s.matchBytes([]byte(v.AsString()))
+}
@@ -0,0 +1,260 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package routingconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector"
+
+import (
+	"encoding/binary"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/connector/connectortest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pipeline"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector/internal/metadata"
+)
+
+func TestNewSplitRoutes(t *testing.T) {
+	routes := newSplitRoutes([]RoutingTableItem{
+		{Split: &SplitConfig{Key: "trace_id", Percentage: 5}},
+		{Condition: `attributes["attr"] == "acme"`},
+		{Split: &SplitConfig{Key: "trace_id", Percentage: 12.5}},
+		{Split: &SplitConfig{Key: "trace_id", Percentage: 1, Mirror: true}},
+		{Split: &SplitConfig{Key: "resource_attribute", Attribute: "service.name", Percentage: 50}},
+	})
+	require.Len(t, routes, 4)
+
+	assert.Equal(t, uint64(0), routes[0].lower)
+	assert.Equal(t, uint64(500), routes[0].upper)
+	assert.Equal(t, uint64(500), routes[1].lower)
+	assert.Equal(t, uint64(1750), routes[1].upper)
+	assert.Equal(t, uint64(0), routes[2].lower)
+	assert.Equal(t, uint64(100), routes[2].upper)
+	assert.True(t, routes[2].mirror)
+	assert.Equal(t, uint64(0), routes[3].lower)
+	assert.Equal(t, uint64(5000), routes[3].upper)
+	assert.Equal(t, "service.name", routes[3].attribute)
+}
+
+func TestSplitRouteMatch(t *testing.T) {
+	all := &splitRoute{lower: 0, upper: splitBuckets}
+	none := &splitRoute{lower: 0, upper: 0}
+
+	assert.True(t, all.matchTraceID(newSplitTraceID(1)))
+	assert.False(t, none.matchTraceID(newSplitTraceID(1)))
+	assert.False(t, all.matchTraceID(pcommon.NewTraceIDEmpty()), "empty trace IDs never match")
+
+	res := pcommon.NewResource()
+	all.attribute = "service.name"
+	assert.False(t, all.matchResource(res), "missing attributes never match")
+	res.Attributes().PutStr("service.name", "checkout")
+	assert.True(t, all.matchResource(res))
+
+	// the same key always selects the same bucket
+	half := &splitRoute{lower: 0, upper: splitBuckets / 2}
+	for i := range 100 {
+		id := newSplitTraceID(i)
+		assert.Equal(t, half.matchTraceID(id), half.matchTraceID(id))
+	}
+}
+
+func TestTracesSplitByTraceID(t *testing.T) {
+	idSinkC := pipeline.NewIDWithName(pipeline.SignalTraces, "canary")
+	idSinkD := pipeline.NewIDWithName(pipeline.SignalTraces, "default")
+
+	cfg := testConfig(
+		withSplit(&SplitConfig{Key: "trace_id", Percentage: 10}, idSinkC),
+		withDefault(idSinkD),
+	)
+	require.NoError(t, cfg.Validate())
+
+	var sinkC, sinkD consumertest.TracesSink
+	router := connector.NewTracesRouter(map[pipeline.ID]consumer.Traces{
+		idSinkC: &sinkC,
+		idSinkD: &sinkD,
+	})
+	conn, err := NewFactory().CreateTracesToTraces(t.Context(),
+		connectortest.NewNopSettings(metadata.Type), cfg, router.(consumer.Traces))
+	require.NoError(t, err)
+
+	const total = 2000
+	require.NoError(t, conn.ConsumeTraces(t.Context(), newSplitTraces(total)))
+	canary := sinkC.SpanCount()
+	assert.Equal(t, total, canary+sinkD.SpanCount())
+	assert.InDelta(t, total/10, canary, total/20)
+
+	// the split is deterministic for the same trace IDs
+	sinkC.Reset()
+	sinkD.Reset()
+	require.NoError(t, conn.ConsumeTraces(t.Context(), newSplitTraces(total)))
+	assert.Equal(t, canary, sinkC.SpanCount())
+}
+
+func TestTracesSplitMirror(t *testing.T) {
+	idSinkM := pipeline.NewIDWithName(pipeline.SignalTraces, "mirror")
+	idSinkD := pipeline.NewIDWithName(pipeline.SignalTraces, "default")
+
+	cfg := testConfig(
+		withSplit(&SplitConfig{Key: "trace_id", Percentage: 25, Mirror: true}, idSinkM),
+		withDefault(idSinkD),
+	)
+	require.NoError(t, cfg.Validate())
+
+	var sinkM, sinkD consumertest.TracesSink
+	router := connector.NewTracesRouter(map[pipeline.ID]consumer.Traces{
+		idSinkM: &sinkM,
+		idSinkD: &sinkD,
+	})
+	conn, err := NewFactory().CreateTracesToTraces(t.Context(),
+		connectortest.NewNopSettings(metadata.Type), cfg, router.(consumer.Traces))
+	require.NoError(t, err)
+
+	const total = 2000
+	require.NoError(t, conn.ConsumeTraces(t.Context(), newSplitTraces(total)))
+	assert.Equal(t, total, sinkD.SpanCount(), "mirrored data continues to be routed")
+	assert.InDelta(t, total/4, sinkM.SpanCount(), total/20)
+}
+
+func TestTracesSplitMirrorAfterSplit(t *testing.T) {
+	idSinkC := pipeline.NewIDWithName(pipeline.SignalTraces, "canary")
+	idSinkM := pipeline.NewIDWithName(pipeline.SignalTraces, "mirror")
+	idSinkD := pipeline.NewIDWithName(pipeline.SignalTraces, "default")
+
+	// the canary split consumes the range of the hash space selected by the
+	// mirror, which still selects its share of all data
+	cfg := testConfig(
+		withSplit(&SplitConfig{Key: "trace_id", Percentage: 50}, idSinkC),
+		withSplit(&SplitConfig{Key: "trace_id", Percentage: 25, Mirror: true}, idSinkM),
+		withDefault(idSinkD),
+	)
+	require.NoError(t, cfg.Validate())
+
+	var sinkC, sinkM, sinkD consumertest.TracesSink
+	router := connector.NewTracesRouter(map[pipeline.ID]consumer.Traces{
+		idSinkC: &sinkC,
+		idSinkM: &sinkM,
+		idSinkD: &sinkD,
+	})
+	conn, err := NewFactory().CreateTracesToTraces(t.Context(),
+		connectortest.NewNopSettings(metadata.Type), cfg, router.(consumer.Traces))
+	require.NoError(t, err)
+
+	const total = 2000
+	require.NoError(t, conn.ConsumeTraces(t.Context(), newSplitTraces(total)))
+	assert.Equal(t, total, sinkC.SpanCount()+sinkD.SpanCount())
+	assert.InDelta(t, total/2, sinkC.SpanCount(), total/20)
+	assert.InDelta(t, total/4, sinkM.SpanCount(), total/20)
+}
+
+func TestLogsSplitByResourceAttribute(t *testing.T) {
+	idSinkC := pipeline.NewIDWithName(pipeline.SignalLogs, "canary")
+	idSinkD := pipeline.NewIDWithName(pipeline.SignalLogs, "default")
+
+	cfg := testConfig(
+		withSplit(&SplitConfig{Key: "resource_attribute", Attribute: "service.name", Percentage: 50}, idSinkC),
+		withDefault(idSinkD),
+	)
+	require.NoError(t, cfg.Validate())
+
+	var sinkC, sinkD consumertest.LogsSink
+	router := connector.NewLogsRouter(map[pipeline.ID]consumer.Logs{
+		idSinkC: &sinkC,
+		idSinkD: &sinkD,
+	})
+	conn, err := NewFactory().CreateLogsToLogs(t.Context(),
+		connectortest.NewNopSettings(metadata.Type), cfg, router.(consumer.Logs))
+	require.NoError(t, err)
+
+	const total = 1000
+	ld := plog.NewLogs()
+	for i := range total {
+		rl := ld.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().PutStr("service.name", "service-"+strconv.Itoa(i))
+		rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	}
+	// resources without the attribute are never part of the split
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+
+	require.NoError(t, conn.ConsumeLogs(t.Context(), ld))
+	assert.Equal(t, total+1, sinkC.LogRecordCount()+sinkD.LogRecordCount())
+	assert.InDelta(t, total/2, sinkC.LogRecordCount(), total/10)
+}
+
+func TestMetricsSplitByTraceIDUnsupported(t *testing.T) {
+	idSinkC := pipeline.NewIDWithName(pipeline.SignalMetrics, "canary")
+
+	cfg := testConfig(
+		withSplit(&SplitConfig{Key: "trace_id", Percentage: 10}, idSinkC),
+	)
+	router := connector.NewMetricsRouter(map[pipeline.ID]consumer.Metrics{
+		idSinkC: consumertest.NewNop(),
+	})
+	_, err := NewFactory().CreateMetricsToMetrics(t.Context(),
+		connectortest.NewNopSettings(metadata.Type), cfg, router.(consumer.Metrics))
+	assert.ErrorIs(t, err, errSplitTraceIDMetrics)
+}
+
+func TestMetricsSplitByResourceAttribute(t *testing.T) {
+	idSinkC := pipeline.NewIDWithName(pipeline.SignalMetrics, "canary")
+	idSinkD := pipeline.NewIDWithName(pipeline.SignalMetrics, "default")
+
+	cfg := testConfig(
+		withSplit(&SplitConfig{Key: "resource_attribute", Attribute: "host.name", Percentage: 100}, idSinkC),
+		withDefault(idSinkD),
+	)
+	require.NoError(t, cfg.Validate())
+
+	var sinkC, sinkD consumertest.MetricsSink
+	router := connector.NewMetricsRouter(map[pipeline.ID]consumer.Metrics{
+		idSinkC: &sinkC,
+		idSinkD: &sinkD,
+	})
+	conn, err := NewFactory().CreateMetricsToMetrics(t.Context(),
+		connectortest.NewNopSettings(metadata.Type), cfg, router.(consumer.Metrics))
+	require.NoError(t, err)
+
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty().Resource().Attributes().PutStr("host.name", "a")
+	md.ResourceMetrics().AppendEmpty().Resource().Attributes().PutStr("host.name", "b")
+	md.ResourceMetrics().AppendEmpty()
+
+	require.NoError(t, conn.ConsumeMetrics(t.Context(), md))
+	require.Len(t, sinkC.AllMetrics(), 1)
+	assert.Equal(t, 2, sinkC.AllMetrics()[0].ResourceMetrics().Len())
+	require.Len(t, sinkD.AllMetrics(), 1)
+	assert.Equal(t, 1, sinkD.AllMetrics()[0].ResourceMetrics().Len())
+}
+
+func withSplit(split *SplitConfig, pipelines ...pipeline.ID) testConfigOption {
+	return func(cfg *Config) {
+		cfg.Table = append(cfg.Table,
+			RoutingTableItem{
+				Split:     split,
+				Pipelines: pipelines,
+			})
+	}
+}
+
+func newSplitTraceID(i int) pcommon.TraceID {
+	var id pcommon.TraceID
+	binary.BigEndian.PutUint64(id[8:], uint64(i)+1)
+	return id
+}
+
+func newSplitTraces(n int) ptrace.Traces {
+	td := ptrace.NewTraces()
+	spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	for i := range n {
+		spans.AppendEmpty().SetTraceID(newSplitTraceID(i))
+	}
+	return td
+}
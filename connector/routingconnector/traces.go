@@ -62,7 +62,14 @@ func (*tracesConnector) Capabilities() consumer.Capabilities {
 func (c *tracesConnector) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
 	groups := make(map[consumer.Traces]ptrace.Traces)
 	var errs error
-	for i := 0; i < len(c.router.routeSlice) && td.ResourceSpans().Len() > 0; i++ {
+	// mirrored splits select their share of the data received by the
+	// connector, not of the data left by the routes before them
+	var original ptrace.Traces
+	if c.router.mirrored {
+		original = ptrace.NewTraces()
+		td.CopyTo(original)
+	}
+	for i := 0; i < len(c.router.routeSlice) && (td.ResourceSpans().Len() > 0 || c.router.mirrored); i++ {
 		route := c.router.routeSlice[i]
 		matchedSpans := ptrace.NewTraces()
 		switch route.statementContext {
@@ -80,6 +87,12 @@ func (c *tracesConnector) ConsumeTraces(ctx context.Context, td ptrace.Traces) e
 					return isMatch
 				},
 			)
+		case "split":
+			if route.split.mirror {
+				splitTraces(original, matchedSpans, route.split)
+			} else {
+				splitTraces(td, matchedSpans, route.split)
+			}
 		case "span":
 			ptraceutil.MoveSpansWithContextIf(td, matchedSpans,
 				func(rs ptrace.ResourceSpans, ss ptrace.ScopeSpans, s ptrace.Span) bool {
@@ -106,6 +119,30 @@ func (c *tracesConnector) ConsumeTraces(ctx context.Context, td ptrace.Traces) e
 	return errs
 }
 
+// splitTraces moves the data selected by the split from td to matched. When
+// the split is mirrored the selected data is copied instead.
+func splitTraces(td, matched ptrace.Traces, split *splitRoute) {
+	if split.mirror {
+		mirrored := ptrace.NewTraces()
+		td.CopyTo(mirrored)
+		td = mirrored
+	}
+	switch split.key {
+	case splitKeyTraceID:
+		ptraceutil.MoveSpansWithContextIf(td, matched,
+			func(_ ptrace.ResourceSpans, _ ptrace.ScopeSpans, s ptrace.Span) bool {
+				return split.matchTraceID(s.TraceID())
+			},
+		)
+	case splitKeyResourceAttribute:
+		ptraceutil.MoveResourcesIf(td, matched,
+			func(rs ptrace.ResourceSpans) bool {
+				return split.matchResource(rs.Resource())
+			},
+		)
+	}
+}
+
 func groupAllTraces(
 	groups map[consumer.Traces]ptrace.Traces,
 	cons consumer.Traces,
@@ -61,6 +61,9 @@ type Config struct {
 	// effectively shifting metrics to appear as if they were generated in the past.
 	// Default is 0, which means no offset is applied.
 	MetricsTimestampOffset time.Duration `mapstructure:"metrics_timestamp_offset"`
+
+	// Messaging contains the config for requests across messaging systems.
+	Messaging MessagingConfig `mapstructure:"messaging"`
 }
 
 type StoreConfig struct {
@@ -73,6 +76,21 @@ type StoreConfig struct {
 	_ struct{}
 }
 
+type MessagingConfig struct {
+	// FollowSpanLinks pairs consumer spans with the producer spans they are linked to, instead of
+	// their parent span. This is how most messaging instrumentations connect producers and consumers.
+	FollowSpanLinks bool `mapstructure:"follow_span_links"`
+	// QueueNameDimension adds the `messaging.destination.name` of the producer or consumer span as
+	// `queue_name` dimension to messaging system edges.
+	QueueNameDimension bool `mapstructure:"queue_name_dimension"`
+	// QueueLatency enables the `traces_service_graph_request_messaging_system` histogram, the time
+	// between the end of the producer span and the start of the consumer span.
+	QueueLatency bool `mapstructure:"queue_latency"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
 // Validate checks if the connector configuration is valid.
 func (c *Config) Validate() error {
 	if c.LatencyHistogramBuckets == nil && c.ExponentialHistogramMaxSize < 0 {
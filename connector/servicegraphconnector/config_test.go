@@ -38,6 +38,11 @@ func TestLoadConfig(t *testing.T) {
 			CacheLoop:              time.Minute,
 			StoreExpirationLoop:    2 * time.Second,
 			DatabaseNameAttributes: []string{"db.name"},
+			Messaging: MessagingConfig{
+				FollowSpanLinks:    true,
+				QueueNameDimension: true,
+				QueueLatency:       true,
+			},
 		},
 		cfg.Connectors[component.NewID(metadata.Type)],
 	)
@@ -33,6 +33,7 @@ const (
 	clientKind         = "client"
 	serverKind         = "server"
 	virtualNodeLabel   = "virtual_node"
+	queueNameDimension = "queue_name"
 	millisecondsUnit   = "ms"
 	secondsUnit        = "s"
 )
@@ -81,6 +82,10 @@ type serviceGraphConnector struct {
 	reqServerDurationSecondsSum          map[string]float64
 	reqServerDurationSecondsBucketCounts map[string][]uint64
 	reqServerDurationExpHistogram        map[string]*structure.Histogram[float64]
+	reqQueueLatencySecondsCount          map[string]uint64
+	reqQueueLatencySecondsSum            map[string]float64
+	reqQueueLatencySecondsBucketCounts   map[string][]uint64
+	reqQueueLatencyExpHistogram          map[string]*structure.Histogram[float64]
 	reqDurationBounds                    []float64
 
 	metricMutex sync.RWMutex
@@ -152,6 +157,10 @@ func newConnector(set component.TelemetrySettings, config component.Config, next
 		reqServerDurationSecondsSum:          make(map[string]float64),
 		reqServerDurationSecondsBucketCounts: make(map[string][]uint64),
 		reqServerDurationExpHistogram:        make(map[string]*structure.Histogram[float64]),
+		reqQueueLatencySecondsCount:          make(map[string]uint64),
+		reqQueueLatencySecondsSum:            make(map[string]float64),
+		reqQueueLatencySecondsBucketCounts:   make(map[string][]uint64),
+		reqQueueLatencyExpHistogram:          make(map[string]*structure.Histogram[float64]),
 		reqDurationBounds:                    bounds,
 		keyToMetric:                          make(map[string]metricSeries),
 		shutdownCh:                           make(chan any),
@@ -234,11 +243,6 @@ func (p *serviceGraphConnector) ConsumeTraces(ctx context.Context, td ptrace.Tra
 }
 
 func (p *serviceGraphConnector) aggregateMetrics(ctx context.Context, td ptrace.Traces) (err error) {
-	var (
-		isNew             bool
-		totalDroppedSpans int
-	)
-
 	rss := td.ResourceSpans()
 	for i := 0; i < rss.Len(); i++ {
 		rSpans := rss.At(i)
@@ -267,7 +271,7 @@ func (p *serviceGraphConnector) aggregateMetrics(ctx context.Context, td ptrace.
 				case ptrace.SpanKindClient:
 					traceID := span.TraceID()
 					key := store.NewKey(traceID, span.SpanID())
-					isNew, err = p.store.UpsertEdge(key, func(e *store.Edge) {
+					err = p.upsertEdge(ctx, key, func(e *store.Edge) {
 						e.TraceID = traceID
 						e.ConnectionType = connectionType
 						e.ClientService = serviceName
@@ -279,6 +283,11 @@ func (p *serviceGraphConnector) aggregateMetrics(ctx context.Context, td ptrace.
 							p.upsertPeerAttributes(p.config.VirtualNodePeerAttributes, e.Peer, span.Attributes())
 						}
 
+						if connectionType == store.MessagingSystem {
+							e.ClientEndTime = span.EndTimestamp()
+							p.upsertQueueName(e.Dimensions, span.Attributes())
+						}
+
 						// A database request will only have one span, we don't wait for the server
 						// span but just copy details from the client span
 						if dbName, ok := getFirstMatchingValue(p.config.DatabaseNameAttributes, rAttributes, span.Attributes()); ok {
@@ -290,43 +299,77 @@ func (p *serviceGraphConnector) aggregateMetrics(ctx context.Context, td ptrace.
 				case ptrace.SpanKindConsumer:
 					// override connection type and continue processing as span kind server
 					connectionType = store.MessagingSystem
+					if p.config.Messaging.FollowSpanLinks && span.Links().Len() > 0 {
+						// Consumers are usually linked to the producer of each message they
+						// process instead of being its child, pair them through every link.
+						for l := 0; l < span.Links().Len() && err == nil; l++ {
+							link := span.Links().At(l)
+							err = p.upsertEdge(ctx, store.NewLinkKey(link),
+								p.serverEdgeUpdater(link.TraceID(), serviceName, connectionType, span, rAttributes))
+						}
+						break
+					}
 					fallthrough
 				case ptrace.SpanKindServer:
 					traceID := span.TraceID()
 					key := store.NewKey(traceID, span.ParentSpanID())
-					isNew, err = p.store.UpsertEdge(key, func(e *store.Edge) {
-						e.TraceID = traceID
-						e.ConnectionType = connectionType
-						e.ServerService = serviceName
-						e.ServerLatencySec = spanDuration(span)
-						e.Failed = e.Failed || span.Status().Code() == ptrace.StatusCodeError
-						p.upsertDimensions(serverKind, e.Dimensions, rAttributes, span.Attributes())
-					})
+					err = p.upsertEdge(ctx, key, p.serverEdgeUpdater(traceID, serviceName, connectionType, span, rAttributes))
 				default:
 					// this span is not part of an edge
 					continue
 				}
 
-				if errors.Is(err, store.ErrTooManyItems) {
-					totalDroppedSpans++
-					p.telemetryBuilder.ConnectorServicegraphDroppedSpans.Add(ctx, 1)
-					continue
-				}
-
-				// UpsertEdge will only return ErrTooManyItems
+				// upsertEdge will not return ErrTooManyItems
 				if err != nil {
 					return err
 				}
-
-				if isNew {
-					p.telemetryBuilder.ConnectorServicegraphTotalEdges.Add(ctx, 1)
-				}
 			}
 		}
 	}
 	return nil
 }
 
+// upsertEdge upserts an edge in the store and records the store telemetry. Spans dropped because
+// the store is full are not reported as error.
+func (p *serviceGraphConnector) upsertEdge(ctx context.Context, key store.Key, update store.Callback) error {
+	isNew, err := p.store.UpsertEdge(key, update)
+	if errors.Is(err, store.ErrTooManyItems) {
+		p.telemetryBuilder.ConnectorServicegraphDroppedSpans.Add(ctx, 1)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if isNew {
+		p.telemetryBuilder.ConnectorServicegraphTotalEdges.Add(ctx, 1)
+	}
+	return nil
+}
+
+// serverEdgeUpdater returns the callback updating an edge with the server or consumer span of the request.
+func (p *serviceGraphConnector) serverEdgeUpdater(
+	traceID pcommon.TraceID,
+	serviceName string,
+	connectionType store.ConnectionType,
+	span ptrace.Span,
+	rAttributes pcommon.Map,
+) store.Callback {
+	return func(e *store.Edge) {
+		e.TraceID = traceID
+		e.ConnectionType = connectionType
+		e.ServerService = serviceName
+		e.ServerLatencySec = spanDuration(span)
+		e.Failed = e.Failed || span.Status().Code() == ptrace.StatusCodeError
+		p.upsertDimensions(serverKind, e.Dimensions, rAttributes, span.Attributes())
+
+		if connectionType == store.MessagingSystem {
+			e.ServerStartTime = span.StartTimestamp()
+			p.upsertQueueName(e.Dimensions, span.Attributes())
+		}
+	}
+}
+
 func (p *serviceGraphConnector) upsertDimensions(kind string, m map[string]string, resourceAttr, spanAttr pcommon.Map) {
 	for _, dim := range p.config.Dimensions {
 		if v, ok := pdatautil.GetAttributeValue(dim, resourceAttr, spanAttr); ok {
@@ -335,6 +378,20 @@ func (p *serviceGraphConnector) upsertDimensions(kind string, m map[string]strin
 	}
 }
 
+// upsertQueueName adds the messaging destination of the span as queue name dimension, if enabled
+// and not already set by the other side of the edge.
+func (p *serviceGraphConnector) upsertQueueName(m map[string]string, spanAttr pcommon.Map) {
+	if !p.config.Messaging.QueueNameDimension {
+		return
+	}
+	if _, ok := m[queueNameDimension]; ok {
+		return
+	}
+	if v, ok := pdatautil.GetAttributeValue(string(semconv.MessagingDestinationNameKey), spanAttr); ok {
+		m[queueNameDimension] = v
+	}
+}
+
 func (*serviceGraphConnector) upsertPeerAttributes(m []string, peers map[string]string, spanAttr pcommon.Map) {
 	for _, s := range m {
 		if v, ok := pdatautil.GetAttributeValue(s, spanAttr); ok {
@@ -402,6 +459,10 @@ func (p *serviceGraphConnector) aggregateMetricsForEdge(e *store.Edge) {
 		p.updateErrorMetrics(metricKey)
 	}
 	p.updateDurationMetrics(metricKey, e.ServerLatencySec, e.ClientLatencySec)
+	if p.config.Messaging.QueueLatency && e.ConnectionType == store.MessagingSystem && e.ClientEndTime != 0 && e.ServerStartTime != 0 {
+		// clock skew between producer and consumer can result in negative latencies
+		p.updateQueueLatencyMetrics(metricKey, max(timestampDiff(e.ClientEndTime, e.ServerStartTime), 0))
+	}
 }
 
 func (p *serviceGraphConnector) updateSeries(key string, dimensions pcommon.Map) {
@@ -483,6 +544,33 @@ func (p *serviceGraphConnector) updateClientDurationMetrics(key string, duration
 	}
 }
 
+// updateQueueLatencyMetrics records the time a message spent in the messaging system, between
+// the end of the producer span and the start of the consumer span.
+func (p *serviceGraphConnector) updateQueueLatencyMetrics(key string, latency float64) {
+	if p.reqDurationBounds == nil {
+		histogram, ok := p.reqQueueLatencyExpHistogram[key]
+		if !ok {
+			histogram = new(structure.Histogram[float64])
+			cfg := structure.NewConfig(
+				structure.WithMaxSize(p.config.ExponentialHistogramMaxSize),
+			)
+			histogram.Init(cfg)
+			p.reqQueueLatencyExpHistogram[key] = histogram
+		}
+
+		histogram.Update(latency)
+	} else {
+		index := sort.SearchFloat64s(p.reqDurationBounds, latency) // Search bucket index
+		if _, ok := p.reqQueueLatencySecondsBucketCounts[key]; !ok {
+			p.reqQueueLatencySecondsBucketCounts[key] = make([]uint64, len(p.reqDurationBounds)+1)
+		}
+
+		p.reqQueueLatencySecondsSum[key] += latency
+		p.reqQueueLatencySecondsCount[key]++
+		p.reqQueueLatencySecondsBucketCounts[key][index]++
+	}
+}
+
 func buildDimensions(e *store.Edge) pcommon.Map {
 	dims := pcommon.NewMap()
 	dims.PutStr("client", e.ClientService)
@@ -583,7 +671,61 @@ func (p *serviceGraphConnector) collectLatencyMetrics(ilm pmetric.ScopeMetrics)
 		return err
 	}
 
-	return p.collectClientLatencyMetrics(ilm)
+	if err := p.collectClientLatencyMetrics(ilm); err != nil {
+		return err
+	}
+
+	return p.collectQueueLatencyMetrics(ilm)
+}
+
+func (p *serviceGraphConnector) collectQueueLatencyMetrics(ilm pmetric.ScopeMetrics) error {
+	mLatency := pmetric.NewMetric()
+	mLatency.SetName("traces_service_graph_request_messaging_system")
+	mLatency.SetUnit(secondsUnit)
+	if legacyLatencyUnitMsFeatureGate.IsEnabled() {
+		mLatency.SetUnit(millisecondsUnit)
+	}
+	timestamp := pcommon.NewTimestampFromTime(p.nowWithOffset())
+
+	if p.reqDurationBounds == nil {
+		if len(p.reqQueueLatencyExpHistogram) == 0 {
+			return nil
+		}
+		mLatency.SetEmptyExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		for key, expHistogram := range p.reqQueueLatencyExpHistogram {
+			dpLatency := mLatency.ExponentialHistogram().DataPoints().AppendEmpty()
+			dpLatency.SetStartTimestamp(pcommon.NewTimestampFromTime(p.startTime))
+			dpLatency.SetTimestamp(timestamp)
+			dimensions, ok := p.dimensionsForSeries(key)
+			if !ok {
+				return fmt.Errorf("failed to find dimensions for key %s", key)
+			}
+
+			dimensions.CopyTo(dpLatency.Attributes())
+			pdatautil.ExpoHistToExponentialDataPoint(expHistogram, dpLatency)
+		}
+		mLatency.CopyTo(ilm.Metrics().AppendEmpty())
+	} else if len(p.reqQueueLatencySecondsCount) > 0 {
+		mLatency.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		for key := range p.reqQueueLatencySecondsCount {
+			dpLatency := mLatency.Histogram().DataPoints().AppendEmpty()
+			dpLatency.SetStartTimestamp(pcommon.NewTimestampFromTime(p.startTime))
+			dpLatency.SetTimestamp(timestamp)
+			dpLatency.ExplicitBounds().FromRaw(p.reqDurationBounds)
+			dpLatency.BucketCounts().FromRaw(p.reqQueueLatencySecondsBucketCounts[key])
+			dpLatency.SetCount(p.reqQueueLatencySecondsCount[key])
+			dpLatency.SetSum(p.reqQueueLatencySecondsSum[key])
+
+			dimensions, ok := p.dimensionsForSeries(key)
+			if !ok {
+				return fmt.Errorf("failed to find dimensions for key %s", key)
+			}
+
+			dimensions.CopyTo(dpLatency.Attributes())
+		}
+		mLatency.CopyTo(ilm.Metrics().AppendEmpty())
+	}
+	return nil
 }
 
 func (p *serviceGraphConnector) collectClientLatencyMetrics(ilm pmetric.ScopeMetrics) error {
@@ -704,6 +846,10 @@ func (p *serviceGraphConnector) buildMetricKey(clientName, serverName, connectio
 		}
 	}
 
+	if queueName, ok := edgeDimensions[queueNameDimension]; ok {
+		metricKey.WriteString(metricKeySeparator + queueNameDimension + "_" + queueName)
+	}
+
 	return metricKey.String()
 }
 
@@ -767,6 +913,10 @@ func (p *serviceGraphConnector) cleanCache() {
 		delete(p.reqServerDurationSecondsBucketCounts, key)
 		delete(p.reqServerDurationExpHistogram, key)
 		delete(p.reqClientDurationExpHistogram, key)
+		delete(p.reqQueueLatencySecondsCount, key)
+		delete(p.reqQueueLatencySecondsSum, key)
+		delete(p.reqQueueLatencySecondsBucketCounts, key)
+		delete(p.reqQueueLatencyExpHistogram, key)
 	}
 	p.seriesMutex.Unlock()
 
@@ -785,6 +935,15 @@ func spanDuration(span ptrace.Span) float64 {
 	return float64(span.EndTimestamp()-span.StartTimestamp()) / float64(time.Second.Nanoseconds())
 }
 
+// timestampDiff returns the time between start and end in seconds (legacy ms).
+func timestampDiff(start, end pcommon.Timestamp) float64 {
+	diff := float64(int64(end) - int64(start))
+	if legacyLatencyUnitMsFeatureGate.IsEnabled() {
+		return diff / float64(time.Millisecond.Nanoseconds())
+	}
+	return diff / float64(time.Second.Nanoseconds())
+}
+
 // durationToFloat converts the given duration to the number of seconds (legacy ms) it represents.
 func durationToFloat(d time.Duration) float64 {
 	if legacyLatencyUnitMsFeatureGate.IsEnabled() {
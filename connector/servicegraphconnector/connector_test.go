@@ -30,6 +30,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/servicegraphconnector/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/servicegraphconnector/internal/metadatatest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/servicegraphconnector/internal/store"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/golden"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatatest/pmetrictest"
 )
@@ -863,3 +864,94 @@ func verifyExpDuration(t *testing.T, m pmetric.Metric, expectedDp pmetric.Expone
 func ptr[T any](value T) *T {
 	return &value
 }
+
+func TestMessagingEdgesWithSpanLinks(t *testing.T) {
+	cfg := &Config{
+		Store:                StoreConfig{MaxItems: 10, TTL: time.Hour},
+		MetricsFlushInterval: ptr(time.Hour),
+		Messaging: MessagingConfig{
+			FollowSpanLinks:    true,
+			QueueNameDimension: true,
+			QueueLatency:       true,
+		},
+	}
+
+	set := componenttest.NewNopTelemetrySettings()
+	set.Logger = zaptest.NewLogger(t)
+	conn, err := newConnector(set, cfg, newMockMetricsExporter())
+	require.NoError(t, err)
+	assert.NoError(t, conn.Start(t.Context(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, conn.Shutdown(t.Context())) }()
+
+	assert.NoError(t, conn.ConsumeTraces(t.Context(), buildBatchConsumerTraces()))
+	md, err := conn.buildMetrics()
+	require.NoError(t, err)
+
+	expectAttributes := pcommon.NewMap()
+	expectAttributes.PutStr("client", "producer-service")
+	expectAttributes.PutStr("server", "consumer-service")
+	expectAttributes.PutStr("connection_type", string(store.MessagingSystem))
+	expectAttributes.PutBool("failed", false)
+	expectAttributes.PutStr("queue_name", "orders")
+
+	ms := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 4, ms.Len())
+
+	// Both messages of the batch are paired with the consumer span through their link
+	mCount := ms.At(0)
+	assert.Equal(t, "traces_service_graph_request_total", mCount.Name())
+	require.Equal(t, 1, mCount.Sum().DataPoints().Len())
+	assert.Equal(t, int64(2), mCount.Sum().DataPoints().At(0).IntValue())
+	assert.Equal(t, expectAttributes.AsRaw(), mCount.Sum().DataPoints().At(0).Attributes().AsRaw())
+
+	mQueue := ms.At(3)
+	assert.Equal(t, "traces_service_graph_request_messaging_system", mQueue.Name())
+	assert.Equal(t, secondsUnit, mQueue.Unit())
+	require.Equal(t, 1, mQueue.Histogram().DataPoints().Len())
+	dp := mQueue.Histogram().DataPoints().At(0)
+	assert.Equal(t, uint64(2), dp.Count())
+	// messages spent 2s and 3s in the queue
+	assert.InDelta(t, 5, dp.Sum(), 0.0001)
+	assert.Equal(t, expectAttributes.AsRaw(), dp.Attributes().AsRaw())
+}
+
+// buildBatchConsumerTraces builds two producer spans, each in its own trace, and one consumer span
+// processing both messages in a third trace, linked to the producer spans.
+func buildBatchConsumerTraces() ptrace.Traces {
+	tStart := time.Date(2022, 1, 2, 3, 4, 5, 6, time.UTC)
+
+	traces := ptrace.NewTraces()
+
+	producerSpans := traces.ResourceSpans().AppendEmpty()
+	producerSpans.Resource().Attributes().PutStr(string(semconv.ServiceNameKey), "producer-service")
+	consumerSpans := traces.ResourceSpans().AppendEmpty()
+	consumerSpans.Resource().Attributes().PutStr(string(semconv.ServiceNameKey), "consumer-service")
+
+	consumerSpan := consumerSpans.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	consumerSpan.SetName("orders process")
+	consumerSpan.SetTraceID([16]byte{3})
+	consumerSpan.SetSpanID([8]byte{3})
+	consumerSpan.SetKind(ptrace.SpanKindConsumer)
+	consumerSpan.SetStartTimestamp(pcommon.NewTimestampFromTime(tStart.Add(4 * time.Second)))
+	consumerSpan.SetEndTimestamp(pcommon.NewTimestampFromTime(tStart.Add(5 * time.Second)))
+
+	for i, end := range []time.Duration{2 * time.Second, time.Second} {
+		traceID := pcommon.TraceID([16]byte{byte(i + 1)})
+		spanID := pcommon.SpanID([8]byte{byte(i + 1)})
+
+		producerSpan := producerSpans.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+		producerSpan.SetName("orders publish")
+		producerSpan.SetTraceID(traceID)
+		producerSpan.SetSpanID(spanID)
+		producerSpan.SetKind(ptrace.SpanKindProducer)
+		producerSpan.SetStartTimestamp(pcommon.NewTimestampFromTime(tStart))
+		producerSpan.SetEndTimestamp(pcommon.NewTimestampFromTime(tStart.Add(end)))
+		producerSpan.Attributes().PutStr(string(semconv.MessagingDestinationNameKey), "orders")
+
+		link := consumerSpan.Links().AppendEmpty()
+		link.SetTraceID(traceID)
+		link.SetSpanID(spanID)
+	}
+
+	return traces
+}
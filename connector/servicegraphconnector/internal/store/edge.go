@@ -50,6 +50,10 @@ type Edge struct {
 
 	// VirtualNodeLabel is an optional label to be added to the spans
 	VirtualNodeLabel VirtualNodeLabel
+
+	// ClientEndTime and ServerStartTime are the end of the producer span and the start of the
+	// consumer span of a messaging system Edge, used to compute the time spent in the queue
+	ClientEndTime, ServerStartTime pcommon.Timestamp
 }
 
 func newEdge(key Key, ttl time.Duration) *Edge {
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 )
 
 var ErrTooManyItems = errors.New("too many items")
@@ -29,6 +30,12 @@ func NewKey(tid pcommon.TraceID, sid pcommon.SpanID) Key {
 	return Key{tid: tid, sid: sid}
 }
 
+// NewLinkKey returns the Key of the span a link points to. It is used to pair consumer spans with
+// the producer spans they are linked to, which can belong to another trace.
+func NewLinkKey(link ptrace.SpanLink) Key {
+	return Key{tid: link.TraceID(), sid: link.SpanID()}
+}
+
 type Store struct {
 	l   *list.List
 	mtx sync.Mutex
@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 )
 
 const clientService = "client"
@@ -99,6 +100,33 @@ func TestStoreUpsertEdge_errTooManyItems(t *testing.T) {
 	assert.Equal(t, 0, onCallbackCounter)
 }
 
+func TestStoreUpsertEdge_linkKey(t *testing.T) {
+	producerTraceID := pcommon.TraceID([16]byte{1, 2, 3})
+	producerSpanID := pcommon.SpanID([8]byte{4, 5, 6})
+
+	var completed []*Edge
+	s := NewStore(time.Hour, 10, func(e *Edge) { completed = append(completed, e) }, noopCallback)
+
+	_, err := s.UpsertEdge(NewKey(producerTraceID, producerSpanID), func(e *Edge) {
+		e.ClientService = clientService
+	})
+	require.NoError(t, err)
+
+	// The consumer span belongs to another trace and is linked to the producer span
+	link := ptrace.NewSpanLink()
+	link.SetTraceID(producerTraceID)
+	link.SetSpanID(producerSpanID)
+	_, err = s.UpsertEdge(NewLinkKey(link), func(e *Edge) {
+		e.ServerService = "server"
+	})
+	require.NoError(t, err)
+
+	require.Len(t, completed, 1)
+	assert.Equal(t, clientService, completed[0].ClientService)
+	assert.Equal(t, "server", completed[0].ServerService)
+	assert.Equal(t, 0, s.Len())
+}
+
 func TestStoreExpire(t *testing.T) {
 	const testSize = 100
 
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/lightstep/go-expohisto/structure"
 	"go.opentelemetry.io/collector/component"
@@ -34,6 +35,21 @@ const (
 	defaultExponentialHistogramMaxSize = 160
 )
 
+const (
+	// defaultPairedEventsTimeout is the default time a start event waits
+	// for its end event before it is counted as timed out.
+	defaultPairedEventsTimeout = 5 * time.Minute
+	// defaultPairedEventsMaxPending is the default number of start events
+	// waiting for their end event kept per metric.
+	defaultPairedEventsMaxPending = 10_000
+	// defaultPairedEventsUnit is the default unit of the durations recorded
+	// for paired events, it matches the default histogram buckets.
+	defaultPairedEventsUnit = "ms"
+	// timeoutMetricSuffix is appended to the metric name to create the name
+	// of the counter of timed out start events, if not configured.
+	timeoutMetricSuffix = ".timeouts"
+)
+
 var defaultHistogramBuckets = []float64{
 	2, 4, 6, 8, 10, 50, 100, 200, 400, 800, 1000, 1400, 2000, 5000, 10_000, 15_000,
 }
@@ -87,6 +103,9 @@ func (c *Config) Validate() error {
 			if err := validateMetricInfo(dp, parser); err != nil {
 				multiError = errors.Join(multiError, fmt.Errorf("failed to validate datapoints configuration: %w", err))
 			}
+			if dp.PairedEvents.HasValue() {
+				multiError = errors.Join(multiError, errors.New("failed to validate datapoints configuration: paired events are only supported for spans and logs"))
+			}
 		}
 	}
 	if len(c.Logs) > 0 {
@@ -117,6 +136,9 @@ func (c *Config) Validate() error {
 			if err := validateMetricInfo(profile, parser); err != nil {
 				multiError = errors.Join(multiError, fmt.Errorf("failed to validate profiles configuration: %w", err))
 			}
+			if profile.PairedEvents.HasValue() {
+				multiError = errors.Join(multiError, errors.New("failed to validate profiles configuration: paired events are only supported for spans and logs"))
+			}
 		}
 	}
 	return multiError
@@ -191,6 +213,35 @@ type Gauge struct {
 	_ struct{}
 }
 
+// PairedEvents configures a histogram, or an exponential histogram, to record
+// the duration between a start event and the end event sharing the same
+// correlation key. The events can be part of different batches, start events
+// are kept until their end event is received or until they time out.
+type PairedEvents struct {
+	// StartConditions are a set of OTTL conditions which are ORed. Events
+	// matching them start a new duration.
+	StartConditions []string `mapstructure:"start_conditions"`
+	// EndConditions are a set of OTTL conditions which are ORed. Events
+	// matching them end the duration started with the same correlation key.
+	EndConditions []string `mapstructure:"end_conditions"`
+	// CorrelationKey is an OTTL value expression returning the key pairing
+	// a start event with its end event.
+	CorrelationKey string `mapstructure:"correlation_key"`
+	// Timeout is the time a start event waits for its end event before it
+	// is dropped and counted in the timeout metric.
+	Timeout time.Duration `mapstructure:"timeout"`
+	// MaxPending is the maximum number of start events waiting for their
+	// end event. When reached, the oldest start event is dropped and
+	// counted in the timeout metric.
+	MaxPending int `mapstructure:"max_pending"`
+	// TimeoutMetric is the name of the sum counting the start events that
+	// were dropped without a matching end event. Defaults to the metric
+	// name suffixed with `.timeouts`.
+	TimeoutMetric string `mapstructure:"timeout_metric"`
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
 // MetricInfo defines the structure of the metric produced by the connector.
 type MetricInfo struct {
 	Name        string `mapstructure:"name"`
@@ -210,6 +261,10 @@ type MetricInfo struct {
 	ExponentialHistogram configoptional.Optional[ExponentialHistogram] `mapstructure:"exponential_histogram"`
 	Sum                  configoptional.Optional[Sum]                  `mapstructure:"sum"`
 	Gauge                configoptional.Optional[Gauge]                `mapstructure:"gauge"`
+	// PairedEvents, if set, makes the histogram or exponential histogram
+	// record the duration between paired start and end events instead of
+	// a value extracted from each event.
+	PairedEvents configoptional.Optional[PairedEvents] `mapstructure:"paired_events"`
 	// prevent unkeyed literal initialization
 	_ struct{}
 }
@@ -226,6 +281,21 @@ func (mi *MetricInfo) ensureDefaults() {
 			mi.ExponentialHistogram.Get().MaxSize = defaultExponentialHistogramMaxSize
 		}
 	}
+	if mi.PairedEvents.HasValue() {
+		pe := mi.PairedEvents.Get()
+		if pe.Timeout == 0 {
+			pe.Timeout = defaultPairedEventsTimeout
+		}
+		if pe.MaxPending == 0 {
+			pe.MaxPending = defaultPairedEventsMaxPending
+		}
+		if pe.TimeoutMetric == "" {
+			pe.TimeoutMetric = mi.Name + timeoutMetricSuffix
+		}
+		if mi.Unit == "" {
+			mi.Unit = defaultPairedEventsUnit
+		}
+	}
 }
 
 func (mi *MetricInfo) validateAttributes() error {
@@ -250,14 +320,19 @@ func (mi *MetricInfo) validateAttributes() error {
 }
 
 func (mi *MetricInfo) validateHistogram() error {
+	// The value of paired events histograms is the duration between the events.
+	paired := mi.PairedEvents.HasValue()
 	if mi.Histogram.HasValue() {
 		h := mi.Histogram.Get()
 		if len(h.Buckets) == 0 {
 			return errors.New("histogram buckets missing")
 		}
-		if h.Value == "" {
+		if h.Value == "" && !paired {
 			return errors.New("value OTTL statement is required")
 		}
+		if h.Value != "" && paired {
+			return errors.New("value OTTL statement must not be set for paired events")
+		}
 	}
 	if mi.ExponentialHistogram.HasValue() {
 		eh := mi.ExponentialHistogram.Get()
@@ -266,9 +341,46 @@ func (mi *MetricInfo) validateHistogram() error {
 		).Validate(); err != nil {
 			return err
 		}
-		if eh.Value == "" {
+		if eh.Value == "" && !paired {
 			return errors.New("value OTTL statement is required")
 		}
+		if eh.Value != "" && paired {
+			return errors.New("value OTTL statement must not be set for paired events")
+		}
+	}
+	return nil
+}
+
+func (mi *MetricInfo) validatePairedEvents() error {
+	if !mi.PairedEvents.HasValue() {
+		return nil
+	}
+	pe := mi.PairedEvents.Get()
+	if !mi.Histogram.HasValue() && !mi.ExponentialHistogram.HasValue() {
+		return errors.New("paired events require a histogram or an exponential histogram")
+	}
+	if len(pe.StartConditions) == 0 {
+		return errors.New("start conditions are required")
+	}
+	if len(pe.EndConditions) == 0 {
+		return errors.New("end conditions are required")
+	}
+	if pe.CorrelationKey == "" {
+		return errors.New("correlation key OTTL statement is required")
+	}
+	if pe.Timeout <= 0 {
+		return errors.New("timeout must be positive")
+	}
+	if pe.MaxPending <= 0 {
+		return errors.New("max pending must be positive")
+	}
+	if pe.TimeoutMetric == "" {
+		return errors.New("timeout metric name is required")
+	}
+	switch mi.Unit {
+	case "s", "ms", "us", "ns":
+	default:
+		return fmt.Errorf("unit must be one of s, ms, us or ns for paired events, got %q", mi.Unit)
 	}
 	return nil
 }
@@ -303,6 +415,9 @@ func validateMetricInfo[K any](mi *MetricInfo, parser ottl.Parser[K]) error {
 	if err := mi.validateHistogram(); err != nil {
 		return fmt.Errorf("histogram validation failed: %w", err)
 	}
+	if err := mi.validatePairedEvents(); err != nil {
+		return fmt.Errorf("paired events validation failed: %w", err)
+	}
 	if err := mi.validateSum(); err != nil {
 		return fmt.Errorf("sum validation failed: %w", err)
 	}
@@ -322,8 +437,10 @@ func validateMetricInfo[K any](mi *MetricInfo, parser ottl.Parser[K]) error {
 				return fmt.Errorf("failed to parse count OTTL expression for explicit histogram: %w", err)
 			}
 		}
-		if _, err := parser.ParseValueExpression(h.Value); err != nil {
-			return fmt.Errorf("failed to parse value OTTL expression for explicit histogram: %w", err)
+		if h.Value != "" {
+			if _, err := parser.ParseValueExpression(h.Value); err != nil {
+				return fmt.Errorf("failed to parse value OTTL expression for explicit histogram: %w", err)
+			}
 		}
 	}
 	if mi.ExponentialHistogram.HasValue() {
@@ -334,8 +451,10 @@ func validateMetricInfo[K any](mi *MetricInfo, parser ottl.Parser[K]) error {
 				return fmt.Errorf("failed to parse count OTTL expression for exponential histogram: %w", err)
 			}
 		}
-		if _, err := parser.ParseValueExpression(eh.Value); err != nil {
-			return fmt.Errorf("failed to parse value OTTL expression for exponential histogram: %w", err)
+		if eh.Value != "" {
+			if _, err := parser.ParseValueExpression(eh.Value); err != nil {
+				return fmt.Errorf("failed to parse value OTTL expression for exponential histogram: %w", err)
+			}
 		}
 	}
 	if mi.Sum.HasValue() {
@@ -366,5 +485,17 @@ func validateMetricInfo[K any](mi *MetricInfo, parser ottl.Parser[K]) error {
 	if _, err := parser.ParseConditions(mi.Conditions); err != nil {
 		return fmt.Errorf("failed to parse OTTL conditions: %w", err)
 	}
+	if mi.PairedEvents.HasValue() {
+		pe := mi.PairedEvents.Get()
+		if _, err := parser.ParseConditions(pe.StartConditions); err != nil {
+			return fmt.Errorf("failed to parse OTTL start conditions: %w", err)
+		}
+		if _, err := parser.ParseConditions(pe.EndConditions); err != nil {
+			return fmt.Errorf("failed to parse OTTL end conditions: %w", err)
+		}
+		if _, err := parser.ParseValueExpression(pe.CorrelationKey); err != nil {
+			return fmt.Errorf("failed to parse correlation key OTTL expression: %w", err)
+		}
+	}
 	return nil
 }
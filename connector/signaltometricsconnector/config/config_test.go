@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -124,6 +125,56 @@ func TestConfig(t *testing.T) {
 				fullErrorForSignal(t, "profiles", "failed to parse OTTL conditions"),
 			},
 		},
+		{
+			path: "invalid_paired_events",
+			errorMsgs: []string{
+				fullErrorForSignal(t, "spans", "paired events validation failed: paired events require a histogram or an exponential histogram"),
+				fullErrorForSignal(t, "datapoints", "paired events are only supported for spans and logs"),
+				fullErrorForSignal(t, "logs", "histogram validation failed: value OTTL statement must not be set for paired events"),
+				fullErrorForSignal(t, "profiles", "paired events validation failed: unit must be one of s, ms, us or ns"),
+				fullErrorForSignal(t, "profiles", "paired events are only supported for spans and logs"),
+			},
+		},
+		{
+			path: "valid_paired_events",
+			expected: &Config{
+				Spans: []MetricInfo{
+					{
+						Name: "span.paired.duration",
+						Unit: "ms",
+						ExponentialHistogram: configoptional.Some(ExponentialHistogram{
+							MaxSize: 10,
+						}),
+						PairedEvents: configoptional.Some(PairedEvents{
+							StartConditions: []string{`name == "enqueue"`},
+							EndConditions:   []string{`name == "dequeue"`},
+							CorrelationKey:  `attributes["message.id"]`,
+							Timeout:         defaultPairedEventsTimeout,
+							MaxPending:      defaultPairedEventsMaxPending,
+							TimeoutMetric:   "span.paired.duration.timeouts",
+						}),
+					},
+				},
+				Logs: []MetricInfo{
+					{
+						Name:       "log.paired.duration",
+						Unit:       "s",
+						Attributes: []Attribute{{Key: "job.name"}},
+						Histogram: configoptional.Some(Histogram{
+							Buckets: []float64{1, 10, 100},
+						}),
+						PairedEvents: configoptional.Some(PairedEvents{
+							StartConditions: []string{`attributes["event"] == "start"`},
+							EndConditions:   []string{`attributes["event"] == "end"`},
+							CorrelationKey:  `attributes["job.id"]`,
+							Timeout:         time.Hour,
+							MaxPending:      100,
+							TimeoutMetric:   "log.paired.timeouts",
+						}),
+					},
+				},
+			},
+		},
 		{
 			path: "valid_full",
 			expected: &Config{
@@ -6,6 +6,8 @@ package signaltometricsconnector // import "github.com/open-telemetry/openteleme
 import (
 	"context"
 	"fmt"
+	"sync"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
@@ -33,15 +35,91 @@ type signalToMetrics struct {
 	dpMetricDefs      []model.MetricDef[ottldatapoint.TransformContext]
 	logMetricDefs     []model.MetricDef[ottllog.TransformContext]
 	profileMetricDefs []model.MetricDef[ottlprofile.TransformContext]
+	// pendingEvents holds the start events of paired events metrics, indexed
+	// like the metric definitions of the signal handled by the connector. The
+	// entries of metric definitions without paired events are nil.
+	pendingEvents []*aggregator.PendingEvents
+	// sweepInterval is the interval at which expired start events are
+	// counted, so that they are reported even without further batches.
+	sweepInterval time.Duration
 
-	component.StartFunc
-	component.ShutdownFunc
+	cancel context.CancelFunc
+	done   sync.WaitGroup
+}
+
+func (sm *signalToMetrics) Start(context.Context, component.Host) error {
+	if !hasPendingEvents(sm.pendingEvents) {
+		return nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	sm.cancel = cancel
+	sm.done.Add(1)
+	go func() {
+		defer sm.done.Done()
+
+		tick := time.NewTicker(sm.sweepInterval)
+		defer tick.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-tick.C:
+				if err := sm.sweep(ctx); err != nil {
+					sm.logger.Error("failed to consume timed out paired events", zap.Error(err))
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+func (sm *signalToMetrics) Shutdown(context.Context) error {
+	if sm.cancel != nil {
+		sm.cancel()
+	}
+	sm.done.Wait()
+	return nil
 }
 
 func (*signalToMetrics) Capabilities() consumer.Capabilities {
 	return consumer.Capabilities{MutatesData: false}
 }
 
+// sweep counts the start events of paired events metrics that expired or
+// were evicted since the last batch or sweep.
+func (sm *signalToMetrics) sweep(ctx context.Context) error {
+	processedMetrics := pmetric.NewMetrics()
+	switch {
+	case len(sm.spanMetricDefs) > 0:
+		aggregateTimeouts(processedMetrics, sm.spanMetricDefs, sm.pendingEvents)
+	case len(sm.logMetricDefs) > 0:
+		aggregateTimeouts(processedMetrics, sm.logMetricDefs, sm.pendingEvents)
+	}
+	if processedMetrics.MetricCount() == 0 {
+		return nil
+	}
+	return sm.next.ConsumeMetrics(ctx, processedMetrics)
+}
+
+func aggregateTimeouts[K any](processedMetrics pmetric.Metrics, mds []model.MetricDef[K], pending []*aggregator.PendingEvents) {
+	aggregator := aggregator.NewAggregator[K](processedMetrics)
+	for mdIdx, md := range mds {
+		if md.PairedEvents != nil {
+			aggregator.AggregateTimeouts(md, pending[mdIdx])
+		}
+	}
+	aggregator.Finalize(mds)
+}
+
+func hasPendingEvents(pending []*aggregator.PendingEvents) bool {
+	for _, p := range pending {
+		if p != nil {
+			return true
+		}
+	}
+	return false
+}
+
 func (sm *signalToMetrics) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
 	if len(sm.spanMetricDefs) == 0 {
 		return nil
@@ -59,7 +137,7 @@ func (sm *signalToMetrics) ConsumeTraces(ctx context.Context, td ptrace.Traces)
 			for k := 0; k < scopeSpan.Spans().Len(); k++ {
 				span := scopeSpan.Spans().At(k)
 				spanAttrs := span.Attributes()
-				for _, md := range sm.spanMetricDefs {
+				for mdIdx, md := range sm.spanMetricDefs {
 					filteredSpanAttrs, ok := md.FilterAttributes(spanAttrs)
 					if !ok {
 						continue
@@ -80,6 +158,16 @@ func (sm *signalToMetrics) ConsumeTraces(ctx context.Context, td ptrace.Traces)
 					}
 
 					filteredResAttrs := md.FilterResourceAttributes(resourceAttrs, sm.collectorInstanceInfo)
+					if md.PairedEvents != nil {
+						if err := aggregator.AggregatePairedEvents(
+							ctx, tCtx, md, sm.pendingEvents[mdIdx],
+							filteredResAttrs, filteredSpanAttrs,
+							span.StartTimestamp(), span.EndTimestamp(),
+						); err != nil {
+							return err
+						}
+						continue
+					}
 					if err := aggregator.Aggregate(ctx, tCtx, md, filteredResAttrs, filteredSpanAttrs, 1); err != nil {
 						return err
 					}
@@ -87,6 +175,11 @@ func (sm *signalToMetrics) ConsumeTraces(ctx context.Context, td ptrace.Traces)
 			}
 		}
 	}
+	for mdIdx, md := range sm.spanMetricDefs {
+		if md.PairedEvents != nil {
+			aggregator.AggregateTimeouts(md, sm.pendingEvents[mdIdx])
+		}
+	}
 	aggregator.Finalize(sm.spanMetricDefs)
 	return sm.next.ConsumeMetrics(ctx, processedMetrics)
 }
@@ -215,7 +308,7 @@ func (sm *signalToMetrics) ConsumeLogs(ctx context.Context, logs plog.Logs) erro
 			for k := 0; k < scopeLog.LogRecords().Len(); k++ {
 				log := scopeLog.LogRecords().At(k)
 				logAttrs := log.Attributes()
-				for _, md := range sm.logMetricDefs {
+				for mdIdx, md := range sm.logMetricDefs {
 					filteredLogAttrs, ok := md.FilterAttributes(logAttrs)
 					if !ok {
 						continue
@@ -235,6 +328,20 @@ func (sm *signalToMetrics) ConsumeLogs(ctx context.Context, logs plog.Logs) erro
 						}
 					}
 					filteredResAttrs := md.FilterResourceAttributes(resourceAttrs, sm.collectorInstanceInfo)
+					if md.PairedEvents != nil {
+						timestamp := log.Timestamp()
+						if timestamp == 0 {
+							timestamp = log.ObservedTimestamp()
+						}
+						if err := aggregator.AggregatePairedEvents(
+							ctx, tCtx, md, sm.pendingEvents[mdIdx],
+							filteredResAttrs, filteredLogAttrs,
+							timestamp, timestamp,
+						); err != nil {
+							return err
+						}
+						continue
+					}
 					if err := aggregator.Aggregate(ctx, tCtx, md, filteredResAttrs, filteredLogAttrs, 1); err != nil {
 						return err
 					}
@@ -242,6 +349,11 @@ func (sm *signalToMetrics) ConsumeLogs(ctx context.Context, logs plog.Logs) erro
 			}
 		}
 	}
+	for mdIdx, md := range sm.logMetricDefs {
+		if md.PairedEvents != nil {
+			aggregator.AggregateTimeouts(md, sm.pendingEvents[mdIdx])
+		}
+	}
 	aggregator.Finalize(sm.logMetricDefs)
 	return sm.next.ConsumeMetrics(ctx, processedMetrics)
 }
@@ -7,10 +7,12 @@ import (
 	"context"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configoptional"
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
@@ -21,6 +23,7 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 	"go.uber.org/zap/zapcore"
@@ -135,6 +138,118 @@ func TestConnectorWithLogs(t *testing.T) {
 	}
 }
 
+func TestConnectorWithPairedEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	next := &consumertest.MetricsSink{}
+	factory, settings, cfg := setupConnector(t, filepath.Join(testDataDir, "logs", "paired_events"))
+	connector, err := factory.CreateLogsToMetrics(ctx, settings, cfg, next)
+	require.NoError(t, err)
+
+	// The start events are paired with end events of later batches.
+	require.NoError(t, connector.ConsumeLogs(ctx, pairedEventLogs(
+		pairedEvent{event: "start", id: "1", timestamp: 0},
+		pairedEvent{event: "start", id: "2", timestamp: time.Second},
+	)))
+	require.Len(t, next.AllMetrics(), 1)
+	assert.Nil(t, findMetric(next.AllMetrics()[0], "job.duration"))
+
+	// Job 2 is evicted as only 2 start events can be pending.
+	require.NoError(t, connector.ConsumeLogs(ctx, pairedEventLogs(
+		pairedEvent{event: "end", id: "1", timestamp: 5 * time.Second},
+		pairedEvent{event: "start", id: "3", timestamp: 6 * time.Second},
+		pairedEvent{event: "start", id: "4", timestamp: 7 * time.Second},
+		pairedEvent{event: "end", id: "unknown", timestamp: 8 * time.Second},
+	)))
+	require.Len(t, next.AllMetrics(), 2)
+	duration := findMetric(next.AllMetrics()[1], "job.duration")
+	require.NotNil(t, duration)
+	assert.Equal(t, "s", duration.Unit())
+	require.Equal(t, 1, duration.Histogram().DataPoints().Len())
+	dp := duration.Histogram().DataPoints().At(0)
+	assert.Equal(t, uint64(1), dp.Count())
+	assert.Equal(t, 5.0, dp.Sum())
+	assert.Equal(t, []uint64{0, 1, 0, 0}, dp.BucketCounts().AsRaw())
+	jobName, ok := dp.Attributes().Get("job.name")
+	require.True(t, ok)
+	assert.Equal(t, "backup", jobName.Str())
+
+	timeouts := findMetric(next.AllMetrics()[1], "job.duration.timeouts")
+	require.NotNil(t, timeouts)
+	require.Equal(t, 1, timeouts.Sum().DataPoints().Len())
+	assert.Equal(t, int64(1), timeouts.Sum().DataPoints().At(0).IntValue())
+
+	require.NoError(t, connector.ConsumeLogs(ctx, pairedEventLogs(
+		pairedEvent{event: "end", id: "4", timestamp: 27 * time.Second},
+	)))
+	require.Len(t, next.AllMetrics(), 3)
+	duration = findMetric(next.AllMetrics()[2], "job.duration")
+	require.NotNil(t, duration)
+	assert.Equal(t, 20.0, duration.Histogram().DataPoints().At(0).Sum())
+	assert.Nil(t, findMetric(next.AllMetrics()[2], "job.duration.timeouts"))
+}
+
+func TestConnectorSweepsPairedEvents(t *testing.T) {
+	next := &consumertest.MetricsSink{}
+	factory, settings, cfg := setupConnector(t, filepath.Join(testDataDir, "logs", "paired_events"))
+	cfg.(*config.Config).Logs[0].PairedEvents.Get().Timeout = 10 * time.Millisecond
+	connector, err := factory.CreateLogsToMetrics(t.Context(), settings, cfg, next)
+	require.NoError(t, err)
+	connector.(*signalToMetrics).sweepInterval = time.Millisecond
+	require.NoError(t, connector.Start(t.Context(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, connector.Shutdown(t.Context()))
+	}()
+
+	require.NoError(t, connector.ConsumeLogs(t.Context(), pairedEventLogs(
+		pairedEvent{event: "start", id: "1", timestamp: 0},
+	)))
+
+	// The start event times out without receiving any further batch.
+	require.Eventually(t, func() bool {
+		return len(next.AllMetrics()) == 2
+	}, time.Second, time.Millisecond)
+	timeouts := findMetric(next.AllMetrics()[1], "job.duration.timeouts")
+	require.NotNil(t, timeouts)
+	require.Equal(t, 1, timeouts.Sum().DataPoints().Len())
+	assert.Equal(t, int64(1), timeouts.Sum().DataPoints().At(0).IntValue())
+}
+
+type pairedEvent struct {
+	event     string
+	id        string
+	timestamp time.Duration
+}
+
+func pairedEventLogs(events ...pairedEvent) plog.Logs {
+	logs := plog.NewLogs()
+	records := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	for _, e := range events {
+		lr := records.AppendEmpty()
+		lr.SetTimestamp(pcommon.NewTimestampFromTime(time.Unix(0, 0).Add(e.timestamp)))
+		lr.Attributes().PutStr("event", e.event)
+		lr.Attributes().PutStr("job.id", e.id)
+		lr.Attributes().PutStr("job.name", "backup")
+	}
+	return logs
+}
+
+func findMetric(md pmetric.Metrics, name string) *pmetric.Metric {
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		sms := md.ResourceMetrics().At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			metrics := sms.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				if m := metrics.At(k); m.Name() == name {
+					return &m
+				}
+			}
+		}
+	}
+	return nil
+}
+
 func TestConnectorWithProfiles(t *testing.T) {
 	testCases := []string{
 		"sum",
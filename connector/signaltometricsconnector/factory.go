@@ -6,6 +6,7 @@ package signaltometricsconnector // import "github.com/open-telemetry/openteleme
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/connector"
@@ -13,6 +14,7 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/signaltometricsconnector/config"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/signaltometricsconnector/internal/aggregator"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/signaltometricsconnector/internal/customottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/signaltometricsconnector/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/signaltometricsconnector/internal/model"
@@ -67,6 +69,8 @@ func createTracesToMetrics(
 		),
 		next:           nextConsumer,
 		spanMetricDefs: metricDefs,
+		pendingEvents:  newPendingEvents(metricDefs),
+		sweepInterval:  defaultSweepInterval,
 	}, nil
 }
 
@@ -131,6 +135,8 @@ func createLogsToMetrics(
 		),
 		next:          nextConsumer,
 		logMetricDefs: metricDefs,
+		pendingEvents: newPendingEvents(metricDefs),
+		sweepInterval: defaultSweepInterval,
 	}, nil
 }
 
@@ -165,3 +171,19 @@ func createProfilesToMetrics(
 		profileMetricDefs: metricDefs,
 	}, nil
 }
+
+// defaultSweepInterval is the interval at which expired start events of
+// paired events metrics are counted.
+const defaultSweepInterval = time.Second
+
+// newPendingEvents creates the state kept across batches for the metric
+// definitions recording paired events.
+func newPendingEvents[K any](mds []model.MetricDef[K]) []*aggregator.PendingEvents {
+	pending := make([]*aggregator.PendingEvents, len(mds))
+	for i, md := range mds {
+		if md.PairedEvents != nil {
+			pending[i] = aggregator.NewPendingEvents(md.PairedEvents.Timeout, md.PairedEvents.MaxPending)
+		}
+	}
+	return pending
+}
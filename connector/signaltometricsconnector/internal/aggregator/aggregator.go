@@ -153,21 +153,8 @@ func (a *Aggregator[K]) Finalize(mds []model.MetricDef[K]) {
 				)
 			}
 		}
-		for resID, dpMap := range a.sums[md.Key] {
-			if md.Sum == nil {
-				continue
-			}
-			metrics := a.smLookup[resID].Metrics()
-			destMetric := metrics.AppendEmpty()
-			destMetric.SetName(md.Key.Name)
-			destMetric.SetUnit(md.Key.Unit)
-			destMetric.SetDescription(md.Key.Description)
-			destCounter := destMetric.SetEmptySum()
-			destCounter.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
-			destCounter.DataPoints().EnsureCapacity(len(dpMap))
-			for _, dp := range dpMap {
-				dp.Copy(a.timestamp, destCounter.DataPoints().AppendEmpty())
-			}
+		if md.Sum != nil {
+			a.finalizeSums(md.Key)
 		}
 		for resID, dpMap := range a.gauges[md.Key] {
 			if md.Gauge == nil {
@@ -190,6 +177,26 @@ func (a *Aggregator[K]) Finalize(mds []model.MetricDef[K]) {
 		delete(a.valueCounts, md.Key)
 		delete(a.sums, md.Key)
 		delete(a.gauges, md.Key)
+		if md.PairedEvents != nil {
+			a.finalizeSums(md.PairedEvents.TimeoutKey)
+			delete(a.sums, md.PairedEvents.TimeoutKey)
+		}
+	}
+}
+
+func (a *Aggregator[K]) finalizeSums(key model.MetricKey) {
+	for resID, dpMap := range a.sums[key] {
+		metrics := a.smLookup[resID].Metrics()
+		destMetric := metrics.AppendEmpty()
+		destMetric.SetName(key.Name)
+		destMetric.SetUnit(key.Unit)
+		destMetric.SetDescription(key.Description)
+		destCounter := destMetric.SetEmptySum()
+		destCounter.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+		destCounter.DataPoints().EnsureCapacity(len(dpMap))
+		for _, dp := range dpMap {
+			dp.Copy(a.timestamp, destCounter.DataPoints().AppendEmpty())
+		}
 	}
 }
 
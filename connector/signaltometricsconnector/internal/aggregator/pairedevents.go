@@ -0,0 +1,192 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package aggregator // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/signaltometricsconnector/internal/aggregator"
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/signaltometricsconnector/internal/model"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+// PendingEvents holds the start events of a paired events metric waiting for
+// their end event. Unlike the aggregator, it outlives a single batch so that
+// events can be paired across batches. The number of pending events is
+// bounded and events expire after a timeout.
+type PendingEvents struct {
+	mu         sync.Mutex
+	timeout    time.Duration
+	maxPending int
+	// events maps a correlation key to its element in order.
+	events map[string]*list.Element
+	// order holds the pending events, oldest first.
+	order *list.List
+	// evicted holds the events dropped because maxPending was reached,
+	// they are reported as timed out on the next expiry.
+	evicted []*pendingEvent
+}
+
+type pendingEvent struct {
+	key      string
+	start    pcommon.Timestamp
+	resAttrs pcommon.Map
+	attrs    pcommon.Map
+	received time.Time
+}
+
+// NewPendingEvents creates a new instance of pending events.
+func NewPendingEvents(timeout time.Duration, maxPending int) *PendingEvents {
+	return &PendingEvents{
+		timeout:    timeout,
+		maxPending: maxPending,
+		events:     make(map[string]*list.Element),
+		order:      list.New(),
+	}
+}
+
+// Len returns the number of start events waiting for their end event.
+func (p *PendingEvents) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.order.Len()
+}
+
+// start records a start event, replacing any pending start event with the
+// same key.
+func (p *PendingEvents) start(event *pendingEvent) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if elem, ok := p.events[event.key]; ok {
+		p.order.Remove(elem)
+		delete(p.events, event.key)
+	}
+	for p.order.Len() >= p.maxPending {
+		oldest := p.order.Remove(p.order.Front()).(*pendingEvent)
+		delete(p.events, oldest.key)
+		p.evicted = append(p.evicted, oldest)
+	}
+	p.events[event.key] = p.order.PushBack(event)
+}
+
+// end removes and returns the pending start event with the given key.
+func (p *PendingEvents) end(key string) (*pendingEvent, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	elem, ok := p.events[key]
+	if !ok {
+		return nil, false
+	}
+	p.order.Remove(elem)
+	delete(p.events, key)
+	return elem.Value.(*pendingEvent), true
+}
+
+// expire removes and returns the start events received before now minus the
+// timeout along with the events evicted since the last call.
+func (p *PendingEvents) expire(now time.Time) []*pendingEvent {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	expired := p.evicted
+	p.evicted = nil
+	for elem := p.order.Front(); elem != nil; elem = p.order.Front() {
+		event := elem.Value.(*pendingEvent)
+		if now.Sub(event.received) < p.timeout {
+			break
+		}
+		p.order.Remove(elem)
+		delete(p.events, event.key)
+		expired = append(expired, event)
+	}
+	return expired
+}
+
+// AggregatePairedEvents evaluates the start and end conditions of a paired
+// events metric definition. Start events are kept in pending until the end
+// event with the same correlation key is received, the duration between the
+// start timestamp of the start event and the end timestamp of the end event
+// is then recorded. The resource attributes and attributes of the start event
+// are used for the recorded duration.
+func (a *Aggregator[K]) AggregatePairedEvents(
+	ctx context.Context,
+	tCtx K,
+	md model.MetricDef[K],
+	pending *PendingEvents,
+	resAttrs, srcAttrs pcommon.Map,
+	startTimestamp, endTimestamp pcommon.Timestamp,
+) error {
+	isEnd, err := md.PairedEvents.EndConditions.Eval(ctx, tCtx)
+	if err != nil {
+		return fmt.Errorf("failed to evaluate end conditions: %w", err)
+	}
+	isStart, err := md.PairedEvents.StartConditions.Eval(ctx, tCtx)
+	if err != nil {
+		return fmt.Errorf("failed to evaluate start conditions: %w", err)
+	}
+	if !isStart && !isEnd {
+		return nil
+	}
+	key, err := getCorrelationKey(ctx, tCtx, md.PairedEvents.CorrelationKey)
+	if err != nil {
+		return err
+	}
+	if key == "" {
+		return nil
+	}
+
+	if isEnd {
+		if event, ok := pending.end(key); ok {
+			duration := max(endTimestamp.AsTime().Sub(event.start.AsTime()), 0)
+			value := float64(duration) / float64(md.PairedEvents.DurationUnit)
+			if err := a.aggregateValueCount(md, event.resAttrs, event.attrs, value, 1); err != nil {
+				return err
+			}
+		}
+	}
+	if isStart {
+		pending.start(&pendingEvent{
+			key:      key,
+			start:    startTimestamp,
+			resAttrs: resAttrs,
+			attrs:    srcAttrs,
+			received: a.timestamp,
+		})
+	}
+	return nil
+}
+
+// AggregateTimeouts counts the start events of a paired events metric
+// definition that expired or were evicted without a matching end event.
+func (a *Aggregator[K]) AggregateTimeouts(md model.MetricDef[K], pending *PendingEvents) {
+	timeoutMD := model.MetricDef[K]{Key: md.PairedEvents.TimeoutKey}
+	for _, event := range pending.expire(a.timestamp) {
+		_ = a.aggregateInt(timeoutMD, event.resAttrs, event.attrs, 1)
+	}
+}
+
+func getCorrelationKey[K any](
+	ctx context.Context,
+	tCtx K,
+	s *ottl.ValueExpression[K],
+) (string, error) {
+	raw, err := s.Eval(ctx, tCtx)
+	if err != nil {
+		return "", fmt.Errorf("failed to execute OTTL correlation key: %w", err)
+	}
+	switch v := raw.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case pcommon.Value:
+		return v.AsString(), nil
+	default:
+		return fmt.Sprint(v), nil
+	}
+}
@@ -6,6 +6,7 @@ package model // import "github.com/open-telemetry/opentelemetry-collector-contr
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/pcommon"
@@ -50,6 +51,10 @@ func (h *ExplicitHistogram[K]) fromConfig(
 			return fmt.Errorf("failed to parse count OTTL expression for explicit histogram: %w", err)
 		}
 	}
+	if mi.Value == "" {
+		// Paired events histograms record durations instead of a value.
+		return nil
+	}
 	h.Value, err = parser.ParseValueExpression(mi.Value)
 	if err != nil {
 		return fmt.Errorf("failed to parse value statement for explicit histogram: %w", err)
@@ -79,6 +84,10 @@ func (h *ExponentialHistogram[K]) fromConfig(
 			return fmt.Errorf("failed to parse count OTTL expression for exponential histogram: %w", err)
 		}
 	}
+	if mi.Value == "" {
+		// Paired events histograms record durations instead of a value.
+		return nil
+	}
 	h.Value, err = parser.ParseValueExpression(mi.Value)
 	if err != nil {
 		return fmt.Errorf("failed to parse value OTTL expression for exponential histogram: %w", err)
@@ -126,6 +135,74 @@ func (s *Gauge[K]) fromConfig(
 	return nil
 }
 
+type PairedEvents[K any] struct {
+	StartConditions *ottl.ConditionSequence[K]
+	EndConditions   *ottl.ConditionSequence[K]
+	CorrelationKey  *ottl.ValueExpression[K]
+	Timeout         time.Duration
+	MaxPending      int
+	// TimeoutKey identifies the sum counting the start events dropped
+	// without a matching end event.
+	TimeoutKey MetricKey
+	// DurationUnit is the unit in which durations are recorded.
+	DurationUnit time.Duration
+}
+
+func (pe *PairedEvents[K]) fromConfig(
+	mi *config.PairedEvents,
+	unit string,
+	parser ottl.Parser[K],
+	telemetrySettings component.TelemetrySettings,
+) error {
+	if mi == nil {
+		return nil
+	}
+
+	startConditions, err := parser.ParseConditions(mi.StartConditions)
+	if err != nil {
+		return fmt.Errorf("failed to parse OTTL start conditions: %w", err)
+	}
+	startSeq := ottl.NewConditionSequence(
+		startConditions,
+		telemetrySettings,
+		ottl.WithLogicOperation[K](ottl.Or),
+	)
+	pe.StartConditions = &startSeq
+	endConditions, err := parser.ParseConditions(mi.EndConditions)
+	if err != nil {
+		return fmt.Errorf("failed to parse OTTL end conditions: %w", err)
+	}
+	endSeq := ottl.NewConditionSequence(
+		endConditions,
+		telemetrySettings,
+		ottl.WithLogicOperation[K](ottl.Or),
+	)
+	pe.EndConditions = &endSeq
+	pe.CorrelationKey, err = parser.ParseValueExpression(mi.CorrelationKey)
+	if err != nil {
+		return fmt.Errorf("failed to parse correlation key OTTL expression: %w", err)
+	}
+	pe.Timeout = mi.Timeout
+	pe.MaxPending = mi.MaxPending
+	pe.TimeoutKey = MetricKey{
+		Name:        mi.TimeoutMetric,
+		Type:        pmetric.MetricTypeSum,
+		Unit:        "{event}",
+		Description: "Number of start events dropped without a matching end event",
+	}
+	switch unit {
+	case "s":
+		pe.DurationUnit = time.Second
+	case "us":
+		pe.DurationUnit = time.Microsecond
+	case "ns":
+		pe.DurationUnit = time.Nanosecond
+	default:
+		pe.DurationUnit = time.Millisecond
+	}
+	return nil
+}
+
 type MetricDef[K any] struct {
 	Key                       MetricKey
 	IncludeResourceAttributes []AttributeKeyValue
@@ -135,6 +212,7 @@ type MetricDef[K any] struct {
 	ExplicitHistogram         *ExplicitHistogram[K]
 	Sum                       *Sum[K]
 	Gauge                     *Gauge[K]
+	PairedEvents              *PairedEvents[K]
 }
 
 func (md *MetricDef[K]) FromMetricInfo(
@@ -195,6 +273,12 @@ func (md *MetricDef[K]) FromMetricInfo(
 			return fmt.Errorf("failed to parse gauge config: %w", err)
 		}
 	}
+	if mi.PairedEvents.HasValue() {
+		md.PairedEvents = new(PairedEvents[K])
+		if err := md.PairedEvents.fromConfig(mi.PairedEvents.Get(), mi.Unit, parser, telemetrySettings); err != nil {
+			return fmt.Errorf("failed to parse paired events config: %w", err)
+		}
+	}
 	return nil
 }
 
@@ -114,6 +114,9 @@ type HistogramConfig struct {
 type ExemplarsConfig struct {
 	Enabled         bool `mapstructure:"enabled"`
 	MaxPerDataPoint int  `mapstructure:"max_per_data_point"`
+	// Reservoir selects which spans are kept as exemplars, one of `first`, `random`,
+	// `aligned_histogram_bucket` or `slowest_error_first`. Defaults to `first`.
+	Reservoir metrics.ExemplarReservoir `mapstructure:"reservoir"`
 	// prevent unkeyed literal initialization
 	_ struct{}
 }
@@ -178,6 +181,17 @@ func (c Config) Validate() error {
 		return fmt.Errorf("invalid max_per_data_point: %v, the value should be positive", c.Exemplars.MaxPerDataPoint)
 	}
 
+	if !c.Exemplars.Reservoir.IsValid() {
+		return fmt.Errorf(
+			"invalid exemplars reservoir: %q, should be one of %q, %q, %q or %q",
+			c.Exemplars.Reservoir,
+			metrics.FirstExemplarReservoir,
+			metrics.RandomExemplarReservoir,
+			metrics.AlignedHistogramBucketExemplarReservoir,
+			metrics.SlowestErrorFirstExemplarReservoir,
+		)
+	}
+
 	return nil
 }
 
@@ -135,6 +135,27 @@ func TestLoadConfig(t *testing.T) {
 				Namespace:                DefaultNamespace,
 			},
 		},
+		{
+			name: "exemplars_enabled_with_reservoir",
+			id:   component.NewIDWithName(metadata.Type, "exemplars_enabled_with_reservoir"),
+			expected: &Config{
+				AggregationTemporality:   "AGGREGATION_TEMPORALITY_CUMULATIVE",
+				ResourceMetricsCacheSize: defaultResourceMetricsCacheSize,
+				MetricsFlushInterval:     60 * time.Second,
+				Histogram:                HistogramConfig{Disable: false, Unit: defaultUnit},
+				Exemplars: ExemplarsConfig{
+					Enabled:         true,
+					MaxPerDataPoint: defaultMaxPerDatapoint,
+					Reservoir:       metrics.SlowestErrorFirstExemplarReservoir,
+				},
+				Namespace: DefaultNamespace,
+			},
+		},
+		{
+			name:         "invalid_exemplars_reservoir",
+			id:           component.NewIDWithName(metadata.Type, "invalid_exemplars_reservoir"),
+			errorMessage: `invalid exemplars reservoir: "last"`,
+		},
 		{
 			name: "resource_metrics_key_attributes",
 			id:   component.NewIDWithName(metadata.Type, "resource_metrics_key_attributes"),
@@ -171,7 +171,7 @@ func initHistogramMetrics(cfg Config) metrics.HistogramMetrics {
 		if expConfig := cfg.Histogram.Exponential.Get(); expConfig != nil && expConfig.MaxSize != 0 {
 			maxSize = expConfig.MaxSize
 		}
-		return metrics.NewExponentialHistogramMetrics(maxSize, cfg.Exemplars.MaxPerDataPoint, cfg.AggregationCardinalityLimit)
+		return metrics.NewExponentialHistogramMetrics(maxSize, cfg.Exemplars.Reservoir, cfg.Exemplars.MaxPerDataPoint, cfg.AggregationCardinalityLimit)
 	}
 
 	var bounds []float64
@@ -191,7 +191,7 @@ func initHistogramMetrics(cfg Config) metrics.HistogramMetrics {
 		}
 	}
 
-	return metrics.NewExplicitHistogramMetrics(bounds, cfg.Exemplars.MaxPerDataPoint, cfg.AggregationCardinalityLimit)
+	return metrics.NewExplicitHistogramMetrics(bounds, cfg.Exemplars.Reservoir, cfg.Exemplars.MaxPerDataPoint, cfg.AggregationCardinalityLimit)
 }
 
 // unitDivider returns a unit divider to convert nanoseconds to milliseconds or seconds.
@@ -415,10 +415,12 @@ func (p *connectorImp) aggregateMetrics(traces ptrace.Traces) {
 					return p.buildAttributes(serviceName, span, resourceAttr, callsDimensions, ils.Scope())
 				}
 
+				isError := span.Status().Code() == ptrace.StatusCodeError
+
 				// aggregate sums metrics
 				s, limitReached := sums.GetOrCreate(key, attributesFun, startTimestamp)
 				if !limitReached && p.config.Exemplars.Enabled && !span.TraceID().IsEmpty() {
-					s.AddExemplar(span.TraceID(), span.SpanID(), duration)
+					s.AddExemplar(span.TraceID(), span.SpanID(), duration, isError)
 				}
 				s.Add(1)
 
@@ -431,10 +433,10 @@ func (p *connectorImp) aggregateMetrics(traces ptrace.Traces) {
 						return p.buildAttributes(serviceName, span, resourceAttr, durationDimensions, ils.Scope())
 					}
 					h, durationLimitReached := histograms.GetOrCreate(durationKey, attributesFun, startTimestamp)
+					h.Observe(duration)
 					if !durationLimitReached && p.config.Exemplars.Enabled && !span.TraceID().IsEmpty() {
 						p.addExemplar(span, duration, h)
 					}
-					h.Observe(duration)
 				}
 
 				// aggregate events metrics
@@ -460,7 +462,7 @@ func (p *connectorImp) aggregateMetrics(traces ptrace.Traces) {
 						}
 						e, eventLimitReached := events.GetOrCreate(eKey, attributesFun, startTimestamp)
 						if !eventLimitReached && p.config.Exemplars.Enabled && !span.TraceID().IsEmpty() {
-							e.AddExemplar(span.TraceID(), span.SpanID(), duration)
+							e.AddExemplar(span.TraceID(), span.SpanID(), duration, isError)
 						}
 						e.Add(1)
 					}
@@ -478,7 +480,7 @@ func (p *connectorImp) addExemplar(span ptrace.Span, duration float64, h metrics
 		return
 	}
 
-	h.AddExemplar(span.TraceID(), span.SpanID(), duration)
+	h.AddExemplar(span.TraceID(), span.SpanID(), duration, span.Status().Code() == ptrace.StatusCodeError)
 }
 
 type resourceKey [16]byte
@@ -502,8 +504,8 @@ func (p *connectorImp) getOrCreateResourceMetrics(attr pcommon.Map) *resourceMet
 	if !ok {
 		v = &resourceMetrics{
 			histograms: initHistogramMetrics(p.config),
-			sums:       metrics.NewSumMetrics(p.config.Exemplars.MaxPerDataPoint, p.config.AggregationCardinalityLimit),
-			events:     metrics.NewSumMetrics(p.config.Exemplars.MaxPerDataPoint, p.config.AggregationCardinalityLimit),
+			sums:       metrics.NewSumMetrics(p.config.Exemplars.Reservoir, p.config.Exemplars.MaxPerDataPoint, p.config.AggregationCardinalityLimit),
+			events:     metrics.NewSumMetrics(p.config.Exemplars.Reservoir, p.config.Exemplars.MaxPerDataPoint, p.config.AggregationCardinalityLimit),
 			attributes: attr,
 		}
 		p.resourceMetrics.Add(key, v)
@@ -1523,7 +1523,7 @@ func TestConnector_initHistogramMetrics(t *testing.T) {
 		{
 			name:   "initialize histogram with no config provided",
 			config: Config{},
-			want:   metrics.NewExplicitHistogramMetrics(defaultHistogramBucketsMs, 0, 0),
+			want:   metrics.NewExplicitHistogramMetrics(defaultHistogramBucketsMs, metrics.FirstExemplarReservoir, 0, 0),
 		},
 		{
 			name: "Disable histogram",
@@ -1541,7 +1541,7 @@ func TestConnector_initHistogramMetrics(t *testing.T) {
 					Unit: metrics.Milliseconds,
 				},
 			},
-			want: metrics.NewExplicitHistogramMetrics(defaultHistogramBucketsMs, 0, 0),
+			want: metrics.NewExplicitHistogramMetrics(defaultHistogramBucketsMs, metrics.FirstExemplarReservoir, 0, 0),
 		},
 		{
 			name: "initialize explicit histogram with default bounds (seconds)",
@@ -1550,7 +1550,7 @@ func TestConnector_initHistogramMetrics(t *testing.T) {
 					Unit: metrics.Seconds,
 				},
 			},
-			want: metrics.NewExplicitHistogramMetrics(defaultHistogramBucketsSeconds, 0, 0),
+			want: metrics.NewExplicitHistogramMetrics(defaultHistogramBucketsSeconds, metrics.FirstExemplarReservoir, 0, 0),
 		},
 		{
 			name: "initialize explicit histogram with bounds (seconds)",
@@ -1565,7 +1565,7 @@ func TestConnector_initHistogramMetrics(t *testing.T) {
 					}),
 				},
 			},
-			want: metrics.NewExplicitHistogramMetrics([]float64{0.1, 1}, 0, 0),
+			want: metrics.NewExplicitHistogramMetrics([]float64{0.1, 1}, metrics.FirstExemplarReservoir, 0, 0),
 		},
 		{
 			name: "initialize explicit histogram with bounds (ms)",
@@ -1580,7 +1580,7 @@ func TestConnector_initHistogramMetrics(t *testing.T) {
 					}),
 				},
 			},
-			want: metrics.NewExplicitHistogramMetrics([]float64{100, 1000}, 0, 0),
+			want: metrics.NewExplicitHistogramMetrics([]float64{100, 1000}, metrics.FirstExemplarReservoir, 0, 0),
 		},
 		{
 			name: "initialize exponential histogram",
@@ -1592,7 +1592,7 @@ func TestConnector_initHistogramMetrics(t *testing.T) {
 					}),
 				},
 			},
-			want: metrics.NewExponentialHistogramMetrics(10, 0, 0),
+			want: metrics.NewExponentialHistogramMetrics(10, metrics.FirstExemplarReservoir, 0, 0),
 		},
 		{
 			name: "initialize exponential histogram with default max buckets count",
@@ -1602,7 +1602,7 @@ func TestConnector_initHistogramMetrics(t *testing.T) {
 					Exponential: configoptional.Some(ExponentialHistogramConfig{}),
 				},
 			},
-			want: metrics.NewExponentialHistogramMetrics(structure.DefaultMaxSize, 0, 0),
+			want: metrics.NewExponentialHistogramMetrics(structure.DefaultMaxSize, metrics.FirstExemplarReservoir, 0, 0),
 		},
 	}
 	for _, tt := range tests {
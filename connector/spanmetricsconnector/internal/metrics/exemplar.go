@@ -0,0 +1,228 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/spanmetricsconnector/internal/metrics"
+
+import (
+	"math"
+	"math/rand/v2"
+
+	"github.com/lightstep/go-expohisto/mapping"
+	"github.com/lightstep/go-expohisto/mapping/exponent"
+	"github.com/lightstep/go-expohisto/mapping/logarithm"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// ExemplarReservoir is the strategy used to select which spans are kept as exemplars of a data point.
+type ExemplarReservoir string
+
+const (
+	// FirstExemplarReservoir keeps the first spans seen during a flush interval.
+	FirstExemplarReservoir ExemplarReservoir = "first"
+	// RandomExemplarReservoir keeps a uniformly distributed random sample of the spans seen
+	// during a flush interval.
+	RandomExemplarReservoir ExemplarReservoir = "random"
+	// AlignedHistogramBucketExemplarReservoir keeps the last span seen for each histogram bucket,
+	// see https://opentelemetry.io/docs/specs/otel/metrics/sdk/#alignedhistogrambucketexemplarreservoir.
+	// Sums, which have no buckets, use RandomExemplarReservoir instead.
+	AlignedHistogramBucketExemplarReservoir ExemplarReservoir = "aligned_histogram_bucket"
+	// SlowestErrorFirstExemplarReservoir keeps the spans with an error status first and the slowest
+	// spans after them.
+	SlowestErrorFirstExemplarReservoir ExemplarReservoir = "slowest_error_first"
+)
+
+// IsValid returns true if r is a known reservoir, the empty value selects FirstExemplarReservoir.
+func (r ExemplarReservoir) IsValid() bool {
+	switch r {
+	case "", FirstExemplarReservoir, RandomExemplarReservoir, AlignedHistogramBucketExemplarReservoir, SlowestErrorFirstExemplarReservoir:
+		return true
+	}
+	return false
+}
+
+func defaultExemplarReservoir(r ExemplarReservoir) ExemplarReservoir {
+	if r == "" {
+		return FirstExemplarReservoir
+	}
+	return r
+}
+
+// zeroBucket is the bucket used for zero durations by exponential histograms.
+const zeroBucket = math.MinInt
+
+type exemplarInfo struct {
+	isError bool
+	// seq is the order in which the exemplar was offered, used to keep the latest exemplar when
+	// buckets are merged.
+	seq int
+}
+
+// exemplarReservoir holds the exemplars of a single data point until the next flush.
+type exemplarReservoir struct {
+	reservoir ExemplarReservoir
+	maxCount  int
+
+	exemplars pmetric.ExemplarSlice
+	info      []exemplarInfo
+	offered   int
+	// buckets maps a histogram bucket to the index of its exemplar, only used by
+	// AlignedHistogramBucketExemplarReservoir.
+	buckets map[int]int
+}
+
+func newExemplarReservoir(reservoir ExemplarReservoir, maxCount int) *exemplarReservoir {
+	r := &exemplarReservoir{
+		reservoir: reservoir,
+		maxCount:  maxCount,
+		exemplars: pmetric.NewExemplarSlice(),
+	}
+	if reservoir == AlignedHistogramBucketExemplarReservoir {
+		r.buckets = make(map[int]int)
+	}
+	return r
+}
+
+// Len returns the number of exemplars currently held.
+func (r *exemplarReservoir) Len() int {
+	return r.exemplars.Len()
+}
+
+// offer proposes a span as exemplar, bucket is only used by AlignedHistogramBucketExemplarReservoir.
+func (r *exemplarReservoir) offer(traceID pcommon.TraceID, spanID pcommon.SpanID, value float64, isError bool, bucket int) {
+	r.offered++
+	switch r.reservoir {
+	case RandomExemplarReservoir:
+		if r.exemplars.Len() < r.maxCount {
+			r.append(traceID, spanID, value, isError)
+			return
+		}
+		// Algorithm R, every offered span has the same probability to be kept.
+		if i := rand.IntN(r.offered); i < r.maxCount {
+			r.set(i, traceID, spanID, value, isError)
+		}
+	case AlignedHistogramBucketExemplarReservoir:
+		if i, ok := r.buckets[bucket]; ok {
+			r.set(i, traceID, spanID, value, isError)
+			return
+		}
+		r.buckets[bucket] = r.exemplars.Len()
+		r.append(traceID, spanID, value, isError)
+	case SlowestErrorFirstExemplarReservoir:
+		if r.exemplars.Len() < r.maxCount {
+			r.append(traceID, spanID, value, isError)
+			return
+		}
+		lowest := -1
+		for i := 0; i < r.exemplars.Len(); i++ {
+			if lowest < 0 || r.lessImportant(i, lowest) {
+				lowest = i
+			}
+		}
+		if lowest < 0 {
+			return
+		}
+		if isError != r.info[lowest].isError {
+			if isError {
+				r.set(lowest, traceID, spanID, value, isError)
+			}
+			return
+		}
+		if value > r.exemplars.At(lowest).DoubleValue() {
+			r.set(lowest, traceID, spanID, value, isError)
+		}
+	default:
+		if r.exemplars.Len() < r.maxCount {
+			r.append(traceID, spanID, value, isError)
+		}
+	}
+}
+
+// lessImportant reports whether the exemplar at index i should be evicted before the one at index j.
+func (r *exemplarReservoir) lessImportant(i, j int) bool {
+	if r.info[i].isError != r.info[j].isError {
+		return !r.info[i].isError
+	}
+	return r.exemplars.At(i).DoubleValue() < r.exemplars.At(j).DoubleValue()
+}
+
+func (r *exemplarReservoir) append(traceID pcommon.TraceID, spanID pcommon.SpanID, value float64, isError bool) {
+	r.exemplars.AppendEmpty()
+	r.info = append(r.info, exemplarInfo{})
+	r.set(r.exemplars.Len()-1, traceID, spanID, value, isError)
+}
+
+func (r *exemplarReservoir) set(i int, traceID pcommon.TraceID, spanID pcommon.SpanID, value float64, isError bool) {
+	e := r.exemplars.At(i)
+	e.SetTraceID(traceID)
+	e.SetSpanID(spanID)
+	e.SetDoubleValue(value)
+	r.info[i] = exemplarInfo{isError: isError, seq: r.offered}
+}
+
+// downscale merges the buckets of an exponential histogram after its scale was reduced by
+// shift, keeping the latest exemplar of the merged buckets.
+func (r *exemplarReservoir) downscale(shift int32) {
+	if shift <= 0 || len(r.buckets) == 0 {
+		return
+	}
+	latest := make(map[int]int, len(r.buckets))
+	for bucket, i := range r.buckets {
+		if bucket != zeroBucket {
+			bucket >>= shift
+		}
+		if j, ok := latest[bucket]; !ok || r.info[i].seq > r.info[j].seq {
+			latest[bucket] = i
+		}
+	}
+	keep := make([]bool, r.exemplars.Len())
+	for _, i := range latest {
+		keep[i] = true
+	}
+	newIndex := make([]int, r.exemplars.Len())
+	info := r.info[:0]
+	for i, k := range keep {
+		if k {
+			newIndex[i] = len(info)
+			info = append(info, r.info[i])
+		}
+	}
+	r.info = info
+	i := 0
+	r.exemplars.RemoveIf(func(pmetric.Exemplar) bool {
+		remove := !keep[i]
+		i++
+		return remove
+	})
+	clear(r.buckets)
+	for bucket, i := range latest {
+		r.buckets[bucket] = newIndex[i]
+	}
+}
+
+// copyTo copies the exemplars to dest, setting their timestamp.
+func (r *exemplarReservoir) copyTo(dest pmetric.ExemplarSlice, timestamp pcommon.Timestamp) {
+	for i := 0; i < r.exemplars.Len(); i++ {
+		r.exemplars.At(i).SetTimestamp(timestamp)
+	}
+	r.exemplars.CopyTo(dest)
+}
+
+// reset drops all exemplars, they are only kept for one flush interval.
+func (r *exemplarReservoir) reset() {
+	r.exemplars = pmetric.NewExemplarSlice()
+	r.info = r.info[:0]
+	r.offered = 0
+	clear(r.buckets)
+}
+
+// newExponentialMapping returns the mapping used by exponential histograms at the given scale.
+func newExponentialMapping(scale int32) mapping.Mapping {
+	var m mapping.Mapping
+	if scale <= 0 {
+		m, _ = exponent.NewMapping(scale)
+	} else {
+		m, _ = logarithm.NewMapping(scale)
+	}
+	return m
+}
@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestExemplarReservoir_IsValid(t *testing.T) {
+	for _, r := range []ExemplarReservoir{
+		"",
+		FirstExemplarReservoir,
+		RandomExemplarReservoir,
+		AlignedHistogramBucketExemplarReservoir,
+		SlowestErrorFirstExemplarReservoir,
+	} {
+		assert.True(t, r.IsValid(), r)
+	}
+	assert.False(t, ExemplarReservoir("last").IsValid())
+}
+
+func TestExemplarReservoir_First(t *testing.T) {
+	r := newExemplarReservoir(FirstExemplarReservoir, 2)
+	for i := range 5 {
+		r.offer(pcommon.TraceID{}, pcommon.SpanID{}, float64(i), false, 0)
+	}
+	assert.Equal(t, []float64{0, 1}, exemplarValues(r.exemplars))
+}
+
+func TestExemplarReservoir_Random(t *testing.T) {
+	const maxCount = 5
+	r := newExemplarReservoir(RandomExemplarReservoir, maxCount)
+	for i := range 1000 {
+		r.offer(pcommon.TraceID{}, pcommon.SpanID{}, float64(i), false, 0)
+	}
+	require.Equal(t, maxCount, r.Len())
+	// The chance of keeping exactly the first spans out of 1000 is negligible.
+	assert.NotEqual(t, []float64{0, 1, 2, 3, 4}, exemplarValues(r.exemplars))
+
+	r.reset()
+	assert.Equal(t, 0, r.Len())
+	assert.Equal(t, 0, r.offered)
+}
+
+func TestExemplarReservoir_SlowestErrorFirst(t *testing.T) {
+	r := newExemplarReservoir(SlowestErrorFirstExemplarReservoir, 3)
+	r.offer(pcommon.TraceID{}, pcommon.SpanID{}, 10, false, 0)
+	r.offer(pcommon.TraceID{}, pcommon.SpanID{}, 1, true, 0)
+	r.offer(pcommon.TraceID{}, pcommon.SpanID{}, 20, false, 0)
+	r.offer(pcommon.TraceID{}, pcommon.SpanID{}, 5, false, 0)
+	r.offer(pcommon.TraceID{}, pcommon.SpanID{}, 30, false, 0)
+	assert.ElementsMatch(t, []float64{1, 20, 30}, exemplarValues(r.exemplars))
+
+	// errors replace the fastest successful span first
+	r.offer(pcommon.TraceID{}, pcommon.SpanID{}, 2, true, 0)
+	r.offer(pcommon.TraceID{}, pcommon.SpanID{}, 3, true, 0)
+	assert.ElementsMatch(t, []float64{1, 2, 3}, exemplarValues(r.exemplars))
+
+	// then the fastest error
+	r.offer(pcommon.TraceID{}, pcommon.SpanID{}, 4, true, 0)
+	r.offer(pcommon.TraceID{}, pcommon.SpanID{}, 100, false, 0)
+	assert.ElementsMatch(t, []float64{2, 3, 4}, exemplarValues(r.exemplars))
+}
+
+func TestExplicitHistogram_AlignedHistogramBucketExemplars(t *testing.T) {
+	m := NewExplicitHistogramMetrics([]float64{10, 100}, AlignedHistogramBucketExemplarReservoir, 1, 0)
+	h, _ := m.GetOrCreate("key", pcommon.NewMap, 0)
+	for _, v := range []float64{1, 5, 50, 500, 600} {
+		h.Observe(v)
+		h.AddExemplar(pcommon.TraceID{}, pcommon.SpanID{}, v, false)
+	}
+
+	metric := pmetric.NewMetric()
+	m.BuildMetrics(metric, 1, func(Key, pcommon.Timestamp) pcommon.Timestamp { return 0 }, pmetric.AggregationTemporalityDelta)
+	require.Equal(t, 1, metric.Histogram().DataPoints().Len())
+	exemplars := metric.Histogram().DataPoints().At(0).Exemplars()
+	assert.ElementsMatch(t, []float64{5, 50, 600}, exemplarValues(exemplars))
+	for i := 0; i < exemplars.Len(); i++ {
+		assert.Equal(t, pcommon.Timestamp(1), exemplars.At(i).Timestamp())
+	}
+
+	m.ClearExemplars()
+	h.Observe(5)
+	h.AddExemplar(pcommon.TraceID{}, pcommon.SpanID{}, 5, false)
+	metric = pmetric.NewMetric()
+	m.BuildMetrics(metric, 2, func(Key, pcommon.Timestamp) pcommon.Timestamp { return 0 }, pmetric.AggregationTemporalityDelta)
+	assert.Equal(t, []float64{5}, exemplarValues(metric.Histogram().DataPoints().At(0).Exemplars()))
+}
+
+func TestExponentialHistogram_AlignedHistogramBucketExemplars(t *testing.T) {
+	m := NewExponentialHistogramMetrics(4, AlignedHistogramBucketExemplarReservoir, 1, 0)
+	h, _ := m.GetOrCreate("key", pcommon.NewMap, 0)
+	for _, v := range []float64{0, 2, 3} {
+		h.Observe(v)
+		h.AddExemplar(pcommon.TraceID{}, pcommon.SpanID{}, v, false)
+	}
+
+	metric := pmetric.NewMetric()
+	m.BuildMetrics(metric, 1, func(Key, pcommon.Timestamp) pcommon.Timestamp { return 0 }, pmetric.AggregationTemporalityDelta)
+	assert.ElementsMatch(t, []float64{0, 2, 3}, exemplarValues(metric.ExponentialHistogram().DataPoints().At(0).Exemplars()))
+
+	// 1000 downscales the histogram until 2 and 3 share the same bucket, only the latest is kept.
+	h.Observe(1000)
+	h.AddExemplar(pcommon.TraceID{}, pcommon.SpanID{}, 1000, false)
+
+	metric = pmetric.NewMetric()
+	m.BuildMetrics(metric, 1, func(Key, pcommon.Timestamp) pcommon.Timestamp { return 0 }, pmetric.AggregationTemporalityDelta)
+	assert.ElementsMatch(t, []float64{0, 3, 1000}, exemplarValues(metric.ExponentialHistogram().DataPoints().At(0).Exemplars()))
+}
+
+func TestSumMetrics_AlignedHistogramBucketFallsBackToRandom(t *testing.T) {
+	m := NewSumMetrics(AlignedHistogramBucketExemplarReservoir, 2, 0)
+	s, _ := m.GetOrCreate("key", pcommon.NewMap, 0)
+	for i := range 10 {
+		s.AddExemplar(pcommon.TraceID{}, pcommon.SpanID{}, float64(i), false)
+	}
+	assert.Equal(t, RandomExemplarReservoir, s.exemplars.reservoir)
+	assert.Equal(t, 2, s.exemplars.Len())
+}
+
+func newTestExemplarReservoir(maxCount, n int) *exemplarReservoir {
+	r := newExemplarReservoir(FirstExemplarReservoir, maxCount)
+	for i := range n {
+		r.offer(pcommon.TraceID{}, pcommon.SpanID{}, float64(i+1), false, 0)
+	}
+	return r
+}
+
+func exemplarValues(exemplars pmetric.ExemplarSlice) []float64 {
+	values := make([]float64, 0, exemplars.Len())
+	for i := 0; i < exemplars.Len(); i++ {
+		values = append(values, exemplars.At(i).DoubleValue())
+	}
+	return values
+}
@@ -6,6 +6,7 @@ package metrics // import "github.com/open-telemetry/opentelemetry-collector-con
 import (
 	"sort"
 
+	"github.com/lightstep/go-expohisto/mapping"
 	"github.com/lightstep/go-expohisto/structure"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
@@ -24,26 +25,28 @@ type HistogramMetrics interface {
 
 type Histogram interface {
 	Observe(value float64)
-	AddExemplar(traceID pcommon.TraceID, spanID pcommon.SpanID, value float64)
+	AddExemplar(traceID pcommon.TraceID, spanID pcommon.SpanID, value float64, isError bool)
 }
 
 type explicitHistogramMetrics struct {
-	metrics          map[Key]*explicitHistogram
-	bounds           []float64
-	maxExemplarCount int
-	cardinalityLimit int
+	metrics           map[Key]*explicitHistogram
+	bounds            []float64
+	exemplarReservoir ExemplarReservoir
+	maxExemplarCount  int
+	cardinalityLimit  int
 }
 
 type exponentialHistogramMetrics struct {
-	metrics          map[Key]*exponentialHistogram
-	maxSize          int32
-	maxExemplarCount int
-	cardinalityLimit int
+	metrics           map[Key]*exponentialHistogram
+	maxSize           int32
+	exemplarReservoir ExemplarReservoir
+	maxExemplarCount  int
+	cardinalityLimit  int
 }
 
 type explicitHistogram struct {
 	attributes pcommon.Map
-	exemplars  pmetric.ExemplarSlice
+	exemplars  *exemplarReservoir
 
 	bucketCounts []uint64
 	count        uint64
@@ -51,39 +54,40 @@ type explicitHistogram struct {
 
 	bounds []float64
 
-	maxExemplarCount int
-
 	startTimestamp pcommon.Timestamp
 }
 
 type exponentialHistogram struct {
 	attributes pcommon.Map
-	exemplars  pmetric.ExemplarSlice
+	exemplars  *exemplarReservoir
+	// exemplarMapping maps exemplars to the buckets of the histogram at the scale
+	// they were last aligned to.
+	exemplarMapping mapping.Mapping
 
 	histogram *structure.Histogram[float64]
 
-	maxExemplarCount int
-
 	startTimestamp pcommon.Timestamp
 }
 
 type BuildAttributesFun func() pcommon.Map
 
-func NewExponentialHistogramMetrics(maxSize int32, maxExemplarCount, cardinalityLimit int) HistogramMetrics {
+func NewExponentialHistogramMetrics(maxSize int32, exemplarReservoir ExemplarReservoir, maxExemplarCount, cardinalityLimit int) HistogramMetrics {
 	return &exponentialHistogramMetrics{
-		metrics:          make(map[Key]*exponentialHistogram),
-		maxSize:          maxSize,
-		maxExemplarCount: maxExemplarCount,
-		cardinalityLimit: cardinalityLimit,
+		metrics:           make(map[Key]*exponentialHistogram),
+		maxSize:           maxSize,
+		exemplarReservoir: defaultExemplarReservoir(exemplarReservoir),
+		maxExemplarCount:  maxExemplarCount,
+		cardinalityLimit:  cardinalityLimit,
 	}
 }
 
-func NewExplicitHistogramMetrics(bounds []float64, maxExemplarCount, cardinalityLimit int) HistogramMetrics {
+func NewExplicitHistogramMetrics(bounds []float64, exemplarReservoir ExemplarReservoir, maxExemplarCount, cardinalityLimit int) HistogramMetrics {
 	return &explicitHistogramMetrics{
-		metrics:          make(map[Key]*explicitHistogram),
-		bounds:           bounds,
-		maxExemplarCount: maxExemplarCount,
-		cardinalityLimit: cardinalityLimit,
+		metrics:           make(map[Key]*explicitHistogram),
+		bounds:            bounds,
+		exemplarReservoir: defaultExemplarReservoir(exemplarReservoir),
+		maxExemplarCount:  maxExemplarCount,
+		cardinalityLimit:  cardinalityLimit,
 	}
 }
 
@@ -113,12 +117,11 @@ func (m *explicitHistogramMetrics) GetOrCreate(key Key, attributesFun BuildAttri
 		}
 
 		h = &explicitHistogram{
-			attributes:       attributes,
-			exemplars:        pmetric.NewExemplarSlice(),
-			bounds:           m.bounds,
-			bucketCounts:     make([]uint64, len(m.bounds)+1),
-			maxExemplarCount: m.maxExemplarCount,
-			startTimestamp:   startTimestamp,
+			attributes:     attributes,
+			exemplars:      newExemplarReservoir(m.exemplarReservoir, m.maxExemplarCount),
+			bounds:         m.bounds,
+			bucketCounts:   make([]uint64, len(m.bounds)+1),
+			startTimestamp: startTimestamp,
 		}
 		m.metrics[key] = h
 	}
@@ -143,17 +146,14 @@ func (m *explicitHistogramMetrics) BuildMetrics(
 		dp.BucketCounts().FromRaw(h.bucketCounts)
 		dp.SetCount(h.count)
 		dp.SetSum(h.sum)
-		for i := 0; i < h.exemplars.Len(); i++ {
-			h.exemplars.At(i).SetTimestamp(timestamp)
-		}
-		h.exemplars.CopyTo(dp.Exemplars())
+		h.exemplars.copyTo(dp.Exemplars(), timestamp)
 		h.attributes.CopyTo(dp.Attributes())
 	}
 }
 
 func (m *explicitHistogramMetrics) ClearExemplars() {
 	for _, h := range m.metrics {
-		h.exemplars = pmetric.NewExemplarSlice()
+		h.exemplars.reset()
 	}
 }
 
@@ -189,11 +189,10 @@ func (m *exponentialHistogramMetrics) GetOrCreate(key Key, attributesFun BuildAt
 		}
 
 		h = &exponentialHistogram{
-			histogram:        histogram,
-			attributes:       attributes,
-			exemplars:        pmetric.NewExemplarSlice(),
-			maxExemplarCount: m.maxExemplarCount,
-			startTimestamp:   startTimeStamp,
+			histogram:      histogram,
+			attributes:     attributes,
+			exemplars:      newExemplarReservoir(m.exemplarReservoir, m.maxExemplarCount),
+			startTimestamp: startTimeStamp,
 		}
 		m.metrics[key] = h
 	}
@@ -215,10 +214,8 @@ func (m *exponentialHistogramMetrics) BuildMetrics(
 		dp.SetStartTimestamp(startTimestamp)
 		dp.SetTimestamp(timestamp)
 		expoHistToExponentialDataPoint(e.histogram, dp)
-		for i := 0; i < e.exemplars.Len(); i++ {
-			e.exemplars.At(i).SetTimestamp(timestamp)
-		}
-		e.exemplars.CopyTo(dp.Exemplars())
+		e.alignExemplars()
+		e.exemplars.copyTo(dp.Exemplars(), timestamp)
 		e.attributes.CopyTo(dp.Attributes())
 	}
 }
@@ -256,7 +253,7 @@ func expoHistToExponentialDataPoint(agg *structure.Histogram[float64], dp pmetri
 
 func (m *exponentialHistogramMetrics) ClearExemplars() {
 	for _, m := range m.metrics {
-		m.exemplars = pmetric.NewExemplarSlice()
+		m.exemplars.reset()
 	}
 }
 
@@ -269,36 +266,46 @@ func (h *explicitHistogram) Observe(value float64) {
 	h.bucketCounts[index]++
 }
 
-func (h *explicitHistogram) AddExemplar(traceID pcommon.TraceID, spanID pcommon.SpanID, value float64) {
-	if h.exemplars.Len() >= h.maxExemplarCount {
-		return
-	}
-	e := h.exemplars.AppendEmpty()
-	e.SetTraceID(traceID)
-	e.SetSpanID(spanID)
-	e.SetDoubleValue(value)
+func (h *explicitHistogram) AddExemplar(traceID pcommon.TraceID, spanID pcommon.SpanID, value float64, isError bool) {
+	h.exemplars.offer(traceID, spanID, value, isError, sort.SearchFloat64s(h.bounds, value))
 }
 
 func (h *exponentialHistogram) Observe(value float64) {
 	h.histogram.Update(value)
 }
 
-func (h *exponentialHistogram) AddExemplar(traceID pcommon.TraceID, spanID pcommon.SpanID, value float64) {
-	if h.exemplars.Len() >= h.maxExemplarCount {
+// AddExemplar offers a span as exemplar, it must be called after the value was observed so the
+// exemplar is aligned to the bucket the value was counted in.
+func (h *exponentialHistogram) AddExemplar(traceID pcommon.TraceID, spanID pcommon.SpanID, value float64, isError bool) {
+	bucket := zeroBucket
+	if h.exemplars.reservoir == AlignedHistogramBucketExemplarReservoir && value > 0 {
+		h.alignExemplars()
+		bucket = int(h.exemplarMapping.MapToIndex(value))
+	}
+	h.exemplars.offer(traceID, spanID, value, isError, bucket)
+}
+
+// alignExemplars merges the exemplar buckets when the histogram was downscaled since the
+// exemplars were last aligned.
+func (h *exponentialHistogram) alignExemplars() {
+	if h.exemplars.reservoir != AlignedHistogramBucketExemplarReservoir {
+		return
+	}
+	scale := h.histogram.Scale()
+	if h.exemplarMapping != nil && h.exemplarMapping.Scale() == scale {
 		return
 	}
-	e := h.exemplars.AppendEmpty()
-	e.SetTraceID(traceID)
-	e.SetSpanID(spanID)
-	e.SetDoubleValue(value)
+	if h.exemplarMapping != nil {
+		h.exemplars.downscale(h.exemplarMapping.Scale() - scale)
+	}
+	h.exemplarMapping = newExponentialMapping(scale)
 }
 
 type Sum struct {
 	attributes pcommon.Map
 	count      uint64
 
-	exemplars        pmetric.ExemplarSlice
-	maxExemplarCount int
+	exemplars *exemplarReservoir
 
 	startTimestamp pcommon.Timestamp
 	// isFirst is used to track if this datapoint is new to the Sum. This
@@ -312,18 +319,25 @@ func (s *Sum) Add(value uint64) {
 	s.count += value
 }
 
-func NewSumMetrics(maxExemplarCount, cardinalityLimit int) SumMetrics {
+func NewSumMetrics(exemplarReservoir ExemplarReservoir, maxExemplarCount, cardinalityLimit int) SumMetrics {
+	exemplarReservoir = defaultExemplarReservoir(exemplarReservoir)
+	// Sums have no buckets to align exemplars to.
+	if exemplarReservoir == AlignedHistogramBucketExemplarReservoir {
+		exemplarReservoir = RandomExemplarReservoir
+	}
 	return SumMetrics{
-		metrics:          make(map[Key]*Sum),
-		maxExemplarCount: maxExemplarCount,
-		cardinalityLimit: cardinalityLimit,
+		metrics:           make(map[Key]*Sum),
+		exemplarReservoir: exemplarReservoir,
+		maxExemplarCount:  maxExemplarCount,
+		cardinalityLimit:  cardinalityLimit,
 	}
 }
 
 type SumMetrics struct {
-	metrics          map[Key]*Sum
-	maxExemplarCount int
-	cardinalityLimit int
+	metrics           map[Key]*Sum
+	exemplarReservoir ExemplarReservoir
+	maxExemplarCount  int
+	cardinalityLimit  int
 }
 
 func (m *SumMetrics) IsCardinalityLimitReached() bool {
@@ -353,11 +367,10 @@ func (m *SumMetrics) GetOrCreate(key Key, attributesFun BuildAttributesFun, star
 		}
 
 		s = &Sum{
-			attributes:       attributes,
-			exemplars:        pmetric.NewExemplarSlice(),
-			maxExemplarCount: m.maxExemplarCount,
-			startTimestamp:   startTimestamp,
-			isFirst:          true,
+			attributes:     attributes,
+			exemplars:      newExemplarReservoir(m.exemplarReservoir, m.maxExemplarCount),
+			startTimestamp: startTimestamp,
+			isFirst:        true,
 		}
 		m.metrics[key] = s
 	}
@@ -365,14 +378,8 @@ func (m *SumMetrics) GetOrCreate(key Key, attributesFun BuildAttributesFun, star
 	return s, limitReached
 }
 
-func (s *Sum) AddExemplar(traceID pcommon.TraceID, spanID pcommon.SpanID, value float64) {
-	if s.exemplars.Len() >= s.maxExemplarCount {
-		return
-	}
-	e := s.exemplars.AppendEmpty()
-	e.SetTraceID(traceID)
-	e.SetSpanID(spanID)
-	e.SetDoubleValue(value)
+func (s *Sum) AddExemplar(traceID pcommon.TraceID, spanID pcommon.SpanID, value float64, isError bool) {
+	s.exemplars.offer(traceID, spanID, value, isError, 0)
 }
 
 func (m *SumMetrics) BuildMetrics(
@@ -397,16 +404,13 @@ func (m *SumMetrics) BuildMetrics(
 		} else {
 			dp.SetIntValue(int64(s.count))
 		}
-		for i := 0; i < s.exemplars.Len(); i++ {
-			s.exemplars.At(i).SetTimestamp(timestamp)
-		}
-		s.exemplars.CopyTo(dp.Exemplars())
+		s.exemplars.copyTo(dp.Exemplars(), timestamp)
 		s.attributes.CopyTo(dp.Attributes())
 	}
 }
 
 func (m *SumMetrics) ClearExemplars() {
 	for _, sum := range m.metrics {
-		sum.exemplars = pmetric.NewExemplarSlice()
+		sum.exemplars.reset()
 	}
 }
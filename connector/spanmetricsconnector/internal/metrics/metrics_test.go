@@ -114,53 +114,23 @@ func TestSum_AddExemplar(t *testing.T) {
 	}{
 		{
 			name:  "Sum Metric - set maxExemplarCount zero",
-			input: Sum{exemplars: pmetric.NewExemplarSlice(), maxExemplarCount: 0},
+			input: Sum{exemplars: newTestExemplarReservoir(0, 0)},
 			want:  0,
 		},
 		{
-			name: "Sum Metric - With exemplars length less than configured max count",
-			input: func() Sum {
-				exs := pmetric.NewExemplarSlice()
-
-				e1 := exs.AppendEmpty()
-				e1.SetTimestamp(1)
-				e1.SetDoubleValue(1)
-
-				return Sum{
-					exemplars:        exs,
-					maxExemplarCount: maxCount,
-				}
-			}(),
-			want: 2,
+			name:  "Sum Metric - With exemplars length less than configured max count",
+			input: Sum{exemplars: newTestExemplarReservoir(maxCount, 1)},
+			want:  2,
 		},
 		{
-			name: "Sum Metric - With exemplars length equal to configured max count",
-			input: func() Sum {
-				exs := pmetric.NewExemplarSlice()
-
-				e1 := exs.AppendEmpty()
-				e1.SetTimestamp(1)
-				e1.SetDoubleValue(1)
-
-				e2 := exs.AppendEmpty()
-				e2.SetTimestamp(2)
-				e2.SetDoubleValue(2)
-
-				e3 := exs.AppendEmpty()
-				e3.SetTimestamp(3)
-				e3.SetDoubleValue(3)
-
-				return Sum{
-					exemplars:        exs,
-					maxExemplarCount: maxCount,
-				}
-			}(),
-			want: 3,
+			name:  "Sum Metric - With exemplars length equal to configured max count",
+			input: Sum{exemplars: newTestExemplarReservoir(maxCount, 3)},
+			want:  3,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tt.input.AddExemplar(pcommon.TraceID{}, pcommon.SpanID{}, 4)
+			tt.input.AddExemplar(pcommon.TraceID{}, pcommon.SpanID{}, 4, false)
 			assert.Equal(t, tt.want, tt.input.exemplars.Len())
 		})
 	}
@@ -175,53 +145,23 @@ func TestExplicitHistogram_AddExemplar(t *testing.T) {
 	}{
 		{
 			name:  "Explicit Histogram - set maxExemplarCount zero",
-			input: explicitHistogram{exemplars: pmetric.NewExemplarSlice(), maxExemplarCount: 0},
+			input: explicitHistogram{exemplars: newTestExemplarReservoir(0, 0)},
 			want:  0,
 		},
 		{
-			name: "Explicit Histogram - With exemplars length less than configured max count",
-			input: func() explicitHistogram {
-				exs := pmetric.NewExemplarSlice()
-
-				e1 := exs.AppendEmpty()
-				e1.SetTimestamp(1)
-				e1.SetDoubleValue(1)
-
-				return explicitHistogram{
-					exemplars:        exs,
-					maxExemplarCount: maxCount,
-				}
-			}(),
-			want: 2,
+			name:  "Explicit Histogram - With exemplars length less than configured max count",
+			input: explicitHistogram{exemplars: newTestExemplarReservoir(maxCount, 1)},
+			want:  2,
 		},
 		{
-			name: "Explicit Histogram - With exemplars length equal to configured max count",
-			input: func() explicitHistogram {
-				exs := pmetric.NewExemplarSlice()
-
-				e1 := exs.AppendEmpty()
-				e1.SetTimestamp(1)
-				e1.SetDoubleValue(1)
-
-				e2 := exs.AppendEmpty()
-				e2.SetTimestamp(2)
-				e2.SetDoubleValue(2)
-
-				e3 := exs.AppendEmpty()
-				e3.SetTimestamp(3)
-				e3.SetDoubleValue(3)
-
-				return explicitHistogram{
-					exemplars:        exs,
-					maxExemplarCount: maxCount,
-				}
-			}(),
-			want: 3,
+			name:  "Explicit Histogram - With exemplars length equal to configured max count",
+			input: explicitHistogram{exemplars: newTestExemplarReservoir(maxCount, 3)},
+			want:  3,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tt.input.AddExemplar(pcommon.TraceID{}, pcommon.SpanID{}, 4)
+			tt.input.AddExemplar(pcommon.TraceID{}, pcommon.SpanID{}, 4, false)
 			assert.Equal(t, tt.want, tt.input.exemplars.Len())
 		})
 	}
@@ -236,53 +176,23 @@ func TestExponentialHistogram_AddExemplar(t *testing.T) {
 	}{
 		{
 			name:  "Exponential Histogram - set maxExemplarCount zero",
-			input: exponentialHistogram{exemplars: pmetric.NewExemplarSlice(), maxExemplarCount: 0},
+			input: exponentialHistogram{exemplars: newTestExemplarReservoir(0, 0)},
 			want:  0,
 		},
 		{
-			name: "Exponential Histogram - With exemplars length less than configured max count",
-			input: func() exponentialHistogram {
-				exs := pmetric.NewExemplarSlice()
-
-				e1 := exs.AppendEmpty()
-				e1.SetTimestamp(1)
-				e1.SetDoubleValue(1)
-
-				return exponentialHistogram{
-					exemplars:        exs,
-					maxExemplarCount: maxCount,
-				}
-			}(),
-			want: 2,
+			name:  "Exponential Histogram - With exemplars length less than configured max count",
+			input: exponentialHistogram{exemplars: newTestExemplarReservoir(maxCount, 1)},
+			want:  2,
 		},
 		{
-			name: "Exponential Histogram - With exemplars length equal to configured max count",
-			input: func() exponentialHistogram {
-				exs := pmetric.NewExemplarSlice()
-
-				e1 := exs.AppendEmpty()
-				e1.SetTimestamp(1)
-				e1.SetDoubleValue(1)
-
-				e2 := exs.AppendEmpty()
-				e2.SetTimestamp(2)
-				e2.SetDoubleValue(2)
-
-				e3 := exs.AppendEmpty()
-				e3.SetTimestamp(3)
-				e3.SetDoubleValue(3)
-
-				return exponentialHistogram{
-					exemplars:        exs,
-					maxExemplarCount: maxCount,
-				}
-			}(),
-			want: 3,
+			name:  "Exponential Histogram - With exemplars length equal to configured max count",
+			input: exponentialHistogram{exemplars: newTestExemplarReservoir(maxCount, 3)},
+			want:  3,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tt.input.AddExemplar(pcommon.TraceID{}, pcommon.SpanID{}, 4)
+			tt.input.AddExemplar(pcommon.TraceID{}, pcommon.SpanID{}, 4, false)
 			assert.Equal(t, tt.want, tt.input.exemplars.Len())
 		})
 	}
@@ -478,7 +388,7 @@ func TestSumMetrics_BuildMetrics(t *testing.T) {
 						m.PutStr("attr1", "value1")
 						return m
 					}(),
-					exemplars: pmetric.NewExemplarSlice(),
+					exemplars: newTestExemplarReservoir(0, 0),
 				},
 			},
 			temporality:   pmetric.AggregationTemporalityCumulative,
@@ -495,7 +405,7 @@ func TestSumMetrics_BuildMetrics(t *testing.T) {
 						m.PutStr("attr1", "value1")
 						return m
 					}(),
-					exemplars: pmetric.NewExemplarSlice(),
+					exemplars: newTestExemplarReservoir(0, 0),
 				},
 				"key2": {
 					count: 10,
@@ -504,7 +414,7 @@ func TestSumMetrics_BuildMetrics(t *testing.T) {
 						m.PutStr("attr2", "value2")
 						return m
 					}(),
-					exemplars: pmetric.NewExemplarSlice(),
+					exemplars: newTestExemplarReservoir(0, 0),
 				},
 			},
 			temporality:   pmetric.AggregationTemporalityDelta,
@@ -553,19 +463,10 @@ func TestSumMetrics_ClearExemplars(t *testing.T) {
 			name: "Clear exemplars from multiple sums",
 			metrics: map[Key]*Sum{
 				"key1": {
-					exemplars: func() pmetric.ExemplarSlice {
-						es := pmetric.NewExemplarSlice()
-						es.AppendEmpty()
-						return es
-					}(),
+					exemplars: newTestExemplarReservoir(1, 1),
 				},
 				"key2": {
-					exemplars: func() pmetric.ExemplarSlice {
-						es := pmetric.NewExemplarSlice()
-						es.AppendEmpty()
-						es.AppendEmpty()
-						return es
-					}(),
+					exemplars: newTestExemplarReservoir(2, 2),
 				},
 			},
 			expected: 0,
@@ -5,18 +5,30 @@ package kafkaexporter // import "github.com/open-telemetry/opentelemetry-collect
 
 import (
 	"errors"
+	"fmt"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configretry"
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/kafka/configkafka"
 )
 
 var _ component.Config = (*Config)(nil)
 
-var errLogsPartitionExclusive = errors.New("partition_logs_by_resource_attributes and partition_logs_by_trace_id cannot both be enabled")
+var (
+	errLogsPartitionExclusive         = errors.New("partition_logs_by_resource_attributes and partition_logs_by_trace_id cannot both be enabled")
+	errTransactionsRequireAcksAll     = errors.New("transactions require producer::required_acks to be all (-1)")
+	errTransactionsTimeoutNotPositive = errors.New("transactions::timeout must be positive")
+
+	errProfilesMessageTemplate = errors.New("profiles::message_key and profiles::message_headers are not supported")
+	errTracesMessageTemplate   = errors.New("traces::message_key and traces::message_headers cannot be used with partition_traces_by_id")
+	errMetricsMessageTemplate  = errors.New("metrics::message_key and metrics::message_headers cannot be used with partition_metrics_by_resource_attributes")
+	errLogsMessageTemplate     = errors.New("logs::message_key and logs::message_headers cannot be used with partition_logs_by_resource_attributes or partition_logs_by_trace_id")
+)
 
 // Config defines configuration for Kafka exporter.
 type Config struct {
@@ -83,15 +95,58 @@ type Config struct {
 	// selection falls back to the Kafka client’s default strategy. Resource
 	// attributes are not used for the key when this option is enabled.
 	PartitionLogsByTraceID bool `mapstructure:"partition_logs_by_trace_id"`
+
+	// Transactions holds configuration for producing messages in Kafka
+	// transactions, providing exactly-once delivery to consumers reading
+	// with the read_committed isolation level.
+	Transactions TransactionsConfig `mapstructure:"transactions"`
 }
 
 func (c *Config) Validate() (err error) {
 	if c.PartitionLogsByResourceAttributes && c.PartitionLogsByTraceID {
 		return errLogsPartitionExclusive
 	}
+	if err := c.validateMessageTemplates(); err != nil {
+		return err
+	}
+	if c.Transactions.Enabled {
+		if c.Producer.RequiredAcks != configkafka.WaitForAll {
+			return errTransactionsRequireAcksAll
+		}
+		if c.Transactions.Timeout <= 0 {
+			return errTransactionsTimeoutNotPositive
+		}
+	}
 	return err
 }
 
+func (c *Config) validateMessageTemplates() error {
+	if c.Profiles.hasMessageTemplate() {
+		return errProfilesMessageTemplate
+	}
+	if c.Traces.hasMessageTemplate() && c.PartitionTracesByID {
+		return errTracesMessageTemplate
+	}
+	if c.Metrics.hasMessageTemplate() && c.PartitionMetricsByResourceAttributes {
+		return errMetricsMessageTemplate
+	}
+	if c.Logs.hasMessageTemplate() && (c.PartitionLogsByResourceAttributes || c.PartitionLogsByTraceID) {
+		return errLogsMessageTemplate
+	}
+
+	set := component.TelemetrySettings{Logger: zap.NewNop()}
+	if _, err := newLogsMessageTemplate(c.Logs, set); err != nil {
+		return fmt.Errorf("invalid logs message template: %w", err)
+	}
+	if _, err := newMetricsMessageTemplate(c.Metrics, set); err != nil {
+		return fmt.Errorf("invalid metrics message template: %w", err)
+	}
+	if _, err := newTracesMessageTemplate(c.Traces, set); err != nil {
+		return fmt.Errorf("invalid traces message template: %w", err)
+	}
+	return nil
+}
+
 func (c *Config) Unmarshal(conf *confmap.Conf) error {
 	if err := conf.Unmarshal(c); err != nil {
 		return err
@@ -155,4 +210,59 @@ type SignalConfig struct {
 	//
 	// Defaults to "otlp_proto".
 	Encoding string `mapstructure:"encoding"`
+
+	// MessageKey holds an OTTL value expression computing the message key
+	// of each record: log record, span, or metric. Records are split into
+	// messages by their computed key and headers.
+	//
+	// MessageKey cannot be used with the partition_* options of the signal,
+	// nor for profiles.
+	MessageKey string `mapstructure:"message_key"`
+
+	// MessageHeaders holds message headers whose values are computed from
+	// OTTL value expressions for each record, like MessageKey. Headers whose
+	// value is nil are omitted.
+	MessageHeaders []MessageHeader `mapstructure:"message_headers"`
+}
+
+// MessageHeader holds configuration for a message header computed from
+// an OTTL value expression.
+type MessageHeader struct {
+	// Key is the key of the header.
+	Key string `mapstructure:"key"`
+
+	// Value holds the OTTL value expression computing the header value.
+	Value string `mapstructure:"value"`
+}
+
+// hasMessageTemplate returns whether the message key or headers of the
+// signal are computed from OTTL value expressions.
+func (c SignalConfig) hasMessageTemplate() bool {
+	return c.MessageKey != "" || len(c.MessageHeaders) > 0
+}
+
+// TransactionsConfig holds configuration for transactional producing.
+type TransactionsConfig struct {
+	// Enabled controls whether each export is produced in its own Kafka
+	// transaction. Messages of an export that failed are aborted, and are
+	// never visible to consumers reading with the read_committed isolation
+	// level, so retrying the export does not produce duplicates.
+	//
+	// Transactions require the franz-go client, and producer::required_acks
+	// to be set to all. Defaults to false.
+	Enabled bool `mapstructure:"enabled"`
+
+	// TransactionalID is the prefix of the transactional IDs of the
+	// producers, which is suffixed with the signal type. Transactional IDs
+	// must be stable across restarts of a collector instance, and unique
+	// across collector instances: a producer fences off any other producer
+	// using the same transactional ID.
+	//
+	// Defaults to the hostname followed by the component ID.
+	TransactionalID string `mapstructure:"transactional_id"`
+
+	// Timeout is the maximum time a transaction may remain open before the
+	// broker aborts it. It must not be greater than the broker's
+	// transaction.max.timeout.ms setting. Defaults to 1m.
+	Timeout time.Duration `mapstructure:"timeout"`
 }
@@ -81,6 +81,9 @@ func TestLoadConfig(t *testing.T) {
 				PartitionMetricsByResourceAttributes: true,
 				PartitionLogsByResourceAttributes:    true,
 				PartitionLogsByTraceID:               false,
+				Transactions: TransactionsConfig{
+					Timeout: time.Minute,
+				},
 			},
 		},
 		{
@@ -109,6 +112,9 @@ func TestLoadConfig(t *testing.T) {
 					Encoding: "otlp_proto",
 				},
 				Topic: "legacy_topic",
+				Transactions: TransactionsConfig{
+					Timeout: time.Minute,
+				},
 			},
 		},
 		{
@@ -136,6 +142,44 @@ func TestLoadConfig(t *testing.T) {
 					Encoding: "legacy_encoding",
 				},
 				Encoding: "legacy_encoding",
+				Transactions: TransactionsConfig{
+					Timeout: time.Minute,
+				},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "transactions"),
+			expected: &Config{
+				TimeoutSettings:  exporterhelper.NewDefaultTimeoutConfig(),
+				BackOffConfig:    configretry.NewDefaultBackOffConfig(),
+				QueueBatchConfig: exporterhelper.NewDefaultQueueConfig(),
+				ClientConfig:     configkafka.NewDefaultClientConfig(),
+				Producer: func() configkafka.ProducerConfig {
+					config := configkafka.NewDefaultProducerConfig()
+					config.RequiredAcks = configkafka.WaitForAll
+					return config
+				}(),
+				Logs: SignalConfig{
+					Topic:    "otlp_logs",
+					Encoding: "otlp_proto",
+				},
+				Metrics: SignalConfig{
+					Topic:    "otlp_metrics",
+					Encoding: "otlp_proto",
+				},
+				Traces: SignalConfig{
+					Topic:    "otlp_spans",
+					Encoding: "otlp_proto",
+				},
+				Profiles: SignalConfig{
+					Topic:    "otlp_profiles",
+					Encoding: "otlp_proto",
+				},
+				Transactions: TransactionsConfig{
+					Enabled:         true,
+					TransactionalID: "billing-collector-0",
+					Timeout:         30 * time.Second,
+				},
 			},
 		},
 	}
@@ -167,6 +211,36 @@ func TestLoadConfigFailed(t *testing.T) {
 			expectedError: errLogsPartitionExclusive,
 			configFile:    "config-partitioning-failed.yaml",
 		},
+		{
+			id:            component.NewIDWithName(metadata.Type, "acks"),
+			expectedError: errTransactionsRequireAcksAll,
+			configFile:    "config-transactions-failed.yaml",
+		},
+		{
+			id:            component.NewIDWithName(metadata.Type, "timeout"),
+			expectedError: errTransactionsTimeoutNotPositive,
+			configFile:    "config-transactions-failed.yaml",
+		},
+		{
+			id:            component.NewIDWithName(metadata.Type, "profiles"),
+			expectedError: errProfilesMessageTemplate,
+			configFile:    "config-message-template-failed.yaml",
+		},
+		{
+			id:            component.NewIDWithName(metadata.Type, "traces"),
+			expectedError: errTracesMessageTemplate,
+			configFile:    "config-message-template-failed.yaml",
+		},
+		{
+			id:            component.NewIDWithName(metadata.Type, "metrics"),
+			expectedError: errMetricsMessageTemplate,
+			configFile:    "config-message-template-failed.yaml",
+		},
+		{
+			id:            component.NewIDWithName(metadata.Type, "logs"),
+			expectedError: errLogsMessageTemplate,
+			configFile:    "config-message-template-failed.yaml",
+		},
 	}
 
 	for _, tt := range tests {
@@ -184,3 +258,13 @@ func TestLoadConfigFailed(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigValidate_messageTemplate(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Logs.MessageKey = `resource.attributes["k8s.namespace.name"]`
+	cfg.Logs.MessageHeaders = []MessageHeader{{Key: "tenant", Value: `attributes["tenant"]`}}
+	assert.NoError(t, xconfmap.Validate(cfg))
+
+	cfg.Metrics.MessageKey = `resource.attributes[`
+	assert.ErrorContains(t, xconfmap.Validate(cfg), "invalid metrics message template: failed to parse message_key")
+}
@@ -5,6 +5,7 @@ package kafkaexporter // import "github.com/open-telemetry/opentelemetry-collect
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configretry"
@@ -34,6 +35,8 @@ const (
 	defaultPartitionLogsByResourceAttributesEnabled = false
 	// partitioning logs by trace id is disabled by default
 	defaultPartitionLogsByTraceIDEnabled = false
+	// transactions are aborted by the broker if not ended within a minute by default
+	defaultTransactionTimeout = time.Minute
 )
 
 // NewFactory creates Kafka exporter factory.
@@ -74,6 +77,9 @@ func createDefaultConfig() component.Config {
 		PartitionMetricsByResourceAttributes: defaultPartitionMetricsByResourceAttributesEnabled,
 		PartitionLogsByResourceAttributes:    defaultPartitionLogsByResourceAttributesEnabled,
 		PartitionLogsByTraceID:               defaultPartitionLogsByTraceIDEnabled,
+		Transactions: TransactionsConfig{
+			Timeout: defaultTransactionTimeout,
+		},
 	}
 }
 
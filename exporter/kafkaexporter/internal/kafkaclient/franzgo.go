@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/twmb/franz-go/pkg/kgo"
 )
@@ -31,15 +32,32 @@ func NewFranzSyncProducer(client *kgo.Client,
 
 // ExportData sends a batch of messages to Kafka
 func (p *FranzSyncProducer) ExportData(ctx context.Context, msgs Messages) error {
+	return produceFranzMessages(ctx, p.client, msgs, p.metadataKeys)
+}
+
+// Close shuts down the producer and flushes any remaining messages.
+func (p *FranzSyncProducer) Close() error {
+	p.client.Close()
+	return nil
+}
+
+// franzProducer is the subset of kgo.Client used to produce messages.
+type franzProducer interface {
+	ProduceSync(ctx context.Context, rs ...*kgo.Record) kgo.ProduceResults
+}
+
+// produceFranzMessages sends a batch of messages to Kafka with client,
+// setting the headers from the metadata of ctx.
+func produceFranzMessages(ctx context.Context, client franzProducer, msgs Messages, metadataKeys []string) error {
 	messages := makeFranzMessages(msgs)
-	setMessageHeaders(ctx, messages, p.metadataKeys,
+	setMessageHeaders(ctx, messages, metadataKeys,
 		func(key string, value []byte) kgo.RecordHeader {
 			return kgo.RecordHeader{Key: key, Value: value}
 		},
 		func(m *kgo.Record) []kgo.RecordHeader { return m.Headers },
 		func(m *kgo.Record, h []kgo.RecordHeader) { m.Headers = h },
 	)
-	result := p.client.ProduceSync(ctx, messages...)
+	result := client.ProduceSync(ctx, messages...)
 	var errs []error
 	for _, r := range result {
 		if r.Err != nil {
@@ -52,15 +70,13 @@ func (p *FranzSyncProducer) ExportData(ctx context.Context, msgs Messages) error
 	return errors.Join(errs...)
 }
 
-// Close shuts down the producer and flushes any remaining messages.
-func (p *FranzSyncProducer) Close() error {
-	p.client.Close()
-	return nil
-}
-
 func makeFranzMessages(messages Messages) []*kgo.Record {
 	msgs := make([]*kgo.Record, 0, messages.Count)
 	for _, msg := range messages.TopicMessages {
+		var headers []kgo.RecordHeader
+		for _, header := range msg.Headers {
+			headers = append(headers, kgo.RecordHeader{Key: header.Key, Value: header.Value})
+		}
 		for _, message := range msg.Messages {
 			msg := &kgo.Record{Topic: msg.Topic}
 			if message.Key != nil {
@@ -69,6 +85,9 @@ func makeFranzMessages(messages Messages) []*kgo.Record {
 			if message.Value != nil {
 				msg.Value = message.Value
 			}
+			if len(headers) > 0 {
+				msg.Headers = slices.Clone(headers)
+			}
 			msgs = append(msgs, msg)
 		}
 	}
@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kafkaclient // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/kafkaexporter/internal/kafkaclient"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+	"go.uber.org/zap"
+)
+
+// franzTransactionalClient is the subset of kgo.Client used to produce
+// messages in transactions.
+type franzTransactionalClient interface {
+	franzProducer
+	BeginTransaction() error
+	AbortBufferedRecords(ctx context.Context) error
+	EndTransaction(ctx context.Context, commit kgo.TransactionEndTry) error
+	Close()
+}
+
+// FranzTransactionalProducer is a franz-go producer which sends each batch
+// of messages in its own Kafka transaction. The client must be configured
+// with a transactional ID.
+//
+// When sending a batch fails, its transaction is aborted, so none of its
+// messages are visible to consumers reading with the read_committed isolation
+// level, and the error is returned for the export to be retried in a new
+// transaction.
+type FranzTransactionalProducer struct {
+	client       franzTransactionalClient
+	metadataKeys []string
+	logger       *zap.Logger
+
+	// mu serializes the transactions, as a client can only have a single
+	// transaction in progress.
+	mu sync.Mutex
+}
+
+// NewFranzTransactionalProducer creates a transactional producer from a
+// kgo.Client configured with a transactional ID.
+func NewFranzTransactionalProducer(client *kgo.Client,
+	metadataKeys []string,
+	logger *zap.Logger,
+) *FranzTransactionalProducer {
+	return &FranzTransactionalProducer{
+		client:       client,
+		metadataKeys: metadataKeys,
+		logger:       logger,
+	}
+}
+
+// ExportData sends a batch of messages to Kafka in a transaction, which is
+// committed if all messages were sent, and aborted otherwise.
+func (p *FranzTransactionalProducer) ExportData(ctx context.Context, msgs Messages) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// BeginTransaction recovers the producer ID if a previous transaction
+	// failed with a recoverable error, which aborts that transaction.
+	if err := p.client.BeginTransaction(); err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	// Canceling the context while ending the transaction would make it
+	// impossible to know whether it was committed or aborted.
+	endCtx := context.WithoutCancel(ctx)
+	if err := produceFranzMessages(ctx, p.client, msgs, p.metadataKeys); err != nil {
+		return errors.Join(err, p.abort(endCtx))
+	}
+	if err := p.client.EndTransaction(endCtx, kgo.TryCommit); err != nil {
+		p.logger.Warn("failed to commit transaction, messages will be sent again if the export is retried",
+			zap.Int("records", msgs.Count),
+			zap.Error(err),
+		)
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// abort aborts the current transaction, after failing any messages which
+// are still buffered so they do not become part of the next transaction.
+func (p *FranzTransactionalProducer) abort(ctx context.Context) error {
+	if err := p.client.AbortBufferedRecords(ctx); err != nil {
+		return fmt.Errorf("failed to abort buffered messages: %w", err)
+	}
+	if err := p.client.EndTransaction(ctx, kgo.TryAbort); err != nil {
+		return fmt.Errorf("failed to abort transaction: %w", err)
+	}
+	return nil
+}
+
+// Close shuts down the producer, aborting any transaction in progress.
+func (p *FranzTransactionalProducer) Close() error {
+	p.client.Close()
+	return nil
+}
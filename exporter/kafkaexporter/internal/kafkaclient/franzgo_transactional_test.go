@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kafkaclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"go.opentelemetry.io/collector/client"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/kafkaexporter/internal/marshaler"
+)
+
+func TestFranzTransactionalProducer(t *testing.T) {
+	errBroker := errors.New("broker error")
+	tests := []struct {
+		name          string
+		client        fakeTransactionalClient
+		expectedErr   string
+		expectedCalls []string
+	}{
+		{
+			name:          "committed",
+			expectedCalls: []string{"begin", "produce", "commit"},
+		},
+		{
+			name:          "begin error",
+			client:        fakeTransactionalClient{beginErr: errBroker},
+			expectedErr:   "failed to begin transaction: broker error",
+			expectedCalls: []string{"begin"},
+		},
+		{
+			name:          "produce error",
+			client:        fakeTransactionalClient{produceErr: errBroker},
+			expectedErr:   `error exporting to topic "otlp_logs": broker error`,
+			expectedCalls: []string{"begin", "produce", "abort_buffered", "abort"},
+		},
+		{
+			name:          "abort error",
+			client:        fakeTransactionalClient{produceErr: errBroker, abortErr: errBroker},
+			expectedErr:   "failed to abort transaction: broker error",
+			expectedCalls: []string{"begin", "produce", "abort_buffered", "abort"},
+		},
+		{
+			name:          "commit error",
+			client:        fakeTransactionalClient{commitErr: errBroker},
+			expectedErr:   "failed to commit transaction: broker error",
+			expectedCalls: []string{"begin", "produce", "commit"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			producer := &FranzTransactionalProducer{
+				client:       &tt.client,
+				metadataKeys: []string{"x-tenant-id"},
+				logger:       zap.NewNop(),
+			}
+			ctx := client.NewContext(t.Context(), client.Info{
+				Metadata: client.NewMetadata(map[string][]string{"x-tenant-id": {"tenant"}}),
+			})
+			err := producer.ExportData(ctx, Messages{
+				Count: 2,
+				TopicMessages: []TopicMessages{{
+					Topic:    "otlp_logs",
+					Messages: []marshaler.Message{{Value: []byte("a")}, {Value: []byte("b")}},
+				}},
+			})
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedCalls, tt.client.calls)
+			if len(tt.client.records) > 0 {
+				require.Len(t, tt.client.records, 2)
+				assert.Equal(t, []kgo.RecordHeader{{Key: "x-tenant-id", Value: []byte("tenant")}}, tt.client.records[0].Headers)
+			}
+			require.NoError(t, producer.Close())
+		})
+	}
+}
+
+type fakeTransactionalClient struct {
+	beginErr   error
+	produceErr error
+	abortErr   error
+	commitErr  error
+
+	calls   []string
+	records []*kgo.Record
+}
+
+func (c *fakeTransactionalClient) BeginTransaction() error {
+	c.calls = append(c.calls, "begin")
+	return c.beginErr
+}
+
+func (c *fakeTransactionalClient) ProduceSync(_ context.Context, rs ...*kgo.Record) kgo.ProduceResults {
+	c.calls = append(c.calls, "produce")
+	c.records = append(c.records, rs...)
+	results := make(kgo.ProduceResults, len(rs))
+	for i, r := range rs {
+		results[i] = kgo.ProduceResult{Record: r, Err: c.produceErr}
+	}
+	return results
+}
+
+func (c *fakeTransactionalClient) AbortBufferedRecords(context.Context) error {
+	c.calls = append(c.calls, "abort_buffered")
+	return nil
+}
+
+func (c *fakeTransactionalClient) EndTransaction(_ context.Context, commit kgo.TransactionEndTry) error {
+	if commit {
+		c.calls = append(c.calls, "commit")
+		return c.commitErr
+	}
+	c.calls = append(c.calls, "abort")
+	return c.abortErr
+}
+
+func (*fakeTransactionalClient) Close() {}
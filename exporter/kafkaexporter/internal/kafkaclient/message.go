@@ -18,4 +18,14 @@ type Messages struct {
 type TopicMessages struct {
 	Topic    string
 	Messages []marshaler.Message
+
+	// Headers are set on each of the messages, before the headers
+	// propagated from the client metadata.
+	Headers []Header
+}
+
+// Header is a Kafka message header.
+type Header struct {
+	Key   string
+	Value []byte
 }
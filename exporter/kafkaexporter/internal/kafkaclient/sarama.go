@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/IBM/sarama"
@@ -61,6 +62,10 @@ func (p *SaramaSyncProducer) Close() error {
 func makeSaramaMessages(messages Messages) []*sarama.ProducerMessage {
 	msgs := make([]*sarama.ProducerMessage, 0, messages.Count)
 	for _, msg := range messages.TopicMessages {
+		var headers []sarama.RecordHeader
+		for _, header := range msg.Headers {
+			headers = append(headers, sarama.RecordHeader{Key: []byte(header.Key), Value: header.Value})
+		}
 		for _, message := range msg.Messages {
 			msg := &sarama.ProducerMessage{Topic: msg.Topic}
 			if message.Key != nil {
@@ -69,6 +74,9 @@ func makeSaramaMessages(messages Messages) []*sarama.ProducerMessage {
 			if message.Value != nil {
 				msg.Value = sarama.ByteEncoder(message.Value)
 			}
+			if len(headers) > 0 {
+				msg.Headers = slices.Clone(headers)
+			}
 			msgs = append(msgs, msg)
 		}
 	}
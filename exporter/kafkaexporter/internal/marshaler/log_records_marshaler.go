@@ -0,0 +1,39 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package marshaler // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/kafkaexporter/internal/marshaler"
+
+import (
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+var _ LogsMarshaler = logRecordsMarshaler{}
+
+// LogRecordsMarshaler marshals each log record of a plog.Logs into its own
+// payload. Encoding extensions whose payloads hold a single log record, such
+// as schema_registry_encoding, implement it in addition to plog.Marshaler.
+type LogRecordsMarshaler interface {
+	MarshalLogRecords(ld plog.Logs) ([][]byte, error)
+}
+
+type logRecordsMarshaler struct {
+	marshaler LogRecordsMarshaler
+}
+
+// NewLogRecordsMarshaler returns a new LogsMarshaler that produces a
+// message per log record using the given LogRecordsMarshaler.
+func NewLogRecordsMarshaler(m LogRecordsMarshaler) LogsMarshaler {
+	return logRecordsMarshaler{marshaler: m}
+}
+
+func (p logRecordsMarshaler) MarshalLogs(ld plog.Logs) ([]Message, error) {
+	payloads, err := p.marshaler.MarshalLogRecords(ld)
+	if err != nil {
+		return nil, err
+	}
+	messages := make([]Message, len(payloads))
+	for i, payload := range payloads {
+		messages[i] = Message{Value: payload}
+	}
+	return messages, nil
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"iter"
+	"os"
 
 	"github.com/twmb/franz-go/pkg/kgo"
 	"go.opentelemetry.io/collector/client"
@@ -19,6 +20,8 @@ import (
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/pprofile"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/pipeline/xpipeline"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/kafkaexporter/internal/kafkaclient"
@@ -28,6 +31,9 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/kafka"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/batchpersignal"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/kafka/topic"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil"
 )
 
@@ -52,11 +58,17 @@ type producer interface {
 	Close() error
 }
 
+// partition holds the key and headers of the messages of a part of the data.
+type partition struct {
+	key     []byte
+	headers []kafkaclient.Header
+}
+
 type messenger[T any] interface {
 	// partitionData returns an iterator that yields key-value pairs
-	// where the key is the partition key, and the value is the pdata
+	// where the key is the partition, and the value is the pdata
 	// type (plog.Logs, etc.)
-	partitionData(T) iter.Seq2[[]byte, T]
+	partitionData(context.Context, T) (iter.Seq2[partition, T], error)
 
 	// marshalData marshals a pdata type into one or more messages.
 	marshalData(T) ([]marshaler.Message, error)
@@ -68,6 +80,7 @@ type messenger[T any] interface {
 type kafkaExporter[T any] struct {
 	cfg          Config
 	set          exporter.Settings
+	signal       pipeline.Signal
 	tb           *metadata.TelemetryBuilder
 	logger       *zap.Logger
 	newMessenger func(host component.Host) (messenger[T], error)
@@ -78,11 +91,13 @@ type kafkaExporter[T any] struct {
 func newKafkaExporter[T any](
 	config Config,
 	set exporter.Settings,
+	signal pipeline.Signal,
 	newMessenger func(component.Host) (messenger[T], error),
 ) *kafkaExporter[T] {
 	return &kafkaExporter[T]{
 		cfg:          config,
 		set:          set,
+		signal:       signal,
 		logger:       set.Logger,
 		newMessenger: newMessenger,
 	}
@@ -100,22 +115,42 @@ func (e *kafkaExporter[T]) Start(ctx context.Context, host component.Host) (err
 	}
 
 	if franzGoClientFeatureGate.IsEnabled() {
+		opts := []kgo.Opt{kgo.WithHooks(kafkaclient.NewFranzProducerMetrics(tb))}
+		if e.cfg.Transactions.Enabled {
+			transactionalID, terr := e.transactionalID()
+			if terr != nil {
+				return terr
+			}
+			opts = append(opts,
+				kgo.TransactionalID(transactionalID),
+				kgo.TransactionTimeout(e.cfg.Transactions.Timeout),
+			)
+		}
 		producer, ferr := kafka.NewFranzSyncProducer(
 			ctx,
 			e.cfg.ClientConfig,
 			e.cfg.Producer,
 			e.cfg.TimeoutSettings.Timeout,
 			e.logger,
-			kgo.WithHooks(kafkaclient.NewFranzProducerMetrics(tb)),
+			opts...,
 		)
 		if ferr != nil {
 			return ferr
 		}
+		if e.cfg.Transactions.Enabled {
+			e.producer = kafkaclient.NewFranzTransactionalProducer(producer,
+				e.cfg.IncludeMetadataKeys, e.logger,
+			)
+			return nil
+		}
 		e.producer = kafkaclient.NewFranzSyncProducer(producer,
 			e.cfg.IncludeMetadataKeys,
 		)
 		return nil
 	}
+	if e.cfg.Transactions.Enabled {
+		return fmt.Errorf("transactions require the franz-go client, enable the %s feature gate", franzGoClientFeatureGateName)
+	}
 	producer, err := kafka.NewSaramaSyncProducer(ctx, e.cfg.ClientConfig,
 		e.cfg.Producer, e.cfg.TimeoutSettings.Timeout,
 	)
@@ -130,6 +165,21 @@ func (e *kafkaExporter[T]) Start(ctx context.Context, host component.Host) (err
 	return nil
 }
 
+// transactionalID returns the transactional ID of the producer, which is
+// the configured prefix, or the hostname and component ID, followed by the
+// signal type, as each signal has its own producer.
+func (e *kafkaExporter[T]) transactionalID() (string, error) {
+	prefix := e.cfg.Transactions.TransactionalID
+	if prefix == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return "", fmt.Errorf("failed to get hostname for the transactional ID: %w", err)
+		}
+		prefix = hostname + "-" + e.set.ID.String()
+	}
+	return prefix + "-" + e.signal.String(), nil
+}
+
 func (e *kafkaExporter[T]) Close(context.Context) (err error) {
 	if e.producer == nil {
 		return nil
@@ -144,8 +194,14 @@ func (e *kafkaExporter[T]) Close(context.Context) (err error) {
 }
 
 func (e *kafkaExporter[T]) exportData(ctx context.Context, data T) error {
+	partitions, err := e.messenger.partitionData(ctx, data)
+	if err != nil {
+		err = fmt.Errorf("error partitioning data: %w", err)
+		e.logger.Error("kafka records partition data failed", zap.Error(err))
+		return consumererror.NewPermanent(err)
+	}
 	var m kafkaclient.Messages
-	for key, data := range e.messenger.partitionData(data) {
+	for partition, data := range partitions {
 		topic := e.messenger.getTopic(ctx, data)
 		partitionMessages, err := e.messenger.marshalData(data)
 		if err != nil {
@@ -159,17 +215,18 @@ func (e *kafkaExporter[T]) exportData(ctx context.Context, data T) error {
 		for i := range partitionMessages {
 			// Marshalers may set the Key, so don't override
 			// if it's set and we're not partitioning here.
-			if key != nil {
-				partitionMessages[i].Key = key
+			if partition.key != nil {
+				partitionMessages[i].Key = partition.key
 			}
 		}
 		m.Count += len(partitionMessages)
 		m.TopicMessages = append(m.TopicMessages, kafkaclient.TopicMessages{
 			Topic:    topic,
 			Messages: partitionMessages,
+			Headers:  partition.headers,
 		})
 	}
-	err := e.producer.ExportData(ctx, m)
+	err = e.producer.ExportData(ctx, m)
 	if err == nil {
 		if e.logger.Core().Enabled(zap.DebugLevel) {
 			for _, mi := range m.TopicMessages {
@@ -198,14 +255,19 @@ func newTracesExporter(config Config, set exporter.Settings) *kafkaExporter[ptra
 	case "jaeger_proto", "jaeger_json":
 		config.PartitionTracesByID = false
 	}
-	return newKafkaExporter(config, set, func(host component.Host) (messenger[ptrace.Traces], error) {
+	return newKafkaExporter(config, set, pipeline.SignalTraces, func(host component.Host) (messenger[ptrace.Traces], error) {
 		marshaler, err := getTracesMarshaler(config.Traces.Encoding, host)
 		if err != nil {
 			return nil, err
 		}
+		template, err := newTracesMessageTemplate(config.Traces, set.TelemetrySettings)
+		if err != nil {
+			return nil, err
+		}
 		return &kafkaTracesMessenger{
 			config:    config,
 			marshaler: marshaler,
+			template:  template,
 		}, nil
 	})
 }
@@ -213,6 +275,7 @@ func newTracesExporter(config Config, set exporter.Settings) *kafkaExporter[ptra
 type kafkaTracesMessenger struct {
 	config    Config
 	marshaler marshaler.TracesMarshaler
+	template  *messageTemplate[ottlspan.TransformContext]
 }
 
 func (e *kafkaTracesMessenger) marshalData(td ptrace.Traces) ([]marshaler.Message, error) {
@@ -223,10 +286,13 @@ func (e *kafkaTracesMessenger) getTopic(ctx context.Context, td ptrace.Traces) s
 	return getTopic[ptrace.ResourceSpans](ctx, e.config.Traces, e.config.TopicFromAttribute, td.ResourceSpans())
 }
 
-func (e *kafkaTracesMessenger) partitionData(td ptrace.Traces) iter.Seq2[[]byte, ptrace.Traces] {
-	return func(yield func([]byte, ptrace.Traces) bool) {
+func (e *kafkaTracesMessenger) partitionData(ctx context.Context, td ptrace.Traces) (iter.Seq2[partition, ptrace.Traces], error) {
+	if e.template != nil {
+		return partitionTraces(ctx, td, e.template)
+	}
+	return func(yield func(partition, ptrace.Traces) bool) {
 		if !e.config.PartitionTracesByID {
-			yield(nil, td)
+			yield(partition{}, td)
 			return
 		}
 		for _, td := range batchpersignal.SplitTraces(td) {
@@ -235,22 +301,27 @@ func (e *kafkaTracesMessenger) partitionData(td ptrace.Traces) iter.Seq2[[]byte,
 			key := []byte(traceutil.TraceIDToHexOrEmptyString(
 				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).TraceID(),
 			))
-			if !yield(key, td) {
+			if !yield(partition{key: key}, td) {
 				return
 			}
 		}
-	}
+	}, nil
 }
 
 func newLogsExporter(config Config, set exporter.Settings) *kafkaExporter[plog.Logs] {
-	return newKafkaExporter(config, set, func(host component.Host) (messenger[plog.Logs], error) {
+	return newKafkaExporter(config, set, pipeline.SignalLogs, func(host component.Host) (messenger[plog.Logs], error) {
 		marshaler, err := getLogsMarshaler(config.Logs.Encoding, host)
 		if err != nil {
 			return nil, err
 		}
+		template, err := newLogsMessageTemplate(config.Logs, set.TelemetrySettings)
+		if err != nil {
+			return nil, err
+		}
 		return &kafkaLogsMessenger{
 			config:    config,
 			marshaler: marshaler,
+			template:  template,
 		}, nil
 	})
 }
@@ -258,6 +329,7 @@ func newLogsExporter(config Config, set exporter.Settings) *kafkaExporter[plog.L
 type kafkaLogsMessenger struct {
 	config    Config
 	marshaler marshaler.LogsMarshaler
+	template  *messageTemplate[ottllog.TransformContext]
 }
 
 func (e *kafkaLogsMessenger) marshalData(ld plog.Logs) ([]marshaler.Message, error) {
@@ -268,14 +340,17 @@ func (e *kafkaLogsMessenger) getTopic(ctx context.Context, ld plog.Logs) string
 	return getTopic[plog.ResourceLogs](ctx, e.config.Logs, e.config.TopicFromAttribute, ld.ResourceLogs())
 }
 
-func (e *kafkaLogsMessenger) partitionData(ld plog.Logs) iter.Seq2[[]byte, plog.Logs] {
-	return func(yield func([]byte, plog.Logs) bool) {
+func (e *kafkaLogsMessenger) partitionData(ctx context.Context, ld plog.Logs) (iter.Seq2[partition, plog.Logs], error) {
+	if e.template != nil {
+		return partitionLogs(ctx, ld, e.template)
+	}
+	return func(yield func(partition, plog.Logs) bool) {
 		if e.config.PartitionLogsByResourceAttributes {
 			for _, resourceLogs := range ld.ResourceLogs().All() {
 				hash := pdatautil.MapHash(resourceLogs.Resource().Attributes())
 				newLogs := plog.NewLogs()
 				resourceLogs.CopyTo(newLogs.ResourceLogs().AppendEmpty())
-				if !yield(hash[:], newLogs) {
+				if !yield(partition{key: hash[:]}, newLogs) {
 					return
 				}
 			}
@@ -288,25 +363,30 @@ func (e *kafkaLogsMessenger) partitionData(ld plog.Logs) iter.Seq2[[]byte, plog.
 				if !traceID.IsEmpty() {
 					key = []byte(traceutil.TraceIDToHexOrEmptyString(traceID))
 				}
-				if !yield(key, l) {
+				if !yield(partition{key: key}, l) {
 					return
 				}
 			}
 			return
 		}
-		yield(nil, ld)
-	}
+		yield(partition{}, ld)
+	}, nil
 }
 
 func newMetricsExporter(config Config, set exporter.Settings) *kafkaExporter[pmetric.Metrics] {
-	return newKafkaExporter(config, set, func(host component.Host) (messenger[pmetric.Metrics], error) {
+	return newKafkaExporter(config, set, pipeline.SignalMetrics, func(host component.Host) (messenger[pmetric.Metrics], error) {
 		marshaler, err := getMetricsMarshaler(config.Metrics.Encoding, host)
 		if err != nil {
 			return nil, err
 		}
+		template, err := newMetricsMessageTemplate(config.Metrics, set.TelemetrySettings)
+		if err != nil {
+			return nil, err
+		}
 		return &kafkaMetricsMessenger{
 			config:    config,
 			marshaler: marshaler,
+			template:  template,
 		}, nil
 	})
 }
@@ -314,6 +394,7 @@ func newMetricsExporter(config Config, set exporter.Settings) *kafkaExporter[pme
 type kafkaMetricsMessenger struct {
 	config    Config
 	marshaler marshaler.MetricsMarshaler
+	template  *messageTemplate[ottlmetric.TransformContext]
 }
 
 func (e *kafkaMetricsMessenger) marshalData(md pmetric.Metrics) ([]marshaler.Message, error) {
@@ -324,25 +405,28 @@ func (e *kafkaMetricsMessenger) getTopic(ctx context.Context, md pmetric.Metrics
 	return getTopic[pmetric.ResourceMetrics](ctx, e.config.Metrics, e.config.TopicFromAttribute, md.ResourceMetrics())
 }
 
-func (e *kafkaMetricsMessenger) partitionData(md pmetric.Metrics) iter.Seq2[[]byte, pmetric.Metrics] {
-	return func(yield func([]byte, pmetric.Metrics) bool) {
+func (e *kafkaMetricsMessenger) partitionData(ctx context.Context, md pmetric.Metrics) (iter.Seq2[partition, pmetric.Metrics], error) {
+	if e.template != nil {
+		return partitionMetrics(ctx, md, e.template)
+	}
+	return func(yield func(partition, pmetric.Metrics) bool) {
 		if !e.config.PartitionMetricsByResourceAttributes {
-			yield(nil, md)
+			yield(partition{}, md)
 			return
 		}
 		for _, resourceMetrics := range md.ResourceMetrics().All() {
 			hash := pdatautil.MapHash(resourceMetrics.Resource().Attributes())
 			newMetrics := pmetric.NewMetrics()
 			resourceMetrics.CopyTo(newMetrics.ResourceMetrics().AppendEmpty())
-			if !yield(hash[:], newMetrics) {
+			if !yield(partition{key: hash[:]}, newMetrics) {
 				return
 			}
 		}
-	}
+	}, nil
 }
 
 func newProfilesExporter(config Config, set exporter.Settings) *kafkaExporter[pprofile.Profiles] {
-	return newKafkaExporter(config, set, func(host component.Host) (messenger[pprofile.Profiles], error) {
+	return newKafkaExporter(config, set, xpipeline.SignalProfiles, func(host component.Host) (messenger[pprofile.Profiles], error) {
 		marshaler, err := getProfilesMarshaler(config.Profiles.Encoding, host)
 		if err != nil {
 			return nil, err
@@ -367,10 +451,10 @@ func (e *kafkaProfilesMessenger) getTopic(ctx context.Context, ld pprofile.Profi
 	return getTopic[pprofile.ResourceProfiles](ctx, e.config.Profiles, e.config.TopicFromAttribute, ld.ResourceProfiles())
 }
 
-func (*kafkaProfilesMessenger) partitionData(ld pprofile.Profiles) iter.Seq2[[]byte, pprofile.Profiles] {
-	return func(yield func([]byte, pprofile.Profiles) bool) {
-		yield(nil, ld)
-	}
+func (*kafkaProfilesMessenger) partitionData(_ context.Context, ld pprofile.Profiles) (iter.Seq2[partition, pprofile.Profiles], error) {
+	return func(yield func(partition, pprofile.Profiles) bool) {
+		yield(partition{}, ld)
+	}, nil
 }
 
 type resourceSlice[T any] interface {
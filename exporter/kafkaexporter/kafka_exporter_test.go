@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"testing"
 
 	"github.com/IBM/sarama"
@@ -32,6 +33,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/kafkaexporter/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/traceutil"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/kafka/kafkatest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/kafka/configkafka"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/kafka/topic"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatatest/plogtest"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatatest/pmetrictest"
@@ -377,6 +379,31 @@ func TestTracesPusher_partitioning(t *testing.T) {
 			[]byte(traceID2.String()),
 		}, keys)
 	})
+	t.Run("message_template", func(t *testing.T) {
+		config := createDefaultConfig().(*Config)
+		config.Traces.MessageKey = `trace_id.string`
+		exp, producer := newMockTracesExporter(t, *config, componenttest.NewNopHost())
+
+		// Spans are split by their computed key.
+		for _, traceID := range []pcommon.TraceID{traceID1, traceID2} {
+			producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(
+				func(msg *sarama.ProducerMessage) error {
+					key, err := msg.Key.Encode()
+					require.NoError(t, err)
+					assert.Equal(t, traceutil.TraceIDToHexOrEmptyString(traceID), string(key))
+
+					value, err := msg.Value.Encode()
+					require.NoError(t, err)
+					output, err := (&ptrace.ProtoUnmarshaler{}).UnmarshalTraces(value)
+					require.NoError(t, err)
+					assert.Equal(t, 2, output.SpanCount())
+					return nil
+				},
+			)
+		}
+
+		require.NoError(t, exp.exportData(t.Context(), input))
+	})
 }
 
 func TestMetricsDataPusher(t *testing.T) {
@@ -570,6 +597,29 @@ func TestMetricsPusher_partitioning(t *testing.T) {
 		assert.Equal(t, keys[0], keys[1])
 		assert.NotEqual(t, keys[0], keys[2])
 	})
+	t.Run("message_template", func(t *testing.T) {
+		config := createDefaultConfig().(*Config)
+		config.Metrics.MessageHeaders = []MessageHeader{
+			{Key: "service", Value: `resource.attributes["service.name"]`},
+		}
+		exp, producer := newMockMetricsExporter(t, *config, componenttest.NewNopHost())
+
+		// Metrics are split by their computed headers, and keep the
+		// default partitioning without a computed key.
+		for _, serviceName := range []string{"service1", "service2"} {
+			producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(
+				func(msg *sarama.ProducerMessage) error {
+					assert.Nil(t, msg.Key)
+					assert.Equal(t, []sarama.RecordHeader{
+						{Key: []byte("service"), Value: []byte(serviceName)},
+					}, msg.Headers)
+					return nil
+				},
+			)
+		}
+
+		require.NoError(t, exp.exportData(t.Context(), input))
+	})
 }
 
 func TestMetricsDataPusher_Kgo(t *testing.T) {
@@ -1043,6 +1093,62 @@ func TestLogsPusher_partitioning(t *testing.T) {
 		assert.NotEqual(t, keys[0], keys[2])
 	})
 
+	t.Run("message_template", func(t *testing.T) {
+		config := createDefaultConfig().(*Config)
+		config.Logs.MessageKey = `resource.attributes["service.name"]`
+		config.Logs.MessageHeaders = []MessageHeader{
+			{Key: "service", Value: `Concat(["svc", resource.attributes["service.name"]], "-")`},
+			{Key: "missing", Value: `attributes["missing"]`},
+		}
+		exp, producer := newMockLogsExporter(t, *config, componenttest.NewNopHost())
+
+		// Log records are split by their computed key and headers,
+		// keeping their resource.
+		expected := []struct {
+			key         string
+			resourceLen int
+		}{
+			{key: "service1", resourceLen: 2},
+			{key: "service2", resourceLen: 1},
+		}
+		for _, tt := range expected {
+			producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(
+				func(msg *sarama.ProducerMessage) error {
+					key, err := msg.Key.Encode()
+					require.NoError(t, err)
+					assert.Equal(t, tt.key, string(key))
+					assert.Equal(t, []sarama.RecordHeader{
+						{Key: []byte("service"), Value: []byte("svc-" + tt.key)},
+					}, msg.Headers)
+
+					value, err := msg.Value.Encode()
+					require.NoError(t, err)
+					output, err := (&plog.ProtoUnmarshaler{}).UnmarshalLogs(value)
+					require.NoError(t, err)
+					assert.Equal(t, tt.resourceLen, output.ResourceLogs().Len())
+					return nil
+				},
+			)
+		}
+
+		require.NoError(t, exp.exportData(t.Context(), input))
+	})
+
+	t.Run("message_template_error", func(t *testing.T) {
+		config := createDefaultConfig().(*Config)
+		config.Logs.MessageKey = `SHA256(resource.attributes["service.name"])`
+		exp, _ := newMockLogsExporter(t, *config, componenttest.NewNopHost())
+
+		in := plog.NewLogs()
+		rl := in.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().PutInt("service.name", 1)
+		rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+
+		err := exp.exportData(t.Context(), in)
+		assert.True(t, consumererror.IsPermanent(err))
+		assert.ErrorContains(t, err, "failed to compute message key")
+	})
+
 	// ensure that when TraceID partitioning is enabled but a log record has no TraceID,
 	// the exporter falls back to default partitioning (nil key).
 	t.Run("trace_id_partitioning_missing_traceid_defaults_to_nil_key", func(t *testing.T) {
@@ -1317,6 +1423,62 @@ func TestProfilesPusher_partitioning(t *testing.T) {
 	})
 }
 
+func TestTransactions(t *testing.T) {
+	t.Run("transactional ID", func(t *testing.T) {
+		config := createDefaultConfig().(*Config)
+		config.Transactions.TransactionalID = "billing-collector-0"
+		exp := newLogsExporter(*config, exportertest.NewNopSettings(metadata.Type))
+		transactionalID, err := exp.transactionalID()
+		require.NoError(t, err)
+		assert.Equal(t, "billing-collector-0-logs", transactionalID)
+
+		hostname, err := os.Hostname()
+		require.NoError(t, err)
+		config.Transactions.TransactionalID = ""
+		set := exportertest.NewNopSettings(metadata.Type)
+		set.ID = component.NewIDWithName(metadata.Type, "billing")
+		profilesExp := newProfilesExporter(*config, set)
+		transactionalID, err = profilesExp.transactionalID()
+		require.NoError(t, err)
+		assert.Equal(t, hostname+"-kafka/billing-profiles", transactionalID)
+	})
+
+	t.Run("franz-go", func(t *testing.T) {
+		setFranzGoClientFeatureGate(t, true)
+		_, clientConfig := kafkatest.NewCluster(t)
+		config := createDefaultConfig().(*Config)
+		config.ClientConfig = clientConfig
+		config.Producer.RequiredAcks = configkafka.WaitForAll
+		config.Transactions.Enabled = true
+		exp := newTracesExporter(*config, exportertest.NewNopSettings(metadata.Type))
+
+		require.NoError(t, exp.Start(t.Context(), componenttest.NewNopHost()))
+		assert.IsType(t, &kafkaclient.FranzTransactionalProducer{}, exp.producer)
+		require.NoError(t, exp.Close(t.Context()))
+	})
+
+	t.Run("sarama", func(t *testing.T) {
+		setFranzGoClientFeatureGate(t, false)
+		config := createDefaultConfig().(*Config)
+		config.Producer.RequiredAcks = configkafka.WaitForAll
+		config.Transactions.Enabled = true
+		exp := newTracesExporter(*config, exportertest.NewNopSettings(metadata.Type))
+
+		err := exp.Start(t.Context(), componenttest.NewNopHost())
+		assert.ErrorContains(t, err, "transactions require the franz-go client")
+	})
+}
+
+// setFranzGoClientFeatureGate sets the franz-go client feature gate for the
+// duration of the test.
+func setFranzGoClientFeatureGate(tb testing.TB, enabled bool) {
+	previous := franzGoClientFeatureGate.IsEnabled()
+	require.NoError(tb, featuregate.GlobalRegistry().Set(franzGoClientFeatureGateName, enabled))
+	tb.Cleanup(func() {
+		require.NoError(tb, featuregate.GlobalRegistry().Set(franzGoClientFeatureGateName, previous))
+	})
+}
+
 func Test_GetTopic(t *testing.T) {
 	tests := []struct {
 		name               string
@@ -67,6 +67,9 @@ func getLogsMarshaler(encoding string, host component.Host) (marshaler.LogsMarsh
 			return nil, err
 		}
 	} else {
+		if m, ok := m.(marshaler.LogRecordsMarshaler); ok {
+			return marshaler.NewLogRecordsMarshaler(m), nil
+		}
 		return marshaler.NewPdataLogsMarshaler(m), nil
 	}
 	switch encoding {
@@ -46,6 +46,25 @@ func TestGetLogsMarshaler(t *testing.T) {
 	require.Len(t, messages, 1)
 	assert.Equal(t, "bob", string(messages[0].Value))
 
+	// Verify extensions marshaling each log record are used to produce a
+	// message per log record.
+	m = mustGetLogsMarshaler(t, "schema_registry_encoding", extensionsHost{
+		component.MustNewID("schema_registry_encoding"): plogRecordsMarshalerExtension{
+			plogMarshalerFuncExtension: func(plog.Logs) ([]byte, error) {
+				return []byte("single"), nil
+			},
+		},
+	})
+	logs := plog.NewLogs()
+	logRecords := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	logRecords.AppendEmpty().Body().SetStr("first")
+	logRecords.AppendEmpty().Body().SetStr("second")
+	messages, err = m.MarshalLogs(logs)
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+	assert.Equal(t, "first", string(messages[0].Value))
+	assert.Equal(t, "second", string(messages[1].Value))
+
 	// Specifying an extension for a different type should fail fast.
 	m, err = getLogsMarshaler("otlp_proto", extensionsHost{
 		component.MustNewID("otlp_proto"): struct{ component.Component }{},
@@ -166,3 +185,18 @@ func mustGetProfilesMarshaler(tb testing.TB, encoding string, host component.Hos
 	require.NoError(tb, err)
 	return m
 }
+
+// plogRecordsMarshalerExtension is a logs marshaler extension marshaling the
+// body of each log record into its own payload.
+type plogRecordsMarshalerExtension struct {
+	plogMarshalerFuncExtension
+}
+
+func (plogRecordsMarshalerExtension) MarshalLogRecords(ld plog.Logs) ([][]byte, error) {
+	var payloads [][]byte
+	logRecords := ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	for i := 0; i < logRecords.Len(); i++ {
+		payloads = append(payloads, []byte(logRecords.At(i).Body().Str()))
+	}
+	return payloads, nil
+}
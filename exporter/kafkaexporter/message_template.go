@@ -0,0 +1,349 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kafkaexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/kafkaexporter"
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"iter"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/kafkaexporter/internal/kafkaclient"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+)
+
+// messageTemplate computes the key and headers of the messages of records
+// from OTTL value expressions.
+type messageTemplate[K any] struct {
+	key     *ottl.ValueExpression[K]
+	headers []headerTemplate[K]
+}
+
+type headerTemplate[K any] struct {
+	key   string
+	value *ottl.ValueExpression[K]
+}
+
+// newMessageTemplate parses the message_key and message_headers of cfg. It
+// returns nil if neither is configured.
+func newMessageTemplate[K any](cfg SignalConfig, parser ottl.Parser[K]) (*messageTemplate[K], error) {
+	var t messageTemplate[K]
+	if cfg.MessageKey != "" {
+		key, err := parser.ParseValueExpression(cfg.MessageKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse message_key: %w", err)
+		}
+		t.key = key
+	}
+	for _, header := range cfg.MessageHeaders {
+		if header.Key == "" {
+			return nil, errors.New("message_headers key must be specified")
+		}
+		value, err := parser.ParseValueExpression(header.Value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse value of message header %q: %w", header.Key, err)
+		}
+		t.headers = append(t.headers, headerTemplate[K]{key: header.Key, value: value})
+	}
+	return &t, nil
+}
+
+func newLogsMessageTemplate(cfg SignalConfig, set component.TelemetrySettings) (*messageTemplate[ottllog.TransformContext], error) {
+	if !cfg.hasMessageTemplate() {
+		return nil, nil
+	}
+	parser, err := ottllog.NewParser(ottlfuncs.StandardConverters[ottllog.TransformContext](), set)
+	if err != nil {
+		return nil, err
+	}
+	return newMessageTemplate(cfg, parser)
+}
+
+func newMetricsMessageTemplate(cfg SignalConfig, set component.TelemetrySettings) (*messageTemplate[ottlmetric.TransformContext], error) {
+	if !cfg.hasMessageTemplate() {
+		return nil, nil
+	}
+	parser, err := ottlmetric.NewParser(ottlfuncs.StandardConverters[ottlmetric.TransformContext](), set)
+	if err != nil {
+		return nil, err
+	}
+	return newMessageTemplate(cfg, parser)
+}
+
+func newTracesMessageTemplate(cfg SignalConfig, set component.TelemetrySettings) (*messageTemplate[ottlspan.TransformContext], error) {
+	if !cfg.hasMessageTemplate() {
+		return nil, nil
+	}
+	parser, err := ottlspan.NewParser(ottlfuncs.StandardConverters[ottlspan.TransformContext](), set)
+	if err != nil {
+		return nil, err
+	}
+	return newMessageTemplate(cfg, parser)
+}
+
+// partition computes the key and headers of the message of a record.
+func (t *messageTemplate[K]) partition(ctx context.Context, tCtx K) (partition, error) {
+	var p partition
+	if t.key != nil {
+		value, err := t.key.Eval(ctx, tCtx)
+		if err != nil {
+			return p, fmt.Errorf("failed to compute message key: %w", err)
+		}
+		if p.key, err = valueToBytes(value); err != nil {
+			return p, fmt.Errorf("failed to compute message key: %w", err)
+		}
+	}
+	for _, header := range t.headers {
+		value, err := header.value.Eval(ctx, tCtx)
+		if err != nil {
+			return p, fmt.Errorf("failed to compute message header %q: %w", header.key, err)
+		}
+		headerValue, err := valueToBytes(value)
+		if err != nil {
+			return p, fmt.Errorf("failed to compute message header %q: %w", header.key, err)
+		}
+		if headerValue != nil {
+			p.headers = append(p.headers, kafkaclient.Header{Key: header.key, Value: headerValue})
+		}
+	}
+	return p, nil
+}
+
+// valueToBytes converts the result of an OTTL value expression to bytes.
+// Strings and bytes are used as is, nil values are converted to nil, and
+// other values are converted to their string representation.
+func valueToBytes(value any) ([]byte, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []byte(v), nil
+	case []byte:
+		return v, nil
+	case pcommon.Value:
+		switch v.Type() {
+		case pcommon.ValueTypeEmpty:
+			return nil, nil
+		case pcommon.ValueTypeBytes:
+			return v.Bytes().AsRaw(), nil
+		default:
+			return []byte(v.AsString()), nil
+		}
+	case pcommon.Map:
+		pv := pcommon.NewValueEmpty()
+		v.CopyTo(pv.SetEmptyMap())
+		return valueToBytes(pv)
+	case pcommon.Slice:
+		pv := pcommon.NewValueEmpty()
+		v.CopyTo(pv.SetEmptySlice())
+		return valueToBytes(pv)
+	}
+	pv := pcommon.NewValueEmpty()
+	if err := pv.FromRaw(value); err != nil {
+		return nil, err
+	}
+	return valueToBytes(pv)
+}
+
+// id returns a string identifying the key and headers of p.
+func (p partition) id() string {
+	b := appendPartitionBytes(nil, p.key)
+	for _, header := range p.headers {
+		b = appendPartitionBytes(b, []byte(header.Key))
+		b = appendPartitionBytes(b, header.Value)
+	}
+	return string(b)
+}
+
+// appendPartitionBytes appends v, prefixed with its length, distinguishing
+// nil from empty values.
+func appendPartitionBytes(b, v []byte) []byte {
+	if v == nil {
+		return append(b, 0)
+	}
+	b = append(b, 1)
+	b = binary.AppendUvarint(b, uint64(len(v)))
+	return append(b, v...)
+}
+
+// partitionGroups holds the parts of data split by partition, in the
+// order their partition was first computed.
+type partitionGroups[G any] struct {
+	ids    map[string]int
+	groups []G
+}
+
+// get returns the group of the partition p, which is created with
+// newGroup the first time p is seen.
+func (g *partitionGroups[G]) get(p partition, newGroup func() G) G {
+	if g.ids == nil {
+		g.ids = make(map[string]int)
+	}
+	id := p.id()
+	if i, ok := g.ids[id]; ok {
+		return g.groups[i]
+	}
+	group := newGroup()
+	g.ids[id] = len(g.groups)
+	g.groups = append(g.groups, group)
+	return group
+}
+
+type logsGroup struct {
+	partition    partition
+	logs         plog.Logs
+	resourceLogs plog.ResourceLogs
+	scopeLogs    plog.ScopeLogs
+	// resource and scope are the indexes in the source logs of the
+	// resource and scope of the last log record added to the group.
+	resource, scope int
+}
+
+// partitionLogs splits ld by the key and headers computed by t for each
+// log record.
+func partitionLogs(ctx context.Context, ld plog.Logs, t *messageTemplate[ottllog.TransformContext]) (iter.Seq2[partition, plog.Logs], error) {
+	var groups partitionGroups[*logsGroup]
+	for i, rl := range ld.ResourceLogs().All() {
+		for j, sl := range rl.ScopeLogs().All() {
+			for _, lr := range sl.LogRecords().All() {
+				p, err := t.partition(ctx, ottllog.NewTransformContext(lr, sl.Scope(), rl.Resource(), sl, rl))
+				if err != nil {
+					return nil, err
+				}
+				g := groups.get(p, func() *logsGroup {
+					return &logsGroup{partition: p, logs: plog.NewLogs(), resource: -1}
+				})
+				if g.resource != i {
+					g.resourceLogs = g.logs.ResourceLogs().AppendEmpty()
+					rl.Resource().CopyTo(g.resourceLogs.Resource())
+					g.resourceLogs.SetSchemaUrl(rl.SchemaUrl())
+					g.resource, g.scope = i, -1
+				}
+				if g.scope != j {
+					g.scopeLogs = g.resourceLogs.ScopeLogs().AppendEmpty()
+					sl.Scope().CopyTo(g.scopeLogs.Scope())
+					g.scopeLogs.SetSchemaUrl(sl.SchemaUrl())
+					g.scope = j
+				}
+				lr.CopyTo(g.scopeLogs.LogRecords().AppendEmpty())
+			}
+		}
+	}
+	return func(yield func(partition, plog.Logs) bool) {
+		for _, g := range groups.groups {
+			if !yield(g.partition, g.logs) {
+				return
+			}
+		}
+	}, nil
+}
+
+type metricsGroup struct {
+	partition       partition
+	metrics         pmetric.Metrics
+	resourceMetrics pmetric.ResourceMetrics
+	scopeMetrics    pmetric.ScopeMetrics
+	// resource and scope are the indexes in the source metrics of the
+	// resource and scope of the last metric added to the group.
+	resource, scope int
+}
+
+// partitionMetrics splits md by the key and headers computed by t for
+// each metric.
+func partitionMetrics(ctx context.Context, md pmetric.Metrics, t *messageTemplate[ottlmetric.TransformContext]) (iter.Seq2[partition, pmetric.Metrics], error) {
+	var groups partitionGroups[*metricsGroup]
+	for i, rm := range md.ResourceMetrics().All() {
+		for j, sm := range rm.ScopeMetrics().All() {
+			for _, m := range sm.Metrics().All() {
+				p, err := t.partition(ctx, ottlmetric.NewTransformContext(m, sm.Metrics(), sm.Scope(), rm.Resource(), sm, rm))
+				if err != nil {
+					return nil, err
+				}
+				g := groups.get(p, func() *metricsGroup {
+					return &metricsGroup{partition: p, metrics: pmetric.NewMetrics(), resource: -1}
+				})
+				if g.resource != i {
+					g.resourceMetrics = g.metrics.ResourceMetrics().AppendEmpty()
+					rm.Resource().CopyTo(g.resourceMetrics.Resource())
+					g.resourceMetrics.SetSchemaUrl(rm.SchemaUrl())
+					g.resource, g.scope = i, -1
+				}
+				if g.scope != j {
+					g.scopeMetrics = g.resourceMetrics.ScopeMetrics().AppendEmpty()
+					sm.Scope().CopyTo(g.scopeMetrics.Scope())
+					g.scopeMetrics.SetSchemaUrl(sm.SchemaUrl())
+					g.scope = j
+				}
+				m.CopyTo(g.scopeMetrics.Metrics().AppendEmpty())
+			}
+		}
+	}
+	return func(yield func(partition, pmetric.Metrics) bool) {
+		for _, g := range groups.groups {
+			if !yield(g.partition, g.metrics) {
+				return
+			}
+		}
+	}, nil
+}
+
+type tracesGroup struct {
+	partition     partition
+	traces        ptrace.Traces
+	resourceSpans ptrace.ResourceSpans
+	scopeSpans    ptrace.ScopeSpans
+	// resource and scope are the indexes in the source traces of the
+	// resource and scope of the last span added to the group.
+	resource, scope int
+}
+
+// partitionTraces splits td by the key and headers computed by t for each
+// span.
+func partitionTraces(ctx context.Context, td ptrace.Traces, t *messageTemplate[ottlspan.TransformContext]) (iter.Seq2[partition, ptrace.Traces], error) {
+	var groups partitionGroups[*tracesGroup]
+	for i, rs := range td.ResourceSpans().All() {
+		for j, ss := range rs.ScopeSpans().All() {
+			for _, span := range ss.Spans().All() {
+				p, err := t.partition(ctx, ottlspan.NewTransformContext(span, ss.Scope(), rs.Resource(), ss, rs))
+				if err != nil {
+					return nil, err
+				}
+				g := groups.get(p, func() *tracesGroup {
+					return &tracesGroup{partition: p, traces: ptrace.NewTraces(), resource: -1}
+				})
+				if g.resource != i {
+					g.resourceSpans = g.traces.ResourceSpans().AppendEmpty()
+					rs.Resource().CopyTo(g.resourceSpans.Resource())
+					g.resourceSpans.SetSchemaUrl(rs.SchemaUrl())
+					g.resource, g.scope = i, -1
+				}
+				if g.scope != j {
+					g.scopeSpans = g.resourceSpans.ScopeSpans().AppendEmpty()
+					ss.Scope().CopyTo(g.scopeSpans.Scope())
+					g.scopeSpans.SetSchemaUrl(ss.SchemaUrl())
+					g.scope = j
+				}
+				span.CopyTo(g.scopeSpans.Spans().AppendEmpty())
+			}
+		}
+	}
+	return func(yield func(partition, ptrace.Traces) bool) {
+		for _, g := range groups.groups {
+			if !yield(g.partition, g.traces) {
+				return
+			}
+		}
+	}, nil
+}
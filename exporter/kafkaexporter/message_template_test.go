@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kafkaexporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/kafkaexporter/internal/kafkaclient"
+)
+
+func TestValueToBytes(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		expected []byte
+	}{
+		{name: "nil", value: nil, expected: nil},
+		{name: "string", value: "value", expected: []byte("value")},
+		{name: "bytes", value: []byte{1, 2}, expected: []byte{1, 2}},
+		{name: "int", value: int64(42), expected: []byte("42")},
+		{name: "bool", value: true, expected: []byte("true")},
+		{name: "empty value", value: pcommon.NewValueEmpty(), expected: nil},
+		{name: "string value", value: pcommon.NewValueStr("value"), expected: []byte("value")},
+		{name: "map", value: func() pcommon.Map {
+			m := pcommon.NewMap()
+			m.PutStr("key", "value")
+			return m
+		}(), expected: []byte(`{"key":"value"}`)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := valueToBytes(tt.value)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestPartitionID(t *testing.T) {
+	ids := []string{
+		partition{}.id(),
+		partition{key: []byte{}}.id(),
+		partition{key: []byte("a")}.id(),
+		partition{headers: []kafkaclient.Header{{Key: "a", Value: []byte("b")}}}.id(),
+		partition{headers: []kafkaclient.Header{{Key: "ab", Value: nil}}}.id(),
+	}
+	for i := range ids {
+		for j := i + 1; j < len(ids); j++ {
+			assert.NotEqual(t, ids[i], ids[j])
+		}
+	}
+	assert.Equal(t, partition{key: []byte("a")}.id(), partition{key: []byte("a")}.id())
+}
+
+func TestPartitionLogs(t *testing.T) {
+	template, err := newLogsMessageTemplate(SignalConfig{
+		MessageKey: `attributes["tenant"]`,
+	}, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	ld := plog.NewLogs()
+	for _, serviceName := range []string{"service1", "service2"} {
+		rl := ld.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().PutStr("service.name", serviceName)
+		sl := rl.ScopeLogs().AppendEmpty()
+		sl.Scope().SetName("scope")
+		for _, tenant := range []string{"a", "b", "a"} {
+			lr := sl.LogRecords().AppendEmpty()
+			lr.Attributes().PutStr("tenant", tenant)
+			lr.Body().SetStr(serviceName)
+		}
+	}
+	// Log records without the attribute have a nil key.
+	ld.ResourceLogs().At(1).ScopeLogs().At(0).LogRecords().AppendEmpty()
+
+	partitions, err := partitionLogs(t.Context(), ld, template)
+	require.NoError(t, err)
+
+	var keys []string
+	for p, logs := range partitions {
+		if p.key == nil {
+			keys = append(keys, "<nil>")
+			assert.Equal(t, 1, logs.LogRecordCount())
+			continue
+		}
+		keys = append(keys, string(p.key))
+		// Log records of a resource and scope are grouped together.
+		require.Equal(t, 2, logs.ResourceLogs().Len())
+		for i, rl := range logs.ResourceLogs().All() {
+			serviceName, _ := rl.Resource().Attributes().Get("service.name")
+			assert.Equal(t, ld.ResourceLogs().At(i).Resource().Attributes().AsRaw(), rl.Resource().Attributes().AsRaw())
+			require.Equal(t, 1, rl.ScopeLogs().Len())
+			assert.Equal(t, "scope", rl.ScopeLogs().At(0).Scope().Name())
+			for _, lr := range rl.ScopeLogs().At(0).LogRecords().All() {
+				tenant, _ := lr.Attributes().Get("tenant")
+				assert.Equal(t, string(p.key), tenant.Str())
+				assert.Equal(t, serviceName.Str(), lr.Body().Str())
+			}
+		}
+	}
+	assert.Equal(t, []string{"a", "b", "<nil>"}, keys)
+}
+
+func TestMessageTemplate_invalid(t *testing.T) {
+	_, err := newLogsMessageTemplate(SignalConfig{
+		MessageKey: `attributes[`,
+	}, componenttest.NewNopTelemetrySettings())
+	assert.ErrorContains(t, err, "failed to parse message_key")
+
+	_, err = newLogsMessageTemplate(SignalConfig{
+		MessageHeaders: []MessageHeader{{Value: `attributes["tenant"]`}},
+	}, componenttest.NewNopTelemetrySettings())
+	assert.ErrorContains(t, err, "message_headers key must be specified")
+
+	_, err = newLogsMessageTemplate(SignalConfig{
+		MessageHeaders: []MessageHeader{{Key: "tenant", Value: `Unknown()`}},
+	}, componenttest.NewNopTelemetrySettings())
+	assert.ErrorContains(t, err, `failed to parse value of message header "tenant"`)
+}
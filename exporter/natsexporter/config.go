@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/natsexporter"
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/nats"
+)
+
+var _ component.Config = (*Config)(nil)
+
+// Config defines configuration for the NATS exporter.
+type Config struct {
+	TimeoutSettings           exporterhelper.TimeoutConfig    `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	QueueBatchConfig          exporterhelper.QueueBatchConfig `mapstructure:"sending_queue"`
+	configretry.BackOffConfig `mapstructure:"retry_on_failure"`
+	nats.ClientConfig         `mapstructure:",squash"`
+
+	// Logs holds configuration about how logs should be published.
+	Logs SignalConfig `mapstructure:"logs"`
+
+	// Metrics holds configuration about how metrics should be published.
+	Metrics SignalConfig `mapstructure:"metrics"`
+
+	// Traces holds configuration about how traces should be published.
+	Traces SignalConfig `mapstructure:"traces"`
+}
+
+// SignalConfig holds the publishing configuration of a signal.
+type SignalConfig struct {
+	// Subject holds the subject to which messages are published. It may
+	// contain {<attribute>} placeholders, which are replaced with the value
+	// of the resource attribute, so that the data of resources with
+	// different values is published to different subjects.
+	Subject string `mapstructure:"subject"`
+
+	// Encoding holds the encoding of the messages: otlp_proto, otlp_json,
+	// or the ID of an encoding extension.
+	Encoding string `mapstructure:"encoding"`
+}
+
+func (c SignalConfig) validate() error {
+	if _, err := parseSubjectTemplate(c.Subject); err != nil {
+		return err
+	}
+	if c.Encoding == "" {
+		return errors.New("encoding must be specified")
+	}
+	return nil
+}
+
+// Validate checks the exporter configuration is valid.
+func (cfg *Config) Validate() error {
+	var errs []error
+	if err := cfg.Logs.validate(); err != nil {
+		errs = append(errs, fmt.Errorf("logs::%w", err))
+	}
+	if err := cfg.Metrics.validate(); err != nil {
+		errs = append(errs, fmt.Errorf("metrics::%w", err))
+	}
+	if err := cfg.Traces.validate(); err != nil {
+		errs = append(errs, fmt.Errorf("traces::%w", err))
+	}
+	return errors.Join(errs...)
+}
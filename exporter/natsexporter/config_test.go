@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsexporter
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/natsexporter/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/nats"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id          component.ID
+		expected    component.Config
+		expectedErr string
+	}{
+		{
+			id:       component.NewIDWithName(metadata.Type, ""),
+			expected: createDefaultConfig(),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "custom"),
+			expected: &Config{
+				TimeoutSettings: exporterhelper.TimeoutConfig{
+					Timeout: 10 * time.Second,
+				},
+				BackOffConfig: func() configretry.BackOffConfig {
+					config := configretry.NewDefaultBackOffConfig()
+					config.Enabled = false
+					return config
+				}(),
+				QueueBatchConfig: func() exporterhelper.QueueBatchConfig {
+					config := exporterhelper.NewDefaultQueueConfig()
+					config.Enabled = false
+					return config
+				}(),
+				ClientConfig: func() nats.ClientConfig {
+					config := nats.NewDefaultClientConfig()
+					config.URL = "nats://nats-1:4222"
+					config.Auth.Token = "secret"
+					return config
+				}(),
+				Logs: SignalConfig{
+					Subject:  "telemetry.logs.{service.name}",
+					Encoding: "text_encoding",
+				},
+				Metrics: SignalConfig{
+					Subject:  "otlp.metrics",
+					Encoding: "otlp_json",
+				},
+				Traces: SignalConfig{
+					Subject:  "otlp.traces",
+					Encoding: "otlp_proto",
+				},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "invalid_subject"),
+			expectedErr: `logs::subject "telemetry.logs.*" must not contain wildcards or whitespace` + "\n" +
+				`metrics::subject "telemetry..metrics" must not contain empty tokens` + "\n" +
+				`traces::subject "telemetry.traces.{service.name" has an unterminated placeholder`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			t.Parallel()
+
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			err = xconfmap.Validate(cfg)
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/natsexporter"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/natsexporter/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/nats"
+)
+
+const (
+	defaultLogsSubject     = "otlp.logs"
+	defaultLogsEncoding    = "otlp_proto"
+	defaultMetricsSubject  = "otlp.metrics"
+	defaultMetricsEncoding = "otlp_proto"
+	defaultTracesSubject   = "otlp.traces"
+	defaultTracesEncoding  = "otlp_proto"
+)
+
+// NewFactory creates NATS exporter factory.
+func NewFactory() exporter.Factory {
+	return exporter.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		exporter.WithTraces(createTracesExporter, metadata.TracesStability),
+		exporter.WithMetrics(createMetricsExporter, metadata.MetricsStability),
+		exporter.WithLogs(createLogsExporter, metadata.LogsStability),
+	)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		TimeoutSettings:  exporterhelper.NewDefaultTimeoutConfig(),
+		BackOffConfig:    configretry.NewDefaultBackOffConfig(),
+		QueueBatchConfig: exporterhelper.NewDefaultQueueConfig(),
+		ClientConfig:     nats.NewDefaultClientConfig(),
+		Logs: SignalConfig{
+			Subject:  defaultLogsSubject,
+			Encoding: defaultLogsEncoding,
+		},
+		Metrics: SignalConfig{
+			Subject:  defaultMetricsSubject,
+			Encoding: defaultMetricsEncoding,
+		},
+		Traces: SignalConfig{
+			Subject:  defaultTracesSubject,
+			Encoding: defaultTracesEncoding,
+		},
+	}
+}
+
+func createTracesExporter(
+	ctx context.Context,
+	set exporter.Settings,
+	cfg component.Config,
+) (exporter.Traces, error) {
+	oCfg := *(cfg.(*Config)) // Clone the config
+	exp := newTracesExporter(oCfg, set)
+	return exporterhelper.NewTraces(
+		ctx,
+		set,
+		&oCfg,
+		exp.exportData,
+		exporterhelperOptions(oCfg, exp.Start, exp.Close)...,
+	)
+}
+
+func createMetricsExporter(
+	ctx context.Context,
+	set exporter.Settings,
+	cfg component.Config,
+) (exporter.Metrics, error) {
+	oCfg := *(cfg.(*Config)) // Clone the config
+	exp := newMetricsExporter(oCfg, set)
+	return exporterhelper.NewMetrics(
+		ctx,
+		set,
+		&oCfg,
+		exp.exportData,
+		exporterhelperOptions(oCfg, exp.Start, exp.Close)...,
+	)
+}
+
+func createLogsExporter(
+	ctx context.Context,
+	set exporter.Settings,
+	cfg component.Config,
+) (exporter.Logs, error) {
+	oCfg := *(cfg.(*Config)) // Clone the config
+	exp := newLogsExporter(oCfg, set)
+	return exporterhelper.NewLogs(
+		ctx,
+		set,
+		&oCfg,
+		exp.exportData,
+		exporterhelperOptions(oCfg, exp.Start, exp.Close)...,
+	)
+}
+
+func exporterhelperOptions(
+	cfg Config,
+	startFunc component.StartFunc,
+	shutdownFunc component.ShutdownFunc,
+) []exporterhelper.Option {
+	return []exporterhelper.Option{
+		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
+		exporterhelper.WithTimeout(cfg.TimeoutSettings),
+		exporterhelper.WithRetry(cfg.BackOffConfig),
+		exporterhelper.WithQueue(cfg.QueueBatchConfig),
+		exporterhelper.WithStart(startFunc),
+		exporterhelper.WithShutdown(shutdownFunc),
+	}
+}
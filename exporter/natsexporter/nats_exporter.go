@@ -0,0 +1,257 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/natsexporter"
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"iter"
+
+	natsgo "github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/encodingutil"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/nats"
+)
+
+type messenger[T any] interface {
+	// marshalData marshals a pdata type into a message payload.
+	marshalData(T) ([]byte, error)
+
+	// splitBySubject splits the data into groups published to the same
+	// subject.
+	splitBySubject(T) iter.Seq2[string, T]
+}
+
+// natsExporter publishes the data of a signal to JetStream, waiting for
+// the publish acknowledgement of each message.
+type natsExporter[T any] struct {
+	cfg          Config
+	set          exporter.Settings
+	newMessenger func(host component.Host) (messenger[T], error)
+
+	conn      *natsgo.Conn
+	js        jetstream.JetStream
+	messenger messenger[T]
+}
+
+func newNatsExporter[T any](
+	cfg Config,
+	set exporter.Settings,
+	newMessenger func(component.Host) (messenger[T], error),
+) *natsExporter[T] {
+	return &natsExporter[T]{
+		cfg:          cfg,
+		set:          set,
+		newMessenger: newMessenger,
+	}
+}
+
+func (e *natsExporter[T]) Start(ctx context.Context, host component.Host) error {
+	messenger, err := e.newMessenger(host)
+	if err != nil {
+		return err
+	}
+	conn, err := nats.Connect(ctx, e.cfg.ClientConfig, e.set.ID.String(), e.set.Logger)
+	if err != nil {
+		return err
+	}
+	js, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	e.messenger = messenger
+	e.conn = conn
+	e.js = js
+	return nil
+}
+
+func (e *natsExporter[T]) Close(context.Context) error {
+	if e.conn == nil {
+		return nil
+	}
+	e.conn.Close()
+	e.conn = nil
+	return nil
+}
+
+func (e *natsExporter[T]) exportData(ctx context.Context, data T) error {
+	// Marshal all the messages first, so marshaling errors do not cause
+	// messages to be published again when the export is retried.
+	var msgs []*natsgo.Msg
+	for subject, data := range e.messenger.splitBySubject(data) {
+		payload, err := e.messenger.marshalData(data)
+		if err != nil {
+			return consumererror.NewPermanent(fmt.Errorf("failed to marshal data: %w", err))
+		}
+		msgs = append(msgs, &natsgo.Msg{Subject: subject, Data: payload})
+	}
+	for _, msg := range msgs {
+		// Messages published again by a retried export have the same ID, so
+		// the stream discards those it already stored.
+		if _, err := e.js.PublishMsg(ctx, msg, jetstream.WithMsgID(msgID(msg))); err != nil {
+			return fmt.Errorf("failed to publish to subject %q: %w", msg.Subject, err)
+		}
+	}
+	return nil
+}
+
+// msgID returns the Nats-Msg-Id of msg, a hash of its subject and payload.
+func msgID(msg *natsgo.Msg) string {
+	h := sha256.New()
+	h.Write([]byte(msg.Subject))
+	h.Write([]byte{0})
+	h.Write(msg.Data)
+	return hex.EncodeToString(h.Sum(nil)[:16])
+}
+
+func newLogsExporter(cfg Config, set exporter.Settings) *natsExporter[plog.Logs] {
+	// The subject was validated as part of the config.
+	subject, _ := parseSubjectTemplate(cfg.Logs.Subject)
+	return newNatsExporter(cfg, set, func(host component.Host) (messenger[plog.Logs], error) {
+		marshaler, err := encodingutil.NewLogsMarshaler(cfg.Logs.Encoding, host)
+		if err != nil {
+			return nil, err
+		}
+		return &logsMessenger{marshaler: marshaler, subject: subject}, nil
+	})
+}
+
+type logsMessenger struct {
+	marshaler plog.Marshaler
+	subject   subjectTemplate
+}
+
+func (m *logsMessenger) marshalData(ld plog.Logs) ([]byte, error) {
+	return m.marshaler.MarshalLogs(ld)
+}
+
+func (m *logsMessenger) splitBySubject(ld plog.Logs) iter.Seq2[string, plog.Logs] {
+	return func(yield func(string, plog.Logs) bool) {
+		if subject, ok := m.subject.static(); ok {
+			yield(subject, ld)
+			return
+		}
+		var subjects []string
+		groups := make(map[string]plog.Logs)
+		for _, rl := range ld.ResourceLogs().All() {
+			subject := m.subject.execute(rl.Resource())
+			group, ok := groups[subject]
+			if !ok {
+				group = plog.NewLogs()
+				groups[subject] = group
+				subjects = append(subjects, subject)
+			}
+			rl.CopyTo(group.ResourceLogs().AppendEmpty())
+		}
+		for _, subject := range subjects {
+			if !yield(subject, groups[subject]) {
+				return
+			}
+		}
+	}
+}
+
+func newMetricsExporter(cfg Config, set exporter.Settings) *natsExporter[pmetric.Metrics] {
+	// The subject was validated as part of the config.
+	subject, _ := parseSubjectTemplate(cfg.Metrics.Subject)
+	return newNatsExporter(cfg, set, func(host component.Host) (messenger[pmetric.Metrics], error) {
+		marshaler, err := encodingutil.NewMetricsMarshaler(cfg.Metrics.Encoding, host)
+		if err != nil {
+			return nil, err
+		}
+		return &metricsMessenger{marshaler: marshaler, subject: subject}, nil
+	})
+}
+
+type metricsMessenger struct {
+	marshaler pmetric.Marshaler
+	subject   subjectTemplate
+}
+
+func (m *metricsMessenger) marshalData(md pmetric.Metrics) ([]byte, error) {
+	return m.marshaler.MarshalMetrics(md)
+}
+
+func (m *metricsMessenger) splitBySubject(md pmetric.Metrics) iter.Seq2[string, pmetric.Metrics] {
+	return func(yield func(string, pmetric.Metrics) bool) {
+		if subject, ok := m.subject.static(); ok {
+			yield(subject, md)
+			return
+		}
+		var subjects []string
+		groups := make(map[string]pmetric.Metrics)
+		for _, rm := range md.ResourceMetrics().All() {
+			subject := m.subject.execute(rm.Resource())
+			group, ok := groups[subject]
+			if !ok {
+				group = pmetric.NewMetrics()
+				groups[subject] = group
+				subjects = append(subjects, subject)
+			}
+			rm.CopyTo(group.ResourceMetrics().AppendEmpty())
+		}
+		for _, subject := range subjects {
+			if !yield(subject, groups[subject]) {
+				return
+			}
+		}
+	}
+}
+
+func newTracesExporter(cfg Config, set exporter.Settings) *natsExporter[ptrace.Traces] {
+	// The subject was validated as part of the config.
+	subject, _ := parseSubjectTemplate(cfg.Traces.Subject)
+	return newNatsExporter(cfg, set, func(host component.Host) (messenger[ptrace.Traces], error) {
+		marshaler, err := encodingutil.NewTracesMarshaler(cfg.Traces.Encoding, host)
+		if err != nil {
+			return nil, err
+		}
+		return &tracesMessenger{marshaler: marshaler, subject: subject}, nil
+	})
+}
+
+type tracesMessenger struct {
+	marshaler ptrace.Marshaler
+	subject   subjectTemplate
+}
+
+func (m *tracesMessenger) marshalData(td ptrace.Traces) ([]byte, error) {
+	return m.marshaler.MarshalTraces(td)
+}
+
+func (m *tracesMessenger) splitBySubject(td ptrace.Traces) iter.Seq2[string, ptrace.Traces] {
+	return func(yield func(string, ptrace.Traces) bool) {
+		if subject, ok := m.subject.static(); ok {
+			yield(subject, td)
+			return
+		}
+		var subjects []string
+		groups := make(map[string]ptrace.Traces)
+		for _, rs := range td.ResourceSpans().All() {
+			subject := m.subject.execute(rs.Resource())
+			group, ok := groups[subject]
+			if !ok {
+				group = ptrace.NewTraces()
+				groups[subject] = group
+				subjects = append(subjects, subject)
+			}
+			rs.CopyTo(group.ResourceSpans().AppendEmpty())
+		}
+		for _, subject := range subjects {
+			if !yield(subject, groups[subject]) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,231 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsexporter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/natsexporter/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/nats"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/nats/natstest"
+)
+
+func TestExporter_logs(t *testing.T) {
+	config, js := newTestConfig(t)
+	exp := newLogsExporter(*config, exportertest.NewNopSettings(metadata.Type))
+	startExporter(t, exp, componenttest.NewNopHost())
+
+	logs := newLogs("checkout", "log message")
+	require.NoError(t, exp.exportData(t.Context(), logs))
+
+	msgs := fetchMessages(t, js, 1)
+	assert.Equal(t, "otlp.logs", msgs[0].Subject())
+	actual, err := (&plog.ProtoUnmarshaler{}).UnmarshalLogs(msgs[0].Data())
+	require.NoError(t, err)
+	assert.Equal(t, logs, actual)
+}
+
+func TestExporter_metrics(t *testing.T) {
+	config, js := newTestConfig(t)
+	config.Metrics.Encoding = "otlp_json"
+	exp := newMetricsExporter(*config, exportertest.NewNopSettings(metadata.Type))
+	startExporter(t, exp, componenttest.NewNopHost())
+
+	metrics := pmetric.NewMetrics()
+	metric := metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	metric.SetName("metric")
+	metric.SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(1)
+	require.NoError(t, exp.exportData(t.Context(), metrics))
+
+	msgs := fetchMessages(t, js, 1)
+	assert.Equal(t, "otlp.metrics", msgs[0].Subject())
+	actual, err := (&pmetric.JSONUnmarshaler{}).UnmarshalMetrics(msgs[0].Data())
+	require.NoError(t, err)
+	assert.Equal(t, metrics, actual)
+}
+
+func TestExporter_traces(t *testing.T) {
+	config, js := newTestConfig(t)
+	exp := newTracesExporter(*config, exportertest.NewNopSettings(metadata.Type))
+	startExporter(t, exp, componenttest.NewNopHost())
+
+	traces := ptrace.NewTraces()
+	traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("span")
+	require.NoError(t, exp.exportData(t.Context(), traces))
+
+	msgs := fetchMessages(t, js, 1)
+	assert.Equal(t, "otlp.traces", msgs[0].Subject())
+	actual, err := (&ptrace.ProtoUnmarshaler{}).UnmarshalTraces(msgs[0].Data())
+	require.NoError(t, err)
+	assert.Equal(t, traces, actual)
+}
+
+func TestExporter_subjectTemplate(t *testing.T) {
+	config, js := newTestConfig(t)
+	config.Logs.Subject = "otlp.logs.{service.name}"
+	exp := newLogsExporter(*config, exportertest.NewNopSettings(metadata.Type))
+	startExporter(t, exp, componenttest.NewNopHost())
+
+	logs := newLogs("checkout", "first")
+	newLogs("cart", "second").ResourceLogs().MoveAndAppendTo(logs.ResourceLogs())
+	newLogs("checkout", "third").ResourceLogs().MoveAndAppendTo(logs.ResourceLogs())
+	require.NoError(t, exp.exportData(t.Context(), logs))
+
+	msgs := fetchMessages(t, js, 2)
+	assert.Equal(t, "otlp.logs.checkout", msgs[0].Subject())
+	actual, err := (&plog.ProtoUnmarshaler{}).UnmarshalLogs(msgs[0].Data())
+	require.NoError(t, err)
+	assert.Equal(t, 2, actual.ResourceLogs().Len())
+	assert.Equal(t, 2, actual.LogRecordCount())
+
+	assert.Equal(t, "otlp.logs.cart", msgs[1].Subject())
+	actual, err = (&plog.ProtoUnmarshaler{}).UnmarshalLogs(msgs[1].Data())
+	require.NoError(t, err)
+	assert.Equal(t, newLogs("cart", "second"), actual)
+}
+
+func TestExporter_encodingExtension(t *testing.T) {
+	config, js := newTestConfig(t)
+	config.Logs.Encoding = "text_encoding"
+	exp := newLogsExporter(*config, exportertest.NewNopSettings(metadata.Type))
+	startExporter(t, exp, extensionsHost{
+		component.MustNewID("text_encoding"): textLogsMarshalerExtension{},
+	})
+
+	require.NoError(t, exp.exportData(t.Context(), newLogs("checkout", "log message")))
+
+	msgs := fetchMessages(t, js, 1)
+	assert.Equal(t, "log message", string(msgs[0].Data()))
+}
+
+func TestExporter_deduplication(t *testing.T) {
+	config, js := newTestConfig(t)
+	exp := newLogsExporter(*config, exportertest.NewNopSettings(metadata.Type))
+	startExporter(t, exp, componenttest.NewNopHost())
+
+	// a retried export publishes the same messages again
+	logs := newLogs("checkout", "log message")
+	require.NoError(t, exp.exportData(t.Context(), logs))
+	require.NoError(t, exp.exportData(t.Context(), logs))
+	require.NoError(t, exp.exportData(t.Context(), newLogs("checkout", "other message")))
+
+	stream, err := js.Stream(t.Context(), "OTLP")
+	require.NoError(t, err)
+	info, err := stream.Info(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), info.State.Msgs)
+
+	msgs := fetchMessages(t, js, 2)
+	assert.NotEmpty(t, msgs[0].Headers().Get(jetstream.MsgIDHeader))
+	assert.NotEqual(t, msgs[0].Headers().Get(jetstream.MsgIDHeader), msgs[1].Headers().Get(jetstream.MsgIDHeader))
+}
+
+func TestExporter_publishError(t *testing.T) {
+	config, _ := newTestConfig(t)
+	config.Logs.Subject = "other.logs"
+	exp := newLogsExporter(*config, exportertest.NewNopSettings(metadata.Type))
+	startExporter(t, exp, componenttest.NewNopHost())
+
+	err := exp.exportData(t.Context(), newLogs("checkout", "log message"))
+	assert.ErrorContains(t, err, `failed to publish to subject "other.logs"`)
+	assert.False(t, consumererror.IsPermanent(err))
+}
+
+func TestExporter_startErrors(t *testing.T) {
+	t.Run("unknown encoding", func(t *testing.T) {
+		config, _ := newTestConfig(t)
+		config.Logs.Encoding = "unknown"
+		exp := newLogsExporter(*config, exportertest.NewNopSettings(metadata.Type))
+		err := exp.Start(t.Context(), componenttest.NewNopHost())
+		assert.EqualError(t, err, `unrecognized logs encoding "unknown"`)
+		assert.NoError(t, exp.Close(t.Context()))
+	})
+	t.Run("connection refused", func(t *testing.T) {
+		config := createDefaultConfig().(*Config)
+		config.URL = "nats://127.0.0.1:1"
+		exp := newLogsExporter(*config, exportertest.NewNopSettings(metadata.Type))
+		err := exp.Start(t.Context(), componenttest.NewNopHost())
+		assert.ErrorContains(t, err, "failed to connect to NATS server")
+		assert.NoError(t, exp.Close(t.Context()))
+	})
+}
+
+// newTestConfig starts a NATS server with an "OTLP" stream capturing the
+// "otlp.>" subjects, and returns the default configuration to connect to it.
+func newTestConfig(tb testing.TB) (*Config, jetstream.JetStream) {
+	_, clientConfig := natstest.NewServer(tb)
+	conn, err := nats.Connect(tb.Context(), clientConfig, "test", zap.NewNop())
+	require.NoError(tb, err)
+	tb.Cleanup(conn.Close)
+	js, err := jetstream.New(conn)
+	require.NoError(tb, err)
+	_, err = js.CreateStream(tb.Context(), jetstream.StreamConfig{
+		Name:     "OTLP",
+		Subjects: []string{"otlp.>"},
+	})
+	require.NoError(tb, err)
+
+	config := createDefaultConfig().(*Config)
+	config.ClientConfig = clientConfig
+	return config, js
+}
+
+func startExporter[T any](tb testing.TB, exp *natsExporter[T], host component.Host) {
+	require.NoError(tb, exp.Start(tb.Context(), host))
+	tb.Cleanup(func() {
+		assert.NoError(tb, exp.Close(context.Background()))
+	})
+}
+
+// fetchMessages returns the first n messages of the "OTLP" stream.
+func fetchMessages(tb testing.TB, js jetstream.JetStream, n int) []jetstream.Msg {
+	cons, err := js.OrderedConsumer(tb.Context(), "OTLP", jetstream.OrderedConsumerConfig{})
+	require.NoError(tb, err)
+	batch, err := cons.Fetch(n, jetstream.FetchMaxWait(10*time.Second))
+	require.NoError(tb, err)
+	var msgs []jetstream.Msg
+	for msg := range batch.Messages() {
+		msgs = append(msgs, msg)
+	}
+	require.NoError(tb, batch.Error())
+	require.Len(tb, msgs, n)
+	return msgs
+}
+
+func newLogs(serviceName, body string) plog.Logs {
+	logs := plog.NewLogs()
+	rl := logs.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", serviceName)
+	rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr(body)
+	return logs
+}
+
+type textLogsMarshalerExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+}
+
+func (textLogsMarshalerExtension) MarshalLogs(ld plog.Logs) ([]byte, error) {
+	return []byte(ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().Str()), nil
+}
+
+type extensionsHost map[component.ID]component.Component
+
+func (h extensionsHost) GetExtensions() map[component.ID]component.Component {
+	return h
+}
@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/natsexporter"
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// unknownSubjectToken replaces the placeholders of resource attributes
+// which are missing or empty.
+const unknownSubjectToken = "unknown"
+
+// subjectTemplate is a subject which may contain {<attribute>}
+// placeholders, replaced with the values of resource attributes.
+type subjectTemplate struct {
+	parts []subjectPart
+}
+
+// subjectPart is either literal text, or the name of a resource attribute.
+type subjectPart struct {
+	literal   string
+	attribute string
+}
+
+func parseSubjectTemplate(subject string) (subjectTemplate, error) {
+	var t subjectTemplate
+	if subject == "" {
+		return t, errors.New("subject must be specified")
+	}
+	rest := subject
+	for rest != "" {
+		start := strings.IndexAny(rest, "{}")
+		if start < 0 {
+			t.parts = append(t.parts, subjectPart{literal: rest})
+			break
+		}
+		if rest[start] == '}' {
+			return t, fmt.Errorf("subject %q has an unexpected '}'", subject)
+		}
+		if start > 0 {
+			t.parts = append(t.parts, subjectPart{literal: rest[:start]})
+		}
+		end := strings.IndexByte(rest[start:], '}')
+		if end < 0 {
+			return t, fmt.Errorf("subject %q has an unterminated placeholder", subject)
+		}
+		attribute := rest[start+1 : start+end]
+		if attribute == "" || strings.ContainsRune(attribute, '{') {
+			return t, fmt.Errorf("subject %q has an invalid placeholder", subject)
+		}
+		t.parts = append(t.parts, subjectPart{attribute: attribute})
+		rest = rest[start+end+1:]
+	}
+	for _, part := range t.parts {
+		if strings.ContainsAny(part.literal, "*> \t\r\n") {
+			return t, fmt.Errorf("subject %q must not contain wildcards or whitespace", subject)
+		}
+	}
+	// Check the tokens of the subject, replacing each placeholder with a
+	// valid token.
+	for token := range strings.SplitSeq(t.execute(pcommon.NewResource()), ".") {
+		if token == "" {
+			return t, fmt.Errorf("subject %q must not contain empty tokens", subject)
+		}
+	}
+	return t, nil
+}
+
+// static returns the subject if it has no placeholders.
+func (t subjectTemplate) static() (string, bool) {
+	if len(t.parts) == 1 && t.parts[0].attribute == "" {
+		return t.parts[0].literal, true
+	}
+	return "", false
+}
+
+// execute returns the subject for a resource. Characters of attribute
+// values which are not allowed in subject tokens are replaced with '_'.
+func (t subjectTemplate) execute(resource pcommon.Resource) string {
+	var sb strings.Builder
+	for _, part := range t.parts {
+		if part.attribute == "" {
+			sb.WriteString(part.literal)
+			continue
+		}
+		value, ok := resource.Attributes().Get(part.attribute)
+		if !ok || value.AsString() == "" {
+			sb.WriteString(unknownSubjectToken)
+			continue
+		}
+		sb.WriteString(strings.Map(func(r rune) rune {
+			switch r {
+			case '.', '*', '>', ' ', '\t', '\r', '\n':
+				return '_'
+			}
+			return r
+		}, value.AsString()))
+	}
+	return sb.String()
+}
@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsexporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestSubjectTemplate(t *testing.T) {
+	resource := pcommon.NewResource()
+	resource.Attributes().PutStr("service.name", "checkout")
+	resource.Attributes().PutStr("site", "eu west.1")
+	resource.Attributes().PutInt("shard", 3)
+	resource.Attributes().PutStr("empty", "")
+
+	tests := []struct {
+		template string
+		expected string
+	}{
+		{template: "otlp.logs", expected: "otlp.logs"},
+		{template: "otlp.logs.{service.name}", expected: "otlp.logs.checkout"},
+		{template: "{site}.logs", expected: "eu_west_1.logs"},
+		{template: "otlp.{service.name}-{shard}", expected: "otlp.checkout-3"},
+		{template: "otlp.{missing}", expected: "otlp.unknown"},
+		{template: "otlp.{empty}", expected: "otlp.unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.template, func(t *testing.T) {
+			template, err := parseSubjectTemplate(tt.template)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, template.execute(resource))
+			subject, ok := template.static()
+			assert.Equal(t, tt.template == tt.expected, ok)
+			if ok {
+				assert.Equal(t, tt.expected, subject)
+			}
+		})
+	}
+}
+
+func TestSubjectTemplate_invalid(t *testing.T) {
+	tests := []struct {
+		template    string
+		expectedErr string
+	}{
+		{template: "", expectedErr: "subject must be specified"},
+		{template: "otlp.>", expectedErr: `subject "otlp.>" must not contain wildcards or whitespace`},
+		{template: "otlp logs", expectedErr: `subject "otlp logs" must not contain wildcards or whitespace`},
+		{template: ".otlp", expectedErr: `subject ".otlp" must not contain empty tokens`},
+		{template: "otlp.{a}.", expectedErr: `subject "otlp.{a}." must not contain empty tokens`},
+		{template: "otlp.{a", expectedErr: `subject "otlp.{a" has an unterminated placeholder`},
+		{template: "otlp.a}", expectedErr: `subject "otlp.a}" has an unexpected '}'`},
+		{template: "otlp.{}", expectedErr: `subject "otlp.{}" has an invalid placeholder`},
+		{template: "otlp.{{a}}", expectedErr: `subject "otlp.{{a}}" has an invalid placeholder`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.template, func(t *testing.T) {
+			_, err := parseSubjectTemplate(tt.template)
+			assert.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
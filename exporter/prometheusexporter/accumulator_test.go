@@ -567,6 +567,92 @@ func TestAccumulateDeltaToCumulativeHistogram(t *testing.T) {
 	})
 }
 
+func TestAccumulateDeltaToCumulativeExponentialHistogram(t *testing.T) {
+	appendDeltaHistogram := func(startTs, ts time.Time, scale int32, offset int32, counts []uint64, metrics pmetric.MetricSlice) {
+		metric := metrics.AppendEmpty()
+		metric.SetName("test_metric")
+		metric.SetEmptyExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+		dp := metric.ExponentialHistogram().DataPoints().AppendEmpty()
+		dp.SetScale(scale)
+		dp.Positive().SetOffset(offset)
+		dp.Positive().BucketCounts().FromRaw(counts)
+		var count uint64
+		for _, c := range counts {
+			count += c
+		}
+		dp.SetCount(count + 1)
+		dp.SetZeroCount(1)
+		dp.SetSum(float64(count))
+		dp.Attributes().PutStr("label_1", "1")
+		dp.SetTimestamp(pcommon.NewTimestampFromTime(ts))
+		dp.SetStartTimestamp(pcommon.NewTimestampFromTime(startTs))
+	}
+
+	t.Run("AccumulateHappyPath", func(t *testing.T) {
+		startTs := time.Now().Add(-5 * time.Second)
+		ts1 := time.Now().Add(-4 * time.Second)
+		ts2 := time.Now().Add(-3 * time.Second)
+		resourceMetrics := pmetric.NewResourceMetrics()
+		ilm := resourceMetrics.ScopeMetrics().AppendEmpty()
+		ilm.Scope().SetName("test")
+		appendDeltaHistogram(startTs, ts1, 1, 2, []uint64{1, 2, 3, 4}, ilm.Metrics())
+		// finer scale, merged at the coarser one
+		appendDeltaHistogram(ts1, ts2, 2, 4, []uint64{1, 1, 1, 1}, ilm.Metrics())
+
+		signature := timeseriesSignature(ilm.Scope().Name(), ilm.Scope().Version(), ilm.SchemaUrl(), ilm.Scope().Attributes(), ilm.Metrics().At(0), ilm.Metrics().At(0).ExponentialHistogram().DataPoints().At(0).Attributes(), pcommon.NewMap())
+
+		a := newAccumulator(zap.NewNop(), 1*time.Hour).(*lastValueAccumulator)
+		n := a.Accumulate(resourceMetrics)
+		require.Equal(t, 2, n)
+
+		m, ok := a.registeredMetrics.Load(signature)
+		require.True(t, ok)
+		mv := m.(*accumulatedValue).value
+		require.Equal(t, pmetric.AggregationTemporalityCumulative, mv.ExponentialHistogram().AggregationTemporality())
+
+		v := mv.ExponentialHistogram().DataPoints().At(0)
+		require.Equal(t, int32(1), v.Scale())
+		require.Equal(t, uint64(16), v.Count())
+		require.Equal(t, uint64(2), v.ZeroCount())
+		require.Equal(t, 14.0, v.Sum())
+		require.Equal(t, pcommon.NewTimestampFromTime(startTs), v.StartTimestamp())
+		require.Equal(t, pcommon.NewTimestampFromTime(ts2), v.Timestamp())
+		require.Equal(t, int32(2), v.Positive().Offset())
+		require.Equal(t, []uint64{3, 4, 3, 4}, v.Positive().BucketCounts().AsRaw())
+	})
+	t.Run("Misaligned/Drop", func(t *testing.T) {
+		startTs := time.Now().Add(-5 * time.Second)
+		ts1 := time.Now().Add(-3 * time.Second)
+		ts2 := time.Now().Add(-4 * time.Second)
+		resourceMetrics := pmetric.NewResourceMetrics()
+		ilm := resourceMetrics.ScopeMetrics().AppendEmpty()
+		appendDeltaHistogram(startTs, ts1, 0, 0, []uint64{1, 2}, ilm.Metrics())
+		appendDeltaHistogram(startTs, ts2, 0, 0, []uint64{3, 4}, ilm.Metrics())
+
+		a := newAccumulator(zap.NewNop(), 1*time.Hour).(*lastValueAccumulator)
+		require.Equal(t, 1, a.Accumulate(resourceMetrics))
+	})
+	t.Run("Misaligned/Reset", func(t *testing.T) {
+		startTs := time.Now().Add(-5 * time.Second)
+		ts1 := time.Now().Add(-3 * time.Second)
+		ts2 := time.Now().Add(-1 * time.Second)
+		resourceMetrics := pmetric.NewResourceMetrics()
+		ilm := resourceMetrics.ScopeMetrics().AppendEmpty()
+		appendDeltaHistogram(startTs, ts1, 0, 0, []uint64{1, 2}, ilm.Metrics())
+		appendDeltaHistogram(ts1.Add(time.Second), ts2, 0, 0, []uint64{3, 4}, ilm.Metrics())
+
+		signature := timeseriesSignature(ilm.Scope().Name(), ilm.Scope().Version(), ilm.SchemaUrl(), ilm.Scope().Attributes(), ilm.Metrics().At(0), ilm.Metrics().At(0).ExponentialHistogram().DataPoints().At(0).Attributes(), pcommon.NewMap())
+
+		a := newAccumulator(zap.NewNop(), 1*time.Hour).(*lastValueAccumulator)
+		require.Equal(t, 2, a.Accumulate(resourceMetrics))
+
+		m, ok := a.registeredMetrics.Load(signature)
+		require.True(t, ok)
+		v := m.(*accumulatedValue).value.ExponentialHistogram().DataPoints().At(0)
+		require.Equal(t, []uint64{3, 4}, v.Positive().BucketCounts().AsRaw())
+	})
+}
+
 func TestAccumulateDroppedMetrics(t *testing.T) {
 	tests := []struct {
 		name       string
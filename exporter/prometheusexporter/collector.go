@@ -181,6 +181,8 @@ func (c *collector) convertMetric(metric pmetric.Metric, resourceAttrs pcommon.M
 		return c.convertSum(metric, resourceAttrs, scopeName, scopeVersion, scopeSchemaURL, scopeAttributes)
 	case pmetric.MetricTypeHistogram:
 		return c.convertDoubleHistogram(metric, resourceAttrs, scopeName, scopeVersion, scopeSchemaURL, scopeAttributes)
+	case pmetric.MetricTypeExponentialHistogram:
+		return c.convertExponentialHistogram(metric, resourceAttrs, scopeName, scopeVersion, scopeSchemaURL, scopeAttributes)
 	case pmetric.MetricTypeSummary:
 		return c.convertSummary(metric, resourceAttrs, scopeName, scopeVersion, scopeSchemaURL, scopeAttributes)
 	}
@@ -413,6 +415,83 @@ func (c *collector) convertDoubleHistogram(metric pmetric.Metric, resourceAttrs
 	return m, nil
 }
 
+// Native histograms support a subset of the scales of exponential histograms.
+const (
+	nativeHistogramMinScale = -4
+	nativeHistogramMaxScale = 8
+)
+
+var errExponentialHistogramScale = fmt.Errorf("exponential histogram scale below %d cannot be represented as native histogram", nativeHistogramMinScale)
+
+// convertExponentialHistogram converts an exponential histogram into a native
+// histogram. Only the protobuf exposition format can represent native
+// histograms, the text formats only include count and sum.
+func (c *collector) convertExponentialHistogram(metric pmetric.Metric, resourceAttrs pcommon.Map, scopeName, scopeVersion, scopeSchemaURL string, scopeAttributes pcommon.Map) (prometheus.Metric, error) {
+	ip := metric.ExponentialHistogram().DataPoints().At(0)
+	if ip.Scale() < nativeHistogramMinScale {
+		return nil, errExponentialHistogramScale
+	}
+
+	desc, attributes, err := c.getMetricMetadata(metric, dto.MetricType_HISTOGRAM.Enum(), ip.Attributes(), resourceAttrs, scopeName, scopeVersion, scopeSchemaURL, scopeAttributes)
+	if err != nil {
+		return nil, err
+	}
+
+	// buckets of finer scales are merged into those of the finest native
+	// histogram scale, which works due to the perfect subsetting of scales.
+	scale := min(ip.Scale(), nativeHistogramMaxScale)
+	shift := ip.Scale() - scale
+
+	var created time.Time
+	if ip.StartTimestamp().AsTime().Unix() > 0 {
+		created = ip.StartTimestamp().AsTime()
+	}
+
+	m, err := prometheus.NewConstNativeHistogram(desc,
+		ip.Count(),
+		ip.Sum(),
+		nativeBuckets(ip.Positive(), shift),
+		nativeBuckets(ip.Negative(), shift),
+		ip.ZeroCount(),
+		scale,
+		ip.ZeroThreshold(),
+		created,
+		attributes...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if exemplars := convertExemplars(ip.Exemplars()); len(exemplars) > 0 {
+		m, err = prometheus.NewMetricWithExemplars(m, exemplars...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if c.sendTimestamps {
+		return prometheus.NewMetricWithTimestamp(ip.Timestamp().AsTime(), m), nil
+	}
+	return m, nil
+}
+
+// nativeBuckets returns the counts of buckets by native histogram bucket index,
+// merging 2^shift adjacent buckets into one. Bucket i of an exponential
+// histogram covers (base^i, base^(i+1)], while native histograms call this
+// bucket i+1.
+func nativeBuckets(buckets pmetric.ExponentialHistogramDataPointBuckets, shift int32) map[int]int64 {
+	counts := make(map[int]int64, buckets.BucketCounts().Len())
+	for i := 0; i < buckets.BucketCounts().Len(); i++ {
+		count := buckets.BucketCounts().At(i)
+		if count == 0 {
+			continue
+		}
+		index := (int(buckets.Offset())+i)>>shift + 1
+		counts[index] += int64(count)
+	}
+	return counts
+}
+
 func (c *collector) createTargetInfoMetrics(resourceAttrs []pcommon.Map) ([]prometheus.Metric, error) {
 	var lastErr error
 
@@ -252,6 +252,58 @@ func TestConvertDoubleHistogramExemplar(t *testing.T) {
 	exemplarsEqual(t, promExporterExemplars, buckets[0].GetExemplar())
 }
 
+func TestConvertExponentialHistogram(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+
+	metric := pmetric.NewMetric()
+	metric.SetName("test_metric")
+	metric.SetUnit("s")
+	dp := metric.SetEmptyExponentialHistogram().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(pcommon.NewTimestampFromTime(start))
+	dp.SetScale(9)
+	dp.SetCount(10)
+	dp.SetSum(12.5)
+	dp.SetZeroCount(1)
+	dp.SetZeroThreshold(0.001)
+	dp.Positive().SetOffset(-3)
+	dp.Positive().BucketCounts().FromRaw([]uint64{1, 2, 0, 4})
+	dp.Negative().SetOffset(0)
+	dp.Negative().BucketCounts().FromRaw([]uint64{2})
+	setTestExemplarWithDoubleValue(dp.Exemplars().AppendEmpty(), 3.0)
+	// native histograms only carry exemplars with timestamps
+	dp.Exemplars().At(0).SetTimestamp(pcommon.NewTimestampFromTime(start))
+
+	c := newCollector(&Config{AddMetricSuffixes: true}, zap.NewNop())
+	m, err := c.convertExponentialHistogram(metric, pcommon.NewMap(), "test", "1.0.0", "http://test.com", pcommon.NewMap())
+	require.NoError(t, err)
+	require.Contains(t, m.Desc().String(), `fqName: "test_metric_seconds"`)
+
+	var pb io_prometheus_client.Metric
+	require.NoError(t, m.Write(&pb))
+	h := pb.GetHistogram()
+	require.Equal(t, uint64(10), h.GetSampleCount())
+	require.Equal(t, 12.5, h.GetSampleSum())
+	require.Equal(t, uint64(1), h.GetZeroCount())
+	require.Equal(t, 0.001, h.GetZeroThreshold())
+	require.Equal(t, timestamppb.New(start), h.GetCreatedTimestamp())
+	require.Empty(t, h.GetBucket())
+
+	// scale 9 is merged into the finest native scale: exponential buckets
+	// -3, -2 and 0 become native buckets -1, 0 and 1
+	require.Equal(t, int32(8), h.GetSchema())
+	require.Equal(t, []*io_prometheus_client.BucketSpan{{Offset: proto.Int32(-1), Length: proto.Uint32(3)}}, h.GetPositiveSpan())
+	require.Equal(t, []int64{1, 1, 2}, h.GetPositiveDelta())
+	require.Equal(t, []*io_prometheus_client.BucketSpan{{Offset: proto.Int32(1), Length: proto.Uint32(1)}}, h.GetNegativeSpan())
+	require.Equal(t, []int64{2}, h.GetNegativeDelta())
+
+	require.Len(t, h.GetExemplars(), 1)
+	exemplarsEqual(t, dp.Exemplars().At(0), h.GetExemplars()[0])
+
+	dp.SetScale(-5)
+	_, err = c.convertExponentialHistogram(metric, pcommon.NewMap(), "test", "1.0.0", "http://test.com", pcommon.NewMap())
+	require.ErrorIs(t, err, errExponentialHistogramScale)
+}
+
 func TestConvertMonotonicSumExemplar(t *testing.T) {
 	// initialize empty metric
 	metric := pmetric.NewMetric()
@@ -4,6 +4,7 @@
 package prometheusexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/prometheusexporter"
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
@@ -41,6 +42,10 @@ type Config struct {
 	// EnableOpenMetrics enables the use of the OpenMetrics encoding option for the prometheus exporter.
 	EnableOpenMetrics bool `mapstructure:"enable_open_metrics"`
 
+	// EnableCreatedSamples adds the start time of counters, histograms and summaries as _created samples
+	// to the OpenMetrics text format. The protobuf format always includes them as created timestamps.
+	EnableCreatedSamples bool `mapstructure:"enable_created_samples"`
+
 	// AddMetricSuffixes controls whether suffixes are added to metric names. Defaults to true.
 	// Deprecated: Use TranslationStrategy instead. This setting is ignored when TranslationStrategy is explicitly set.
 	AddMetricSuffixes bool `mapstructure:"add_metric_suffixes"`
@@ -54,6 +59,10 @@ var _ component.Config = (*Config)(nil)
 
 // Validate checks if the exporter configuration is valid
 func (cfg *Config) Validate() error {
+	if cfg.EnableCreatedSamples && !cfg.EnableOpenMetrics {
+		return errors.New("enable_created_samples requires enable_open_metrics")
+	}
+
 	// Validate translation strategy if set
 	if cfg.TranslationStrategy != "" {
 		switch cfg.TranslationStrategy {
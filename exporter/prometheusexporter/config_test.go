@@ -73,3 +73,46 @@ func TestLoadConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		err    string
+	}{
+		{
+			name:   "default",
+			modify: func(*Config) {},
+		},
+		{
+			name:   "invalid translation strategy",
+			modify: func(cfg *Config) { cfg.TranslationStrategy = "Unknown" },
+			err:    "invalid translation_strategy: Unknown",
+		},
+		{
+			name: "created samples",
+			modify: func(cfg *Config) {
+				cfg.EnableOpenMetrics = true
+				cfg.EnableCreatedSamples = true
+			},
+		},
+		{
+			name:   "created samples without open metrics",
+			modify: func(cfg *Config) { cfg.EnableCreatedSamples = true },
+			err:    "enable_created_samples requires enable_open_metrics",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.modify(cfg)
+			err := cfg.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
@@ -4,12 +4,15 @@
 package prometheusexporter
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"testing"
 	"time"
 
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
@@ -696,3 +699,85 @@ this_one_there_where_{arch="x86",instance="test-instance",job="test-service",os=
 		})
 	}
 }
+
+func TestPrometheusExporter_NativeHistograms(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = addr
+	cfg.EnableOpenMetrics = true
+	cfg.EnableCreatedSamples = true
+
+	exp, err := NewFactory().CreateMetrics(t.Context(), exportertest.NewNopSettings(metadata.Type), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(t.Context(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, exp.Shutdown(t.Context()))
+	}()
+
+	start := time.Unix(1700000000, 0)
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr(string(conventions.ServiceNameKey), "test-service")
+	metric := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	metric.SetName("request.duration")
+	metric.SetUnit("s")
+	metric.SetEmptyExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	dp := metric.ExponentialHistogram().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(pcommon.NewTimestampFromTime(start))
+	dp.SetTimestamp(pcommon.NewTimestampFromTime(start.Add(time.Minute)))
+	dp.SetScale(2)
+	dp.SetCount(3)
+	dp.SetSum(4.5)
+	dp.Positive().SetOffset(1)
+	dp.Positive().BucketCounts().FromRaw([]uint64{1, 2})
+	require.NoError(t, exp.ConsumeMetrics(t.Context(), md))
+
+	scrape := func(accept string) *http.Response {
+		req, err := http.NewRequest(http.MethodGet, "http://"+addr+"/metrics", http.NoBody)
+		require.NoError(t, err)
+		req.Header.Set("Accept", accept)
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		return res
+	}
+
+	t.Run("protobuf", func(t *testing.T) {
+		res := scrape("application/vnd.google.protobuf;proto=io.prometheus.client.MetricFamily;encoding=delimited")
+		defer res.Body.Close()
+
+		families := map[string]*dto.MetricFamily{}
+		dec := expfmt.NewDecoder(res.Body, expfmt.ResponseFormat(res.Header))
+		for {
+			var mf dto.MetricFamily
+			err := dec.Decode(&mf)
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			require.NoError(t, err)
+			families[mf.GetName()] = &mf
+		}
+
+		mf, ok := families["request_duration_seconds"]
+		require.True(t, ok)
+		require.Equal(t, dto.MetricType_HISTOGRAM, mf.GetType())
+		h := mf.GetMetric()[0].GetHistogram()
+		require.Equal(t, uint64(3), h.GetSampleCount())
+		require.Equal(t, 4.5, h.GetSampleSum())
+		require.Equal(t, int32(2), h.GetSchema())
+		require.Equal(t, []int64{1, 1}, h.GetPositiveDelta())
+		require.Equal(t, start.Unix(), h.GetCreatedTimestamp().GetSeconds())
+	})
+
+	t.Run("openmetrics", func(t *testing.T) {
+		res := scrape("application/openmetrics-text;version=1.0.0")
+		defer res.Body.Close()
+		blob, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+
+		// text formats cannot represent native buckets
+		assert.Contains(t, string(blob), "# TYPE request_duration_seconds histogram")
+		assert.Contains(t, string(blob), `request_duration_seconds_count{job="test-service",otel_scope_name="",otel_scope_schema_url="",otel_scope_version=""} 3`)
+		assert.Contains(t, string(blob), `request_duration_seconds_created{job="test-service",otel_scope_name="",otel_scope_schema_url="",otel_scope_version=""} 1.7e+09`)
+	})
+}
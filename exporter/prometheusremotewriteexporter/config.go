@@ -6,6 +6,7 @@ package prometheusremotewriteexporter // import "github.com/open-telemetry/opent
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/prometheus/prometheus/config"
 	"go.opentelemetry.io/collector/component"
@@ -60,6 +61,34 @@ type Config struct {
 
 	// RemoteWriteProtoMsg controls whether prometheus remote write v1 or v2 is sent.
 	RemoteWriteProtoMsg config.RemoteWriteProtoMsg `mapstructure:"protobuf_message,omitempty"`
+
+	// Tenant enables sending metrics of multiple tenants, each with their own queue and WAL.
+	Tenant configoptional.Optional[TenantConfig] `mapstructure:"tenant"`
+}
+
+// TenantConfig configures how the tenant of metrics is determined and sent.
+type TenantConfig struct {
+	// Header is the HTTP header the tenant is sent in.
+	Header string `mapstructure:"header"`
+
+	// FromResourceAttribute is the resource attribute holding the tenant of metrics.
+	FromResourceAttribute string `mapstructure:"from_resource_attribute"`
+
+	// FromContext is the client metadata key holding the tenant of metrics.
+	FromContext string `mapstructure:"from_context"`
+
+	// Default is the tenant of metrics without one. If empty, they are sent without tenant header.
+	Default string `mapstructure:"default"`
+
+	// MaxTenants is the maximum number of tenants with a pipeline at a time. Metrics of further tenants are rejected.
+	MaxTenants int `mapstructure:"max_tenants"`
+
+	// IdleTimeout is the time after which the pipeline of a tenant without metrics is shut down. Zero keeps
+	// pipelines until the exporter is shut down.
+	IdleTimeout time.Duration `mapstructure:"idle_timeout"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
 }
 
 type TargetInfo struct {
@@ -106,6 +135,10 @@ func (cfg *Config) Validate() error {
 		return errors.New("a 0 size queue will drop all the data")
 	}
 
+	if wal := cfg.WAL.Get(); wal != nil && wal.MaxSizeBytes < 0 {
+		return errors.New("wal max size can't be negative")
+	}
+
 	if cfg.RemoteWriteQueue.NumConsumers < 0 {
 		return errors.New("remote write consumer number can't be negative")
 	}
@@ -131,5 +164,20 @@ func (cfg *Config) Validate() error {
 		return fmt.Errorf("remote write v2 is only supported with the feature gate %s", enableSendingRW2FeatureGate.ID())
 	}
 
+	if tenant := cfg.Tenant.Get(); tenant != nil {
+		if tenant.Header == "" {
+			return errors.New("tenant header must not be empty")
+		}
+		if (tenant.FromResourceAttribute == "") == (tenant.FromContext == "") {
+			return errors.New("exactly one of tenant from_resource_attribute and from_context must be set")
+		}
+		if tenant.MaxTenants <= 0 {
+			return errors.New("tenant max_tenants must be positive")
+		}
+		if tenant.IdleTimeout < 0 {
+			return errors.New("tenant idle_timeout can't be negative")
+		}
+	}
+
 	return nil
 }
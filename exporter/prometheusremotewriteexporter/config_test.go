@@ -15,6 +15,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/config/configoptional"
 	"go.opentelemetry.io/collector/config/configretry"
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
@@ -83,12 +84,55 @@ func TestLoadConfig(t *testing.T) {
 					Enabled: true,
 				},
 				RemoteWriteProtoMsg: config.RemoteWriteProtoMsgV1,
+				Tenant: configoptional.Default(TenantConfig{
+					Header:      defaultTenantHeader,
+					MaxTenants:  defaultMaxTenants,
+					IdleTimeout: defaultTenantIdleTimeout,
+				}),
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "tenant"),
+			expected: func() component.Config {
+				cfg := createDefaultConfig().(*Config)
+				cfg.Tenant = configoptional.Some(TenantConfig{
+					Header:                defaultTenantHeader,
+					FromResourceAttribute: "tenant.id",
+					Default:               "anonymous",
+					MaxTenants:            10,
+					IdleTimeout:           defaultTenantIdleTimeout,
+				})
+				return cfg
+			}(),
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "tenant_without_source"),
+			errorMessage: "exactly one of tenant from_resource_attribute and from_context must be set",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "tenant_with_both_sources"),
+			errorMessage: "exactly one of tenant from_resource_attribute and from_context must be set",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "tenant_empty_header"),
+			errorMessage: "tenant header must not be empty",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "tenant_no_max_tenants"),
+			errorMessage: "tenant max_tenants must be positive",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "tenant_negative_idle_timeout"),
+			errorMessage: "tenant idle_timeout can't be negative",
+		},
 		{
 			id:           component.NewIDWithName(metadata.Type, "negative_queue_size"),
 			errorMessage: "remote write queue size can't be negative",
 		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "negative_wal_max_size"),
+			errorMessage: "wal max size can't be negative",
+		},
 		{
 			id:           component.NewIDWithName(metadata.Type, "negative_num_consumers"),
 			errorMessage: "remote write consumer number can't be negative",
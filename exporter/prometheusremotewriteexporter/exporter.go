@@ -140,6 +140,10 @@ type prwExporter struct {
 	telemetry           prwTelemetry
 	RemoteWriteProtoMsg config.RemoteWriteProtoMsg
 
+	// tenant is sent in tenantHeader, if set.
+	tenant       string
+	tenantHeader string
+
 	// When concurrency is enabled, concurrent goroutines would potentially
 	// fight over the same batchState object. To avoid this, we use a pool
 	// to provide each goroutine with its own state.
@@ -438,6 +442,9 @@ func (prwe *prwExporter) execute(ctx context.Context, buf []byte) error {
 		// https://cortexmetrics.io/docs/apis/#remote-api
 		req.Header.Add("Content-Encoding", "snappy")
 		req.Header.Set("User-Agent", prwe.userAgentHeader)
+		if prwe.tenant != "" {
+			req.Header.Set(prwe.tenantHeader, prwe.tenant)
+		}
 
 		switch {
 		// If feature flag not enabled support only RW1
@@ -11,6 +11,7 @@ import (
 	"github.com/prometheus/prometheus/config"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configoptional"
 	"go.opentelemetry.io/collector/config/configretry"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
@@ -61,10 +62,23 @@ func createMetricsExporter(ctx context.Context, set exporter.Settings,
 		set.Logger.Warn("`remote_write_queue.num_consumers` will be used to configure processing parallelism, rather than request parallelism in a future release. This may cause out-of-order issues unless you take action. Please migrate to using `max_batch_request_parallelism` to keep the your existing behavior.")
 	}
 
+	if prwCfg.Tenant.HasValue() {
+		return newTenantExporter(prwCfg, set, createPipeline), nil
+	}
+	return createPipeline(ctx, set, prwCfg, "")
+}
+
+// createPipeline creates the exporter sending metrics of a single tenant, or
+// without tenant if it is empty.
+func createPipeline(ctx context.Context, set exporter.Settings, prwCfg *Config, tenant string) (exporter.Metrics, error) {
 	prwe, err := newPRWExporter(prwCfg, set)
 	if err != nil {
 		return nil, err
 	}
+	if tenant != "" {
+		prwe.tenantHeader = prwCfg.Tenant.Get().Header
+		prwe.tenant = tenant
+	}
 
 	numConsumers := 1
 	if enableMultipleWorkersFeatureGate.IsEnabled() {
@@ -73,7 +87,7 @@ func createMetricsExporter(ctx context.Context, set exporter.Settings,
 	exporter, err := exporterhelper.NewMetrics(
 		ctx,
 		set,
-		cfg,
+		prwCfg,
 		prwe.PushMetrics,
 		exporterhelper.WithTimeout(prwCfg.TimeoutSettings),
 		exporterhelper.WithQueue(exporterhelper.QueueBatchConfig{
@@ -126,5 +140,10 @@ func createDefaultConfig() component.Config {
 		TargetInfo: TargetInfo{
 			Enabled: true,
 		},
+		Tenant: configoptional.Default(TenantConfig{
+			Header:      defaultTenantHeader,
+			MaxTenants:  defaultMaxTenants,
+			IdleTimeout: defaultTenantIdleTimeout,
+		}),
 	}
 }
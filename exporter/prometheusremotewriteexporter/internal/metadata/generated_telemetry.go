@@ -32,10 +32,12 @@ type TelemetryBuilder struct {
 	ExporterPrometheusremotewriteTranslatedTimeSeries metric.Int64Counter
 	ExporterPrometheusremotewriteWalBytesRead         metric.Int64Counter
 	ExporterPrometheusremotewriteWalBytesWritten      metric.Int64Counter
+	ExporterPrometheusremotewriteWalEvictedEntries    metric.Int64Counter
 	ExporterPrometheusremotewriteWalLag               metric.Int64Gauge
 	ExporterPrometheusremotewriteWalReadLatency       metric.Int64Histogram
 	ExporterPrometheusremotewriteWalReads             metric.Int64Counter
 	ExporterPrometheusremotewriteWalReadsFailures     metric.Int64Counter
+	ExporterPrometheusremotewriteWalRepairs           metric.Int64Counter
 	ExporterPrometheusremotewriteWalWriteLatency      metric.Int64Histogram
 	ExporterPrometheusremotewriteWalWrites            metric.Int64Counter
 	ExporterPrometheusremotewriteWalWritesFailures    metric.Int64Counter
@@ -109,6 +111,12 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...Teleme
 		metric.WithUnit("By"),
 	)
 	errs = errors.Join(errs, err)
+	builder.ExporterPrometheusremotewriteWalEvictedEntries, err = builder.meter.Int64Counter(
+		"otelcol_exporter_prometheusremotewrite_wal_evicted_entries",
+		metric.WithDescription("Number of WAL entries dropped, oldest first, to keep the WAL within its maximum size [Development]"),
+		metric.WithUnit("{entry}"),
+	)
+	errs = errors.Join(errs, err)
 	builder.ExporterPrometheusremotewriteWalLag, err = builder.meter.Int64Gauge(
 		"otelcol_exporter_prometheusremotewrite_wal_lag",
 		metric.WithDescription("WAL lag [Development]"),
@@ -134,6 +142,12 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...Teleme
 		metric.WithUnit("1"),
 	)
 	errs = errors.Join(errs, err)
+	builder.ExporterPrometheusremotewriteWalRepairs, err = builder.meter.Int64Counter(
+		"otelcol_exporter_prometheusremotewrite_wal_repairs",
+		metric.WithDescription("Number of times the corrupted WAL was truncated after its last valid entry [Development]"),
+		metric.WithUnit("{repair}"),
+	)
+	errs = errors.Join(errs, err)
 	builder.ExporterPrometheusremotewriteWalWriteLatency, err = builder.meter.Int64Histogram(
 		"otelcol_exporter_prometheusremotewrite_wal_write_latency",
 		metric.WithDescription("Response latency in ms for the WAL writes. [Development]"),
@@ -0,0 +1,329 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewriteexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/prometheusremotewriteexporter"
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/prwwal"
+)
+
+// defaultTenantHeader is the tenant header of Cortex, Mimir and Thanos.
+const defaultTenantHeader = "X-Scope-OrgID"
+
+const (
+	defaultMaxTenants        = 100
+	defaultTenantIdleTimeout = time.Hour
+)
+
+// tenantWALDirectory is the directory below the WAL directory holding the WAL
+// partitions of tenants.
+const tenantWALDirectory = "tenants"
+
+// errTooManyTenants is returned for metrics of a new tenant once the pipelines
+// of max_tenants tenants exist.
+var errTooManyTenants = errors.New("too many tenants")
+
+// createPipelineFunc creates the exporter pipeline sending metrics of a tenant.
+type createPipelineFunc func(ctx context.Context, set exporter.Settings, cfg *Config, tenant string) (exporter.Metrics, error)
+
+// tenantExporter fans metrics out to a pipeline per tenant, which is created
+// when metrics of the tenant are seen first. Each pipeline has its own queue
+// and WAL partition, so a tenant whose endpoint is slow or rejects metrics
+// does not block the others. The number of pipelines is limited by
+// max_tenants, and pipelines idle for idle_timeout are shut down.
+type tenantExporter struct {
+	cfg    *Config
+	tenant TenantConfig
+	set    exporter.Settings
+	create createPipelineFunc
+
+	mu        sync.Mutex
+	host      component.Host
+	pipelines map[string]*tenantPipeline
+	shutdown  bool
+
+	cancel context.CancelFunc
+	done   sync.WaitGroup
+}
+
+// tenantPipeline is the pipeline of a tenant, with the bookkeeping needed to
+// shut it down once idle. Its fields are guarded by tenantExporter.mu.
+type tenantPipeline struct {
+	exporter.Metrics
+
+	// inflight is the number of ConsumeMetrics calls in progress
+	inflight int
+	lastUsed time.Time
+}
+
+var _ exporter.Metrics = (*tenantExporter)(nil)
+
+func newTenantExporter(cfg *Config, set exporter.Settings, create createPipelineFunc) *tenantExporter {
+	return &tenantExporter{
+		cfg:       cfg,
+		tenant:    *cfg.Tenant.Get(),
+		set:       set,
+		create:    create,
+		pipelines: make(map[string]*tenantPipeline),
+		cancel:    func() {},
+	}
+}
+
+// Start starts the pipelines of tenants with metrics left in the WAL, so they
+// are sent without waiting for new metrics of these tenants.
+func (te *tenantExporter) Start(ctx context.Context, host component.Host) error {
+	te.mu.Lock()
+	defer te.mu.Unlock()
+	te.host = host
+
+	tenants, err := te.walTenants()
+	if err != nil {
+		return err
+	}
+	for _, tenant := range tenants {
+		_, err := te.pipeline(ctx, tenant)
+		if errors.Is(err, errTooManyTenants) {
+			te.set.Logger.Warn("not starting pipeline of tenant with metrics in the WAL, as max_tenants is reached", zap.String("tenant", tenant))
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if te.tenant.IdleTimeout > 0 {
+		sweepCtx, cancel := context.WithCancel(context.Background())
+		te.cancel = cancel
+		te.done.Add(1)
+		go func() {
+			defer te.done.Done()
+			tick := time.NewTicker(te.tenant.IdleTimeout)
+			defer tick.Stop()
+			for {
+				select {
+				case <-sweepCtx.Done():
+					return
+				case now := <-tick.C:
+					te.sweep(now)
+				}
+			}
+		}()
+	}
+	return nil
+}
+
+func (te *tenantExporter) Shutdown(ctx context.Context) error {
+	te.cancel()
+	te.done.Wait()
+
+	te.mu.Lock()
+	defer te.mu.Unlock()
+	te.shutdown = true
+
+	var errs []error
+	for _, p := range te.pipelines {
+		errs = append(errs, p.Shutdown(ctx))
+	}
+	return errors.Join(errs...)
+}
+
+func (*tenantExporter) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: true}
+}
+
+func (te *tenantExporter) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	var errs []error
+	for tenant, tmd := range te.split(ctx, md) {
+		te.mu.Lock()
+		p, err := te.pipeline(ctx, tenant)
+		if err == nil {
+			p.inflight++
+		}
+		te.mu.Unlock()
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		err = p.ConsumeMetrics(ctx, tmd)
+
+		te.mu.Lock()
+		p.inflight--
+		p.lastUsed = time.Now()
+		te.mu.Unlock()
+		if err != nil {
+			// the pipeline of the tenant already queued or retried its metrics,
+			// retrying them here would resend the metrics of all other tenants
+			errs = append(errs, consumererror.NewPermanent(fmt.Errorf("tenant %q: %w", tenant, err)))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// sweep shuts down the pipelines of tenants without metrics since the idle
+// timeout. Metrics left in their queues are sent before, and metrics left in
+// their WAL partitions once the tenant is seen again or on the next start.
+func (te *tenantExporter) sweep(now time.Time) {
+	idle := now.Add(-te.tenant.IdleTimeout)
+
+	te.mu.Lock()
+	var stopped []exporter.Metrics
+	for tenant, p := range te.pipelines {
+		if p.inflight == 0 && p.lastUsed.Before(idle) {
+			delete(te.pipelines, tenant)
+			stopped = append(stopped, p.Metrics)
+		}
+	}
+	te.mu.Unlock()
+
+	for _, p := range stopped {
+		// not bound to the exporter shutdown, so the queue is drained
+		if err := p.Shutdown(context.Background()); err != nil {
+			te.set.Logger.Warn("failed to shut down pipeline of idle tenant", zap.Error(err))
+		}
+	}
+}
+
+// split returns the metrics of md by tenant.
+func (te *tenantExporter) split(ctx context.Context, md pmetric.Metrics) map[string]pmetric.Metrics {
+	if te.tenant.FromContext != "" {
+		tenant := te.tenant.Default
+		if values := client.FromContext(ctx).Metadata.Get(te.tenant.FromContext); len(values) > 0 && values[0] != "" {
+			tenant = values[0]
+		}
+		return map[string]pmetric.Metrics{tenant: md}
+	}
+
+	byTenant := make(map[string]pmetric.Metrics)
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		tenant := te.tenant.Default
+		if v, ok := rm.Resource().Attributes().Get(te.tenant.FromResourceAttribute); ok && v.AsString() != "" {
+			tenant = v.AsString()
+		}
+
+		tmd, ok := byTenant[tenant]
+		if !ok {
+			tmd = pmetric.NewMetrics()
+			byTenant[tenant] = tmd
+		}
+		rm.MoveTo(tmd.ResourceMetrics().AppendEmpty())
+	}
+	return byTenant
+}
+
+// pipeline returns the pipeline of tenant, creating and starting it if it does
+// not exist yet. te.mu must be held.
+func (te *tenantExporter) pipeline(ctx context.Context, tenant string) (*tenantPipeline, error) {
+	if p, ok := te.pipelines[tenant]; ok {
+		return p, nil
+	}
+	if te.shutdown {
+		return nil, errors.New("shutdown has been called")
+	}
+	if len(te.pipelines) >= te.tenant.MaxTenants {
+		return nil, consumererror.NewPermanent(fmt.Errorf("tenant %q: %w, max_tenants is %d", tenant, errTooManyTenants, te.tenant.MaxTenants))
+	}
+
+	set := te.set
+	set.ID = tenantID(set.ID, tenant)
+	set.Logger = set.Logger.With(zap.String("tenant", tenant))
+	set.TelemetrySettings.Logger = set.Logger
+
+	p, err := te.create(ctx, set, tenantConfig(te.cfg, tenant), tenant)
+	if err != nil {
+		return nil, fmt.Errorf("tenant %q: %w", tenant, err)
+	}
+	if err := p.Start(ctx, te.host); err != nil {
+		return nil, fmt.Errorf("tenant %q: %w", tenant, err)
+	}
+	tp := &tenantPipeline{Metrics: p, lastUsed: time.Now()}
+	te.pipelines[tenant] = tp
+	return tp, nil
+}
+
+// tenantID returns the ID of the pipeline of tenant. The exporterhelper and
+// remote write telemetry is attributed by ID, so each pipeline needs its own
+// for their telemetry not to collide. Metrics without tenant keep the ID of
+// the exporter.
+func tenantID(id component.ID, tenant string) component.ID {
+	if tenant == "" {
+		return id
+	}
+	name := "tenant=" + tenant
+	if id.Name() != "" {
+		name = id.Name() + "/" + name
+	}
+	return component.NewIDWithName(id.Type(), name)
+}
+
+// walTenants returns the tenants with a WAL partition, including the empty
+// tenant if the WAL of metrics without tenant exists.
+func (te *tenantExporter) walTenants() ([]string, error) {
+	wal := te.cfg.WAL.Get()
+	if wal == nil {
+		return nil, nil
+	}
+
+	var tenants []string
+	if _, err := os.Stat(filepath.Join(wal.Directory, prwwal.Dir)); err == nil {
+		tenants = append(tenants, "")
+	}
+
+	entries, err := os.ReadDir(filepath.Join(wal.Directory, tenantWALDirectory))
+	if errors.Is(err, os.ErrNotExist) {
+		return tenants, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tenant WAL partitions: %w", err)
+	}
+
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		tenant, err := base64.RawURLEncoding.DecodeString(e.Name())
+		if err != nil {
+			te.set.Logger.Warn("ignoring unknown directory in tenant WAL partitions", zap.String("directory", e.Name()))
+			continue
+		}
+		tenants = append(tenants, string(tenant))
+	}
+	return tenants, nil
+}
+
+// tenantConfig returns the configuration of the pipeline of tenant. Metrics
+// without tenant use the WAL of the exporter, tenants use a partition of it
+// named after the encoded tenant, which is safe to use in paths.
+func tenantConfig(cfg *Config, tenant string) *Config {
+	tcfg := *cfg
+	if tenant == "" {
+		return &tcfg
+	}
+
+	if wal := cfg.WAL.Get(); wal != nil {
+		twal := *wal
+		twal.Directory = filepath.Join(wal.Directory, tenantWALDirectory, base64.RawURLEncoding.EncodeToString([]byte(tenant)))
+		tcfg.WAL = configoptional.Some(twal)
+	}
+	return &tcfg
+}
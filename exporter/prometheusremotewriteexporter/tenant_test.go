@@ -0,0 +1,229 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewriteexporter
+
+import (
+	"context"
+	"encoding/base64"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/prometheusremotewriteexporter/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/prwwal"
+)
+
+// tenantServer counts the remote write requests by tenant, rejects those of
+// tenant "rejected" and fails those of tenant "unavailable".
+func tenantServer(t *testing.T) (*httptest.Server, func() map[string]int) {
+	var mu sync.Mutex
+	requests := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tenant := r.Header.Get(defaultTenantHeader)
+		mu.Lock()
+		requests[tenant]++
+		mu.Unlock()
+		switch tenant {
+		case "rejected":
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		case "unavailable":
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, func() map[string]int {
+		mu.Lock()
+		defer mu.Unlock()
+		return maps.Clone(requests)
+	}
+}
+
+func tenantMetrics(tenants ...string) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	for _, tenant := range tenants {
+		rm := md.ResourceMetrics().AppendEmpty()
+		if tenant != "" {
+			rm.Resource().Attributes().PutStr("tenant.id", tenant)
+		}
+		m := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+		m.SetName("requests")
+		m.SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(1)
+	}
+	return md
+}
+
+func TestTenantExporter(t *testing.T) {
+	server, requests := tenantServer(t)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.ClientConfig.Endpoint = server.URL
+	cfg.RemoteWriteQueue.Enabled = false
+	cfg.BackOffConfig.Enabled = false
+	cfg.TargetInfo.Enabled = false
+	cfg.Tenant = configoptional.Some(TenantConfig{
+		Header:                defaultTenantHeader,
+		FromResourceAttribute: "tenant.id",
+		Default:               "anonymous",
+		MaxTenants:            defaultMaxTenants,
+	})
+
+	exp, err := NewFactory().CreateMetrics(t.Context(), exportertest.NewNopSettings(metadata.Type), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(t.Context(), componenttest.NewNopHost()))
+	t.Cleanup(func() { assert.NoError(t, exp.Shutdown(t.Context())) })
+
+	require.NoError(t, exp.ConsumeMetrics(t.Context(), tenantMetrics("a", "b", "", "a")))
+	assert.Equal(t, map[string]int{"a": 1, "b": 1, "anonymous": 1}, requests())
+
+	// a rejecting tenant does not affect the others
+	err = exp.ConsumeMetrics(t.Context(), tenantMetrics("rejected", "b"))
+	assert.ErrorContains(t, err, `tenant "rejected"`)
+	assert.Equal(t, map[string]int{"a": 1, "b": 2, "anonymous": 1, "rejected": 1}, requests())
+
+	// retryable errors of a tenant are permanent, as retrying would resend the
+	// metrics of the other tenants
+	err = exp.ConsumeMetrics(t.Context(), tenantMetrics("unavailable", "b"))
+	assert.ErrorContains(t, err, `tenant "unavailable"`)
+	assert.True(t, consumererror.IsPermanent(err))
+	assert.Equal(t, map[string]int{"a": 1, "b": 3, "anonymous": 1, "rejected": 1, "unavailable": 1}, requests())
+
+	assert.Len(t, exp.(*tenantExporter).pipelines, 5)
+}
+
+func TestTenantFromContext(t *testing.T) {
+	server, requests := tenantServer(t)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.ClientConfig.Endpoint = server.URL
+	cfg.RemoteWriteQueue.Enabled = false
+	cfg.TargetInfo.Enabled = false
+	cfg.Tenant = configoptional.Some(TenantConfig{
+		Header:      defaultTenantHeader,
+		FromContext: "x-scope-orgid",
+		MaxTenants:  defaultMaxTenants,
+	})
+
+	exp, err := NewFactory().CreateMetrics(t.Context(), exportertest.NewNopSettings(metadata.Type), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(t.Context(), componenttest.NewNopHost()))
+	t.Cleanup(func() { assert.NoError(t, exp.Shutdown(t.Context())) })
+
+	ctx := client.NewContext(t.Context(), client.Info{
+		Metadata: client.NewMetadata(map[string][]string{"x-scope-orgid": {"a"}}),
+	})
+	require.NoError(t, exp.ConsumeMetrics(ctx, tenantMetrics("b")))
+	// without tenant or default, metrics are sent without header
+	require.NoError(t, exp.ConsumeMetrics(t.Context(), tenantMetrics("b")))
+
+	assert.Equal(t, map[string]int{"a": 1, "": 1}, requests())
+}
+
+func TestTenantWAL(t *testing.T) {
+	dir := t.TempDir()
+	cfg := createDefaultConfig().(*Config)
+	cfg.WAL = configoptional.Some(WALConfig{Directory: dir})
+	cfg.Tenant = configoptional.Some(TenantConfig{
+		Header:      defaultTenantHeader,
+		FromContext: "x-scope-orgid",
+		MaxTenants:  defaultMaxTenants,
+	})
+
+	// tenants are encoded to be safe in paths
+	tcfg := tenantConfig(cfg, "../a")
+	assert.Equal(t, filepath.Join(dir, tenantWALDirectory, base64.RawURLEncoding.EncodeToString([]byte("../a"))), tcfg.WAL.Get().Directory)
+	assert.Equal(t, dir, tenantConfig(cfg, "").WAL.Get().Directory)
+	assert.Equal(t, dir, cfg.WAL.Get().Directory)
+
+	te := newTenantExporter(cfg, exportertest.NewNopSettings(metadata.Type), createPipeline)
+	tenants, err := te.walTenants()
+	require.NoError(t, err)
+	assert.Empty(t, tenants)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, prwwal.Dir), 0o700))
+	require.NoError(t, os.MkdirAll(tcfg.WAL.Get().Directory, 0o700))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, tenantWALDirectory, "not base64!"), 0o700))
+	tenants, err = te.walTenants()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"", "../a"}, tenants)
+}
+
+func TestTenantLimits(t *testing.T) {
+	server, requests := tenantServer(t)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.ClientConfig.Endpoint = server.URL
+	cfg.RemoteWriteQueue.Enabled = false
+	cfg.TargetInfo.Enabled = false
+	cfg.Tenant = configoptional.Some(TenantConfig{
+		Header:                defaultTenantHeader,
+		FromResourceAttribute: "tenant.id",
+		MaxTenants:            2,
+		IdleTimeout:           time.Hour,
+	})
+
+	var mu sync.Mutex
+	ids := make(map[string]component.ID)
+	create := func(ctx context.Context, set exporter.Settings, cfg *Config, tenant string) (exporter.Metrics, error) {
+		mu.Lock()
+		ids[tenant] = set.ID
+		mu.Unlock()
+		return createPipeline(ctx, set, cfg, tenant)
+	}
+	set := exportertest.NewNopSettings(metadata.Type)
+	set.ID = component.NewIDWithName(metadata.Type, "mimir")
+	te := newTenantExporter(cfg, set, create)
+	require.NoError(t, te.Start(t.Context(), componenttest.NewNopHost()))
+	t.Cleanup(func() { assert.NoError(t, te.Shutdown(t.Context())) })
+
+	require.NoError(t, te.ConsumeMetrics(t.Context(), tenantMetrics("a", "b")))
+
+	// each pipeline has its own ID, so their telemetry does not collide
+	assert.Equal(t, map[string]component.ID{
+		"a": component.NewIDWithName(metadata.Type, "mimir/tenant=a"),
+		"b": component.NewIDWithName(metadata.Type, "mimir/tenant=b"),
+	}, ids)
+
+	// metrics of tenants beyond max_tenants are rejected
+	err := te.ConsumeMetrics(t.Context(), tenantMetrics("c", "a"))
+	assert.ErrorIs(t, err, errTooManyTenants)
+	assert.True(t, consumererror.IsPermanent(err))
+	assert.Equal(t, map[string]int{"a": 2, "b": 1}, requests())
+
+	// idle pipelines are shut down, making room for other tenants
+	te.mu.Lock()
+	te.pipelines["a"].lastUsed = time.Now()
+	te.mu.Unlock()
+	te.sweep(time.Now().Add(30 * time.Minute))
+	te.mu.Lock()
+	assert.Len(t, te.pipelines, 2)
+	te.mu.Unlock()
+
+	te.sweep(time.Now().Add(2 * time.Hour))
+	te.mu.Lock()
+	assert.Empty(t, te.pipelines)
+	te.mu.Unlock()
+
+	require.NoError(t, te.ConsumeMetrics(t.Context(), tenantMetrics("c")))
+	assert.Equal(t, map[string]int{"a": 2, "b": 1, "c": 1}, requests())
+}
@@ -22,6 +22,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/prometheusremotewriteexporter/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/prwwal"
 )
 
 type prwWalTelemetry interface {
@@ -34,6 +35,8 @@ type prwWalTelemetry interface {
 	recordWALBytesWritten(ctx context.Context, bytes int)
 	recordWALBytesRead(ctx context.Context, bytes int)
 	recordWALLag(ctx context.Context, lag int64)
+	recordWALRepairs(ctx context.Context)
+	recordWALEvictedEntries(ctx context.Context, entries int64)
 }
 
 type prwWalTelemetryOTel struct {
@@ -77,6 +80,14 @@ func (p *prwWalTelemetryOTel) recordWALLag(ctx context.Context, lag int64) {
 	p.telemetryBuilder.ExporterPrometheusremotewriteWalLag.Record(ctx, lag, metric.WithAttributes(p.otelAttrs...))
 }
 
+func (p *prwWalTelemetryOTel) recordWALRepairs(ctx context.Context) {
+	p.telemetryBuilder.ExporterPrometheusremotewriteWalRepairs.Add(ctx, 1, metric.WithAttributes(p.otelAttrs...))
+}
+
+func (p *prwWalTelemetryOTel) recordWALEvictedEntries(ctx context.Context, entries int64) {
+	p.telemetryBuilder.ExporterPrometheusremotewriteWalEvictedEntries.Add(ctx, entries, metric.WithAttributes(p.otelAttrs...))
+}
+
 func newPRWWalTelemetry(set exporter.Settings) (prwWalTelemetry, error) {
 	telemetryBuilder, err := metadata.NewTelemetryBuilder(set.TelemetrySettings)
 	if err != nil {
@@ -104,12 +115,17 @@ type prweWAL struct {
 	wWALIndex *atomic.Uint64
 
 	telemetry prwWalTelemetry
+	logger    *zap.Logger
 }
 
 const (
 	defaultWALBufferSize         = 300
 	defaultWALTruncateFrequency  = 1 * time.Minute
 	defaultWALLagRecordFrequency = 15 * time.Second
+
+	// walSegmentsPerMaxSize is the number of segments the WAL is split into
+	// when its size is bounded, as the oldest entries are evicted by segment.
+	walSegmentsPerMaxSize = 10
 )
 
 type WALConfig struct {
@@ -117,6 +133,9 @@ type WALConfig struct {
 	BufferSize         int           `mapstructure:"buffer_size"`
 	TruncateFrequency  time.Duration `mapstructure:"truncate_frequency"`
 	LagRecordFrequency time.Duration `mapstructure:"lag_record_frequency"`
+	// MaxSizeBytes is the maximum size of the WAL. When exceeded, the oldest
+	// entries are dropped. Zero means no limit.
+	MaxSizeBytes int64 `mapstructure:"max_size_bytes"`
 }
 
 func (wc *WALConfig) bufferSize() int {
@@ -160,15 +179,20 @@ func newWAL(walConfig *WALConfig, set exporter.Settings, exportSink func(context
 		rWALIndex:  &atomic.Uint64{},
 		wWALIndex:  &atomic.Uint64{},
 		telemetry:  telemetryPRWWal,
+		logger:     set.Logger,
 	}, nil
 }
 
 func (wc *WALConfig) createWAL() (*wal.Log, string, error) {
-	walPath := filepath.Join(wc.Directory, "prom_remotewrite")
-	log, err := wal.Open(walPath, &wal.Options{
+	walPath := filepath.Join(wc.Directory, prwwal.Dir)
+	opts := &wal.Options{
 		SegmentCacheSize: wc.bufferSize(),
 		NoCopy:           true,
-	})
+	}
+	if wc.MaxSizeBytes > 0 {
+		opts.SegmentSize = int(min(max(wc.MaxSizeBytes/walSegmentsPerMaxSize, 1), int64(wal.DefaultOptions.SegmentSize)))
+	}
+	log, err := wal.Open(walPath, opts)
 	if err != nil {
 		return nil, "", fmt.Errorf("prometheusremotewriteexporter: failed to open WAL: %w", err)
 	}
@@ -191,6 +215,12 @@ func (prweWAL *prweWAL) retrieveWALIndices() (err error) {
 	}
 
 	log, walPath, err := prweWAL.walConfig.createWAL()
+	if errors.Is(err, wal.ErrCorrupt) {
+		if err = prweWAL.repairWAL(); err != nil {
+			return err
+		}
+		log, walPath, err = prweWAL.walConfig.createWAL()
+	}
 	if err != nil {
 		return err
 	}
@@ -212,6 +242,20 @@ func (prweWAL *prweWAL) retrieveWALIndices() (err error) {
 	return nil
 }
 
+// repairWAL truncates the closed WAL after its last valid entry, so that it
+// can be opened again after being corrupted, e.g. when the disk became full.
+func (prweWAL *prweWAL) repairWAL() error {
+	walPath := filepath.Join(prweWAL.walConfig.Directory, prwwal.Dir)
+	removed, err := prwwal.Repair(walPath)
+	if err != nil {
+		return fmt.Errorf("prometheusremotewriteexporter: failed to repair corrupted WAL: %w", err)
+	}
+	prweWAL.telemetry.recordWALRepairs(context.Background())
+	prweWAL.logger.Warn("Truncated corrupted WAL after its last valid entry",
+		zap.String("path", walPath), zap.Int64("removed_bytes", removed))
+	return nil
+}
+
 func (prweWAL *prweWAL) stop() error {
 	err := errAlreadyClosed
 	prweWAL.stopOnce.Do(func() {
@@ -264,6 +308,18 @@ func (prweWAL *prweWAL) run(ctx context.Context) (err error) {
 				if err != nil {
 					// log err
 					logger.Error("error processing WAL entries", zap.Error(err))
+					if errors.Is(err, wal.ErrCorrupt) {
+						prweWAL.mu.Lock()
+						errR := prweWAL.closeWAL()
+						if errR == nil {
+							errR = prweWAL.repairWAL()
+						}
+						prweWAL.mu.Unlock()
+						if errR != nil {
+							logger.Error("unable to repair write-ahead log", zap.Error(errR))
+							return
+						}
+					}
 					// Restart WAL
 					if errS := prweWAL.retrieveWALIndices(); errS != nil {
 						logger.Error("unable to re-start write-ahead log after error", zap.Error(errS))
@@ -437,7 +493,59 @@ func (prweWAL *prweWAL) persistToWAL(ctx context.Context, requests []*prompb.Wri
 	default:
 	}
 
-	return prweWAL.wal.WriteBatch(batch)
+	if err := prweWAL.wal.WriteBatch(batch); err != nil {
+		return err
+	}
+	return prweWAL.evictWAL(ctx)
+}
+
+// evictWAL drops the oldest segments of the WAL until it is within its
+// maximum size. The segment being written to is never dropped. prweWAL.mu
+// must be held.
+func (prweWAL *prweWAL) evictWAL(ctx context.Context) error {
+	if prweWAL.walConfig.MaxSizeBytes <= 0 {
+		return nil
+	}
+
+	segments, err := prwwal.List(prweWAL.walPath)
+	if err != nil {
+		return err
+	}
+	size := prwwal.Size(segments)
+	evicted := 0
+	for size > prweWAL.walConfig.MaxSizeBytes && evicted < len(segments)-1 {
+		size -= segments[evicted].Size
+		evicted++
+	}
+	if evicted == 0 {
+		return nil
+	}
+
+	firstIndex, err := prweWAL.wal.FirstIndex()
+	if err != nil {
+		return err
+	}
+	lastIndex, err := prweWAL.wal.LastIndex()
+	if err != nil {
+		return err
+	}
+	// The segment being written to is empty after it was cycled, so the
+	// last entry is kept.
+	index := min(segments[evicted].Index, lastIndex)
+	if index <= firstIndex {
+		return nil
+	}
+	if err := prweWAL.wal.TruncateFront(index); err != nil {
+		return err
+	}
+	if prweWAL.rWALIndex.Load() < index {
+		prweWAL.rWALIndex.Store(index)
+	}
+
+	prweWAL.telemetry.recordWALEvictedEntries(ctx, int64(index-firstIndex))
+	prweWAL.logger.Warn("Dropped oldest WAL entries to stay within its maximum size",
+		zap.Uint64("entries", index-firstIndex), zap.Int64("max_size_bytes", prweWAL.walConfig.MaxSizeBytes))
+	return nil
 }
 
 func (prweWAL *prweWAL) readPrompbFromWAL(ctx context.Context, index uint64) (wreq *prompb.WriteRequest, err error) {
@@ -459,6 +567,8 @@ func (prweWAL *prweWAL) readPrompbFromWAL(ctx context.Context, index uint64) (wr
 		if prweWAL.wal == nil {
 			return nil, errors.New("attempt to read from closed WAL")
 		}
+		// Skip the entries evicted since the read started.
+		index = max(index, prweWAL.rWALIndex.Load())
 		prweWAL.telemetry.recordWALReads(ctx)
 		start := time.Now()
 		protoBlob, err = prweWAL.wal.Read(index)
@@ -30,6 +30,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/prometheusremotewriteexporter/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/prometheusremotewriteexporter/internal/metadatatest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/prwwal"
 )
 
 func doNothingExportSink(_ context.Context, reqL []*prompb.WriteRequest) error {
@@ -434,3 +435,94 @@ func TestWALLag_Telemetry(t *testing.T) {
 	_, err = tel.GetMetric("otelcol_exporter_prometheusremotewrite_wal_lag")
 	require.NoError(t, err)
 }
+
+func TestWAL_repair(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() {
+		require.NoError(t, tel.Shutdown(context.Background())) //nolint:usetesting
+	})
+	set := metadatatest.NewSettings(tel)
+	config := &WALConfig{Directory: t.TempDir()}
+
+	reqL := []*prompb.WriteRequest{
+		{Timeseries: []prompb.TimeSeries{{Labels: []prompb.Label{{Name: "ts1l1", Value: "ts1k1"}}, Samples: []prompb.Sample{{Value: 1, Timestamp: 100}}}}},
+		{Timeseries: []prompb.TimeSeries{{Labels: []prompb.Label{{Name: "ts2l1", Value: "ts2k1"}}, Samples: []prompb.Sample{{Value: 2, Timestamp: 200}}}}},
+	}
+	pwal, err := newWAL(config, set, doNothingExportSink)
+	require.NoError(t, err)
+	require.NoError(t, pwal.retrieveWALIndices())
+	require.NoError(t, pwal.persistToWAL(t.Context(), reqL))
+	require.NoError(t, pwal.stop())
+
+	// Simulate an entry partially written when the disk became full.
+	segments, err := prwwal.List(filepath.Join(config.Directory, prwwal.Dir))
+	require.NoError(t, err)
+	f, err := os.OpenFile(segments[len(segments)-1].Path, os.O_APPEND|os.O_WRONLY, 0o600)
+	require.NoError(t, err)
+	_, err = f.Write([]byte{100, 1, 2})
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	pwal, err = newWAL(config, set, doNothingExportSink)
+	require.NoError(t, err)
+	require.NoError(t, pwal.retrieveWALIndices())
+	t.Cleanup(func() {
+		assert.NoError(t, pwal.stop())
+	})
+
+	for i, expected := range reqL {
+		req, err := pwal.readPrompbFromWAL(t.Context(), uint64(i+1))
+		require.NoError(t, err)
+		assert.Equal(t, expected, req)
+	}
+	metadatatest.AssertEqualExporterPrometheusremotewriteWalRepairs(t, tel,
+		[]metricdata.DataPoint[int64]{{Value: 1}},
+		metricdatatest.IgnoreTimestamp())
+}
+
+func TestWAL_maxSize(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() {
+		require.NoError(t, tel.Shutdown(context.Background())) //nolint:usetesting
+	})
+	set := metadatatest.NewSettings(tel)
+	config := &WALConfig{Directory: t.TempDir(), MaxSizeBytes: 1000}
+
+	pwal, err := newWAL(config, set, doNothingExportSink)
+	require.NoError(t, err)
+	require.NoError(t, pwal.retrieveWALIndices())
+	t.Cleanup(func() {
+		assert.NoError(t, pwal.stop())
+	})
+
+	const n = 100
+	for i := range n {
+		req := &prompb.WriteRequest{Timeseries: []prompb.TimeSeries{{
+			Labels:  []prompb.Label{{Name: "__name__", Value: "test_metric"}},
+			Samples: []prompb.Sample{{Value: float64(i), Timestamp: int64(i)}},
+		}}}
+		require.NoError(t, pwal.persistToWAL(t.Context(), []*prompb.WriteRequest{req}))
+
+		segments, err := prwwal.List(pwal.walPath)
+		require.NoError(t, err)
+		require.LessOrEqual(t, prwwal.Size(segments), config.MaxSizeBytes)
+	}
+
+	// The oldest entries were evicted and reading continues with the first
+	// entry left.
+	first, err := pwal.wal.FirstIndex()
+	require.NoError(t, err)
+	last, err := pwal.wal.LastIndex()
+	require.NoError(t, err)
+	assert.Greater(t, first, uint64(1))
+	assert.Equal(t, uint64(n), last)
+	assert.Equal(t, first, pwal.rWALIndex.Load())
+
+	req, err := pwal.readPrompbFromWAL(t.Context(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, float64(first-1), req.Timeseries[0].Samples[0].Value)
+
+	metadatatest.AssertEqualExporterPrometheusremotewriteWalEvictedEntries(t, tel,
+		[]metricdata.DataPoint[int64]{{Value: int64(first - 1)}},
+		metricdatatest.IgnoreTimestamp())
+}
@@ -4,6 +4,7 @@
 package pulsarexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/pulsarexporter"
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
@@ -25,8 +26,18 @@ type Config struct {
 	Endpoint string `mapstructure:"endpoint"`
 	// The name of the pulsar topic to export to (default otlp_spans for traces, otlp_metrics for metrics)
 	Topic string `mapstructure:"topic"`
-	// Encoding of messages (default "otlp_proto")
+	// TopicFromAttribute is the name of the resource attribute whose value is
+	// used as the topic, falling back to Topic when no resource has it.
+	TopicFromAttribute string `mapstructure:"topic_from_attribute"`
+	// MaxTopicProducers is the maximum number of producers kept open for the
+	// topics taken from TopicFromAttribute (default 100). The least recently
+	// used producer is closed when a new one is needed.
+	MaxTopicProducers int `mapstructure:"max_topic_producers"`
+	// Encoding of messages (default "otlp_proto"), or the ID of an encoding extension
 	Encoding string `mapstructure:"encoding"`
+	// PartitionTracesByID sets the trace ID as the key of trace messages, so
+	// that the spans of a trace are published to the same partition.
+	PartitionTracesByID bool `mapstructure:"partition_traces_by_id"`
 	// Producer configuration of the Pulsar producer
 	Producer Producer `mapstructure:"producer"`
 	// Set the path to the trusted TLS certificate file
@@ -98,7 +109,10 @@ type Producer struct {
 var _ component.Config = (*Config)(nil)
 
 // Validate checks if the exporter configuration is valid
-func (*Config) Validate() error {
+func (cfg *Config) Validate() error {
+	if cfg.MaxTopicProducers <= 0 {
+		return errors.New("max_topic_producers must be greater than 0")
+	}
 	return nil
 }
 
@@ -55,7 +55,10 @@ func TestLoadConfig(t *testing.T) {
 				}(),
 				Endpoint:                "pulsar://localhost:6650",
 				Topic:                   "spans",
+				TopicFromAttribute:      "pulsar_topic",
+				MaxTopicProducers:       10,
 				Encoding:                "otlp-spans",
+				PartitionTracesByID:     true,
 				TLSTrustCertsFilePath:   "ca.pem",
 				Authentication:          Authentication{TLS: configoptional.Some(TLS{CertFile: "cert.pem", KeyFile: "key.pem"})},
 				MaxConnectionsPerBroker: 1,
@@ -94,6 +97,12 @@ func TestLoadConfig(t *testing.T) {
 	}
 }
 
+func TestValidateMaxTopicProducers(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MaxTopicProducers = 0
+	assert.EqualError(t, xconfmap.Validate(cfg), "max_topic_producers must be greater than 0")
+}
+
 func TestClientOptions(t *testing.T) {
 	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
 	require.NoError(t, err)
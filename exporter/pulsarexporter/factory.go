@@ -24,6 +24,9 @@ const (
 	defaultLogsTopic    = "otlp_logs"
 	defaultEncoding     = "otlp_proto"
 	defaultBroker       = "pulsar://localhost:6650"
+	// defaultMaxTopicProducers is the default number of producers kept open
+	// for the topics taken from topic_from_attribute.
+	defaultMaxTopicProducers = 100
 )
 
 // FactoryOption applies changes to pulsarExporterFactory.
@@ -66,6 +69,7 @@ func createDefaultConfig() component.Config {
 		// using an empty topic to track when it has not been set by user, default is based on traces or metrics.
 		Topic:                   "",
 		Encoding:                defaultEncoding,
+		MaxTopicProducers:       defaultMaxTopicProducers,
 		Authentication:          Authentication{},
 		MaxConnectionsPerBroker: 1,
 		ConnectionTimeout:       5 * time.Second,
@@ -88,10 +92,7 @@ func (f *pulsarExporterFactory) createTracesExporter(
 	if oCfg.Topic == "" {
 		oCfg.Topic = defaultTracesTopic
 	}
-	exp, err := newTracesExporter(oCfg, set, f.tracesMarshalers)
-	if err != nil {
-		return nil, err
-	}
+	exp := newTracesExporter(oCfg, set, f.tracesMarshalers)
 	return exporterhelper.NewTraces(
 		ctx,
 		set,
@@ -116,10 +117,7 @@ func (f *pulsarExporterFactory) createMetricsExporter(
 	if oCfg.Topic == "" {
 		oCfg.Topic = defaultMetricsTopic
 	}
-	exp, err := newMetricsExporter(oCfg, set, f.metricsMarshalers)
-	if err != nil {
-		return nil, err
-	}
+	exp := newMetricsExporter(oCfg, set, f.metricsMarshalers)
 	return exporterhelper.NewMetrics(
 		ctx,
 		set,
@@ -144,10 +142,7 @@ func (f *pulsarExporterFactory) createLogsExporter(
 	if oCfg.Topic == "" {
 		oCfg.Topic = defaultLogsTopic
 	}
-	exp, err := newLogsExporter(oCfg, set, f.logsMarshalers)
-	if err != nil {
-		return nil, err
-	}
+	exp := newLogsExporter(oCfg, set, f.logsMarshalers)
 	return exporterhelper.NewLogs(
 		ctx,
 		set,
@@ -5,9 +5,12 @@ package pulsarexporter // import "github.com/open-telemetry/opentelemetry-collec
 
 import (
 	"github.com/apache/pulsar-client-go/pulsar"
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/encodingutil"
 )
 
 // TracesMarshaler marshals traces into Message array.
@@ -70,3 +73,42 @@ func logsMarshalers() map[string]LogsMarshaler {
 		json.Encoding():  json,
 	}
 }
+
+func getTracesMarshaler(encoding string, host component.Host, marshalers map[string]TracesMarshaler) (TracesMarshaler, error) {
+	// Extensions take precedence.
+	if m, ok, err := encodingutil.Extension[ptrace.Marshaler](host, encoding, "traces", "marshaler"); err != nil {
+		return nil, err
+	} else if ok {
+		return newPdataTracesMarshaler(m, encoding), nil
+	}
+	if m, ok := marshalers[encoding]; ok {
+		return m, nil
+	}
+	return nil, errUnrecognizedEncoding
+}
+
+func getMetricsMarshaler(encoding string, host component.Host, marshalers map[string]MetricsMarshaler) (MetricsMarshaler, error) {
+	// Extensions take precedence.
+	if m, ok, err := encodingutil.Extension[pmetric.Marshaler](host, encoding, "metrics", "marshaler"); err != nil {
+		return nil, err
+	} else if ok {
+		return newPdataMetricsMarshaler(m, encoding), nil
+	}
+	if m, ok := marshalers[encoding]; ok {
+		return m, nil
+	}
+	return nil, errUnrecognizedEncoding
+}
+
+func getLogsMarshaler(encoding string, host component.Host, marshalers map[string]LogsMarshaler) (LogsMarshaler, error) {
+	// Extensions take precedence.
+	if m, ok, err := encodingutil.Extension[plog.Marshaler](host, encoding, "logs", "marshaler"); err != nil {
+		return nil, err
+	} else if ok {
+		return newPdataLogsMarshaler(m, encoding), nil
+	}
+	if m, ok := marshalers[encoding]; ok {
+		return m, nil
+	}
+	return nil, errUnrecognizedEncoding
+}
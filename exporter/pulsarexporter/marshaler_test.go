@@ -4,6 +4,7 @@
 package pulsarexporter
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"testing"
@@ -11,7 +12,10 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	conventions "go.opentelemetry.io/otel/semconv/v1.27.0"
 )
@@ -66,6 +70,75 @@ func TestDefaultLogsMarshalers(t *testing.T) {
 	}
 }
 
+func TestGetTracesMarshalerExtension(t *testing.T) {
+	extension := ptraceMarshalerFuncExtension(func(ptrace.Traces) ([]byte, error) {
+		return []byte("traces"), nil
+	})
+	host := extensionsHost{
+		component.MustNewID("trace_encoding"):                  extension,
+		component.MustNewIDWithName("trace_encoding", "alice"): extension,
+		component.MustNewID("not_traces"):                      nopExtension{},
+	}
+
+	for _, encoding := range []string{"trace_encoding", "trace_encoding/alice"} {
+		m, err := getTracesMarshaler(encoding, host, tracesMarshalers())
+		require.NoError(t, err)
+		assert.Equal(t, encoding, m.Encoding())
+		messages, err := m.Marshal(ptrace.NewTraces(), "topic")
+		require.NoError(t, err)
+		require.Len(t, messages, 1)
+		assert.Equal(t, []byte("traces"), messages[0].Payload)
+	}
+
+	// Built-in encodings are used when no extension is found.
+	m, err := getTracesMarshaler("jaeger_proto", host, tracesMarshalers())
+	require.NoError(t, err)
+	assert.Equal(t, "jaeger_proto", m.Encoding())
+
+	_, err = getTracesMarshaler("not_traces", host, tracesMarshalers())
+	assert.EqualError(t, err, `extension "not_traces" is not a traces marshaler`)
+
+	_, err = getTracesMarshaler("unknown", host, tracesMarshalers())
+	assert.ErrorIs(t, err, errUnrecognizedEncoding)
+
+	_, err = getTracesMarshaler("otlp-spans", host, tracesMarshalers())
+	assert.ErrorIs(t, err, errUnrecognizedEncoding)
+}
+
+func TestGetMetricsMarshalerExtension(t *testing.T) {
+	host := extensionsHost{
+		component.MustNewID("metric_encoding"): pmetricMarshalerFuncExtension(func(pmetric.Metrics) ([]byte, error) {
+			return []byte("metrics"), nil
+		}),
+	}
+	m, err := getMetricsMarshaler("metric_encoding", host, metricsMarshalers())
+	require.NoError(t, err)
+	messages, err := m.Marshal(pmetric.NewMetrics(), "topic")
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	assert.Equal(t, []byte("metrics"), messages[0].Payload)
+
+	_, err = getMetricsMarshaler("unknown", host, metricsMarshalers())
+	assert.ErrorIs(t, err, errUnrecognizedEncoding)
+}
+
+func TestGetLogsMarshalerExtension(t *testing.T) {
+	host := extensionsHost{
+		component.MustNewID("log_encoding"): plogMarshalerFuncExtension(func(plog.Logs) ([]byte, error) {
+			return []byte("logs"), nil
+		}),
+	}
+	m, err := getLogsMarshaler("log_encoding", host, logsMarshalers())
+	require.NoError(t, err)
+	messages, err := m.Marshal(plog.NewLogs(), "topic")
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	assert.Equal(t, []byte("logs"), messages[0].Payload)
+
+	_, err = getLogsMarshaler("unknown", host, logsMarshalers())
+	assert.ErrorIs(t, err, errUnrecognizedEncoding)
+}
+
 func TestOTLPTracesJsonMarshaling(t *testing.T) {
 	t.Parallel()
 
@@ -134,3 +207,61 @@ func TestOTLPTracesJsonMarshaling(t *testing.T) {
 
 	assert.Equal(t, expectedMap, final, "Must match the expected value")
 }
+
+type extensionsHost map[component.ID]component.Component
+
+func (m extensionsHost) GetExtensions() map[component.ID]component.Component {
+	return m
+}
+
+type nopExtension struct{}
+
+func (nopExtension) Start(context.Context, component.Host) error {
+	return nil
+}
+
+func (nopExtension) Shutdown(context.Context) error {
+	return nil
+}
+
+type ptraceMarshalerFuncExtension func(ptrace.Traces) ([]byte, error)
+
+func (f ptraceMarshalerFuncExtension) MarshalTraces(td ptrace.Traces) ([]byte, error) {
+	return f(td)
+}
+
+func (ptraceMarshalerFuncExtension) Start(context.Context, component.Host) error {
+	return nil
+}
+
+func (ptraceMarshalerFuncExtension) Shutdown(context.Context) error {
+	return nil
+}
+
+type pmetricMarshalerFuncExtension func(pmetric.Metrics) ([]byte, error)
+
+func (f pmetricMarshalerFuncExtension) MarshalMetrics(md pmetric.Metrics) ([]byte, error) {
+	return f(md)
+}
+
+func (pmetricMarshalerFuncExtension) Start(context.Context, component.Host) error {
+	return nil
+}
+
+func (pmetricMarshalerFuncExtension) Shutdown(context.Context) error {
+	return nil
+}
+
+type plogMarshalerFuncExtension func(plog.Logs) ([]byte, error)
+
+func (f plogMarshalerFuncExtension) MarshalLogs(ld plog.Logs) ([]byte, error) {
+	return f(ld)
+}
+
+func (plogMarshalerFuncExtension) Start(context.Context, component.Host) error {
+	return nil
+}
+
+func (plogMarshalerFuncExtension) Shutdown(context.Context) error {
+	return nil
+}
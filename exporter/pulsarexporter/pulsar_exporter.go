@@ -4,40 +4,57 @@
 package pulsarexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/pulsarexporter"
 
 import (
+	"container/list"
 	"context"
 	"errors"
+	"sync"
 
 	"github.com/apache/pulsar-client-go/pulsar"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/traceutil"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/batchpersignal"
 )
 
 var errUnrecognizedEncoding = errors.New("unrecognized encoding")
 
 type PulsarTracesProducer struct {
-	cfg       Config
-	client    pulsar.Client
-	producer  pulsar.Producer
-	topic     string
-	marshaler TracesMarshaler
-	logger    *zap.Logger
+	cfg            Config
+	client         pulsar.Client
+	producer       pulsar.Producer
+	topicProducers topicProducers
+	topic          string
+	marshaler      TracesMarshaler
+	marshalers     map[string]TracesMarshaler
+	logger         *zap.Logger
 }
 
 func (e *PulsarTracesProducer) tracesPusher(ctx context.Context, td ptrace.Traces) error {
-	messages, err := e.marshaler.Marshal(td, e.topic)
+	topic := getTopic[ptrace.ResourceSpans](e.topic, e.cfg.TopicFromAttribute, td.ResourceSpans())
+	producer := e.producer
+	if topic != e.topic {
+		var err error
+		if producer, err = e.topicProducers.get(e.client, e.cfg, topic); err != nil {
+			return err
+		}
+	}
+
+	messages, err := e.marshal(td, topic)
 	if err != nil {
 		return consumererror.NewPermanent(err)
 	}
 
 	var errs error
 	for _, message := range messages {
-		e.producer.SendAsync(ctx, message, func(_ pulsar.MessageID, _ *pulsar.ProducerMessage, err error) {
+		producer.SendAsync(ctx, message, func(_ pulsar.MessageID, _ *pulsar.ProducerMessage, err error) {
 			if err != nil {
 				errs = multierr.Append(errs, err)
 			}
@@ -47,16 +64,48 @@ func (e *PulsarTracesProducer) tracesPusher(ctx context.Context, td ptrace.Trace
 	return errs
 }
 
+// marshal marshals the traces into messages. If partitioning by trace ID is
+// enabled, each message holds a single trace and is keyed by its trace ID.
+func (e *PulsarTracesProducer) marshal(td ptrace.Traces, topic string) ([]*pulsar.ProducerMessage, error) {
+	if !e.cfg.PartitionTracesByID {
+		return e.marshaler.Marshal(td, topic)
+	}
+	var messages []*pulsar.ProducerMessage
+	for _, td := range batchpersignal.SplitTraces(td) {
+		traceMessages, err := e.marshaler.Marshal(td, topic)
+		if err != nil {
+			return nil, err
+		}
+		// Note that batchpersignal.SplitTraces guarantees that each batch
+		// has exactly one trace, and by implication, at least one span.
+		key := traceutil.TraceIDToHexOrEmptyString(
+			td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).TraceID(),
+		)
+		for _, message := range traceMessages {
+			message.Key = key
+		}
+		messages = append(messages, traceMessages...)
+	}
+	return messages, nil
+}
+
 func (e *PulsarTracesProducer) Close(context.Context) error {
 	if e.producer == nil {
 		return nil
 	}
+	e.topicProducers.close()
 	e.producer.Close()
 	e.client.Close()
 	return nil
 }
 
-func (e *PulsarTracesProducer) start(_ context.Context, _ component.Host) error {
+func (e *PulsarTracesProducer) start(_ context.Context, host component.Host) error {
+	marshaler, err := getTracesMarshaler(e.cfg.Encoding, host, e.marshalers)
+	if err != nil {
+		return err
+	}
+	e.marshaler = marshaler
+
 	client, producer, err := newPulsarProducer(e.cfg)
 	if err != nil {
 		return err
@@ -67,23 +116,34 @@ func (e *PulsarTracesProducer) start(_ context.Context, _ component.Host) error
 }
 
 type PulsarMetricsProducer struct {
-	cfg       Config
-	client    pulsar.Client
-	producer  pulsar.Producer
-	topic     string
-	marshaler MetricsMarshaler
-	logger    *zap.Logger
+	cfg            Config
+	client         pulsar.Client
+	producer       pulsar.Producer
+	topicProducers topicProducers
+	topic          string
+	marshaler      MetricsMarshaler
+	marshalers     map[string]MetricsMarshaler
+	logger         *zap.Logger
 }
 
 func (e *PulsarMetricsProducer) metricsDataPusher(ctx context.Context, md pmetric.Metrics) error {
-	messages, err := e.marshaler.Marshal(md, e.topic)
+	topic := getTopic[pmetric.ResourceMetrics](e.topic, e.cfg.TopicFromAttribute, md.ResourceMetrics())
+	producer := e.producer
+	if topic != e.topic {
+		var err error
+		if producer, err = e.topicProducers.get(e.client, e.cfg, topic); err != nil {
+			return err
+		}
+	}
+
+	messages, err := e.marshaler.Marshal(md, topic)
 	if err != nil {
 		return consumererror.NewPermanent(err)
 	}
 
 	var errs error
 	for _, message := range messages {
-		e.producer.SendAsync(ctx, message, func(_ pulsar.MessageID, _ *pulsar.ProducerMessage, err error) {
+		producer.SendAsync(ctx, message, func(_ pulsar.MessageID, _ *pulsar.ProducerMessage, err error) {
 			if err != nil {
 				errs = multierr.Append(errs, err)
 			}
@@ -97,12 +157,19 @@ func (e *PulsarMetricsProducer) Close(context.Context) error {
 	if e.producer == nil {
 		return nil
 	}
+	e.topicProducers.close()
 	e.producer.Close()
 	e.client.Close()
 	return nil
 }
 
-func (e *PulsarMetricsProducer) start(_ context.Context, _ component.Host) error {
+func (e *PulsarMetricsProducer) start(_ context.Context, host component.Host) error {
+	marshaler, err := getMetricsMarshaler(e.cfg.Encoding, host, e.marshalers)
+	if err != nil {
+		return err
+	}
+	e.marshaler = marshaler
+
 	client, producer, err := newPulsarProducer(e.cfg)
 	if err != nil {
 		return err
@@ -113,23 +180,34 @@ func (e *PulsarMetricsProducer) start(_ context.Context, _ component.Host) error
 }
 
 type PulsarLogsProducer struct {
-	cfg       Config
-	client    pulsar.Client
-	producer  pulsar.Producer
-	topic     string
-	marshaler LogsMarshaler
-	logger    *zap.Logger
+	cfg            Config
+	client         pulsar.Client
+	producer       pulsar.Producer
+	topicProducers topicProducers
+	topic          string
+	marshaler      LogsMarshaler
+	marshalers     map[string]LogsMarshaler
+	logger         *zap.Logger
 }
 
 func (e *PulsarLogsProducer) logsDataPusher(ctx context.Context, ld plog.Logs) error {
-	messages, err := e.marshaler.Marshal(ld, e.topic)
+	topic := getTopic[plog.ResourceLogs](e.topic, e.cfg.TopicFromAttribute, ld.ResourceLogs())
+	producer := e.producer
+	if topic != e.topic {
+		var err error
+		if producer, err = e.topicProducers.get(e.client, e.cfg, topic); err != nil {
+			return err
+		}
+	}
+
+	messages, err := e.marshaler.Marshal(ld, topic)
 	if err != nil {
 		return consumererror.NewPermanent(err)
 	}
 
 	var errs error
 	for _, message := range messages {
-		e.producer.SendAsync(ctx, message, func(_ pulsar.MessageID, _ *pulsar.ProducerMessage, err error) {
+		producer.SendAsync(ctx, message, func(_ pulsar.MessageID, _ *pulsar.ProducerMessage, err error) {
 			if err != nil {
 				errs = multierr.Append(errs, err)
 			}
@@ -143,12 +221,19 @@ func (e *PulsarLogsProducer) Close(context.Context) error {
 	if e.producer == nil {
 		return nil
 	}
+	e.topicProducers.close()
 	e.producer.Close()
 	e.client.Close()
 	return nil
 }
 
-func (e *PulsarLogsProducer) start(_ context.Context, _ component.Host) error {
+func (e *PulsarLogsProducer) start(_ context.Context, host component.Host) error {
+	marshaler, err := getLogsMarshaler(e.cfg.Encoding, host, e.marshalers)
+	if err != nil {
+		return err
+	}
+	e.marshaler = marshaler
+
 	client, producer, err := newPulsarProducer(e.cfg)
 	if err != nil {
 		return err
@@ -176,43 +261,103 @@ func newPulsarProducer(config Config) (pulsar.Client, pulsar.Producer, error) {
 	return client, producer, nil
 }
 
-func newMetricsExporter(config Config, set exporter.Settings, marshalers map[string]MetricsMarshaler) (*PulsarMetricsProducer, error) {
-	marshaler := marshalers[config.Encoding]
-	if marshaler == nil {
-		return nil, errUnrecognizedEncoding
+func newMetricsExporter(config Config, set exporter.Settings, marshalers map[string]MetricsMarshaler) *PulsarMetricsProducer {
+	return &PulsarMetricsProducer{
+		cfg:        config,
+		topic:      config.Topic,
+		marshalers: marshalers,
+		logger:     set.Logger,
 	}
+}
 
-	return &PulsarMetricsProducer{
-		cfg:       config,
-		topic:     config.Topic,
-		marshaler: marshaler,
-		logger:    set.Logger,
-	}, nil
+func newTracesExporter(config Config, set exporter.Settings, marshalers map[string]TracesMarshaler) *PulsarTracesProducer {
+	return &PulsarTracesProducer{
+		cfg:        config,
+		topic:      config.Topic,
+		marshalers: marshalers,
+		logger:     set.Logger,
+	}
 }
 
-func newTracesExporter(config Config, set exporter.Settings, marshalers map[string]TracesMarshaler) (*PulsarTracesProducer, error) {
-	marshaler := marshalers[config.Encoding]
-	if marshaler == nil {
-		return nil, errUnrecognizedEncoding
+func newLogsExporter(config Config, set exporter.Settings, marshalers map[string]LogsMarshaler) *PulsarLogsProducer {
+	return &PulsarLogsProducer{
+		cfg:        config,
+		topic:      config.Topic,
+		marshalers: marshalers,
+		logger:     set.Logger,
 	}
-	return &PulsarTracesProducer{
-		cfg:       config,
-		topic:     config.Topic,
-		marshaler: marshaler,
-		logger:    set.Logger,
-	}, nil
 }
 
-func newLogsExporter(config Config, set exporter.Settings, marshalers map[string]LogsMarshaler) (*PulsarLogsProducer, error) {
-	marshaler := marshalers[config.Encoding]
-	if marshaler == nil {
-		return nil, errUnrecognizedEncoding
+// topicProducers holds the producers of the topics taken from the
+// topic_from_attribute resource attribute, created on first use. At most
+// cfg.MaxTopicProducers are kept open: when a new one is needed, the least
+// recently used producer is closed, which flushes its pending messages.
+type topicProducers struct {
+	mu        sync.Mutex
+	producers map[string]*list.Element
+	// lru holds the producers, most recently used first.
+	lru list.List
+}
+
+type topicProducer struct {
+	topic    string
+	producer pulsar.Producer
+}
+
+func (p *topicProducers) get(client pulsar.Client, cfg Config, topic string) (pulsar.Producer, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if elem, ok := p.producers[topic]; ok {
+		p.lru.MoveToFront(elem)
+		return elem.Value.(*topicProducer).producer, nil
+	}
+	cfg.Topic = topic
+	producer, err := client.CreateProducer(cfg.getProducerOptions())
+	if err != nil {
+		return nil, err
+	}
+	if p.producers == nil {
+		p.producers = make(map[string]*list.Element)
+	}
+	for p.lru.Len() > 0 && p.lru.Len() >= cfg.MaxTopicProducers {
+		evicted := p.lru.Remove(p.lru.Back()).(*topicProducer)
+		delete(p.producers, evicted.topic)
+		evicted.producer.Close()
+	}
+	p.producers[topic] = p.lru.PushFront(&topicProducer{topic: topic, producer: producer})
+	return producer, nil
+}
+
+func (p *topicProducers) close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for elem := p.lru.Front(); elem != nil; elem = elem.Next() {
+		elem.Value.(*topicProducer).producer.Close()
 	}
+	p.producers = nil
+	p.lru.Init()
+}
 
-	return &PulsarLogsProducer{
-		cfg:       config,
-		topic:     config.Topic,
-		marshaler: marshaler,
-		logger:    set.Logger,
-	}, nil
+type resource interface {
+	Resource() pcommon.Resource
+}
+
+type resourceSlice[T resource] interface {
+	Len() int
+	At(int) T
+}
+
+// getTopic returns the value of the topicFromAttribute attribute of the first
+// resource that has it, or the given topic if no resource has it.
+func getTopic[T resource](topic, topicFromAttribute string, resources resourceSlice[T]) string {
+	if topicFromAttribute == "" {
+		return topic
+	}
+	for i := 0; i < resources.Len(); i++ {
+		rv, ok := resources.At(i).Resource().Attributes().Get(topicFromAttribute)
+		if ok && rv.Str() != "" {
+			return rv.Str()
+		}
+	}
+	return topic
 }
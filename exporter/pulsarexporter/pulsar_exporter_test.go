@@ -10,40 +10,44 @@ import (
 
 	"github.com/apache/pulsar-client-go/pulsar"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/pulsarexporter/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/testdata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/traceutil"
 )
 
 func TestNewMetricsExporter_err_encoding(t *testing.T) {
 	c := Config{Encoding: "bar"}
-	mexp, err := newMetricsExporter(c, exportertest.NewNopSettings(metadata.Type), metricsMarshalers())
+	mexp := newMetricsExporter(c, exportertest.NewNopSettings(metadata.Type), metricsMarshalers())
+	err := mexp.start(t.Context(), componenttest.NewNopHost())
 	assert.EqualError(t, err, errUnrecognizedEncoding.Error())
-	assert.Nil(t, mexp)
 }
 
 func TestNewMetricsExporter_err_traces_encoding(t *testing.T) {
 	c := Config{Encoding: "jaeger_proto"}
-	mexp, err := newMetricsExporter(c, exportertest.NewNopSettings(metadata.Type), metricsMarshalers())
+	mexp := newMetricsExporter(c, exportertest.NewNopSettings(metadata.Type), metricsMarshalers())
+	err := mexp.start(t.Context(), componenttest.NewNopHost())
 	assert.EqualError(t, err, errUnrecognizedEncoding.Error())
-	assert.Nil(t, mexp)
 }
 
 func TestNewLogsExporter_err_encoding(t *testing.T) {
 	c := Config{Encoding: "bar"}
-	mexp, err := newLogsExporter(c, exportertest.NewNopSettings(metadata.Type), logsMarshalers())
+	mexp := newLogsExporter(c, exportertest.NewNopSettings(metadata.Type), logsMarshalers())
+	err := mexp.start(t.Context(), componenttest.NewNopHost())
 	assert.EqualError(t, err, errUnrecognizedEncoding.Error())
-	assert.Nil(t, mexp)
 }
 
 func TestNewLogsExporter_err_traces_encoding(t *testing.T) {
 	c := Config{Encoding: "jaeger_proto"}
-	mexp, err := newLogsExporter(c, exportertest.NewNopSettings(metadata.Type), logsMarshalers())
+	mexp := newLogsExporter(c, exportertest.NewNopSettings(metadata.Type), logsMarshalers())
+	err := mexp.start(t.Context(), componenttest.NewNopHost())
 	assert.EqualError(t, err, errUnrecognizedEncoding.Error())
-	assert.Nil(t, mexp)
 }
 
 func Test_tracerPublisher(t *testing.T) {
@@ -63,6 +67,99 @@ func Test_tracerPublisher_marshaler_err(t *testing.T) {
 	assert.True(t, consumererror.IsPermanent(err))
 }
 
+func Test_tracerPublisher_topicFromAttribute(t *testing.T) {
+	mProducer := &mockProducer{name: "producer1", topic: "default"}
+	mClient := &mockClient{producers: map[string]*mockProducer{}}
+	producer := PulsarTracesProducer{
+		cfg:       Config{TopicFromAttribute: "pulsar_topic"},
+		client:    mClient,
+		producer:  mProducer,
+		topic:     "default",
+		marshaler: tracesMarshalers()["otlp_proto"],
+	}
+
+	td := testdata.GenerateTracesManySpansSameResource(10)
+	require.NoError(t, producer.tracesPusher(t.Context(), td))
+	assert.Len(t, mProducer.messages, 1)
+
+	td.ResourceSpans().At(0).Resource().Attributes().PutStr("pulsar_topic", "custom")
+	require.NoError(t, producer.tracesPusher(t.Context(), td))
+	require.NoError(t, producer.tracesPusher(t.Context(), td))
+	assert.Len(t, mProducer.messages, 1)
+	require.Len(t, mClient.producers, 1)
+	assert.Len(t, mClient.producers["custom"].messages, 2)
+
+	require.NoError(t, producer.Close(t.Context()))
+	assert.True(t, mClient.producers["custom"].closed)
+}
+
+func Test_tracerPublisher_maxTopicProducers(t *testing.T) {
+	mClient := &mockClient{producers: map[string]*mockProducer{}}
+	producer := PulsarTracesProducer{
+		cfg:       Config{TopicFromAttribute: "pulsar_topic", MaxTopicProducers: 2},
+		client:    mClient,
+		producer:  &mockProducer{name: "producer1", topic: "default"},
+		topic:     "default",
+		marshaler: tracesMarshalers()["otlp_proto"],
+	}
+
+	push := func(topic string) {
+		td := testdata.GenerateTracesManySpansSameResource(1)
+		td.ResourceSpans().At(0).Resource().Attributes().PutStr("pulsar_topic", topic)
+		require.NoError(t, producer.tracesPusher(t.Context(), td))
+	}
+	push("a")
+	push("b")
+	push("a")
+	// The least recently used producer is closed to make room for c.
+	push("c")
+	require.Len(t, mClient.producers, 3)
+	assert.False(t, mClient.producers["a"].closed)
+	assert.True(t, mClient.producers["b"].closed)
+	assert.False(t, mClient.producers["c"].closed)
+
+	// A producer is created again for an evicted topic.
+	push("b")
+	assert.False(t, mClient.producers["b"].closed)
+	assert.True(t, mClient.producers["a"].closed)
+	assert.Len(t, mClient.producers["b"].messages, 1)
+
+	require.NoError(t, producer.Close(t.Context()))
+	assert.True(t, mClient.producers["b"].closed)
+	assert.True(t, mClient.producers["c"].closed)
+}
+
+func Test_tracerPublisher_partitionTracesByID(t *testing.T) {
+	td := ptrace.NewTraces()
+	spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	traceID1 := pcommon.TraceID{1}
+	traceID2 := pcommon.TraceID{2}
+	spans.AppendEmpty().SetTraceID(traceID1)
+	spans.AppendEmpty().SetTraceID(traceID2)
+	spans.AppendEmpty().SetTraceID(traceID1)
+
+	mProducer := &mockProducer{name: "producer1", topic: "default"}
+	producer := PulsarTracesProducer{
+		cfg:       Config{PartitionTracesByID: true},
+		producer:  mProducer,
+		marshaler: tracesMarshalers()["otlp_proto"],
+	}
+	require.NoError(t, producer.tracesPusher(t.Context(), td))
+
+	require.Len(t, mProducer.messages, 2)
+	unmarshaler := &ptrace.ProtoUnmarshaler{}
+	for _, message := range mProducer.messages {
+		traces, err := unmarshaler.UnmarshalTraces(message.Payload)
+		require.NoError(t, err)
+		spans := traces.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+		for i := 0; i < spans.Len(); i++ {
+			assert.Equal(t, traceutil.TraceIDToHexOrEmptyString(spans.At(i).TraceID()), message.Key)
+		}
+	}
+	assert.Equal(t, traceutil.TraceIDToHexOrEmptyString(traceID1), mProducer.messages[0].Key)
+	assert.Equal(t, traceutil.TraceIDToHexOrEmptyString(traceID2), mProducer.messages[1].Key)
+}
+
 type customTraceMarshaler struct {
 	encoding string
 }
@@ -75,9 +172,24 @@ func (c *customTraceMarshaler) Encoding() string {
 	return c.encoding
 }
 
+type mockClient struct {
+	pulsar.Client
+	producers map[string]*mockProducer
+}
+
+func (c *mockClient) CreateProducer(options pulsar.ProducerOptions) (pulsar.Producer, error) {
+	producer := &mockProducer{name: options.Topic, topic: options.Topic}
+	c.producers[options.Topic] = producer
+	return producer, nil
+}
+
+func (*mockClient) Close() {}
+
 type mockProducer struct {
-	topic string
-	name  string
+	topic    string
+	name     string
+	messages []*pulsar.ProducerMessage
+	closed   bool
 }
 
 var _ pulsar.Producer = (*mockProducer)(nil)
@@ -94,7 +206,8 @@ func (*mockProducer) Send(context.Context, *pulsar.ProducerMessage) (pulsar.Mess
 	return nil, nil
 }
 
-func (*mockProducer) SendAsync(context.Context, *pulsar.ProducerMessage, func(pulsar.MessageID, *pulsar.ProducerMessage, error)) {
+func (c *mockProducer) SendAsync(_ context.Context, message *pulsar.ProducerMessage, _ func(pulsar.MessageID, *pulsar.ProducerMessage, error)) {
+	c.messages = append(c.messages, message)
 }
 
 func (*mockProducer) LastSequenceID() int64 {
@@ -109,5 +222,6 @@ func (*mockProducer) FlushWithCtx(context.Context) error {
 	return nil
 }
 
-func (*mockProducer) Close() {
+func (c *mockProducer) Close() {
+	c.closed = true
 }
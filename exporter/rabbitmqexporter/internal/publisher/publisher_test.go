@@ -361,6 +361,16 @@ func (m *mockChannel) PublishWithDeferredConfirmWithContext(ctx context.Context,
 	return nil, args.Error(1)
 }
 
+func (m *mockChannel) Qos(prefetchCount, prefetchSize int, global bool) error {
+	args := m.Called(prefetchCount, prefetchSize, global)
+	return args.Error(0)
+}
+
+func (m *mockChannel) ConsumeWithContext(ctx context.Context, queue, consumer string, autoAck, exclusive, noLocal, noWait bool, table amqp.Table) (<-chan amqp.Delivery, error) {
+	args := m.Called(ctx, queue, consumer, autoAck, exclusive, noLocal, noWait, table)
+	return args.Get(0).(chan amqp.Delivery), args.Error(1)
+}
+
 func (m *mockChannel) IsClosed() bool {
 	args := m.Called()
 	return args.Bool(0)
@@ -0,0 +1,252 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ackextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/ackextension"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const (
+	// AckIDAttribute is the resource attribute holding the ack ID of the data
+	// of a resource. Unlike client metadata, resource attributes are kept when
+	// the data of several events is batched together.
+	AckIDAttribute = "ack.id"
+	// PartitionIDAttribute is the resource attribute holding the partition ID
+	// of the AckIDAttribute.
+	PartitionIDAttribute = "ack.partition_id"
+)
+
+const (
+	minQueryInterval = time.Millisecond
+	maxQueryInterval = 100 * time.Millisecond
+)
+
+// ResourceAck identifies the data of a resource to acknowledge.
+type ResourceAck struct {
+	PartitionID string
+	AckID       uint64
+}
+
+// MarkResource registers the data of res as a new event of the EndToEnd
+// consuming ctx, if any, and sets the partition ID and ack ID of the event as
+// the PartitionIDAttribute and AckIDAttribute of res. The EndToEnd waits for
+// the events of all the resources marked while consuming to be acknowledged.
+func MarkResource(ctx context.Context, res pcommon.Resource) {
+	t, ok := ctx.Value(trackerKey{}).(*tracker)
+	if !ok {
+		return
+	}
+	ackID := t.ext.ProcessEvent(t.partitionID)
+	t.mu.Lock()
+	t.ackIDs = append(t.ackIDs, ackID)
+	t.mu.Unlock()
+	res.Attributes().PutStr(PartitionIDAttribute, t.partitionID)
+	res.Attributes().PutStr(AckIDAttribute, strconv.FormatUint(ackID, 10))
+}
+
+// MarkLogs marks the resources of ld with MarkResource.
+func MarkLogs(ctx context.Context, ld plog.Logs) {
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		MarkResource(ctx, ld.ResourceLogs().At(i).Resource())
+	}
+}
+
+// MarkMetrics marks the resources of md with MarkResource.
+func MarkMetrics(ctx context.Context, md pmetric.Metrics) {
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		MarkResource(ctx, md.ResourceMetrics().At(i).Resource())
+	}
+}
+
+// MarkTraces marks the resources of td with MarkResource.
+func MarkTraces(ctx context.Context, td ptrace.Traces) {
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		MarkResource(ctx, td.ResourceSpans().At(i).Resource())
+	}
+}
+
+// TakeLogsAcks removes the ack attributes from the resources of ld, and
+// returns the distinct acks they held.
+func TakeLogsAcks(ld plog.Logs) []ResourceAck {
+	var acks ackSet
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		acks.take(ld.ResourceLogs().At(i).Resource().Attributes())
+	}
+	return acks.list
+}
+
+// TakeMetricsAcks removes the ack attributes from the resources of md, and
+// returns the distinct acks they held.
+func TakeMetricsAcks(md pmetric.Metrics) []ResourceAck {
+	var acks ackSet
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		acks.take(md.ResourceMetrics().At(i).Resource().Attributes())
+	}
+	return acks.list
+}
+
+// TakeTracesAcks removes the ack attributes from the resources of td, and
+// returns the distinct acks they held.
+func TakeTracesAcks(td ptrace.Traces) []ResourceAck {
+	var acks ackSet
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		acks.take(td.ResourceSpans().At(i).Resource().Attributes())
+	}
+	return acks.list
+}
+
+// AckResources acknowledges acks with ext.
+func AckResources(ext AckExtension, acks []ResourceAck) {
+	for _, ack := range acks {
+		ext.Ack(ack.PartitionID, ack.AckID)
+	}
+}
+
+type ackSet struct {
+	seen map[ResourceAck]struct{}
+	list []ResourceAck
+}
+
+func (s *ackSet) take(attrs pcommon.Map) {
+	partitionID, hasPartitionID := attrs.Get(PartitionIDAttribute)
+	ackID, hasAckID := attrs.Get(AckIDAttribute)
+	attrs.Remove(PartitionIDAttribute)
+	attrs.Remove(AckIDAttribute)
+	if !hasPartitionID || !hasAckID {
+		return
+	}
+	id, err := strconv.ParseUint(ackID.AsString(), 10, 64)
+	if err != nil {
+		return
+	}
+	ack := ResourceAck{PartitionID: partitionID.AsString(), AckID: id}
+	if _, ok := s.seen[ack]; ok {
+		return
+	}
+	if s.seen == nil {
+		s.seen = map[ResourceAck]struct{}{}
+	}
+	s.seen[ack] = struct{}{}
+	s.list = append(s.list, ack)
+}
+
+// EndToEndConfig configures a receiver to defer acknowledging data to its
+// source until the data has been acknowledged at the end of its pipelines,
+// i.e. by the ack processor.
+type EndToEndConfig struct {
+	// Extension holds the ID of the ack extension tracking the acks. End-to-end
+	// acknowledgement is disabled if not set.
+	Extension *component.ID `mapstructure:"extension"`
+
+	// Timeout is the time to wait for data to be acknowledged, after which
+	// consuming the data is considered failed.
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// Validate checks the end-to-end acknowledgement configuration is valid.
+func (cfg *EndToEndConfig) Validate() error {
+	if cfg.Extension != nil && cfg.Timeout <= 0 {
+		return errors.New("timeout must be positive")
+	}
+	return nil
+}
+
+// EndToEnd tracks the end-to-end acknowledgement of the data consumed by a
+// receiver.
+type EndToEnd struct {
+	ext         AckExtension
+	partitionID string
+	timeout     time.Duration
+}
+
+// NewEndToEnd returns an EndToEnd tracking the acks of the receiver id with the
+// ack extension configured by cfg, or nil if cfg does not enable end-to-end
+// acknowledgement. It fails if a pipeline of the receiver has no ack
+// processor, as the ack attributes of its data would not be removed.
+func NewEndToEnd(host component.Host, cfg EndToEndConfig, id component.ID) (*EndToEnd, error) {
+	if cfg.Extension == nil {
+		return nil, nil
+	}
+	ext, found := host.GetExtensions()[*cfg.Extension]
+	if !found {
+		return nil, fmt.Errorf("specified ack extension with id %q could not be found", *cfg.Extension)
+	}
+	ackExt, ok := ext.(AckExtension)
+	if !ok {
+		return nil, fmt.Errorf("extension with id %q is not an ack extension", *cfg.Extension)
+	}
+	if p, ok := ext.(pipelinesWatcher); ok {
+		if err := p.checkPipelines(id); err != nil {
+			return nil, err
+		}
+	}
+	return &EndToEnd{ext: ackExt, partitionID: id.String(), timeout: cfg.Timeout}, nil
+}
+
+// Consume calls consume with a context in which MarkResource registers the
+// resources of the consumed data as events, and waits for all these events to
+// be acknowledged. A nil EndToEnd only calls consume.
+func (e *EndToEnd) Consume(ctx context.Context, consume func(context.Context) error) error {
+	if e == nil {
+		return consume(ctx)
+	}
+	t := &tracker{ext: e.ext, partitionID: e.partitionID}
+	if err := consume(context.WithValue(ctx, trackerKey{}, t)); err != nil {
+		return err
+	}
+	t.mu.Lock()
+	ackIDs := t.ackIDs
+	t.mu.Unlock()
+	return e.wait(ctx, ackIDs)
+}
+
+func (e *EndToEnd) wait(ctx context.Context, ackIDs []uint64) error {
+	timer := time.NewTimer(e.timeout)
+	defer timer.Stop()
+	interval := minQueryInterval
+	for {
+		// acked IDs are removed from the extension, so they are not queried again
+		pending := ackIDs[:0]
+		for ackID, acked := range e.ext.QueryAcks(e.partitionID, ackIDs) {
+			if !acked {
+				pending = append(pending, ackID)
+			}
+		}
+		if ackIDs = pending; len(ackIDs) == 0 {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+			return fmt.Errorf("data of %d resources was not acknowledged within %s", len(ackIDs), e.timeout)
+		case <-time.After(interval):
+		}
+		interval = min(2*interval, maxQueryInterval)
+	}
+}
+
+// trackerKey is the context key of the tracker of an EndToEnd.Consume call.
+type trackerKey struct{}
+
+// tracker holds the ack IDs of the resources marked during an
+// EndToEnd.Consume call.
+type tracker struct {
+	ext         AckExtension
+	partitionID string
+
+	mu     sync.Mutex
+	ackIDs []uint64
+}
@@ -0,0 +1,188 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ackextension
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+type extensionsHost map[component.ID]component.Component
+
+func (h extensionsHost) GetExtensions() map[component.ID]component.Component {
+	return h
+}
+
+var testExtensionID = component.MustNewID("ack")
+
+var testReceiverID = component.MustNewID("receiver")
+
+func newTestEndToEnd(t *testing.T, timeout time.Duration) (*EndToEnd, *inMemoryAckExtension) {
+	ext := newInMemoryAckExtension(createDefaultConfig().(*Config))
+	e, err := NewEndToEnd(extensionsHost{testExtensionID: ext}, EndToEndConfig{
+		Extension: &testExtensionID,
+		Timeout:   timeout,
+	}, testReceiverID)
+	require.NoError(t, err)
+	require.NotNil(t, e)
+	return e, ext
+}
+
+func TestMarkAndTakeAcks(t *testing.T) {
+	e, _ := newTestEndToEnd(t, time.Minute)
+	var ld, other plog.Logs
+	var md pmetric.Metrics
+	var td ptrace.Traces
+	// the data is never acknowledged, consuming it only marks it
+	_ = e.Consume(context.Background(), func(ctx context.Context) error {
+		ld = plog.NewLogs()
+		ld.ResourceLogs().AppendEmpty()
+		MarkLogs(ctx, ld)
+		other = plog.NewLogs()
+		other.ResourceLogs().AppendEmpty()
+		other.ResourceLogs().AppendEmpty()
+		MarkLogs(ctx, other)
+		md = pmetric.NewMetrics()
+		md.ResourceMetrics().AppendEmpty()
+		MarkMetrics(ctx, md)
+		td = ptrace.NewTraces()
+		td.ResourceSpans().AppendEmpty()
+		MarkTraces(ctx, td)
+		return errors.New("not acknowledged")
+	})
+	unmarked := plog.NewLogs()
+	unmarked.ResourceLogs().AppendEmpty()
+	MarkLogs(context.Background(), unmarked)
+	other.ResourceLogs().MoveAndAppendTo(ld.ResourceLogs())
+	unmarked.ResourceLogs().MoveAndAppendTo(ld.ResourceLogs())
+
+	// each resource has its own ack ID
+	assert.Equal(t, []ResourceAck{
+		{PartitionID: "receiver", AckID: 1},
+		{PartitionID: "receiver", AckID: 2},
+		{PartitionID: "receiver", AckID: 3},
+	}, TakeLogsAcks(ld))
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		assert.Equal(t, 0, ld.ResourceLogs().At(i).Resource().Attributes().Len())
+	}
+	assert.Empty(t, TakeLogsAcks(ld))
+	assert.Equal(t, []ResourceAck{{PartitionID: "receiver", AckID: 4}}, TakeMetricsAcks(md))
+	assert.Equal(t, []ResourceAck{{PartitionID: "receiver", AckID: 5}}, TakeTracesAcks(td))
+}
+
+func TestAckResources(t *testing.T) {
+	ext := newInMemoryAckExtension(createDefaultConfig().(*Config))
+	first := ext.ProcessEvent("a")
+	second := ext.ProcessEvent("b")
+	AckResources(ext, []ResourceAck{{PartitionID: "a", AckID: first}, {PartitionID: "b", AckID: second}})
+	assert.Equal(t, map[uint64]bool{first: true}, ext.QueryAcks("a", []uint64{first}))
+	assert.Equal(t, map[uint64]bool{second: true}, ext.QueryAcks("b", []uint64{second}))
+}
+
+func TestEndToEndConfigValidate(t *testing.T) {
+	assert.NoError(t, (&EndToEndConfig{}).Validate())
+	assert.NoError(t, (&EndToEndConfig{Extension: &testExtensionID, Timeout: time.Second}).Validate())
+	assert.EqualError(t, (&EndToEndConfig{Extension: &testExtensionID}).Validate(), "timeout must be positive")
+}
+
+func TestNewEndToEnd(t *testing.T) {
+	e, err := NewEndToEnd(componenttest.NewNopHost(), EndToEndConfig{}, testReceiverID)
+	require.NoError(t, err)
+	assert.Nil(t, e)
+
+	_, err = NewEndToEnd(componenttest.NewNopHost(), EndToEndConfig{Extension: &testExtensionID}, testReceiverID)
+	assert.EqualError(t, err, `specified ack extension with id "ack" could not be found`)
+
+	host := extensionsHost{testExtensionID: struct {
+		component.StartFunc
+		component.ShutdownFunc
+	}{}}
+	_, err = NewEndToEnd(host, EndToEndConfig{Extension: &testExtensionID}, testReceiverID)
+	assert.EqualError(t, err, `extension with id "ack" is not an ack extension`)
+}
+
+func TestEndToEndConsume(t *testing.T) {
+	e, ext := newTestEndToEnd(t, time.Minute)
+	var acked atomic.Int64
+	err := e.Consume(context.Background(), func(ctx context.Context) error {
+		ld := plog.NewLogs()
+		ld.ResourceLogs().AppendEmpty()
+		ld.ResourceLogs().AppendEmpty()
+		MarkLogs(ctx, ld)
+		// the resources are acknowledged separately, e.g. by different
+		// batches, and consuming waits for both
+		for _, ack := range TakeLogsAcks(ld) {
+			go func() {
+				time.Sleep(10 * time.Millisecond * time.Duration(ack.AckID))
+				acked.Add(1)
+				ext.Ack(ack.PartitionID, ack.AckID)
+			}()
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), acked.Load())
+}
+
+func TestEndToEndConsumeError(t *testing.T) {
+	e, _ := newTestEndToEnd(t, time.Minute)
+	err := e.Consume(context.Background(), func(context.Context) error {
+		return errors.New("consume failed")
+	})
+	assert.EqualError(t, err, "consume failed")
+}
+
+func TestEndToEndConsumeTimeout(t *testing.T) {
+	e, _ := newTestEndToEnd(t, 10*time.Millisecond)
+	err := e.Consume(context.Background(), func(ctx context.Context) error {
+		ld := plog.NewLogs()
+		ld.ResourceLogs().AppendEmpty()
+		MarkLogs(ctx, ld)
+		return nil
+	})
+	assert.EqualError(t, err, "data of 1 resources was not acknowledged within 10ms")
+
+	// consuming data without resources does not wait
+	assert.NoError(t, e.Consume(context.Background(), func(context.Context) error {
+		return nil
+	}))
+}
+
+func TestEndToEndConsumeCanceled(t *testing.T) {
+	e, _ := newTestEndToEnd(t, time.Minute)
+	ctx, cancel := context.WithCancel(context.Background())
+	err := e.Consume(ctx, func(ctx context.Context) error {
+		MarkResource(ctx, pcommon.NewResource())
+		cancel()
+		return nil
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestNilEndToEndConsume(t *testing.T) {
+	var e *EndToEnd
+	var consumed bool
+	err := e.Consume(context.Background(), func(ctx context.Context) error {
+		consumed = true
+		ld := plog.NewLogs()
+		ld.ResourceLogs().AppendEmpty()
+		MarkLogs(ctx, ld)
+		assert.Empty(t, TakeLogsAcks(ld))
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.True(t, consumed)
+}
@@ -9,12 +9,20 @@ import (
 
 	lru "github.com/hashicorp/golang-lru/v2"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/extensioncapabilities"
+)
+
+var (
+	_ extensioncapabilities.ConfigWatcher = (*inMemoryAckExtension)(nil)
+	_ pipelinesWatcher                    = (*inMemoryAckExtension)(nil)
 )
 
 // inMemoryAckExtension is the in-memory implementation of the AckExtension
 // When MaxNumPartition is reached, the acks associated with the least recently used partition are evicted.
 // When MaxNumPendingAcksPerPartition is reached, the least recently used ack is evicted
 type inMemoryAckExtension struct {
+	pipelines
+
 	partitionMap                  *lru.Cache[string, *ackPartition]
 	maxNumPendingAcksPerPartition uint64
 }
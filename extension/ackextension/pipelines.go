@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ackextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/ackextension"
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+)
+
+// ackProcessorType is the type of the ack processor, which removes the ack
+// attributes from the resources of the data of its pipeline.
+var ackProcessorType = component.MustNewType("ack")
+
+// pipelinesWatcher is implemented by the ack extensions which know the
+// pipelines of the collector.
+type pipelinesWatcher interface {
+	// checkPipelines returns an error if a pipeline the receiver id sends data
+	// to has no ack processor.
+	checkPipelines(id component.ID) error
+}
+
+// pipelinesConfig holds the receivers and processors of the pipelines of the
+// collector configuration.
+type pipelinesConfig struct {
+	Service struct {
+		Pipelines map[string]struct {
+			Receivers  []component.ID `mapstructure:"receivers"`
+			Processors []component.ID `mapstructure:"processors"`
+		} `mapstructure:"pipelines"`
+	} `mapstructure:"service"`
+}
+
+// pipelines keeps the pipelines of the collector configuration. It is
+// notified of the configuration before the receivers are started.
+type pipelines struct {
+	mu     sync.Mutex
+	config *pipelinesConfig
+}
+
+// NotifyConfig implements extensioncapabilities.ConfigWatcher.
+func (p *pipelines) NotifyConfig(_ context.Context, conf *confmap.Conf) error {
+	var config pipelinesConfig
+	if err := conf.Unmarshal(&config, confmap.WithIgnoreUnused()); err != nil {
+		return fmt.Errorf("failed to read the pipelines: %w", err)
+	}
+	p.mu.Lock()
+	p.config = &config
+	p.mu.Unlock()
+	return nil
+}
+
+func (p *pipelines) checkPipelines(id component.ID) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.config == nil {
+		return nil
+	}
+	for _, name := range slices.Sorted(maps.Keys(p.config.Service.Pipelines)) {
+		pipeline := p.config.Service.Pipelines[name]
+		if !slices.Contains(pipeline.Receivers, id) {
+			continue
+		}
+		if !slices.ContainsFunc(pipeline.Processors, func(processor component.ID) bool {
+			return processor.Type() == ackProcessorType
+		}) {
+			return fmt.Errorf("pipeline %q has no ack processor to remove the %q resource attributes set by %q", name, AckIDAttribute, id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ackextension
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+)
+
+func TestCheckPipelines(t *testing.T) {
+	ext := newInMemoryAckExtension(createDefaultConfig().(*Config))
+	host := extensionsHost{testExtensionID: ext}
+	cfg := EndToEndConfig{Extension: &testExtensionID, Timeout: time.Second}
+
+	// without the configuration, the pipelines are not checked
+	_, err := NewEndToEnd(host, cfg, testReceiverID)
+	require.NoError(t, err)
+
+	require.NoError(t, ext.NotifyConfig(t.Context(), confmap.NewFromStringMap(map[string]any{
+		"receivers": map[string]any{"receiver": nil},
+		"service": map[string]any{
+			"pipelines": map[string]any{
+				"logs": map[string]any{
+					"receivers":  []any{"receiver"},
+					"processors": []any{"batch", "ack/logs"},
+					"exporters":  []any{"otlp"},
+				},
+				"traces": map[string]any{
+					"receivers": []any{"receiver", "receiver/other"},
+					"exporters": []any{"otlp"},
+				},
+				"metrics": map[string]any{
+					"receivers": []any{"other"},
+					"exporters": []any{"otlp"},
+				},
+			},
+		},
+	})))
+
+	_, err = NewEndToEnd(host, cfg, testReceiverID)
+	assert.EqualError(t, err, `pipeline "traces" has no ack processor to remove the "ack.id" resource attributes set by "receiver"`)
+	_, err = NewEndToEnd(host, cfg, component.MustNewID("other"))
+	assert.ErrorContains(t, err, `pipeline "metrics" has no ack processor`)
+	_, err = NewEndToEnd(host, cfg, component.MustNewID("unused"))
+	assert.NoError(t, err)
+}
@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package schemaregistryencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/schemaregistryencodingextension"
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/linkedin/goavro/v2"
+)
+
+type avroCodec struct {
+	codec *goavro.Codec
+}
+
+func newAvroCodec(schema string) (codec, error) {
+	c, err := goavro.NewCodec(schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create avro codec: %w", err)
+	}
+	return avroCodec{codec: c}, nil
+}
+
+func (c avroCodec) decode(payload []byte) (any, error) {
+	native, _, err := c.codec.NativeFromBinary(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode avro record: %w", err)
+	}
+	return replaceLogicalTypes(native), nil
+}
+
+func (c avroCodec) appendEncoded(dst []byte, value any) ([]byte, error) {
+	dst, err := c.codec.BinaryFromNative(dst, value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode avro record: %w", err)
+	}
+	return dst, nil
+}
+
+// replaceLogicalTypes replaces the values of Avro logical types, which are
+// not supported by pcommon.Value.FromRaw, by their underlying values.
+func replaceLogicalTypes(value any) any {
+	switch v := value.(type) {
+	case time.Time:
+		return v.UnixNano()
+	case time.Duration:
+		return v.Nanoseconds()
+	case *big.Rat:
+		f, _ := v.Float64()
+		return f
+	case map[string]any:
+		for k, mv := range v {
+			v[k] = replaceLogicalTypes(mv)
+		}
+		return v
+	case []any:
+		for i, sv := range v {
+			v[i] = replaceLogicalTypes(sv)
+		}
+		return v
+	}
+	return value
+}
@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package schemaregistryencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/schemaregistryencodingextension"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/schemaregistryencodingextension/internal/schemaregistry"
+)
+
+// codec decodes and encodes the payload of records, following the wire format
+// header, with a registered schema.
+type codec interface {
+	// decode decodes payload into a value supported by pcommon.Value.FromRaw.
+	decode(payload []byte) (any, error)
+	// appendEncoded appends the payload encoding value to dst.
+	appendEncoded(dst []byte, value any) ([]byte, error)
+}
+
+// newCodec returns the codec of the schema with the given ID.
+func newCodec(ctx context.Context, client *schemaregistry.Client, id int, messageName string) (codec, error) {
+	schema, err := client.SchemaByID(ctx, id, "")
+	if err != nil {
+		return nil, err
+	}
+	switch schema.SchemaType() {
+	case schemaregistry.SchemaTypeAvro:
+		if len(schema.References) > 0 {
+			return nil, errors.New("avro schemas with references are not supported")
+		}
+		return newAvroCodec(schema.Schema)
+	case schemaregistry.SchemaTypeProtobuf:
+		// The text form of Protobuf schemas would need to be parsed, so
+		// the serialized FileDescriptorProto is requested instead.
+		schema, err = client.SchemaByID(ctx, id, schemaregistry.FormatSerialized)
+		if err != nil {
+			return nil, err
+		}
+		return newProtobufCodec(ctx, client, schema, messageName)
+	case schemaregistry.SchemaTypeJSON:
+		return jsonCodec{}, nil
+	}
+	return nil, fmt.Errorf("unsupported schema type %q", schema.Type)
+}
+
+// jsonCodec encodes records with a JSON schema, which are plain JSON.
+type jsonCodec struct{}
+
+func (jsonCodec) decode(payload []byte) (any, error) {
+	var value any
+	if err := json.Unmarshal(payload, &value); err != nil {
+		return nil, fmt.Errorf("failed to decode json record: %w", err)
+	}
+	return value, nil
+}
+
+func (jsonCodec) appendEncoded(dst []byte, value any) ([]byte, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode json record: %w", err)
+	}
+	return append(dst, data...), nil
+}
@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package schemaregistryencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/schemaregistryencodingextension"
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/config/confighttp"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/schemaregistryencodingextension/internal/schemaregistry"
+)
+
+type Config struct {
+	// ClientConfig configures the HTTP client of the Schema Registry,
+	// whose URL is the endpoint.
+	confighttp.ClientConfig `mapstructure:",squash"`
+
+	// Marshal configures the schema records are encoded with.
+	Marshal MarshalConfig `mapstructure:"marshal"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+type MarshalConfig struct {
+	// Subject is the subject of the schema.
+	Subject string `mapstructure:"subject"`
+
+	// SchemaType is the type of the schema: AVRO, PROTOBUF or JSON.
+	SchemaType string `mapstructure:"schema_type"`
+
+	// Schema is the schema records are encoded with. If empty, the
+	// latest version of the subject is used.
+	Schema string `mapstructure:"schema"`
+
+	// AutoRegister registers Schema under Subject if it isn't already.
+	// Otherwise Schema must already be registered.
+	AutoRegister bool `mapstructure:"auto_register"`
+
+	// MessageName is the fully qualified name of the message records are
+	// encoded with for Protobuf schemas. If empty, the first message of
+	// the schema is used.
+	MessageName string `mapstructure:"message_name"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+func (c *Config) Validate() error {
+	if c.Endpoint == "" {
+		return errors.New("endpoint must be specified")
+	}
+	switch schemaregistry.SchemaType(c.Marshal.SchemaType) {
+	case schemaregistry.SchemaTypeAvro, schemaregistry.SchemaTypeProtobuf, schemaregistry.SchemaTypeJSON:
+	default:
+		return fmt.Errorf("marshal::schema_type must be one of AVRO, PROTOBUF or JSON, got %q", c.Marshal.SchemaType)
+	}
+	if c.Marshal.Schema != "" && c.Marshal.Subject == "" {
+		return errors.New("marshal::subject must be specified when marshal::schema is specified")
+	}
+	if c.Marshal.MessageName != "" && c.Marshal.SchemaType != string(schemaregistry.SchemaTypeProtobuf) {
+		return errors.New("marshal::message_name is only supported for PROTOBUF schemas")
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package schemaregistryencodingextension
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/schemaregistryencodingextension/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id           component.ID
+		expected     func(*Config)
+		errorMessage string
+	}{
+		{
+			id: component.NewIDWithName(metadata.Type, ""),
+			expected: func(cfg *Config) {
+				cfg.Endpoint = "http://localhost:8081"
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "protobuf"),
+			expected: func(cfg *Config) {
+				cfg.Endpoint = "http://localhost:8081"
+				cfg.Timeout = 5 * time.Second
+				cfg.Marshal = MarshalConfig{
+					Subject:     "logs-value",
+					SchemaType:  "PROTOBUF",
+					MessageName: "example.Log",
+				}
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_schema_type"),
+			errorMessage: `marshal::schema_type must be one of AVRO, PROTOBUF or JSON, got "THRIFT"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cfg := NewFactory().CreateDefaultConfig().(*Config)
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			if tt.expected == nil {
+				assert.EqualError(t, xconfmap.Validate(cfg), tt.errorMessage)
+				return
+			}
+			assert.NoError(t, xconfmap.Validate(cfg))
+			expected := createDefaultConfig().(*Config)
+			tt.expected(expected)
+			assert.Equal(t, expected, cfg)
+		})
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name         string
+		modify       func(*Config)
+		errorMessage string
+	}{
+		{
+			name:         "missing endpoint",
+			modify:       func(cfg *Config) { cfg.Endpoint = "" },
+			errorMessage: "endpoint must be specified",
+		},
+		{
+			name: "schema without subject",
+			modify: func(cfg *Config) {
+				cfg.Marshal.Schema = `"string"`
+			},
+			errorMessage: "marshal::subject must be specified when marshal::schema is specified",
+		},
+		{
+			name: "message name for avro",
+			modify: func(cfg *Config) {
+				cfg.Marshal.MessageName = "example.Log"
+			},
+			errorMessage: "marshal::message_name is only supported for PROTOBUF schemas",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Endpoint = "http://localhost:8081"
+			tt.modify(cfg)
+			assert.EqualError(t, cfg.Validate(), tt.errorMessage)
+		})
+	}
+}
@@ -0,0 +1,190 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package schemaregistryencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/schemaregistryencodingextension"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/schemaregistryencodingextension/internal/schemaregistry"
+)
+
+var (
+	_ encoding.LogsMarshalerExtension   = (*schemaRegistryExtension)(nil)
+	_ encoding.LogsUnmarshalerExtension = (*schemaRegistryExtension)(nil)
+)
+
+type schemaRegistryExtension struct {
+	config   *Config
+	settings extension.Settings
+	client   *schemaregistry.Client
+
+	mu sync.Mutex
+	// decoders are the codecs of the schemas records were decoded with,
+	// by schema ID.
+	decoders map[int]codec
+	// encoder is the codec of the schema records are encoded with, which
+	// is resolved when the first logs are marshaled.
+	encoder   codec
+	encoderID int
+}
+
+func newExtension(config *Config, set extension.Settings) *schemaRegistryExtension {
+	return &schemaRegistryExtension{
+		config:   config,
+		settings: set,
+		decoders: make(map[int]codec),
+	}
+}
+
+func (e *schemaRegistryExtension) Start(ctx context.Context, host component.Host) error {
+	httpClient, err := e.config.ToClient(ctx, host, e.settings.TelemetrySettings)
+	if err != nil {
+		return err
+	}
+	e.client = schemaregistry.NewClient(e.config.Endpoint, httpClient)
+	return nil
+}
+
+func (*schemaRegistryExtension) Shutdown(context.Context) error {
+	return nil
+}
+
+func (e *schemaRegistryExtension) UnmarshalLogs(buf []byte) (plog.Logs, error) {
+	p := plog.NewLogs()
+
+	id, payload, err := schemaregistry.ParseHeader(buf)
+	if err != nil {
+		return p, err
+	}
+	decoder, err := e.decoder(id)
+	if err != nil {
+		return p, err
+	}
+	value, err := decoder.decode(payload)
+	if err != nil {
+		return p, err
+	}
+
+	logRecord := p.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	logRecord.SetObservedTimestamp(pcommon.NewTimestampFromTime(time.Now()))
+	if err := logRecord.Body().FromRaw(value); err != nil {
+		return p, err
+	}
+	return p, nil
+}
+
+// MarshalLogs encodes the body of the single log record of ld, as a record
+// holds a single value.
+func (e *schemaRegistryExtension) MarshalLogs(ld plog.Logs) ([]byte, error) {
+	if n := ld.LogRecordCount(); n != 1 {
+		return nil, fmt.Errorf("schema registry records hold a single log record, got %d", n)
+	}
+	records, err := e.MarshalLogRecords(ld)
+	if err != nil {
+		return nil, err
+	}
+	return records[0], nil
+}
+
+// MarshalLogRecords encodes the body of each log record of ld into its own
+// record. The Kafka exporter uses it to produce a message per log record.
+func (e *schemaRegistryExtension) MarshalLogRecords(ld plog.Logs) ([][]byte, error) {
+	encoder, id, err := e.getEncoder()
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([][]byte, 0, ld.LogRecordCount())
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		sls := rls.At(i).ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			logRecords := sls.At(j).LogRecords()
+			for k := 0; k < logRecords.Len(); k++ {
+				record, err := encoder.appendEncoded(
+					schemaregistry.AppendHeader(nil, id),
+					logRecords.At(k).Body().AsRaw(),
+				)
+				if err != nil {
+					return nil, err
+				}
+				records = append(records, record)
+			}
+		}
+	}
+	return records, nil
+}
+
+func (e *schemaRegistryExtension) decoder(id int) (codec, error) {
+	e.mu.Lock()
+	decoder, ok := e.decoders[id]
+	e.mu.Unlock()
+	if ok {
+		return decoder, nil
+	}
+
+	decoder, err := newCodec(context.Background(), e.client, id, "")
+	if err != nil {
+		return nil, err
+	}
+	e.mu.Lock()
+	e.decoders[id] = decoder
+	e.mu.Unlock()
+	return decoder, nil
+}
+
+// getEncoder returns the codec records are encoded with and the ID of its
+// schema. The schema is registered, or looked up, under the configured
+// subject the first time, and the latest version of the subject is used if
+// no schema is configured.
+func (e *schemaRegistryExtension) getEncoder() (codec, int, error) {
+	e.mu.Lock()
+	encoder, id := e.encoder, e.encoderID
+	e.mu.Unlock()
+	if encoder != nil {
+		return encoder, id, nil
+	}
+
+	ctx := context.Background()
+	cfg := e.config.Marshal
+	if cfg.Subject == "" {
+		return nil, 0, errors.New("marshal::subject must be specified to marshal logs")
+	}
+	schema := schemaregistry.Schema{
+		Type:   schemaregistry.SchemaType(cfg.SchemaType),
+		Schema: cfg.Schema,
+	}
+	var err error
+	switch {
+	case cfg.Schema == "":
+		schema, err = e.client.SchemaBySubject(ctx, cfg.Subject, "latest", "")
+		id = schema.ID
+	case cfg.AutoRegister:
+		id, err = e.client.Register(ctx, cfg.Subject, schema)
+	default:
+		id, err = e.client.Lookup(ctx, cfg.Subject, schema)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	encoder, err = newCodec(ctx, e.client, id, cfg.MessageName)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	e.mu.Lock()
+	e.encoder, e.encoderID = encoder, id
+	e.mu.Unlock()
+	return encoder, id, nil
+}
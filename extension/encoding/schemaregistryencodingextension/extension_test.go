@@ -0,0 +1,313 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package schemaregistryencodingextension
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/linkedin/goavro/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/schemaregistryencodingextension/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/schemaregistryencodingextension/internal/schemaregistry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/schemaregistryencodingextension/internal/schemaregistry/schemaregistrytest"
+)
+
+const avroSchema = `{
+  "type": "record",
+  "name": "Log",
+  "namespace": "example",
+  "fields": [
+    {"name": "message", "type": "string"},
+    {"name": "count", "type": "long"},
+    {"name": "timestamp", "type": {"type": "long", "logicalType": "timestamp-millis"}}
+  ]
+}`
+
+func newTestExtension(t *testing.T, server *schemaregistrytest.Server, marshal MarshalConfig) *schemaRegistryExtension {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = server.URL
+	cfg.Marshal = marshal
+	e := newExtension(cfg, extensiontest.NewNopSettings(metadata.Type))
+	require.NoError(t, e.Start(t.Context(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, e.Shutdown(t.Context())) })
+	return e
+}
+
+func newLogs(bodies ...map[string]any) plog.Logs {
+	logs := plog.NewLogs()
+	logRecords := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	for _, body := range bodies {
+		_ = logRecords.AppendEmpty().Body().SetEmptyMap().FromRaw(body)
+	}
+	return logs
+}
+
+func body(t *testing.T, logs plog.Logs) map[string]any {
+	require.Equal(t, 1, logs.LogRecordCount())
+	return logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().Map().AsRaw()
+}
+
+func TestUnmarshalAvro(t *testing.T) {
+	server := schemaregistrytest.NewServer(t)
+	id := server.Register("logs-value", schemaregistry.Schema{Schema: avroSchema}, "")
+	e := newTestExtension(t, server, MarshalConfig{})
+
+	codec, err := goavro.NewCodec(avroSchema)
+	require.NoError(t, err)
+	timestamp := time.UnixMilli(1697187201488)
+	data, err := codec.BinaryFromNative(schemaregistry.AppendHeader(nil, id), map[string]any{
+		"message":   "log message",
+		"count":     int64(5),
+		"timestamp": timestamp,
+	})
+	require.NoError(t, err)
+
+	logs, err := e.UnmarshalLogs(data)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"message":   "log message",
+		"count":     int64(5),
+		"timestamp": timestamp.UnixNano(),
+	}, body(t, logs))
+
+	// the schema is only fetched once
+	requests := server.Requests()
+	_, err = e.UnmarshalLogs(data)
+	require.NoError(t, err)
+	assert.Equal(t, requests, server.Requests())
+
+	_, err = e.UnmarshalLogs([]byte("not framed"))
+	assert.Error(t, err)
+	_, err = e.UnmarshalLogs(schemaregistry.AppendHeader(nil, 42))
+	assert.ErrorIs(t, err, schemaregistry.ErrNotFound)
+}
+
+func TestMarshalAvro(t *testing.T) {
+	const schema = `{"type": "record", "name": "Log", "fields": [{"name": "message", "type": "string"}]}`
+
+	t.Run("auto register", func(t *testing.T) {
+		server := schemaregistrytest.NewServer(t)
+		e := newTestExtension(t, server, MarshalConfig{
+			Subject:      "logs-value",
+			SchemaType:   "AVRO",
+			Schema:       schema,
+			AutoRegister: true,
+		})
+
+		records, err := e.MarshalLogRecords(newLogs(
+			map[string]any{"message": "first"},
+			map[string]any{"message": "second"},
+		))
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+		id, _, err := schemaregistry.ParseHeader(records[0])
+		require.NoError(t, err)
+		latest, err := e.client.SchemaBySubject(t.Context(), "logs-value", "latest", "")
+		require.NoError(t, err)
+		assert.Equal(t, latest.ID, id)
+
+		for i, message := range []string{"first", "second"} {
+			logs, err := e.UnmarshalLogs(records[i])
+			require.NoError(t, err)
+			assert.Equal(t, map[string]any{"message": message}, body(t, logs))
+		}
+
+		_, err = e.MarshalLogs(newLogs(map[string]any{"message": "first"}, map[string]any{"message": "second"}))
+		assert.ErrorContains(t, err, "schema registry records hold a single log record, got 2")
+		_, err = e.MarshalLogs(newLogs(map[string]any{"count": 1}))
+		assert.ErrorContains(t, err, "failed to encode avro record")
+	})
+
+	t.Run("not registered", func(t *testing.T) {
+		server := schemaregistrytest.NewServer(t)
+		e := newTestExtension(t, server, MarshalConfig{
+			Subject:    "logs-value",
+			SchemaType: "AVRO",
+			Schema:     schema,
+		})
+		_, err := e.MarshalLogs(newLogs(map[string]any{"message": "first"}))
+		assert.ErrorIs(t, err, schemaregistry.ErrNotFound)
+
+		// the schema is looked up again once registered
+		id := server.Register("logs-value", schemaregistry.Schema{Schema: schema}, "")
+		record, err := e.MarshalLogs(newLogs(map[string]any{"message": "first"}))
+		require.NoError(t, err)
+		recordID, _, err := schemaregistry.ParseHeader(record)
+		require.NoError(t, err)
+		assert.Equal(t, id, recordID)
+	})
+
+	t.Run("latest version", func(t *testing.T) {
+		server := schemaregistrytest.NewServer(t)
+		server.Register("logs-value", schemaregistry.Schema{Schema: `"string"`}, "")
+		id := server.Register("logs-value", schemaregistry.Schema{Schema: schema}, "")
+		e := newTestExtension(t, server, MarshalConfig{Subject: "logs-value", SchemaType: "AVRO"})
+
+		record, err := e.MarshalLogs(newLogs(map[string]any{"message": "first"}))
+		require.NoError(t, err)
+		recordID, _, err := schemaregistry.ParseHeader(record)
+		require.NoError(t, err)
+		assert.Equal(t, id, recordID)
+	})
+}
+
+// registerProtobufSchemas registers a schema referencing a schema under
+// another subject, and returns the ID of the former.
+func registerProtobufSchemas(t *testing.T, server *schemaregistrytest.Server) int {
+	label := descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()
+	field := func(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type, typeName string) *descriptorpb.FieldDescriptorProto {
+		f := &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			JsonName: proto.String(name),
+			Number:   proto.Int32(number),
+			Label:    label,
+			Type:     typ.Enum(),
+		}
+		if typeName != "" {
+			f.TypeName = proto.String(typeName)
+		}
+		return f
+	}
+	serialize := func(fdp *descriptorpb.FileDescriptorProto) string {
+		data, err := proto.Marshal(fdp)
+		require.NoError(t, err)
+		return base64.StdEncoding.EncodeToString(data)
+	}
+
+	common := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("common.proto"),
+		Package: proto.String("common"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name:  proto.String("Host"),
+			Field: []*descriptorpb.FieldDescriptorProto{field("name", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, "")},
+		}},
+	}
+	server.Register("common.proto", schemaregistry.Schema{
+		Type:   schemaregistry.SchemaTypeProtobuf,
+		Schema: "common",
+	}, serialize(common))
+
+	tags := field("tags", 4, descriptorpb.FieldDescriptorProto_TYPE_STRING, "")
+	tags.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+	log := &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("log.proto"),
+		Package:    proto.String("example"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"common.proto", "google/protobuf/timestamp.proto"},
+		EnumType: []*descriptorpb.EnumDescriptorProto{{
+			Name: proto.String("Severity"),
+			Value: []*descriptorpb.EnumValueDescriptorProto{
+				{Name: proto.String("INFO"), Number: proto.Int32(0)},
+				{Name: proto.String("WARN"), Number: proto.Int32(1)},
+			},
+		}},
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name:  proto.String("Other"),
+				Field: []*descriptorpb.FieldDescriptorProto{field("value", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, "")},
+			},
+			{
+				Name: proto.String("Log"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("message", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, ""),
+					field("severity", 2, descriptorpb.FieldDescriptorProto_TYPE_ENUM, ".example.Severity"),
+					field("count", 3, descriptorpb.FieldDescriptorProto_TYPE_INT64, ""),
+					tags,
+					field("host", 5, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".common.Host"),
+					field("time", 6, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".google.protobuf.Timestamp"),
+					field("source", 7, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".example.Log.Source"),
+				},
+				NestedType: []*descriptorpb.DescriptorProto{{
+					Name:  proto.String("Source"),
+					Field: []*descriptorpb.FieldDescriptorProto{field("file", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, "")},
+				}},
+			},
+		},
+	}
+	return server.Register("logs-value", schemaregistry.Schema{
+		Type:       schemaregistry.SchemaTypeProtobuf,
+		Schema:     "log",
+		References: []schemaregistry.Reference{{Name: "common.proto", Subject: "common.proto", Version: 1}},
+	}, serialize(log))
+}
+
+func TestProtobuf(t *testing.T) {
+	server := schemaregistrytest.NewServer(t)
+	id := registerProtobufSchemas(t, server)
+	e := newTestExtension(t, server, MarshalConfig{
+		Subject:     "logs-value",
+		SchemaType:  "PROTOBUF",
+		MessageName: "example.Log",
+	})
+
+	logBody := map[string]any{
+		"message":  "log message",
+		"severity": "WARN",
+		"count":    int64(5),
+		"tags":     []any{"a", "b"},
+		"host":     map[string]any{"name": "host1"},
+		"source":   map[string]any{"file": "app.log"},
+	}
+	record, err := e.MarshalLogs(newLogs(logBody))
+	require.NoError(t, err)
+	recordID, payload, err := schemaregistry.ParseHeader(record)
+	require.NoError(t, err)
+	assert.Equal(t, id, recordID)
+	indexes, _, err := schemaregistry.ParseMessageIndexes(payload)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1}, indexes)
+
+	logs, err := e.UnmarshalLogs(record)
+	require.NoError(t, err)
+	assert.Equal(t, logBody, body(t, logs))
+
+	// nested messages are decoded using the message indexes
+	nested := schemaregistry.AppendMessageIndexes(schemaregistry.AppendHeader(nil, id), []int{1, 0})
+	nested = append(nested, 0x0a, 0x03, 'a', '.', 'b')
+	logs, err = e.UnmarshalLogs(nested)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"file": "a.b"}, body(t, logs))
+
+	unknown := schemaregistry.AppendMessageIndexes(schemaregistry.AppendHeader(nil, id), []int{2})
+	_, err = e.UnmarshalLogs(unknown)
+	assert.ErrorContains(t, err, "protobuf message indexes [2] not found")
+}
+
+func TestProtobufUnknownMessage(t *testing.T) {
+	server := schemaregistrytest.NewServer(t)
+	registerProtobufSchemas(t, server)
+	e := newTestExtension(t, server, MarshalConfig{
+		Subject:     "logs-value",
+		SchemaType:  "PROTOBUF",
+		MessageName: "common.Host",
+	})
+	_, err := e.MarshalLogs(newLogs(map[string]any{"name": "host1"}))
+	assert.ErrorContains(t, err, `"common.Host" is not a message of schema`)
+}
+
+func TestJSON(t *testing.T) {
+	server := schemaregistrytest.NewServer(t)
+	e := newTestExtension(t, server, MarshalConfig{
+		Subject:      "logs-value",
+		SchemaType:   "JSON",
+		Schema:       `{"type": "object"}`,
+		AutoRegister: true,
+	})
+
+	record, err := e.MarshalLogs(newLogs(map[string]any{"message": "log message", "count": 5}))
+	require.NoError(t, err)
+	logs, err := e.UnmarshalLogs(record)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"message": "log message", "count": float64(5)}, body(t, logs))
+}
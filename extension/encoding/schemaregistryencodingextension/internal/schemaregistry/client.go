@@ -0,0 +1,187 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package schemaregistry implements a client of the subset of the Confluent
+// Schema Registry REST API needed to encode and decode records framed with the
+// Schema Registry wire format.
+package schemaregistry // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/schemaregistryencodingextension/internal/schemaregistry"
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// SchemaType is the type of a schema. An empty type is an Avro schema.
+type SchemaType string
+
+const (
+	SchemaTypeAvro     SchemaType = "AVRO"
+	SchemaTypeProtobuf SchemaType = "PROTOBUF"
+	SchemaTypeJSON     SchemaType = "JSON"
+)
+
+// FormatSerialized requests Protobuf schemas as a base64 encoded serialized
+// FileDescriptorProto instead of their text form.
+const FormatSerialized = "serialized"
+
+const contentType = "application/vnd.schemaregistry.v1+json"
+
+// ErrNotFound is returned when the schema or subject is not registered.
+var ErrNotFound = errors.New("not found in schema registry")
+
+// Reference is a reference of a schema to a schema registered under another
+// subject, such as a Protobuf import.
+type Reference struct {
+	Name    string `json:"name"`
+	Subject string `json:"subject"`
+	Version int    `json:"version"`
+}
+
+// Schema is a schema registered in the Schema Registry.
+type Schema struct {
+	ID         int         `json:"id,omitempty"`
+	Type       SchemaType  `json:"schemaType,omitempty"`
+	Schema     string      `json:"schema"`
+	References []Reference `json:"references,omitempty"`
+}
+
+// SchemaType returns the type of the schema, defaulting to Avro.
+func (s Schema) SchemaType() SchemaType {
+	if s.Type == "" {
+		return SchemaTypeAvro
+	}
+	return s.Type
+}
+
+type cacheKey struct {
+	id     int
+	format string
+}
+
+// Client is a Schema Registry client. Schemas are cached by ID, as the schema
+// of an ID never changes.
+type Client struct {
+	endpoint   string
+	httpClient *http.Client
+
+	mu      sync.Mutex
+	schemas map[cacheKey]Schema
+}
+
+// NewClient returns a client of the Schema Registry at endpoint.
+func NewClient(endpoint string, httpClient *http.Client) *Client {
+	return &Client{
+		endpoint:   strings.TrimSuffix(endpoint, "/"),
+		httpClient: httpClient,
+		schemas:    make(map[cacheKey]Schema),
+	}
+}
+
+// SchemaByID returns the schema with the given ID in the given format, which
+// is empty for the default format.
+func (c *Client) SchemaByID(ctx context.Context, id int, format string) (Schema, error) {
+	key := cacheKey{id: id, format: format}
+	c.mu.Lock()
+	schema, ok := c.schemas[key]
+	c.mu.Unlock()
+	if ok {
+		return schema, nil
+	}
+
+	path := "/schemas/ids/" + strconv.Itoa(id)
+	if err := c.do(ctx, http.MethodGet, path, format, nil, &schema); err != nil {
+		return Schema{}, fmt.Errorf("failed to get schema %d: %w", id, err)
+	}
+	schema.ID = id
+
+	c.mu.Lock()
+	c.schemas[key] = schema
+	c.mu.Unlock()
+	return schema, nil
+}
+
+// SchemaBySubject returns the schema registered under subject with the given
+// version, which is either a version number or "latest".
+func (c *Client) SchemaBySubject(ctx context.Context, subject, version, format string) (Schema, error) {
+	var schema Schema
+	path := "/subjects/" + url.PathEscape(subject) + "/versions/" + url.PathEscape(version)
+	if err := c.do(ctx, http.MethodGet, path, format, nil, &schema); err != nil {
+		return Schema{}, fmt.Errorf("failed to get version %s of subject %q: %w", version, subject, err)
+	}
+	return schema, nil
+}
+
+// Register registers schema under subject, and returns its ID. Registering a
+// schema that is already registered under subject returns its existing ID.
+func (c *Client) Register(ctx context.Context, subject string, schema Schema) (int, error) {
+	var registered Schema
+	path := "/subjects/" + url.PathEscape(subject) + "/versions"
+	if err := c.do(ctx, http.MethodPost, path, "", schema, &registered); err != nil {
+		return 0, fmt.Errorf("failed to register schema under subject %q: %w", subject, err)
+	}
+	return registered.ID, nil
+}
+
+// Lookup returns the ID of schema if it is registered under subject.
+func (c *Client) Lookup(ctx context.Context, subject string, schema Schema) (int, error) {
+	var registered Schema
+	path := "/subjects/" + url.PathEscape(subject)
+	if err := c.do(ctx, http.MethodPost, path, "", schema, &registered); err != nil {
+		return 0, fmt.Errorf("failed to look up schema under subject %q: %w", subject, err)
+	}
+	return registered.ID, nil
+}
+
+// errorResponse is the body of the error responses of the Schema Registry.
+type errorResponse struct {
+	ErrorCode int    `json:"error_code"`
+	Message   string `json:"message"`
+}
+
+func (c *Client) do(ctx context.Context, method, path, format string, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	u := c.endpoint + path
+	if format != "" {
+		u += "?format=" + url.QueryEscape(format)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", contentType)
+	if in != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp errorResponse
+		_ = json.NewDecoder(resp.Body).Decode(&errResp)
+		if resp.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("%w: %s", ErrNotFound, errResp.Message)
+		}
+		return fmt.Errorf("unexpected status %d (error code %d): %s", resp.StatusCode, errResp.ErrorCode, errResp.Message)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
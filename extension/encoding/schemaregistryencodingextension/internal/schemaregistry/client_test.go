@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package schemaregistry_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/schemaregistryencodingextension/internal/schemaregistry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/schemaregistryencodingextension/internal/schemaregistry/schemaregistrytest"
+)
+
+func TestClient(t *testing.T) {
+	server := schemaregistrytest.NewServer(t)
+	client := schemaregistry.NewClient(server.URL+"/", http.DefaultClient)
+
+	avroSchema := schemaregistry.Schema{Schema: `"string"`}
+	protobufSchema := schemaregistry.Schema{
+		Type:   schemaregistry.SchemaTypeProtobuf,
+		Schema: `syntax = "proto3"; message Log { string body = 1; }`,
+	}
+	server.Register("logs-value", avroSchema, "")
+	protobufID := server.Register("protobuf-value", protobufSchema, "c2VyaWFsaXplZA==")
+
+	// registering an already registered schema returns its ID
+	id, err := client.Register(t.Context(), "logs-value", avroSchema)
+	require.NoError(t, err)
+	assert.Equal(t, 1, id)
+
+	id, err = client.Register(t.Context(), "logs-value", schemaregistry.Schema{Schema: `"bytes"`})
+	require.NoError(t, err)
+	assert.Equal(t, 3, id)
+
+	id, err = client.Lookup(t.Context(), "logs-value", avroSchema)
+	require.NoError(t, err)
+	assert.Equal(t, 1, id)
+	_, err = client.Lookup(t.Context(), "logs-value", schemaregistry.Schema{Schema: `"int"`})
+	assert.ErrorIs(t, err, schemaregistry.ErrNotFound)
+
+	latest, err := client.SchemaBySubject(t.Context(), "logs-value", "latest", "")
+	require.NoError(t, err)
+	assert.Equal(t, 3, latest.ID)
+	assert.Equal(t, schemaregistry.SchemaTypeAvro, latest.SchemaType())
+	first, err := client.SchemaBySubject(t.Context(), "logs-value", "1", "")
+	require.NoError(t, err)
+	assert.Equal(t, `"string"`, first.Schema)
+	_, err = client.SchemaBySubject(t.Context(), "unknown-value", "latest", "")
+	assert.ErrorIs(t, err, schemaregistry.ErrNotFound)
+
+	schema, err := client.SchemaByID(t.Context(), protobufID, "")
+	require.NoError(t, err)
+	assert.Equal(t, protobufSchema.Schema, schema.Schema)
+	assert.Equal(t, schemaregistry.SchemaTypeProtobuf, schema.SchemaType())
+	serialized, err := client.SchemaByID(t.Context(), protobufID, schemaregistry.FormatSerialized)
+	require.NoError(t, err)
+	assert.Equal(t, "c2VyaWFsaXplZA==", serialized.Schema)
+	_, err = client.SchemaByID(t.Context(), 42, "")
+	assert.ErrorIs(t, err, schemaregistry.ErrNotFound)
+
+	// schemas are cached by ID
+	requests := server.Requests()
+	_, err = client.SchemaByID(t.Context(), protobufID, "")
+	require.NoError(t, err)
+	assert.Equal(t, requests, server.Requests())
+}
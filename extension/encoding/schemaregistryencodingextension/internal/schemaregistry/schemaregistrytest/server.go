@@ -0,0 +1,167 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package schemaregistrytest provides an in-memory stand-in of the Schema
+// Registry for tests.
+package schemaregistrytest // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/schemaregistryencodingextension/internal/schemaregistry/schemaregistrytest"
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/schemaregistryencodingextension/internal/schemaregistry"
+)
+
+type entry struct {
+	schema schemaregistry.Schema
+	// serialized is the schema in the serialized format, for Protobuf
+	// schemas.
+	serialized string
+}
+
+// Server is an in-memory Schema Registry serving the subset of the REST API
+// used by schemaregistry.Client.
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	entries  []entry
+	subjects map[string][]int
+	requests int
+}
+
+// NewServer starts a Server which is closed when the test ends.
+func NewServer(tb testing.TB) *Server {
+	s := &Server{subjects: make(map[string][]int)}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /schemas/ids/{id}", s.handleSchemaByID)
+	mux.HandleFunc("GET /subjects/{subject}/versions/{version}", s.handleSchemaBySubject)
+	mux.HandleFunc("POST /subjects/{subject}/versions", s.handleRegister)
+	mux.HandleFunc("POST /subjects/{subject}", s.handleLookup)
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests++
+		s.mu.Unlock()
+		mux.ServeHTTP(w, r)
+	}))
+	tb.Cleanup(s.Close)
+	return s
+}
+
+// Register registers schema under subject and returns its ID. serialized is
+// the schema in the serialized format, which is required for Protobuf schemas
+// as the server doesn't parse them.
+func (s *Server) Register(subject string, schema schemaregistry.Schema, serialized string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.register(subject, schema, serialized)
+}
+
+// Requests returns the number of requests served.
+func (s *Server) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+func (s *Server) register(subject string, schema schemaregistry.Schema, serialized string) int {
+	if id, ok := s.lookup(subject, schema); ok {
+		return id
+	}
+	s.entries = append(s.entries, entry{schema: schema, serialized: serialized})
+	id := len(s.entries)
+	s.subjects[subject] = append(s.subjects[subject], id)
+	return id
+}
+
+func (s *Server) lookup(subject string, schema schemaregistry.Schema) (int, bool) {
+	for _, id := range s.subjects[subject] {
+		registered := s.entries[id-1].schema
+		if registered.Schema == schema.Schema && registered.SchemaType() == schema.SchemaType() {
+			return id, true
+		}
+	}
+	return 0, false
+}
+
+func (s *Server) schema(id int, format string) schemaregistry.Schema {
+	e := s.entries[id-1]
+	schema := e.schema
+	schema.ID = id
+	if format == schemaregistry.FormatSerialized && e.serialized != "" {
+		schema.Schema = e.serialized
+	}
+	return schema
+}
+
+func (s *Server) handleSchemaByID(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil || id < 1 || id > len(s.entries) {
+		writeError(w, http.StatusNotFound, 40403, "Schema not found")
+		return
+	}
+	writeJSON(w, s.schema(id, r.URL.Query().Get("format")))
+}
+
+func (s *Server) handleSchemaBySubject(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ids := s.subjects[r.PathValue("subject")]
+	if len(ids) == 0 {
+		writeError(w, http.StatusNotFound, 40401, "Subject not found")
+		return
+	}
+	version := len(ids)
+	if v := r.PathValue("version"); v != "latest" {
+		var err error
+		if version, err = strconv.Atoi(v); err != nil || version < 1 || version > len(ids) {
+			writeError(w, http.StatusNotFound, 40402, "Version not found")
+			return
+		}
+	}
+	writeJSON(w, s.schema(ids[version-1], r.URL.Query().Get("format")))
+}
+
+func (s *Server) handleRegister(w http.ResponseWriter, r *http.Request) {
+	var schema schemaregistry.Schema
+	if err := json.NewDecoder(r.Body).Decode(&schema); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, 42201, err.Error())
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeJSON(w, map[string]int{"id": s.register(r.PathValue("subject"), schema, "")})
+}
+
+func (s *Server) handleLookup(w http.ResponseWriter, r *http.Request) {
+	var schema schemaregistry.Schema
+	if err := json.NewDecoder(r.Body).Decode(&schema); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, 42201, err.Error())
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id, ok := s.lookup(r.PathValue("subject"), schema)
+	if !ok {
+		writeError(w, http.StatusNotFound, 40403, "Schema not found")
+		return
+	}
+	writeJSON(w, s.schema(id, ""))
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/vnd.schemaregistry.v1+json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status, code int, message string) {
+	w.Header().Set("Content-Type", "application/vnd.schemaregistry.v1+json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]any{"error_code": code, "message": message})
+}
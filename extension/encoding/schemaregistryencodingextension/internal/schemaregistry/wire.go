@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package schemaregistry // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/schemaregistryencodingextension/internal/schemaregistry"
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// The wire format frames records with a magic byte followed by the schema ID
+// as a big endian 32 bits integer. Protobuf records then have the indexes of
+// their message type in the schema, as a zig-zag varint encoded array.
+const (
+	magicByte  = 0
+	headerSize = 5
+)
+
+var (
+	errInvalidHeader         = errors.New("record is not framed with the schema registry wire format")
+	errInvalidMessageIndexes = errors.New("invalid protobuf message indexes")
+)
+
+// AppendHeader appends the wire format header of the schema id to dst.
+func AppendHeader(dst []byte, id int) []byte {
+	dst = append(dst, magicByte)
+	return binary.BigEndian.AppendUint32(dst, uint32(id))
+}
+
+// ParseHeader returns the schema ID of the record in data and its payload.
+func ParseHeader(data []byte) (int, []byte, error) {
+	if len(data) < headerSize || data[0] != magicByte {
+		return 0, nil, errInvalidHeader
+	}
+	return int(binary.BigEndian.Uint32(data[1:headerSize])), data[headerSize:], nil
+}
+
+// AppendMessageIndexes appends the Protobuf message indexes to dst. The
+// indexes of the first message of the schema are encoded as a single 0.
+func AppendMessageIndexes(dst []byte, indexes []int) []byte {
+	if len(indexes) == 1 && indexes[0] == 0 {
+		return binary.AppendVarint(dst, 0)
+	}
+	dst = binary.AppendVarint(dst, int64(len(indexes)))
+	for _, i := range indexes {
+		dst = binary.AppendVarint(dst, int64(i))
+	}
+	return dst
+}
+
+// ParseMessageIndexes returns the Protobuf message indexes at the start of
+// data and the remaining payload.
+func ParseMessageIndexes(data []byte) ([]int, []byte, error) {
+	count, n := binary.Varint(data)
+	if n <= 0 || count < 0 || count > int64(len(data)) {
+		return nil, nil, errInvalidMessageIndexes
+	}
+	data = data[n:]
+	if count == 0 {
+		return []int{0}, data, nil
+	}
+
+	indexes := make([]int, count)
+	for i := range indexes {
+		index, n := binary.Varint(data)
+		if n <= 0 || index < 0 || index > math.MaxInt32 {
+			return nil, nil, fmt.Errorf("%w at position %d", errInvalidMessageIndexes, i)
+		}
+		indexes[i] = int(index)
+		data = data[n:]
+	}
+	return indexes, data, nil
+}
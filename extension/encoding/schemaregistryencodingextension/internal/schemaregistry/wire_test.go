@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package schemaregistry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeader(t *testing.T) {
+	data := AppendHeader(nil, 258)
+	assert.Equal(t, []byte{0, 0, 0, 1, 2}, data)
+
+	id, payload, err := ParseHeader(append(data, "payload"...))
+	require.NoError(t, err)
+	assert.Equal(t, 258, id)
+	assert.Equal(t, []byte("payload"), payload)
+
+	_, _, err = ParseHeader([]byte{0, 0, 1})
+	assert.ErrorIs(t, err, errInvalidHeader)
+	_, _, err = ParseHeader([]byte{1, 0, 0, 0, 1})
+	assert.ErrorIs(t, err, errInvalidHeader)
+}
+
+func TestMessageIndexes(t *testing.T) {
+	tests := []struct {
+		name    string
+		indexes []int
+		encoded []byte
+	}{
+		{
+			name:    "first message",
+			indexes: []int{0},
+			encoded: []byte{0},
+		},
+		{
+			name:    "top-level message",
+			indexes: []int{1},
+			encoded: []byte{2, 2},
+		},
+		{
+			name:    "nested message",
+			indexes: []int{2, 0, 1},
+			encoded: []byte{6, 4, 0, 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := AppendMessageIndexes(nil, tt.indexes)
+			assert.Equal(t, tt.encoded, data)
+
+			indexes, payload, err := ParseMessageIndexes(append(data, "payload"...))
+			require.NoError(t, err)
+			assert.Equal(t, tt.indexes, indexes)
+			assert.Equal(t, []byte("payload"), payload)
+		})
+	}
+
+	_, _, err := ParseMessageIndexes(nil)
+	assert.ErrorIs(t, err, errInvalidMessageIndexes)
+	_, _, err = ParseMessageIndexes([]byte{4, 2})
+	assert.ErrorIs(t, err, errInvalidMessageIndexes)
+}
@@ -0,0 +1,247 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package schemaregistryencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/schemaregistryencodingextension"
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	// Register the well-known types, which schemas import without
+	// referencing them.
+	_ "google.golang.org/protobuf/types/known/anypb"
+	_ "google.golang.org/protobuf/types/known/durationpb"
+	_ "google.golang.org/protobuf/types/known/emptypb"
+	_ "google.golang.org/protobuf/types/known/structpb"
+	_ "google.golang.org/protobuf/types/known/timestamppb"
+	_ "google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/schemaregistryencodingextension/internal/schemaregistry"
+)
+
+type protobufCodec struct {
+	file protoreflect.FileDescriptor
+	// message is the message type records are encoded with, and indexes
+	// its indexes in file.
+	message protoreflect.MessageDescriptor
+	indexes []int
+}
+
+// newProtobufCodec returns the codec of the Protobuf schema in the serialized
+// format. Records are encoded with the message named messageName, or with the
+// first message of the schema if empty.
+func newProtobufCodec(
+	ctx context.Context,
+	client *schemaregistry.Client,
+	schema schemaregistry.Schema,
+	messageName string,
+) (codec, error) {
+	r := resolver{files: new(protoregistry.Files)}
+	file, err := r.resolve(ctx, client, schema)
+	if err != nil {
+		return nil, err
+	}
+
+	c := protobufCodec{file: file}
+	switch {
+	case messageName != "":
+		d, err := r.FindDescriptorByName(protoreflect.FullName(messageName))
+		if err != nil {
+			return nil, fmt.Errorf("failed to find message %q: %w", messageName, err)
+		}
+		md, ok := d.(protoreflect.MessageDescriptor)
+		if !ok || md.ParentFile().Path() != file.Path() {
+			return nil, fmt.Errorf("%q is not a message of schema %d", messageName, schema.ID)
+		}
+		c.message = md
+	case file.Messages().Len() > 0:
+		c.message = file.Messages().Get(0)
+	}
+	if c.message != nil {
+		c.indexes = messageIndexes(c.message)
+	}
+	return c, nil
+}
+
+func (c protobufCodec) decode(payload []byte) (any, error) {
+	indexes, payload, err := schemaregistry.ParseMessageIndexes(payload)
+	if err != nil {
+		return nil, err
+	}
+	md, err := messageByIndexes(c.file, indexes)
+	if err != nil {
+		return nil, err
+	}
+	msg := dynamicpb.NewMessage(md)
+	if err := proto.Unmarshal(payload, msg); err != nil {
+		return nil, fmt.Errorf("failed to decode protobuf record: %w", err)
+	}
+	return messageToRaw(msg), nil
+}
+
+func (c protobufCodec) appendEncoded(dst []byte, value any) ([]byte, error) {
+	if c.message == nil {
+		return nil, fmt.Errorf("schema %s has no message", c.file.Path())
+	}
+	// The value is converted to the message through its JSON mapping,
+	// which accepts both the JSON and the original field names.
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	msg := dynamicpb.NewMessage(c.message)
+	if err := protojson.Unmarshal(data, msg); err != nil {
+		return nil, fmt.Errorf("failed to encode protobuf record: %w", err)
+	}
+	dst = schemaregistry.AppendMessageIndexes(dst, c.indexes)
+	return proto.MarshalOptions{}.MarshalAppend(dst, msg)
+}
+
+// resolver resolves the files of a schema and of its references, falling
+// back to the linked files for the well-known types.
+type resolver struct {
+	files *protoregistry.Files
+}
+
+func (r resolver) FindFileByPath(path string) (protoreflect.FileDescriptor, error) {
+	if fd, err := r.files.FindFileByPath(path); err == nil {
+		return fd, nil
+	}
+	return protoregistry.GlobalFiles.FindFileByPath(path)
+}
+
+func (r resolver) FindDescriptorByName(name protoreflect.FullName) (protoreflect.Descriptor, error) {
+	if d, err := r.files.FindDescriptorByName(name); err == nil {
+		return d, nil
+	}
+	return protoregistry.GlobalFiles.FindDescriptorByName(name)
+}
+
+// resolve returns the file of schema, after resolving its references.
+func (r resolver) resolve(ctx context.Context, client *schemaregistry.Client, schema schemaregistry.Schema) (protoreflect.FileDescriptor, error) {
+	for _, ref := range schema.References {
+		if _, err := r.files.FindFileByPath(ref.Name); err == nil {
+			continue
+		}
+		refSchema, err := client.SchemaBySubject(ctx, ref.Subject, strconv.Itoa(ref.Version), schemaregistry.FormatSerialized)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := r.resolve(ctx, client, refSchema); err != nil {
+			return nil, fmt.Errorf("failed to resolve reference %q: %w", ref.Name, err)
+		}
+	}
+
+	data, err := base64.StdEncoding.DecodeString(schema.Schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode serialized protobuf schema: %w", err)
+	}
+	fdp := &descriptorpb.FileDescriptorProto{}
+	if err := proto.Unmarshal(data, fdp); err != nil {
+		return nil, fmt.Errorf("failed to decode serialized protobuf schema: %w", err)
+	}
+	fd, err := protodesc.NewFile(fdp, r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid protobuf schema: %w", err)
+	}
+	if err := r.files.RegisterFile(fd); err != nil {
+		return nil, err
+	}
+	return fd, nil
+}
+
+// messageIndexes returns the indexes of md in its file, from its top-level
+// message to md.
+func messageIndexes(md protoreflect.MessageDescriptor) []int {
+	var indexes []int
+	for d := md; d != nil; {
+		indexes = append(indexes, d.Index())
+		d, _ = d.Parent().(protoreflect.MessageDescriptor)
+	}
+	slices.Reverse(indexes)
+	return indexes
+}
+
+func messageByIndexes(file protoreflect.FileDescriptor, indexes []int) (protoreflect.MessageDescriptor, error) {
+	if len(indexes) == 0 {
+		return nil, errors.New("empty protobuf message indexes")
+	}
+	var md protoreflect.MessageDescriptor
+	messages := file.Messages()
+	for _, i := range indexes {
+		if i >= messages.Len() {
+			return nil, fmt.Errorf("protobuf message indexes %v not found in schema %s", indexes, file.Path())
+		}
+		md = messages.Get(i)
+		messages = md.Messages()
+	}
+	return md, nil
+}
+
+// messageToRaw converts msg to a map of its populated fields, keyed by their
+// names in the schema.
+func messageToRaw(msg protoreflect.Message) map[string]any {
+	raw := make(map[string]any)
+	msg.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsList():
+			list := v.List()
+			values := make([]any, list.Len())
+			for i := range values {
+				values[i] = valueToRaw(fd, list.Get(i))
+			}
+			raw[string(fd.Name())] = values
+		case fd.IsMap():
+			values := make(map[string]any, v.Map().Len())
+			v.Map().Range(func(k protoreflect.MapKey, mv protoreflect.Value) bool {
+				values[k.String()] = valueToRaw(fd.MapValue(), mv)
+				return true
+			})
+			raw[string(fd.Name())] = values
+		default:
+			raw[string(fd.Name())] = valueToRaw(fd, v)
+		}
+		return true
+	})
+	return raw
+}
+
+func valueToRaw(fd protoreflect.FieldDescriptor, v protoreflect.Value) any {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return v.Bool()
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByNumber(v.Enum()); ev != nil {
+			return string(ev.Name())
+		}
+		return int64(v.Enum())
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return v.Int()
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return int64(v.Uint())
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return v.Float()
+	case protoreflect.StringKind:
+		return v.String()
+	case protoreflect.BytesKind:
+		return v.Bytes()
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return messageToRaw(v.Message())
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package encodingutil creates the unmarshalers of receivers and the marshalers
+// of exporters configured with an encoding, which is either an encoding
+// extension or a built-in OTLP encoding.
+package encodingutil // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/encodingutil"
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var (
+	errUnknownEncodingExtension = errors.New("unknown encoding extension")
+	errInvalidComponentType     = errors.New("invalid component type")
+)
+
+// NewTracesUnmarshaler returns the traces unmarshaler of the encoding extension
+// with the ID encoding, or of the built-in otlp_proto or otlp_json encoding.
+func NewTracesUnmarshaler(encoding string, host component.Host) (ptrace.Unmarshaler, error) {
+	return fromEncoding(host, encoding, "traces", "unmarshaler", map[string]ptrace.Unmarshaler{
+		"otlp_proto": &ptrace.ProtoUnmarshaler{},
+		"otlp_json":  &ptrace.JSONUnmarshaler{},
+	})
+}
+
+// NewMetricsUnmarshaler returns the metrics unmarshaler of the encoding extension
+// with the ID encoding, or of the built-in otlp_proto or otlp_json encoding.
+func NewMetricsUnmarshaler(encoding string, host component.Host) (pmetric.Unmarshaler, error) {
+	return fromEncoding(host, encoding, "metrics", "unmarshaler", map[string]pmetric.Unmarshaler{
+		"otlp_proto": &pmetric.ProtoUnmarshaler{},
+		"otlp_json":  &pmetric.JSONUnmarshaler{},
+	})
+}
+
+// NewLogsUnmarshaler returns the logs unmarshaler of the encoding extension
+// with the ID encoding, or of the built-in otlp_proto or otlp_json encoding.
+func NewLogsUnmarshaler(encoding string, host component.Host) (plog.Unmarshaler, error) {
+	return fromEncoding(host, encoding, "logs", "unmarshaler", map[string]plog.Unmarshaler{
+		"otlp_proto": &plog.ProtoUnmarshaler{},
+		"otlp_json":  &plog.JSONUnmarshaler{},
+	})
+}
+
+// NewTracesMarshaler returns the traces marshaler of the encoding extension
+// with the ID encoding, or of the built-in otlp_proto or otlp_json encoding.
+func NewTracesMarshaler(encoding string, host component.Host) (ptrace.Marshaler, error) {
+	return fromEncoding(host, encoding, "traces", "marshaler", map[string]ptrace.Marshaler{
+		"otlp_proto": &ptrace.ProtoMarshaler{},
+		"otlp_json":  &ptrace.JSONMarshaler{},
+	})
+}
+
+// NewMetricsMarshaler returns the metrics marshaler of the encoding extension
+// with the ID encoding, or of the built-in otlp_proto or otlp_json encoding.
+func NewMetricsMarshaler(encoding string, host component.Host) (pmetric.Marshaler, error) {
+	return fromEncoding(host, encoding, "metrics", "marshaler", map[string]pmetric.Marshaler{
+		"otlp_proto": &pmetric.ProtoMarshaler{},
+		"otlp_json":  &pmetric.JSONMarshaler{},
+	})
+}
+
+// NewLogsMarshaler returns the logs marshaler of the encoding extension with
+// the ID encoding, or of the built-in otlp_proto or otlp_json encoding.
+func NewLogsMarshaler(encoding string, host component.Host) (plog.Marshaler, error) {
+	return fromEncoding(host, encoding, "logs", "marshaler", map[string]plog.Marshaler{
+		"otlp_proto": &plog.ProtoMarshaler{},
+		"otlp_json":  &plog.JSONMarshaler{},
+	})
+}
+
+// fromEncoding returns the encoding extension with the ID encoding as T, or the
+// built-in T of encoding if there is no such extension.
+func fromEncoding[T any](host component.Host, encoding, signal, kind string, builtin map[string]T) (T, error) {
+	// Extensions take precedence.
+	if ext, ok, err := Extension[T](host, encoding, signal, kind); ok || err != nil {
+		return ext, err
+	}
+	if b, ok := builtin[encoding]; ok {
+		return b, nil
+	}
+	var zero T
+	return zero, fmt.Errorf("unrecognized %s encoding %q", signal, encoding)
+}
+
+// Extension returns the encoding extension with the ID encoding as T, for
+// components that fall back to built-in encodings of their own. It reports
+// false if encoding is not the ID of an extension, and an error if the
+// extension is not a T, with signal and kind (e.g. "traces" and "unmarshaler")
+// naming T in the error message.
+func Extension[T any](host component.Host, encoding, signal, kind string) (T, bool, error) {
+	ext, err := loadEncodingExtension[T](host, encoding, signal, kind)
+	if err != nil {
+		if errors.Is(err, errInvalidComponentType) || errors.Is(err, errUnknownEncodingExtension) {
+			return ext, false, nil
+		}
+		return ext, false, err
+	}
+	return ext, true, nil
+}
+
+// loadEncodingExtension tries to load an available extension for the given encoding.
+func loadEncodingExtension[T any](host component.Host, encoding, signal, kind string) (T, error) {
+	var zero T
+	extensionID, err := encodingToComponentID(encoding)
+	if err != nil {
+		return zero, err
+	}
+	encodingExtension, ok := host.GetExtensions()[*extensionID]
+	if !ok {
+		return zero, fmt.Errorf("invalid encoding %q: %w", encoding, errUnknownEncodingExtension)
+	}
+	ext, ok := encodingExtension.(T)
+	if !ok {
+		return zero, fmt.Errorf("extension %q is not a %s %s", encoding, signal, kind)
+	}
+	return ext, nil
+}
+
+// encodingToComponentID attempts to parse the encoding string as a component ID.
+func encodingToComponentID(encoding string) (*component.ID, error) {
+	var id component.ID
+	if err := id.UnmarshalText([]byte(encoding)); err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidComponentType, err)
+	}
+	return &id, nil
+}
@@ -0,0 +1,169 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package encodingutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var (
+	customLogsUnmarshalerExtension struct {
+		component.Component
+		plog.Unmarshaler
+	}
+	customMetricsUnmarshalerExtension struct {
+		component.Component
+		pmetric.Unmarshaler
+	}
+	customTracesUnmarshalerExtension struct {
+		component.Component
+		ptrace.Unmarshaler
+	}
+	customLogsMarshalerExtension struct {
+		component.Component
+		plog.Marshaler
+	}
+	customMetricsMarshalerExtension struct {
+		component.Component
+		pmetric.Marshaler
+	}
+	customTracesMarshalerExtension struct {
+		component.Component
+		ptrace.Marshaler
+	}
+)
+
+func TestNewUnmarshalers(t *testing.T) {
+	host := componenttest.NewNopHost()
+	for _, encoding := range []string{"otlp_proto", "otlp_json"} {
+		t.Run(encoding, func(t *testing.T) {
+			logs, err := NewLogsUnmarshaler(encoding, host)
+			require.NoError(t, err)
+			assert.NotNil(t, logs)
+			metrics, err := NewMetricsUnmarshaler(encoding, host)
+			require.NoError(t, err)
+			assert.NotNil(t, metrics)
+			traces, err := NewTracesUnmarshaler(encoding, host)
+			require.NoError(t, err)
+			assert.NotNil(t, traces)
+		})
+	}
+
+	_, err := NewLogsUnmarshaler("unknown", host)
+	assert.EqualError(t, err, `unrecognized logs encoding "unknown"`)
+	_, err = NewMetricsUnmarshaler("unknown", host)
+	assert.EqualError(t, err, `unrecognized metrics encoding "unknown"`)
+	_, err = NewTracesUnmarshaler("unknown", host)
+	assert.EqualError(t, err, `unrecognized traces encoding "unknown"`)
+}
+
+func TestNewUnmarshalersExtension(t *testing.T) {
+	host := extensionsHost{
+		component.MustNewID("otlp_proto"):                  &customLogsUnmarshalerExtension,
+		component.MustNewIDWithName("encoding", "metrics"): &customMetricsUnmarshalerExtension,
+		component.MustNewIDWithName("encoding", "traces"):  &customTracesUnmarshalerExtension,
+	}
+
+	// Verify extensions take precedence over built-in unmarshalers.
+	logs, err := NewLogsUnmarshaler("otlp_proto", host)
+	require.NoError(t, err)
+	assert.Equal(t, &customLogsUnmarshalerExtension, logs)
+
+	metrics, err := NewMetricsUnmarshaler("encoding/metrics", host)
+	require.NoError(t, err)
+	assert.Equal(t, &customMetricsUnmarshalerExtension, metrics)
+
+	traces, err := NewTracesUnmarshaler("encoding/traces", host)
+	require.NoError(t, err)
+	assert.Equal(t, &customTracesUnmarshalerExtension, traces)
+
+	// Specifying an extension for a different type should fail fast.
+	_, err = NewTracesUnmarshaler("encoding/metrics", host)
+	assert.EqualError(t, err, `extension "encoding/metrics" is not a traces unmarshaler`)
+}
+
+func TestNewMarshalers(t *testing.T) {
+	host := componenttest.NewNopHost()
+	for _, encoding := range []string{"otlp_proto", "otlp_json"} {
+		t.Run(encoding, func(t *testing.T) {
+			logs, err := NewLogsMarshaler(encoding, host)
+			require.NoError(t, err)
+			assert.NotNil(t, logs)
+			metrics, err := NewMetricsMarshaler(encoding, host)
+			require.NoError(t, err)
+			assert.NotNil(t, metrics)
+			traces, err := NewTracesMarshaler(encoding, host)
+			require.NoError(t, err)
+			assert.NotNil(t, traces)
+		})
+	}
+
+	_, err := NewLogsMarshaler("unknown", host)
+	assert.EqualError(t, err, `unrecognized logs encoding "unknown"`)
+	_, err = NewMetricsMarshaler("unknown", host)
+	assert.EqualError(t, err, `unrecognized metrics encoding "unknown"`)
+	_, err = NewTracesMarshaler("unknown", host)
+	assert.EqualError(t, err, `unrecognized traces encoding "unknown"`)
+}
+
+func TestNewMarshalersExtension(t *testing.T) {
+	host := extensionsHost{
+		component.MustNewID("otlp_proto"):                  &customLogsMarshalerExtension,
+		component.MustNewIDWithName("encoding", "metrics"): &customMetricsMarshalerExtension,
+		component.MustNewIDWithName("encoding", "traces"):  &customTracesMarshalerExtension,
+	}
+
+	// Verify extensions take precedence over built-in marshalers.
+	logs, err := NewLogsMarshaler("otlp_proto", host)
+	require.NoError(t, err)
+	assert.Equal(t, &customLogsMarshalerExtension, logs)
+
+	metrics, err := NewMetricsMarshaler("encoding/metrics", host)
+	require.NoError(t, err)
+	assert.Equal(t, &customMetricsMarshalerExtension, metrics)
+
+	traces, err := NewTracesMarshaler("encoding/traces", host)
+	require.NoError(t, err)
+	assert.Equal(t, &customTracesMarshalerExtension, traces)
+
+	// Specifying an extension for a different type should fail fast.
+	_, err = NewTracesMarshaler("encoding/metrics", host)
+	assert.EqualError(t, err, `extension "encoding/metrics" is not a traces marshaler`)
+}
+
+func TestExtension(t *testing.T) {
+	host := extensionsHost{
+		component.MustNewIDWithName("encoding", "traces"): &customTracesUnmarshalerExtension,
+	}
+
+	traces, ok, err := Extension[ptrace.Unmarshaler](host, "encoding/traces", "traces", "unmarshaler")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, &customTracesUnmarshalerExtension, traces)
+
+	// Encodings that are not extensions are left to the caller.
+	for _, encoding := range []string{"otlp_proto", "encoding/unknown", "not a component ID"} {
+		_, ok, err = Extension[ptrace.Unmarshaler](host, encoding, "traces", "unmarshaler")
+		require.NoError(t, err)
+		assert.False(t, ok)
+	}
+
+	_, ok, err = Extension[plog.Unmarshaler](host, "encoding/traces", "logs", "unmarshaler")
+	assert.EqualError(t, err, `extension "encoding/traces" is not a logs unmarshaler`)
+	assert.False(t, ok)
+}
+
+type extensionsHost map[component.ID]component.Component
+
+func (h extensionsHost) GetExtensions() map[component.ID]component.Component {
+	return h
+}
@@ -1,15 +1,15 @@
 // Copyright The OpenTelemetry Authors
 // SPDX-License-Identifier: Apache-2.0
 
-package data // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/data"
+package data // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/data"
 
 import (
 	"math"
 
 	"go.opentelemetry.io/collector/pdata/pmetric"
 
-	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/data/expo"
-	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/putil/pslice"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/data/expo"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/internal/pslice"
 )
 
 // Aggregator performs an operation on two datapoints.
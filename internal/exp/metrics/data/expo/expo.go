@@ -2,7 +2,7 @@
 // SPDX-License-Identifier: Apache-2.0
 
 // Package expo implements various operations on exponential histograms and their bucket counts
-package expo // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/data/expo"
+package expo // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/data/expo"
 
 import "go.opentelemetry.io/collector/pdata/pmetric"
 
@@ -9,8 +9,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
-	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/data/expo"
-	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/data/expo/expotest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/data/expo"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/data/expo/expotest"
 )
 
 func TestAbsolute(t *testing.T) {
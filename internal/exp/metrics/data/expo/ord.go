@@ -1,7 +1,7 @@
 // Copyright The OpenTelemetry Authors
 // SPDX-License-Identifier: Apache-2.0
 
-package expo // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/data/expo"
+package expo // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/data/expo"
 
 import "cmp"
 
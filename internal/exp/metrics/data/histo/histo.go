@@ -1,7 +1,7 @@
 // Copyright The OpenTelemetry Authors
 // SPDX-License-Identifier: Apache-2.0
 
-package histo // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/data/histo"
+package histo // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/data/histo"
 
 import (
 	"slices"
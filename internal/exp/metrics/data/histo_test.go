@@ -9,8 +9,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
-	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/data/histo"
-	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/data/histo/histotest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/data/histo"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/data/histo/histotest"
 )
 
 func TestHistoAdd(t *testing.T) {
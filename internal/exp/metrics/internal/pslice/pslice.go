@@ -1,7 +1,7 @@
 // Copyright The OpenTelemetry Authors
 // SPDX-License-Identifier: Apache-2.0
 
-package pslice // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/putil/pslice"
+package pslice // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/internal/pslice"
 
 type Slice[E any] interface {
 	At(int) E
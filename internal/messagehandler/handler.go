@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package messagehandler handles the messages consumed by the receivers of
+// message brokers, whose payload holds the data of a signal.
+package messagehandler // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/messagehandler"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+)
+
+// Handler unmarshals message payloads into data of type T, and passes the
+// data to the next consumer of the signal, reporting it with the receiver's
+// ObsReport.
+type Handler[T plog.Logs | pmetric.Metrics | ptrace.Traces] struct {
+	encoding  string
+	unmarshal func([]byte) (T, error)
+	// count returns the number of items (log records, metric data points,
+	// spans) of the data.
+	count     func(T) int
+	resources func(T, func(pcommon.Resource))
+	consume   func(context.Context, T) error
+	startOp   func(context.Context) context.Context
+	endOp     func(ctx context.Context, format string, n int, err error)
+}
+
+// NewLogs returns a Handler unmarshaling logs with unmarshaler, and passing
+// them to next.
+func NewLogs(encoding string, unmarshaler plog.Unmarshaler, obsrecv *receiverhelper.ObsReport, next consumer.Logs) *Handler[plog.Logs] {
+	return &Handler[plog.Logs]{
+		encoding:  encoding,
+		unmarshal: unmarshaler.UnmarshalLogs,
+		count:     plog.Logs.LogRecordCount,
+		resources: func(ld plog.Logs, f func(pcommon.Resource)) {
+			for i := 0; i < ld.ResourceLogs().Len(); i++ {
+				f(ld.ResourceLogs().At(i).Resource())
+			}
+		},
+		consume: next.ConsumeLogs,
+		startOp: obsrecv.StartLogsOp,
+		endOp:   obsrecv.EndLogsOp,
+	}
+}
+
+// NewMetrics returns a Handler unmarshaling metrics with unmarshaler, and
+// passing them to next.
+func NewMetrics(encoding string, unmarshaler pmetric.Unmarshaler, obsrecv *receiverhelper.ObsReport, next consumer.Metrics) *Handler[pmetric.Metrics] {
+	return &Handler[pmetric.Metrics]{
+		encoding:  encoding,
+		unmarshal: unmarshaler.UnmarshalMetrics,
+		count:     pmetric.Metrics.DataPointCount,
+		resources: func(md pmetric.Metrics, f func(pcommon.Resource)) {
+			for i := 0; i < md.ResourceMetrics().Len(); i++ {
+				f(md.ResourceMetrics().At(i).Resource())
+			}
+		},
+		consume: next.ConsumeMetrics,
+		startOp: obsrecv.StartMetricsOp,
+		endOp:   obsrecv.EndMetricsOp,
+	}
+}
+
+// NewTraces returns a Handler unmarshaling traces with unmarshaler, and
+// passing them to next.
+func NewTraces(encoding string, unmarshaler ptrace.Unmarshaler, obsrecv *receiverhelper.ObsReport, next consumer.Traces) *Handler[ptrace.Traces] {
+	return &Handler[ptrace.Traces]{
+		encoding:  encoding,
+		unmarshal: unmarshaler.UnmarshalTraces,
+		count:     ptrace.Traces.SpanCount,
+		resources: func(td ptrace.Traces, f func(pcommon.Resource)) {
+			for i := 0; i < td.ResourceSpans().Len(); i++ {
+				f(td.ResourceSpans().At(i).Resource())
+			}
+		},
+		consume: next.ConsumeTraces,
+		startOp: obsrecv.StartTracesOp,
+		endOp:   obsrecv.EndTracesOp,
+	}
+}
+
+// Handle unmarshals payload, calls prepare, if not nil, with each resource of
+// the data, and passes the data to the next consumer. Unmarshaling errors are
+// permanent, as unmarshaling a redelivered message would fail again.
+func (h *Handler[T]) Handle(ctx context.Context, payload []byte, prepare func(pcommon.Resource)) error {
+	obsCtx := h.startOp(ctx)
+	data, err := h.unmarshal(payload)
+	if err != nil {
+		h.endOp(obsCtx, h.encoding, 0, err)
+		return consumererror.NewPermanent(fmt.Errorf("failed to unmarshal message: %w", err))
+	}
+	if prepare != nil {
+		h.resources(data, prepare)
+	}
+	n := h.count(data)
+	err = h.consume(ctx, data)
+	h.endOp(obsCtx, h.encoding, n, err)
+	return err
+}
@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package messagehandler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+func newObsReport(t *testing.T) *receiverhelper.ObsReport {
+	set := receivertest.NewNopSettings(component.MustNewType("test"))
+	obsrecv, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
+		ReceiverID:             set.ID,
+		Transport:              "test",
+		ReceiverCreateSettings: set,
+	})
+	require.NoError(t, err)
+	return obsrecv
+}
+
+func putSource(res pcommon.Resource) {
+	res.Attributes().PutStr("source", "test")
+}
+
+func TestHandler_logs(t *testing.T) {
+	sink := &consumertest.LogsSink{}
+	h := NewLogs("otlp_proto", &plog.ProtoUnmarshaler{}, newObsReport(t), sink)
+
+	logs := plog.NewLogs()
+	logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("log message")
+	payload, err := (&plog.ProtoMarshaler{}).MarshalLogs(logs)
+	require.NoError(t, err)
+	require.NoError(t, h.Handle(t.Context(), payload, putSource))
+
+	putSource(logs.ResourceLogs().At(0).Resource())
+	require.Len(t, sink.AllLogs(), 1)
+	assert.Equal(t, logs, sink.AllLogs()[0])
+}
+
+func TestHandler_metrics(t *testing.T) {
+	sink := &consumertest.MetricsSink{}
+	h := NewMetrics("otlp_json", &pmetric.JSONUnmarshaler{}, newObsReport(t), sink)
+
+	metrics := pmetric.NewMetrics()
+	metric := metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	metric.SetName("metric")
+	metric.SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(1)
+	payload, err := (&pmetric.JSONMarshaler{}).MarshalMetrics(metrics)
+	require.NoError(t, err)
+	require.NoError(t, h.Handle(t.Context(), payload, putSource))
+
+	putSource(metrics.ResourceMetrics().At(0).Resource())
+	require.Len(t, sink.AllMetrics(), 1)
+	assert.Equal(t, metrics, sink.AllMetrics()[0])
+}
+
+func TestHandler_traces(t *testing.T) {
+	sink := &consumertest.TracesSink{}
+	h := NewTraces("otlp_proto", &ptrace.ProtoUnmarshaler{}, newObsReport(t), sink)
+
+	traces := ptrace.NewTraces()
+	traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("span")
+	payload, err := (&ptrace.ProtoMarshaler{}).MarshalTraces(traces)
+	require.NoError(t, err)
+	// prepare is optional
+	require.NoError(t, h.Handle(t.Context(), payload, nil))
+
+	require.Len(t, sink.AllTraces(), 1)
+	assert.Equal(t, traces, sink.AllTraces()[0])
+}
+
+func TestHandler_unmarshalError(t *testing.T) {
+	sink := &consumertest.LogsSink{}
+	h := NewLogs("otlp_proto", &plog.ProtoUnmarshaler{}, newObsReport(t), sink)
+
+	err := h.Handle(t.Context(), []byte("invalid"), putSource)
+	assert.ErrorContains(t, err, "failed to unmarshal message")
+	assert.True(t, consumererror.IsPermanent(err))
+	assert.Empty(t, sink.AllLogs())
+}
+
+func TestHandler_consumeError(t *testing.T) {
+	consumeErr := errors.New("downstream unavailable")
+	next, err := consumer.NewLogs(func(context.Context, plog.Logs) error {
+		return consumeErr
+	})
+	require.NoError(t, err)
+	h := NewLogs("otlp_proto", &plog.ProtoUnmarshaler{}, newObsReport(t), next)
+
+	payload, err := (&plog.ProtoMarshaler{}).MarshalLogs(plog.NewLogs())
+	require.NoError(t, err)
+	err = h.Handle(t.Context(), payload, nil)
+	assert.ErrorIs(t, err, consumeErr)
+	assert.False(t, consumererror.IsPermanent(err))
+}
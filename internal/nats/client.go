@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package nats // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/nats"
+
+import (
+	"context"
+	"fmt"
+
+	natsgo "github.com/nats-io/nats.go"
+	"go.uber.org/zap"
+)
+
+// Connect connects to the NATS servers with the given configuration. The
+// connection is named after name, and reconnects indefinitely when it is
+// lost, logging disconnections and asynchronous errors to logger.
+func Connect(ctx context.Context, cfg ClientConfig, name string, logger *zap.Logger) (*natsgo.Conn, error) {
+	opts := []natsgo.Option{
+		natsgo.Name(name),
+		natsgo.Timeout(cfg.ConnectTimeout),
+		natsgo.MaxReconnects(-1),
+		natsgo.DisconnectErrHandler(func(_ *natsgo.Conn, err error) {
+			if err != nil {
+				logger.Warn("disconnected from NATS server", zap.Error(err))
+			}
+		}),
+		natsgo.ReconnectHandler(func(conn *natsgo.Conn) {
+			logger.Info("reconnected to NATS server", zap.String("url", conn.ConnectedUrlRedacted()))
+		}),
+		natsgo.ErrorHandler(func(_ *natsgo.Conn, sub *natsgo.Subscription, err error) {
+			fields := []zap.Field{zap.Error(err)}
+			if sub != nil {
+				fields = append(fields, zap.String("subject", sub.Subject))
+			}
+			logger.Error("NATS connection error", fields...)
+		}),
+	}
+	if cfg.TLS != nil {
+		tlsConfig, err := cfg.TLS.LoadTLSConfig(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load TLS config: %w", err)
+		}
+		if tlsConfig != nil {
+			opts = append(opts, natsgo.Secure(tlsConfig))
+		}
+	}
+	switch {
+	case cfg.Auth.Username != "":
+		opts = append(opts, natsgo.UserInfo(cfg.Auth.Username, string(cfg.Auth.Password)))
+	case cfg.Auth.Token != "":
+		opts = append(opts, natsgo.Token(string(cfg.Auth.Token)))
+	case cfg.Auth.CredentialsFile != "":
+		opts = append(opts, natsgo.UserCredentials(cfg.Auth.CredentialsFile))
+	}
+
+	conn, err := natsgo.Connect(cfg.URL, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to NATS server: %w", err)
+	}
+	return conn, nil
+}
@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package nats_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/nats"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/nats/natstest"
+)
+
+func TestConnect(t *testing.T) {
+	_, cfg := natstest.NewServer(t)
+	conn, err := nats.Connect(t.Context(), cfg, "test", zap.NewNop())
+	require.NoError(t, err)
+	defer conn.Close()
+	assert.True(t, conn.IsConnected())
+	assert.Equal(t, "test", conn.Opts.Name)
+}
+
+func TestConnect_auth(t *testing.T) {
+	_, cfg := natstest.NewServer(t, func(opts *server.Options) {
+		opts.Username = "user"
+		opts.Password = "pass"
+	})
+
+	cfg.Auth.Username = "user"
+	cfg.Auth.Password = "pass"
+	conn, err := nats.Connect(t.Context(), cfg, "test", zap.NewNop())
+	require.NoError(t, err)
+	conn.Close()
+
+	cfg.Auth.Password = "wrong"
+	_, err = nats.Connect(t.Context(), cfg, "test", zap.NewNop())
+	assert.ErrorContains(t, err, "failed to connect to NATS server: nats: Authorization Violation")
+}
+
+func TestConnect_token(t *testing.T) {
+	_, cfg := natstest.NewServer(t, func(opts *server.Options) {
+		opts.Authorization = "secret"
+	})
+	cfg.Auth.Token = "secret"
+	conn, err := nats.Connect(t.Context(), cfg, "test", zap.NewNop())
+	require.NoError(t, err)
+	conn.Close()
+}
+
+func TestConnect_invalidTLS(t *testing.T) {
+	_, cfg := natstest.NewServer(t)
+	cfg.TLS = &configtls.ClientConfig{Config: configtls.Config{CAFile: "nonexistent"}}
+	_, err := nats.Connect(t.Context(), cfg, "test", zap.NewNop())
+	assert.ErrorContains(t, err, "failed to load TLS config")
+}
+
+func TestConnect_logsDisconnect(t *testing.T) {
+	srv, cfg := natstest.NewServer(t)
+	core, logs := observer.New(zap.WarnLevel)
+	conn, err := nats.Connect(t.Context(), cfg, "test", zap.New(core))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	srv.Shutdown()
+	assert.Eventually(t, func() bool {
+		return logs.FilterMessage("disconnected from NATS server").Len() == 1
+	}, 10*time.Second, 10*time.Millisecond)
+}
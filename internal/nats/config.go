@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package nats // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/nats"
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/config/configtls"
+)
+
+// ClientConfig holds the configuration for connecting to a NATS server.
+type ClientConfig struct {
+	// URL holds the NATS server URL, or a comma-separated list of URLs of
+	// the servers of a cluster. (default "nats://localhost:4222")
+	URL string `mapstructure:"url"`
+
+	// TLS holds TLS-related configuration for connecting to the servers.
+	TLS *configtls.ClientConfig `mapstructure:"tls"`
+
+	// Auth holds the credentials used to authenticate to the servers.
+	Auth AuthConfig `mapstructure:"auth"`
+
+	// ConnectTimeout is the timeout for establishing a connection to a
+	// server. (default 2s)
+	ConnectTimeout time.Duration `mapstructure:"connect_timeout"`
+}
+
+// AuthConfig holds the credentials used to authenticate to NATS servers.
+// At most one of username, token and credentials_file may be set.
+type AuthConfig struct {
+	Username string              `mapstructure:"username"`
+	Password configopaque.String `mapstructure:"password"`
+	Token    configopaque.String `mapstructure:"token"`
+
+	// CredentialsFile is the path to a NATS credentials file, holding a
+	// user JWT and NKey seed.
+	CredentialsFile string `mapstructure:"credentials_file"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// NewDefaultClientConfig returns the default configuration for connecting
+// to a NATS server.
+func NewDefaultClientConfig() ClientConfig {
+	return ClientConfig{
+		URL:            "nats://localhost:4222",
+		ConnectTimeout: 2 * time.Second,
+	}
+}
+
+// Validate checks the client configuration is valid.
+func (c ClientConfig) Validate() error {
+	var errs []error
+	if c.URL == "" {
+		errs = append(errs, errors.New("url must be specified"))
+	}
+	if c.ConnectTimeout <= 0 {
+		errs = append(errs, errors.New("connect_timeout must be positive"))
+	}
+	methods := 0
+	for _, set := range []bool{c.Auth.Username != "", c.Auth.Token != "", c.Auth.CredentialsFile != ""} {
+		if set {
+			methods++
+		}
+	}
+	if methods > 1 {
+		errs = append(errs, errors.New("only one of auth::username, auth::token and auth::credentials_file may be specified"))
+	}
+	if c.Auth.Password != "" && c.Auth.Username == "" {
+		errs = append(errs, errors.New("auth::password requires auth::username"))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package nats
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name        string
+		modify      func(*ClientConfig)
+		expectedErr string
+	}{
+		{
+			name:   "default",
+			modify: func(*ClientConfig) {},
+		},
+		{
+			name: "username and password",
+			modify: func(cfg *ClientConfig) {
+				cfg.Auth.Username = "user"
+				cfg.Auth.Password = "pass"
+			},
+		},
+		{
+			name:        "empty url",
+			modify:      func(cfg *ClientConfig) { cfg.URL = "" },
+			expectedErr: "url must be specified",
+		},
+		{
+			name:        "zero connect_timeout",
+			modify:      func(cfg *ClientConfig) { cfg.ConnectTimeout = 0 },
+			expectedErr: "connect_timeout must be positive",
+		},
+		{
+			name: "username and token",
+			modify: func(cfg *ClientConfig) {
+				cfg.Auth.Username = "user"
+				cfg.Auth.Token = "token"
+			},
+			expectedErr: "only one of auth::username, auth::token and auth::credentials_file may be specified",
+		},
+		{
+			name:        "password without username",
+			modify:      func(cfg *ClientConfig) { cfg.Auth.Password = "pass" },
+			expectedErr: "auth::password requires auth::username",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewDefaultClientConfig()
+			tt.modify(&cfg)
+			err := cfg.Validate()
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
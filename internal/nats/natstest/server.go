@@ -0,0 +1,47 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natstest // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/nats/natstest"
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/nats"
+)
+
+// NewServer starts an embedded NATS server with JetStream enabled, and
+// returns it along with a nats.ClientConfig with the default configuration
+// and the URL set to the server address. The server is shut down when the
+// test completes.
+//
+// The server listens on a random port, and stores its streams in a
+// temporary directory. opts may modify the server options before it starts.
+func NewServer(tb testing.TB, opts ...func(*server.Options)) (*server.Server, nats.ClientConfig) {
+	serverOpts := &server.Options{
+		Host:      "127.0.0.1",
+		Port:      server.RANDOM_PORT,
+		NoLog:     true,
+		NoSigs:    true,
+		JetStream: true,
+		StoreDir:  tb.TempDir(),
+	}
+	for _, opt := range opts {
+		opt(serverOpts)
+	}
+	srv, err := server.NewServer(serverOpts)
+	require.NoError(tb, err)
+	srv.Start()
+	tb.Cleanup(func() {
+		srv.Shutdown()
+		srv.WaitForShutdown()
+	})
+	require.True(tb, srv.ReadyForConnections(10*time.Second), "NATS server not ready")
+
+	cfg := nats.NewDefaultClientConfig()
+	cfg.URL = srv.ClientURL()
+	return srv, cfg
+}
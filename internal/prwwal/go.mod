@@ -0,0 +1,19 @@
+module github.com/open-telemetry/opentelemetry-collector-contrib/internal/prwwal
+
+go 1.24.0
+
+require (
+	github.com/stretchr/testify v1.11.1
+	github.com/tidwall/wal v1.2.1
+	go.uber.org/goleak v1.3.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.2-0.20180830191138-d8f796af33cc // indirect
+	github.com/pmezard/go-difflib v1.0.1-0.20181226105442-5d4384ee4fb2 // indirect
+	github.com/tidwall/gjson v1.10.2 // indirect
+	github.com/tidwall/match v1.1.1 // indirect
+	github.com/tidwall/pretty v1.2.0 // indirect
+	github.com/tidwall/tinylru v1.1.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package prwwal reads the segment files of the write-ahead-log of the
+// Prometheus remote write exporter without opening it, so a corrupted WAL can
+// be inspected and repaired.
+package prwwal // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/prwwal"
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+)
+
+// Dir is the directory of the WAL below the configured WAL directory.
+const Dir = "prom_remotewrite"
+
+// segmentNameLen is the length of segment file names, which are the zero
+// padded index of the first entry of the segment.
+const segmentNameLen = 20
+
+// ErrCorrupt is returned when a segment contains an entry that is not valid,
+// usually one that was partially written when the disk became full.
+var ErrCorrupt = errors.New("corrupt WAL segment")
+
+// Segment is a segment file of the WAL.
+type Segment struct {
+	// Index is the index of the first entry of the segment.
+	Index uint64
+	Path  string
+	Size  int64
+}
+
+// List returns the segments of the WAL in dir ordered by index.
+func List(dir string) ([]Segment, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var segments []Segment
+	for _, e := range entries {
+		if e.IsDir() || len(e.Name()) != segmentNameLen {
+			continue
+		}
+		index, err := strconv.ParseUint(e.Name(), 10, 64)
+		if err != nil || index == 0 {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			return nil, err
+		}
+		segments = append(segments, Segment{
+			Index: index,
+			Path:  filepath.Join(dir, e.Name()),
+			Size:  info.Size(),
+		})
+	}
+	sort.Slice(segments, func(i, j int) bool { return segments[i].Index < segments[j].Index })
+	return segments, nil
+}
+
+// Size returns the total size of segments.
+func Size(segments []Segment) int64 {
+	var size int64
+	for _, s := range segments {
+		size += s.Size
+	}
+	return size
+}
+
+// Entries returns the entries of the segment and the number of bytes they
+// take. If the segment is corrupted, the entries before the corruption are
+// returned with ErrCorrupt.
+func (s Segment) Entries() ([][]byte, int64, error) {
+	data, err := os.ReadFile(s.Path)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var entries [][]byte
+	var valid int64
+	for len(data) > 0 {
+		// entries are the uvarint encoded size of the data followed by the data
+		size, n := binary.Uvarint(data)
+		if n <= 0 || uint64(len(data)-n) < size {
+			return entries, valid, fmt.Errorf("%w %s at offset %d", ErrCorrupt, s.Path, valid)
+		}
+		entries = append(entries, data[n:n+int(size)])
+		data = data[n+int(size):]
+		valid += int64(n) + int64(size)
+	}
+	return entries, valid, nil
+}
+
+// Repair truncates the WAL in dir after its last valid entry. The segments
+// following a corrupted segment are removed, as their entries don't follow
+// the last valid entry anymore, and so is a corrupted segment without valid
+// entries. It returns the number of bytes removed.
+func Repair(dir string) (int64, error) {
+	segments, err := List(dir)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, s := range segments {
+		_, valid, err := s.Entries()
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, ErrCorrupt) {
+			return 0, err
+		}
+
+		removed := s.Size - valid
+		if valid > 0 {
+			err = os.Truncate(s.Path, valid)
+		} else {
+			err = os.Remove(s.Path)
+		}
+		if err != nil {
+			return 0, err
+		}
+		for _, next := range segments[i+1:] {
+			if err := os.Remove(next.Path); err != nil {
+				return removed, err
+			}
+			removed += next.Size
+		}
+		return removed, nil
+	}
+	return 0, nil
+}
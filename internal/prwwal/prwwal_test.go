@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prwwal
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/wal"
+)
+
+// writeWAL writes n entries to a WAL in dir with segments of at most two
+// entries.
+func writeWAL(t *testing.T, dir string, n int) {
+	log, err := wal.Open(dir, &wal.Options{SegmentSize: 10})
+	require.NoError(t, err)
+	for i := 1; i <= n; i++ {
+		require.NoError(t, log.Write(uint64(i), fmt.Appendf(nil, "entry%d", i)))
+	}
+	require.NoError(t, log.Close())
+}
+
+func readWAL(t *testing.T, dir string) []string {
+	segments, err := List(dir)
+	require.NoError(t, err)
+	var entries []string
+	for _, s := range segments {
+		data, _, err := s.Entries()
+		require.NoError(t, err)
+		for _, d := range data {
+			entries = append(entries, string(d))
+		}
+	}
+	return entries
+}
+
+func TestList(t *testing.T) {
+	dir := t.TempDir()
+	writeWAL(t, dir, 5)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "unknown"), nil, 0o600))
+
+	segments, err := List(dir)
+	require.NoError(t, err)
+	require.Len(t, segments, 3)
+	assert.Equal(t, []uint64{1, 3, 5}, []uint64{segments[0].Index, segments[1].Index, segments[2].Index})
+	assert.Equal(t, int64(5*len("xentry1")), Size(segments))
+	assert.Equal(t, []string{"entry1", "entry2", "entry3", "entry4", "entry5"}, readWAL(t, dir))
+}
+
+func TestRepair(t *testing.T) {
+	tests := []struct {
+		name     string
+		corrupt  func(t *testing.T, segments []Segment)
+		removed  int64
+		expected []string
+	}{
+		{
+			name:     "valid",
+			corrupt:  func(*testing.T, []Segment) {},
+			expected: []string{"entry1", "entry2", "entry3", "entry4", "entry5"},
+		},
+		{
+			name: "partially written last entry",
+			corrupt: func(t *testing.T, segments []Segment) {
+				f, err := os.OpenFile(segments[2].Path, os.O_APPEND|os.O_WRONLY, 0o600)
+				require.NoError(t, err)
+				_, err = f.Write([]byte{20, 'e', 'n'})
+				require.NoError(t, err)
+				require.NoError(t, f.Close())
+			},
+			removed:  3,
+			expected: []string{"entry1", "entry2", "entry3", "entry4", "entry5"},
+		},
+		{
+			name: "corrupted segment",
+			corrupt: func(t *testing.T, segments []Segment) {
+				require.NoError(t, os.Truncate(segments[1].Path, 10))
+			},
+			removed:  3 + 7,
+			expected: []string{"entry1", "entry2", "entry3"},
+		},
+		{
+			name: "corrupted first segment",
+			corrupt: func(t *testing.T, segments []Segment) {
+				require.NoError(t, os.WriteFile(segments[0].Path, []byte{0xff}, 0o600))
+			},
+			removed: 1 + 14 + 7,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeWAL(t, dir, 5)
+			segments, err := List(dir)
+			require.NoError(t, err)
+			tt.corrupt(t, segments)
+
+			removed, err := Repair(dir)
+			require.NoError(t, err)
+			assert.Equal(t, tt.removed, removed)
+			assert.Equal(t, tt.expected, readWAL(t, dir))
+
+			// the repaired WAL can be opened and written to
+			log, err := wal.Open(dir, nil)
+			require.NoError(t, err)
+			last, err := log.LastIndex()
+			require.NoError(t, err)
+			assert.Equal(t, uint64(len(tt.expected)), last)
+			require.NoError(t, log.Write(last+1, []byte("next")))
+			require.NoError(t, log.Close())
+		})
+	}
+}
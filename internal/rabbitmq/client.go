@@ -29,6 +29,8 @@ type Connection interface {
 type Channel interface {
 	Confirm(noWait bool) error
 	PublishWithDeferredConfirmWithContext(ctx context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) (DeferredConfirmation, error)
+	Qos(prefetchCount, prefetchSize int, global bool) error
+	ConsumeWithContext(ctx context.Context, queue, consumer string, autoAck, exclusive, noLocal, noWait bool, args amqp.Table) (<-chan amqp.Delivery, error)
 	IsClosed() bool
 	Close() error
 }
@@ -158,6 +160,10 @@ func (c *connectionHolder) isConnected() bool {
 }
 
 func (c *connectionHolder) Channel() (Channel, error) {
+	// The connection may be restored concurrently by another user of the connection.
+	c.connLock.Lock()
+	defer c.connLock.Unlock()
+
 	channel, err := c.connection.Channel()
 	if err != nil {
 		return nil, err
@@ -185,6 +191,14 @@ func (c *channelHolder) PublishWithDeferredConfirmWithContext(ctx context.Contex
 	return &deferredConfirmationHolder{confirmation: confirmation}, nil
 }
 
+func (c *channelHolder) Qos(prefetchCount, prefetchSize int, global bool) error {
+	return c.channel.Qos(prefetchCount, prefetchSize, global)
+}
+
+func (c *channelHolder) ConsumeWithContext(ctx context.Context, queue, consumer string, autoAck, exclusive, noLocal, noWait bool, args amqp.Table) (<-chan amqp.Delivery, error) {
+	return c.channel.ConsumeWithContext(ctx, queue, consumer, autoAck, exclusive, noLocal, noWait, args)
+}
+
 func (c *channelHolder) IsClosed() bool {
 	return c.channel.IsClosed()
 }
@@ -60,6 +60,16 @@ func (m *MockChannel) PublishWithDeferredConfirmWithContext(ctx context.Context,
 	return args.Get(0).(DeferredConfirmation), args.Error(1)
 }
 
+func (m *MockChannel) Qos(prefetchCount, prefetchSize int, global bool) error {
+	args := m.Called(prefetchCount, prefetchSize, global)
+	return args.Error(0)
+}
+
+func (m *MockChannel) ConsumeWithContext(ctx context.Context, queue, consumer string, autoAck, exclusive, noLocal, noWait bool, table amqp.Table) (<-chan amqp.Delivery, error) {
+	args := m.Called(ctx, queue, consumer, autoAck, exclusive, noLocal, noWait, table)
+	return args.Get(0).(chan amqp.Delivery), args.Error(1)
+}
+
 func (m *MockChannel) IsClosed() bool {
 	args := m.Called()
 	return args.Bool(0)
@@ -188,3 +198,20 @@ func TestPublishWithDeferredConfirmWithContext(t *testing.T) {
 	mockChan.AssertExpectations(t)
 	mockDefConf.AssertExpectations(t)
 }
+
+func TestConsumeWithContext(t *testing.T) {
+	mockChan := new(MockChannel)
+	ctx := t.Context()
+	deliveries := make(chan amqp.Delivery, 1)
+	deliveries <- amqp.Delivery{Body: []byte("body")}
+
+	mockChan.On("Qos", 10, 0, false).Return(nil)
+	mockChan.On("ConsumeWithContext", ctx, "queue", "", false, false, false, false, amqp.Table(nil)).Return(deliveries, nil)
+
+	assert.NoError(t, mockChan.Qos(10, 0, false))
+	consumed, err := mockChan.ConsumeWithContext(ctx, "queue", "", false, false, false, false, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("body"), (<-consumed).Body)
+
+	mockChan.AssertExpectations(t)
+}
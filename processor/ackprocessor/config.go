@@ -0,0 +1,26 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ackprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/ackprocessor"
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+var _ component.Config = (*Config)(nil)
+
+// Config defines configuration for the ack processor.
+type Config struct {
+	// Extension holds the ID of the ack extension to acknowledge the data to.
+	Extension component.ID `mapstructure:"extension"`
+}
+
+// Validate checks the processor configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.Extension == (component.ID{}) {
+		return errors.New("extension must be specified")
+	}
+	return nil
+}
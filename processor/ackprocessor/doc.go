@@ -0,0 +1,9 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package ackprocessor implements a processor which acknowledges the data
+// consumed by the rest of its pipeline to an ack extension, so that receivers
+// can defer acknowledging the data to its source until then.
+package ackprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/ackprocessor"
@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ackprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/ackprocessor"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/ackprocessor/internal/metadata"
+)
+
+// NewFactory creates a factory for the ack processor.
+func NewFactory() processor.Factory {
+	return processor.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		processor.WithTraces(createTracesProcessor, metadata.TracesStability),
+		processor.WithMetrics(createMetricsProcessor, metadata.MetricsStability),
+		processor.WithLogs(createLogsProcessor, metadata.LogsStability),
+	)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{}
+}
+
+func createTracesProcessor(
+	_ context.Context,
+	_ processor.Settings,
+	cfg component.Config,
+	nextConsumer consumer.Traces,
+) (processor.Traces, error) {
+	p := newAckProcessor(cfg.(*Config))
+	p.nextTraces = nextConsumer
+	return p, nil
+}
+
+func createMetricsProcessor(
+	_ context.Context,
+	_ processor.Settings,
+	cfg component.Config,
+	nextConsumer consumer.Metrics,
+) (processor.Metrics, error) {
+	p := newAckProcessor(cfg.(*Config))
+	p.nextMetrics = nextConsumer
+	return p, nil
+}
+
+func createLogsProcessor(
+	_ context.Context,
+	_ processor.Settings,
+	cfg component.Config,
+	nextConsumer consumer.Logs,
+) (processor.Logs, error) {
+	p := newAckProcessor(cfg.(*Config))
+	p.nextLogs = nextConsumer
+	return p, nil
+}
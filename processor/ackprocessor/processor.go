@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ackprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/ackprocessor"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/ackextension"
+)
+
+// ackProcessor acknowledges the data of a request once the next consumer
+// accepted the request. The ack IDs are taken from the resources of the data,
+// where receivers mark them so that they survive batching.
+type ackProcessor struct {
+	config *Config
+	ackExt ackextension.AckExtension
+
+	nextTraces  consumer.Traces
+	nextMetrics consumer.Metrics
+	nextLogs    consumer.Logs
+}
+
+func newAckProcessor(config *Config) *ackProcessor {
+	return &ackProcessor{config: config}
+}
+
+func (p *ackProcessor) Start(_ context.Context, host component.Host) error {
+	ext, found := host.GetExtensions()[p.config.Extension]
+	if !found {
+		return fmt.Errorf("specified ack extension with id %q could not be found", p.config.Extension)
+	}
+	ackExt, ok := ext.(ackextension.AckExtension)
+	if !ok {
+		return fmt.Errorf("extension with id %q is not an ack extension", p.config.Extension)
+	}
+	p.ackExt = ackExt
+	return nil
+}
+
+func (*ackProcessor) Shutdown(context.Context) error {
+	return nil
+}
+
+func (*ackProcessor) Capabilities() consumer.Capabilities {
+	// the ack ID attributes are removed from the data
+	return consumer.Capabilities{MutatesData: true}
+}
+
+func (p *ackProcessor) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	acks := ackextension.TakeTracesAcks(td)
+	if err := p.nextTraces.ConsumeTraces(ctx, td); err != nil {
+		return err
+	}
+	ackextension.AckResources(p.ackExt, acks)
+	return nil
+}
+
+func (p *ackProcessor) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	acks := ackextension.TakeMetricsAcks(md)
+	if err := p.nextMetrics.ConsumeMetrics(ctx, md); err != nil {
+		return err
+	}
+	ackextension.AckResources(p.ackExt, acks)
+	return nil
+}
+
+func (p *ackProcessor) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	acks := ackextension.TakeLogsAcks(ld)
+	if err := p.nextLogs.ConsumeLogs(ctx, ld); err != nil {
+		return err
+	}
+	ackextension.AckResources(p.ackExt, acks)
+	return nil
+}
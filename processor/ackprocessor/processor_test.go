@@ -0,0 +1,218 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ackprocessor
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processortest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/ackextension"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/ackprocessor/internal/metadata"
+)
+
+const testPartitionID = "partition"
+
+var testExtensionID = component.MustNewID("ack")
+
+type extensionsHost map[component.ID]component.Component
+
+func (h extensionsHost) GetExtensions() map[component.ID]component.Component {
+	return h
+}
+
+func newTestAckExtension(t *testing.T) ackextension.AckExtension {
+	factory := ackextension.NewFactory()
+	ext, err := factory.Create(
+		t.Context(),
+		extensiontest.NewNopSettings(factory.Type()),
+		factory.CreateDefaultConfig(),
+	)
+	require.NoError(t, err)
+	return ext.(ackextension.AckExtension)
+}
+
+// mark sets the ack attributes of ackID on res, like receivers do.
+func mark(res pcommon.Resource, ackID uint64) {
+	res.Attributes().PutStr(ackextension.PartitionIDAttribute, testPartitionID)
+	res.Attributes().PutStr(ackextension.AckIDAttribute, strconv.FormatUint(ackID, 10))
+}
+
+func TestProcessorAcks(t *testing.T) {
+	tests := []struct {
+		name    string
+		create  func(t *testing.T, err error) component.Component
+		consume func(ctx context.Context, c component.Component, ackID uint64) error
+	}{
+		{
+			name: "traces",
+			create: func(t *testing.T, err error) component.Component {
+				p, createErr := NewFactory().CreateTraces(t.Context(), processortest.NewNopSettings(metadata.Type),
+					&Config{Extension: testExtensionID}, consumertest.NewErr(err))
+				require.NoError(t, createErr)
+				return p
+			},
+			consume: func(ctx context.Context, c component.Component, ackID uint64) error {
+				td := ptrace.NewTraces()
+				mark(td.ResourceSpans().AppendEmpty().Resource(), ackID)
+				return c.(processor.Traces).ConsumeTraces(ctx, td)
+			},
+		},
+		{
+			name: "metrics",
+			create: func(t *testing.T, err error) component.Component {
+				p, createErr := NewFactory().CreateMetrics(t.Context(), processortest.NewNopSettings(metadata.Type),
+					&Config{Extension: testExtensionID}, consumertest.NewErr(err))
+				require.NoError(t, createErr)
+				return p
+			},
+			consume: func(ctx context.Context, c component.Component, ackID uint64) error {
+				md := pmetric.NewMetrics()
+				mark(md.ResourceMetrics().AppendEmpty().Resource(), ackID)
+				return c.(processor.Metrics).ConsumeMetrics(ctx, md)
+			},
+		},
+		{
+			name: "logs",
+			create: func(t *testing.T, err error) component.Component {
+				p, createErr := NewFactory().CreateLogs(t.Context(), processortest.NewNopSettings(metadata.Type),
+					&Config{Extension: testExtensionID}, consumertest.NewErr(err))
+				require.NoError(t, createErr)
+				return p
+			},
+			consume: func(ctx context.Context, c component.Component, ackID uint64) error {
+				ld := plog.NewLogs()
+				mark(ld.ResourceLogs().AppendEmpty().Resource(), ackID)
+				return c.(processor.Logs).ConsumeLogs(ctx, ld)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ackExt := newTestAckExtension(t)
+			host := extensionsHost{testExtensionID: ackExt.(component.Component)}
+
+			p := tt.create(t, nil)
+			require.NoError(t, p.Start(t.Context(), host))
+			defer func() { assert.NoError(t, p.Shutdown(context.Background())) }()
+
+			ackID := ackExt.ProcessEvent(testPartitionID)
+			require.NoError(t, tt.consume(t.Context(), p, ackID))
+			assert.Equal(t, map[uint64]bool{ackID: true}, ackExt.QueryAcks(testPartitionID, []uint64{ackID}))
+
+			failing := tt.create(t, errors.New("export failed"))
+			require.NoError(t, failing.Start(t.Context(), host))
+			defer func() { assert.NoError(t, failing.Shutdown(context.Background())) }()
+
+			ackID = ackExt.ProcessEvent(testPartitionID)
+			require.EqualError(t, tt.consume(t.Context(), failing, ackID), "export failed")
+			assert.Equal(t, map[uint64]bool{ackID: false}, ackExt.QueryAcks(testPartitionID, []uint64{ackID}))
+		})
+	}
+}
+
+// batcher batches logs like the batch processor: the data of all requests is
+// merged, and exported with a context which does not hold their client
+// metadata.
+type batcher struct {
+	next consumer.Logs
+	logs plog.Logs
+}
+
+func (b *batcher) ConsumeLogs(_ context.Context, ld plog.Logs) error {
+	ld.ResourceLogs().MoveAndAppendTo(b.logs.ResourceLogs())
+	return nil
+}
+
+func (b *batcher) flush() error {
+	logs := b.logs
+	b.logs = plog.NewLogs()
+	return b.next.ConsumeLogs(context.Background(), logs)
+}
+
+func TestProcessorAcksBatchedData(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		err     error
+		acked   bool
+		records int
+	}{
+		{name: "exported", acked: true, records: 3},
+		{name: "failed", err: errors.New("export failed")},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ackExt := newTestAckExtension(t)
+			host := extensionsHost{testExtensionID: ackExt.(component.Component)}
+
+			sink := new(consumertest.LogsSink)
+			var next consumer.Logs = sink
+			if tt.err != nil {
+				next = consumertest.NewErr(tt.err)
+			}
+			p, err := NewFactory().CreateLogs(t.Context(), processortest.NewNopSettings(metadata.Type),
+				&Config{Extension: testExtensionID}, next)
+			require.NoError(t, err)
+			require.NoError(t, p.Start(t.Context(), host))
+			defer func() { assert.NoError(t, p.Shutdown(context.Background())) }()
+
+			batcher := &batcher{next: p, logs: plog.NewLogs()}
+			var ackIDs []uint64
+			for range 3 {
+				ackID := ackExt.ProcessEvent(testPartitionID)
+				ackIDs = append(ackIDs, ackID)
+				ld := plog.NewLogs()
+				rl := ld.ResourceLogs().AppendEmpty()
+				rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+				mark(rl.Resource(), ackID)
+				require.NoError(t, batcher.ConsumeLogs(t.Context(), ld))
+			}
+
+			if tt.err != nil {
+				require.EqualError(t, batcher.flush(), tt.err.Error())
+			} else {
+				require.NoError(t, batcher.flush())
+			}
+
+			expected := map[uint64]bool{}
+			for _, ackID := range ackIDs {
+				expected[ackID] = tt.acked
+			}
+			assert.Equal(t, expected, ackExt.QueryAcks(testPartitionID, ackIDs))
+
+			assert.Equal(t, tt.records, sink.LogRecordCount())
+			for _, ld := range sink.AllLogs() {
+				for i := 0; i < ld.ResourceLogs().Len(); i++ {
+					assert.Equal(t, 0, ld.ResourceLogs().At(i).Resource().Attributes().Len())
+				}
+			}
+		})
+	}
+}
+
+func TestProcessorStartErrors(t *testing.T) {
+	p := newAckProcessor(&Config{Extension: testExtensionID})
+	assert.EqualError(t, p.Start(t.Context(), componenttest.NewNopHost()),
+		`specified ack extension with id "ack" could not be found`)
+
+	host := extensionsHost{testExtensionID: struct {
+		component.StartFunc
+		component.ShutdownFunc
+	}{}}
+	assert.EqualError(t, p.Start(t.Context(), host), `extension with id "ack" is not an ack extension`)
+}
@@ -0,0 +1,146 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cardinalitylimitprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/cardinalitylimitprocessor"
+
+import (
+	"cmp"
+	"encoding/binary"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/axiomhq/hyperloglog"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// maxTrackedKeys bounds the number of attribute keys whose cardinality is
+// estimated per budget
+const maxTrackedKeys = 64
+
+// budgetKey identifies a budget: a metric name and the values of the group_by
+// resource attributes
+type budgetKey struct {
+	metric string
+	group  string
+}
+
+// groupOf returns the values of the group_by attributes of res, formatted as
+// key=value pairs
+func groupOf(res pcommon.Resource, groupBy []string) string {
+	if len(groupBy) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	for i, k := range groupBy {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(k)
+		sb.WriteByte('=')
+		if v, ok := res.Attributes().Get(k); ok {
+			sb.WriteString(v.AsString())
+		}
+	}
+	return sb.String()
+}
+
+// budget tracks the active series of a single [budgetKey]. Admitted series are
+// tracked exactly, so their number is bounded by the limit. All series and the
+// values of their attribute keys are counted in sketches, which are bounded in
+// size, to estimate the cardinality that would have been produced.
+type budget struct {
+	limit int
+
+	// last time each admitted series was seen, by series hash
+	series map[uint64]time.Time
+
+	// all series and attribute values seen since the last sweep
+	seen *hyperloglog.Sketch
+	keys map[string]*hyperloglog.Sketch
+
+	// whether series were rejected since the budget last had room
+	exceeded bool
+}
+
+func newBudget(limit int) *budget {
+	return &budget{
+		limit:  limit,
+		series: make(map[uint64]time.Time),
+		seen:   hyperloglog.New14(),
+		keys:   make(map[string]*hyperloglog.Sketch),
+	}
+}
+
+// admit reports whether the series with the given hash and datapoint attributes
+// fits into the budget. Series fit if they were admitted before, or if the
+// budget has room left.
+func (b *budget) admit(series uint64, attrs pcommon.Map, now time.Time) bool {
+	if _, ok := b.series[series]; ok {
+		b.series[series] = now
+		return true
+	}
+
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], series)
+	b.seen.Insert(buf[:])
+	for k, v := range attrs.All() {
+		sk, ok := b.keys[k]
+		if !ok {
+			if len(b.keys) >= maxTrackedKeys {
+				continue
+			}
+			sk = hyperloglog.New14()
+			b.keys[k] = sk
+		}
+		sk.Insert([]byte(v.AsString()))
+	}
+
+	if len(b.series) < b.limit {
+		b.series[series] = now
+		return true
+	}
+	return false
+}
+
+// sweep removes series not seen since before stale and starts a new window for
+// the sketches. It reports whether the budget is empty afterwards.
+func (b *budget) sweep(stale time.Time) bool {
+	for id, last := range b.series {
+		if last.Before(stale) {
+			delete(b.series, id)
+		}
+	}
+	if len(b.series) < b.limit {
+		b.exceeded = false
+	}
+	b.seen = hyperloglog.New14()
+	clear(b.keys)
+	return len(b.series) == 0
+}
+
+type keyEstimate struct {
+	key    string
+	values uint64
+}
+
+func (e keyEstimate) String() string {
+	return fmt.Sprintf("%s(~%d)", e.key, e.values)
+}
+
+// offenders returns the attribute keys with the most distinct values,
+// highest first
+func (b *budget) offenders(n int) []keyEstimate {
+	estimates := make([]keyEstimate, 0, len(b.keys))
+	for k, sk := range b.keys {
+		estimates = append(estimates, keyEstimate{key: k, values: sk.Estimate()})
+	}
+	slices.SortFunc(estimates, func(a, b keyEstimate) int {
+		if c := cmp.Compare(b.values, a.values); c != 0 {
+			return c
+		}
+		return strings.Compare(a.key, b.key)
+	})
+	return estimates[:min(n, len(estimates))]
+}
@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cardinalitylimitprocessor
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestBudget(t *testing.T) {
+	b := newBudget(2)
+	attrs := func(user int) pcommon.Map {
+		m := pcommon.NewMap()
+		m.PutStr("http.route", "/")
+		m.PutStr("user.id", strconv.Itoa(user))
+		return m
+	}
+	start := time.Unix(1000, 0)
+
+	require.True(t, b.admit(1, attrs(1), start))
+	require.True(t, b.admit(2, attrs(2), start))
+	require.False(t, b.admit(3, attrs(3), start))
+	// known series are still admitted
+	require.True(t, b.admit(1, attrs(1), start.Add(time.Minute)))
+
+	for i := 4; i < 100; i++ {
+		require.False(t, b.admit(uint64(i), attrs(i), start))
+	}
+
+	offenders := b.offenders(5)
+	require.Len(t, offenders, 2)
+	require.Equal(t, "user.id", offenders[0].key)
+	require.InDelta(t, 99, offenders[0].values, 5)
+	require.Equal(t, keyEstimate{key: "http.route", values: 1}, offenders[1])
+	require.InDelta(t, 99, b.seen.Estimate(), 5)
+
+	// series 2 expires, making room for series 3
+	require.False(t, b.sweep(start.Add(time.Second)))
+	require.Len(t, b.series, 1)
+	require.Empty(t, b.offenders(5))
+	require.True(t, b.admit(3, attrs(3), start.Add(time.Minute)))
+
+	require.True(t, b.sweep(start.Add(time.Hour)))
+}
+
+func TestGroupOf(t *testing.T) {
+	res := pcommon.NewResource()
+	res.Attributes().PutStr("service.name", "checkout")
+	res.Attributes().PutInt("service.shard", 3)
+
+	require.Empty(t, groupOf(res, nil))
+	require.Equal(t, "service.name=checkout,service.shard=3,missing=", groupOf(res, []string{"service.name", "service.shard", "missing"}))
+}
@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cardinalitylimitprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/cardinalitylimitprocessor"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+)
+
+var _ xconfmap.Validator = (*Config)(nil)
+
+type Config struct {
+	// DefaultLimit is the maximum number of active series per metric, unless
+	// overridden in Metrics. Zero does not limit the number of series.
+	DefaultLimit int `mapstructure:"default_limit"`
+	// Metrics overrides the limit of individual metrics.
+	Metrics []MetricLimit `mapstructure:"metrics"`
+
+	// GroupBy are resource attributes that partition the budget of a metric:
+	// each combination of their values gets its own limit.
+	GroupBy []string `mapstructure:"group_by"`
+
+	// Action is what happens to series exceeding the limit.
+	Action Action `mapstructure:"action"`
+
+	// Expiry is the time after which a series that received no datapoints is
+	// no longer active.
+	Expiry time.Duration `mapstructure:"expiry"`
+}
+
+type MetricLimit struct {
+	// Name is the exact name of the metric.
+	Name string `mapstructure:"name"`
+	// Limit is the maximum number of active series of the metric. Zero does
+	// not limit the number of series.
+	Limit int `mapstructure:"limit"`
+}
+
+type Action string
+
+const (
+	// ActionDrop drops the datapoints of series exceeding the limit.
+	ActionDrop Action = "drop"
+	// ActionOverflow folds the datapoints of series exceeding the limit into
+	// a single series with only the otel.metric.overflow attribute.
+	ActionOverflow Action = "overflow"
+)
+
+func (c *Config) Validate() error {
+	if c.DefaultLimit < 0 {
+		return fmt.Errorf("default_limit must not be negative (got %d)", c.DefaultLimit)
+	}
+	seen := make(map[string]struct{}, len(c.Metrics))
+	for i, m := range c.Metrics {
+		if m.Name == "" {
+			return fmt.Errorf("metrics::%d: name must not be empty", i)
+		}
+		if _, ok := seen[m.Name]; ok {
+			return fmt.Errorf("metrics::%d: duplicate limit for metric %q", i, m.Name)
+		}
+		seen[m.Name] = struct{}{}
+		if m.Limit < 0 {
+			return fmt.Errorf("metrics::%d: limit must not be negative (got %d)", i, m.Limit)
+		}
+	}
+	switch c.Action {
+	case ActionDrop, ActionOverflow:
+	default:
+		return fmt.Errorf("action must be %q or %q (got %q)", ActionDrop, ActionOverflow, c.Action)
+	}
+	if c.Expiry <= 0 {
+		return errors.New("expiry must be a positive duration")
+	}
+	return nil
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		// the default cardinality limit of the OpenTelemetry SDKs
+		DefaultLimit: 2000,
+		Action:       ActionOverflow,
+		Expiry:       5 * time.Minute,
+	}
+}
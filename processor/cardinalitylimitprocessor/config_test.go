@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cardinalitylimitprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/cardinalitylimitprocessor"
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/cardinalitylimitprocessor/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id       component.ID
+		expected component.Config
+	}{
+		{
+			id: component.NewIDWithName(metadata.Type, "all"),
+			expected: &Config{
+				DefaultLimit: 500,
+				Metrics: []MetricLimit{
+					{Name: "http.server.request.duration", Limit: 5000},
+					{Name: "process.cpu.time", Limit: 0},
+				},
+				GroupBy: []string{"service.name"},
+				Action:  ActionDrop,
+				Expiry:  10 * time.Minute,
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "defaults"),
+			expected: &Config{
+				DefaultLimit: 2000,
+				Action:       ActionOverflow,
+				Expiry:       5 * time.Minute,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			assert.NoError(t, xconfmap.Validate(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		err    string
+	}{
+		{
+			name:   "default_limit",
+			modify: func(c *Config) { c.DefaultLimit = -1 },
+			err:    "default_limit must not be negative (got -1)",
+		},
+		{
+			name:   "name",
+			modify: func(c *Config) { c.Metrics = []MetricLimit{{Limit: 1}} },
+			err:    "metrics::0: name must not be empty",
+		},
+		{
+			name:   "duplicate",
+			modify: func(c *Config) { c.Metrics = []MetricLimit{{Name: "a"}, {Name: "a"}} },
+			err:    `metrics::1: duplicate limit for metric "a"`,
+		},
+		{
+			name:   "limit",
+			modify: func(c *Config) { c.Metrics = []MetricLimit{{Name: "a", Limit: -1}} },
+			err:    "metrics::0: limit must not be negative (got -1)",
+		},
+		{
+			name:   "action",
+			modify: func(c *Config) { c.Action = "sample" },
+			err:    `action must be "drop" or "overflow" (got "sample")`,
+		},
+		{
+			name:   "expiry",
+			modify: func(c *Config) { c.Expiry = 0 },
+			err:    "expiry must be a positive duration",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			require.NoError(t, cfg.Validate())
+
+			tt.modify(cfg)
+			require.EqualError(t, cfg.Validate(), tt.err)
+		})
+	}
+}
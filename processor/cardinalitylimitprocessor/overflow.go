@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cardinalitylimitprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/cardinalitylimitprocessor"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/data"
+)
+
+// overflowKey is the attribute of the series that series exceeding the limit
+// are folded into, see
+// https://opentelemetry.io/docs/specs/otel/metrics/sdk/#overflow-attribute
+const overflowKey = "otel.metric.overflow"
+
+type point[Self any] interface {
+	pmetric.NumberDataPoint | pmetric.HistogramDataPoint | pmetric.ExponentialHistogramDataPoint | pmetric.SummaryDataPoint
+
+	Attributes() pcommon.Map
+	StartTimestamp() pcommon.Timestamp
+	SetStartTimestamp(pcommon.Timestamp)
+	Timestamp() pcommon.Timestamp
+	SetTimestamp(pcommon.Timestamp)
+	CopyTo(Self)
+}
+
+// limit removes the datapoints of dps not admitted. If fold is set, they are
+// merged into a single overflow datapoint instead, which takes the place of the
+// first of them. It returns the number of datapoints not admitted.
+func limit[DP point[DP]](dps interface{ RemoveIf(func(DP) bool) }, admit func(DP) bool, fold func(state, dp DP)) int {
+	var (
+		limited  int
+		overflow DP
+	)
+	dps.RemoveIf(func(dp DP) bool {
+		if admit(dp) {
+			return false
+		}
+		limited++
+		if fold == nil {
+			return true
+		}
+
+		if limited == 1 {
+			overflow = dp
+			overflow.Attributes().Clear()
+			overflow.Attributes().PutBool(overflowKey, true)
+			return false
+		}
+		if dp.StartTimestamp() < overflow.StartTimestamp() {
+			overflow.SetStartTimestamp(dp.StartTimestamp())
+		}
+		if dp.Timestamp() > overflow.Timestamp() {
+			overflow.SetTimestamp(dp.Timestamp())
+		}
+		fold(overflow, dp)
+		return true
+	})
+	return limited
+}
+
+// foldSum adds the value of dp to state
+func foldSum(state, dp pmetric.NumberDataPoint) {
+	if state.ValueType() != dp.ValueType() {
+		state.SetDoubleValue(number(state))
+		dp.SetDoubleValue(number(dp))
+	}
+	_ = data.Adder{}.Numbers(state, dp)
+}
+
+// foldGauge keeps the latest value of state and dp. state already covers the
+// timestamp of dp, so dp is the latest unless it is older than state.
+func foldGauge(state, dp pmetric.NumberDataPoint) {
+	if dp.Timestamp() < state.Timestamp() {
+		return
+	}
+	switch dp.ValueType() {
+	case pmetric.NumberDataPointValueTypeInt:
+		state.SetIntValue(dp.IntValue())
+	case pmetric.NumberDataPointValueTypeDouble:
+		state.SetDoubleValue(dp.DoubleValue())
+	}
+}
+
+func foldHistogram(state, dp pmetric.HistogramDataPoint) {
+	_ = data.Adder{}.Histograms(state, dp)
+}
+
+func foldExponential(state, dp pmetric.ExponentialHistogramDataPoint) {
+	_ = data.Adder{}.Exponential(state, dp)
+}
+
+func number(dp pmetric.NumberDataPoint) float64 {
+	if dp.ValueType() == pmetric.NumberDataPointValueTypeInt {
+		return float64(dp.IntValue())
+	}
+	return dp.DoubleValue()
+}
@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cardinalitylimitprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// admitUsers admits the datapoints of the given users only
+func admitUsers[DP interface{ Attributes() pcommon.Map }](users ...string) func(DP) bool {
+	return func(dp DP) bool {
+		user, _ := dp.Attributes().Get("user.id")
+		for _, u := range users {
+			if user.Str() == u {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func sumPoints(values ...int64) pmetric.NumberDataPointSlice {
+	dps := pmetric.NewNumberDataPointSlice()
+	for i, v := range values {
+		dp := dps.AppendEmpty()
+		dp.Attributes().PutStr("user.id", string(rune('a'+i)))
+		dp.SetStartTimestamp(pcommon.Timestamp(10 + i))
+		dp.SetTimestamp(pcommon.Timestamp(20 + i))
+		dp.SetIntValue(v)
+	}
+	return dps
+}
+
+func TestLimitDrop(t *testing.T) {
+	dps := sumPoints(1, 2, 3, 4)
+	n := limit(dps, admitUsers[pmetric.NumberDataPoint]("a", "c"), nil)
+	require.Equal(t, 2, n)
+	require.Equal(t, 2, dps.Len())
+	require.Equal(t, int64(1), dps.At(0).IntValue())
+	require.Equal(t, int64(3), dps.At(1).IntValue())
+}
+
+func TestLimitOverflow(t *testing.T) {
+	t.Run("sum", func(t *testing.T) {
+		dps := sumPoints(1, 2, 3, 4)
+		dps.At(3).SetDoubleValue(0.5)
+
+		n := limit(dps, admitUsers[pmetric.NumberDataPoint]("a"), foldSum)
+		require.Equal(t, 3, n)
+		require.Equal(t, 2, dps.Len())
+
+		overflow := dps.At(1)
+		require.Equal(t, map[string]any{overflowKey: true}, overflow.Attributes().AsRaw())
+		require.Equal(t, 5.5, overflow.DoubleValue())
+		require.Equal(t, pcommon.Timestamp(11), overflow.StartTimestamp())
+		require.Equal(t, pcommon.Timestamp(23), overflow.Timestamp())
+	})
+
+	t.Run("gauge", func(t *testing.T) {
+		dps := sumPoints(1, 2, 3, 4)
+		// the latest overflowing datapoint is not the last one
+		dps.At(2).SetTimestamp(30)
+
+		n := limit(dps, admitUsers[pmetric.NumberDataPoint](), foldGauge)
+		require.Equal(t, 4, n)
+		require.Equal(t, 1, dps.Len())
+		require.Equal(t, int64(3), dps.At(0).IntValue())
+		require.Equal(t, pcommon.Timestamp(30), dps.At(0).Timestamp())
+	})
+
+	t.Run("histogram", func(t *testing.T) {
+		dps := pmetric.NewHistogramDataPointSlice()
+		for _, user := range []string{"a", "b", "c"} {
+			dp := dps.AppendEmpty()
+			dp.Attributes().PutStr("user.id", user)
+			dp.ExplicitBounds().FromRaw([]float64{1})
+			dp.BucketCounts().FromRaw([]uint64{1, 2})
+			dp.SetCount(3)
+		}
+
+		n := limit(dps, admitUsers[pmetric.HistogramDataPoint]("b"), foldHistogram)
+		require.Equal(t, 2, n)
+		require.Equal(t, 2, dps.Len())
+		require.Equal(t, map[string]any{overflowKey: true}, dps.At(0).Attributes().AsRaw())
+		require.Equal(t, []uint64{2, 4}, dps.At(0).BucketCounts().AsRaw())
+		require.Equal(t, uint64(6), dps.At(0).Count())
+	})
+}
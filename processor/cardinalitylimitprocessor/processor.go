@@ -0,0 +1,276 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cardinalitylimitprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/cardinalitylimitprocessor"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/identity"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/cardinalitylimitprocessor/internal/metadata"
+)
+
+// maxOffenders is the number of attribute keys logged when a limit is exceeded
+const maxOffenders = 5
+
+var _ processor.Metrics = (*limitProcessor)(nil)
+
+type limitProcessor struct {
+	next   consumer.Metrics
+	cfg    Config
+	log    *zap.Logger
+	tel    *metadata.TelemetryBuilder
+	limits map[string]int
+
+	mtx     sync.Mutex
+	budgets map[budgetKey]*budget
+	active  int
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   sync.WaitGroup
+}
+
+func newProcessor(cfg *Config, set processor.Settings, next consumer.Metrics) (*limitProcessor, error) {
+	tel, err := metadata.NewTelemetryBuilder(set.TelemetrySettings)
+	if err != nil {
+		return nil, err
+	}
+
+	limits := make(map[string]int, len(cfg.Metrics))
+	for _, m := range cfg.Metrics {
+		limits[m.Name] = m.Limit
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &limitProcessor{
+		next:   next,
+		cfg:    *cfg,
+		log:    set.Logger,
+		tel:    tel,
+		limits: limits,
+
+		budgets: make(map[budgetKey]*budget),
+
+		ctx:    ctx,
+		cancel: cancel,
+	}
+
+	err = tel.RegisterCardinalitylimitSeriesActiveCallback(func(_ context.Context, observer metric.Int64Observer) error {
+		p.mtx.Lock()
+		defer p.mtx.Unlock()
+		observer.Observe(int64(p.active))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *limitProcessor) Start(_ context.Context, _ component.Host) error {
+	p.done.Add(1)
+	go func() {
+		defer p.done.Done()
+
+		tick := time.NewTicker(p.cfg.Expiry)
+		defer tick.Stop()
+		for {
+			select {
+			case <-p.ctx.Done():
+				return
+			case now := <-tick.C:
+				p.sweep(now)
+			}
+		}
+	}()
+	return nil
+}
+
+func (p *limitProcessor) Shutdown(_ context.Context) error {
+	p.cancel()
+	p.done.Wait()
+	p.tel.Shutdown()
+	return nil
+}
+
+func (*limitProcessor) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: true}
+}
+
+func (p *limitProcessor) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	now := time.Now()
+	overflow := p.cfg.Action == ActionOverflow
+
+	limited := make(map[Action]int, 2)
+	p.mtx.Lock()
+	md.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+		group := groupOf(rm.Resource(), p.cfg.GroupBy)
+		rm.ScopeMetrics().RemoveIf(func(sm pmetric.ScopeMetrics) bool {
+			sm.Metrics().RemoveIf(func(m pmetric.Metric) bool {
+				budgetLimit := p.limitOf(m.Name())
+				if budgetLimit == 0 {
+					return false
+				}
+
+				key := budgetKey{metric: m.Name(), group: group}
+				b, ok := p.budgets[key]
+				if !ok {
+					b = newBudget(budgetLimit)
+					p.budgets[key] = b
+				}
+
+				id := identity.OfResourceMetric(rm.Resource(), sm.Scope(), m)
+				n, action := p.enforce(m, id, b, now, overflow)
+				if n > 0 && !b.exceeded {
+					b.exceeded = true
+					p.exceeded(ctx, key, b)
+				}
+				limited[action] += n
+				return datapoints(m) == 0
+			})
+			return sm.Metrics().Len() == 0
+		})
+		return rm.ScopeMetrics().Len() == 0
+	})
+	p.mtx.Unlock()
+
+	for action, n := range limited {
+		if n > 0 {
+			p.tel.CardinalitylimitDatapointsLimited.Add(ctx, int64(n), metric.WithAttributes(attribute.String("action", string(action))))
+		}
+	}
+
+	if md.ResourceMetrics().Len() == 0 {
+		return nil
+	}
+	return p.next.ConsumeMetrics(ctx, md)
+}
+
+// limitOf returns the limit of the metric with the given name
+func (p *limitProcessor) limitOf(name string) int {
+	if limit, ok := p.limits[name]; ok {
+		return limit
+	}
+	return p.cfg.DefaultLimit
+}
+
+// enforce removes or folds the datapoints of m whose series do not fit into b.
+// It returns the number of such datapoints and whether they were dropped or
+// folded.
+//
+// The overflow datapoint is built anew from the datapoints of each batch. For
+// cumulative sums and histograms, its value would depend on which series
+// happened to overflow within the batch, and go up and down between batches,
+// which reads as counter resets. Their datapoints, as well as the always
+// cumulative summaries, are dropped instead of folded.
+func (p *limitProcessor) enforce(m pmetric.Metric, id identity.Metric, b *budget, now time.Time, overflow bool) (int, Action) {
+	before := len(b.series)
+	defer func() { p.active += len(b.series) - before }()
+
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		return limit(m.Gauge().DataPoints(), admitter[pmetric.NumberDataPoint](id, b, now), foldIf(overflow, foldGauge)), actionOf(overflow)
+	case pmetric.MetricTypeSum:
+		fold := overflow && m.Sum().AggregationTemporality() == pmetric.AggregationTemporalityDelta
+		return limit(m.Sum().DataPoints(), admitter[pmetric.NumberDataPoint](id, b, now), foldIf(fold, foldSum)), actionOf(fold)
+	case pmetric.MetricTypeHistogram:
+		fold := overflow && m.Histogram().AggregationTemporality() == pmetric.AggregationTemporalityDelta
+		return limit(m.Histogram().DataPoints(), admitter[pmetric.HistogramDataPoint](id, b, now), foldIf(fold, foldHistogram)), actionOf(fold)
+	case pmetric.MetricTypeExponentialHistogram:
+		fold := overflow && m.ExponentialHistogram().AggregationTemporality() == pmetric.AggregationTemporalityDelta
+		return limit(m.ExponentialHistogram().DataPoints(), admitter[pmetric.ExponentialHistogramDataPoint](id, b, now), foldIf(fold, foldExponential)), actionOf(fold)
+	case pmetric.MetricTypeSummary:
+		return limit(m.Summary().DataPoints(), admitter[pmetric.SummaryDataPoint](id, b, now), nil), ActionDrop
+	}
+	return 0, ActionDrop
+}
+
+func admitter[DP point[DP]](id identity.Metric, b *budget, now time.Time) func(DP) bool {
+	return func(dp DP) bool {
+		if v, ok := dp.Attributes().Get(overflowKey); ok && v.Bool() {
+			// already folded upstream, not a series of its own
+			return true
+		}
+		return b.admit(identity.OfStream(id, dp).Hash().Sum64(), dp.Attributes(), now)
+	}
+}
+
+func foldIf[DP any](overflow bool, fold func(state, dp DP)) func(state, dp DP) {
+	if !overflow {
+		return nil
+	}
+	return fold
+}
+
+func actionOf(fold bool) Action {
+	if fold {
+		return ActionOverflow
+	}
+	return ActionDrop
+}
+
+// exceeded reports a budget that was exceeded
+func (p *limitProcessor) exceeded(ctx context.Context, key budgetKey, b *budget) {
+	p.tel.CardinalitylimitLimitsExceeded.Add(ctx, 1)
+
+	offenders := b.offenders(maxOffenders)
+	keys := make([]string, len(offenders))
+	for i, o := range offenders {
+		keys[i] = o.String()
+	}
+
+	fields := []zap.Field{
+		zap.String("metric", key.metric),
+		zap.Int("limit", b.limit),
+		zap.Uint64("estimated_series", b.seen.Estimate()),
+		zap.Strings("attribute_keys", keys),
+		zap.String("action", string(p.cfg.Action)),
+	}
+	if key.group != "" {
+		fields = append(fields, zap.String("group", key.group))
+	}
+	p.log.Warn("metric exceeds its cardinality limit", fields...)
+}
+
+// sweep expires series not seen within the expiry and removes empty budgets
+func (p *limitProcessor) sweep(now time.Time) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	stale := now.Add(-p.cfg.Expiry)
+	p.active = 0
+	for key, b := range p.budgets {
+		if b.sweep(stale) {
+			delete(p.budgets, key)
+			continue
+		}
+		p.active += len(b.series)
+	}
+}
+
+func datapoints(m pmetric.Metric) int {
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		return m.Gauge().DataPoints().Len()
+	case pmetric.MetricTypeSum:
+		return m.Sum().DataPoints().Len()
+	case pmetric.MetricTypeHistogram:
+		return m.Histogram().DataPoints().Len()
+	case pmetric.MetricTypeExponentialHistogram:
+		return m.ExponentialHistogram().DataPoints().Len()
+	case pmetric.MetricTypeSummary:
+		return m.Summary().DataPoints().Len()
+	}
+	return 0
+}
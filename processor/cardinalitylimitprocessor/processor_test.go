@@ -0,0 +1,180 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cardinalitylimitprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/cardinalitylimitprocessor"
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/cardinalitylimitprocessor/internal/metadatatest"
+)
+
+func requests(service string, users int) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", service)
+	ms := rm.ScopeMetrics().AppendEmpty().Metrics()
+
+	m := ms.AppendEmpty()
+	m.SetName("requests")
+	sum := m.SetEmptySum()
+	sum.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	for i := 1; i <= users; i++ {
+		dp := sum.DataPoints().AppendEmpty()
+		dp.Attributes().PutStr("http.route", "/")
+		dp.Attributes().PutStr("user.id", strconv.Itoa(i))
+		dp.SetIntValue(int64(i))
+	}
+
+	// not limited
+	g := ms.AppendEmpty()
+	g.SetName("up")
+	gauge := g.SetEmptyGauge()
+	for i := 1; i <= users; i++ {
+		dp := gauge.DataPoints().AppendEmpty()
+		dp.Attributes().PutStr("user.id", strconv.Itoa(i))
+		dp.SetIntValue(1)
+	}
+	return md
+}
+
+func TestProcessor(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.DefaultLimit = 2
+	cfg.Metrics = []MetricLimit{{Name: "up", Limit: 0}}
+	cfg.GroupBy = []string{"service.name"}
+
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+	set := metadatatest.NewSettings(tel)
+	core, logs := observer.New(zap.WarnLevel)
+	set.Logger = zap.New(core)
+
+	next := new(consumertest.MetricsSink)
+	p, err := newProcessor(cfg, set, next)
+	require.NoError(t, err)
+
+	require.NoError(t, p.ConsumeMetrics(t.Context(), requests("checkout", 4)))
+	// other services have their own limit
+	require.NoError(t, p.ConsumeMetrics(t.Context(), requests("cart", 2)))
+	// known series pass, the limit was already reported
+	require.NoError(t, p.ConsumeMetrics(t.Context(), requests("checkout", 3)))
+
+	got := next.AllMetrics()
+	require.Len(t, got, 3)
+
+	limited := func(md pmetric.Metrics) []map[string]any {
+		dps := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints()
+		out := make([]map[string]any, dps.Len())
+		for i := 0; i < dps.Len(); i++ {
+			out[i] = dps.At(i).Attributes().AsRaw()
+			out[i]["value"] = dps.At(i).IntValue()
+		}
+		return out
+	}
+	require.Equal(t, []map[string]any{
+		{"http.route": "/", "user.id": "1", "value": int64(1)},
+		{"http.route": "/", "user.id": "2", "value": int64(2)},
+		{overflowKey: true, "value": int64(7)},
+	}, limited(got[0]))
+	require.Len(t, limited(got[1]), 2)
+	require.Equal(t, []map[string]any{
+		{"http.route": "/", "user.id": "1", "value": int64(1)},
+		{"http.route": "/", "user.id": "2", "value": int64(2)},
+		{overflowKey: true, "value": int64(3)},
+	}, limited(got[2]))
+
+	// unlimited metrics are untouched
+	require.Equal(t, 4, got[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(1).Gauge().DataPoints().Len())
+
+	require.Equal(t, 1, logs.Len())
+	entry := logs.All()[0]
+	require.Equal(t, "metric exceeds its cardinality limit", entry.Message)
+	fields := entry.ContextMap()
+	require.Equal(t, "requests", fields["metric"])
+	require.Equal(t, "service.name=checkout", fields["group"])
+	require.Equal(t, []any{"user.id(~4)", "http.route(~1)"}, fields["attribute_keys"])
+
+	metadatatest.AssertEqualCardinalitylimitDatapointsLimited(t, tel, []metricdata.DataPoint[int64]{{
+		Value:      3,
+		Attributes: attribute.NewSet(attribute.String("action", "overflow")),
+	}}, metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualCardinalitylimitLimitsExceeded(t, tel, []metricdata.DataPoint[int64]{{Value: 1}}, metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualCardinalitylimitSeriesActive(t, tel, []metricdata.DataPoint[int64]{{Value: 4}}, metricdatatest.IgnoreTimestamp())
+
+	p.sweep(time.Now().Add(2 * cfg.Expiry))
+	require.Empty(t, p.budgets)
+	metadatatest.AssertEqualCardinalitylimitSeriesActive(t, tel, []metricdata.DataPoint[int64]{{Value: 0}}, metricdatatest.IgnoreTimestamp())
+}
+
+func TestDrop(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.DefaultLimit = 1
+	cfg.Action = ActionDrop
+
+	next := new(consumertest.MetricsSink)
+	p, err := NewFactory().CreateMetrics(t.Context(), metadatatest.NewSettings(componenttest.NewTelemetry()), cfg, next)
+	require.NoError(t, err)
+
+	require.NoError(t, p.ConsumeMetrics(t.Context(), requests("checkout", 3)))
+	// one series of each metric
+	require.Equal(t, 2, next.DataPointCount())
+}
+
+func TestOverflowCumulative(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.DefaultLimit = 2
+
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+
+	next := new(consumertest.MetricsSink)
+	p, err := newProcessor(cfg, metadatatest.NewSettings(tel), next)
+	require.NoError(t, err)
+
+	// different series overflow in each batch, so folding them would make
+	// the cumulative overflow series go up and down
+	for _, users := range []int{4, 3} {
+		md := requests("checkout", users)
+		md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		require.NoError(t, p.ConsumeMetrics(t.Context(), md))
+	}
+
+	got := next.AllMetrics()
+	require.Len(t, got, 2)
+	for _, md := range got {
+		ms := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+		// cumulative sums are dropped
+		sum := ms.At(0).Sum().DataPoints()
+		require.Equal(t, 2, sum.Len())
+		for i := 0; i < sum.Len(); i++ {
+			_, ok := sum.At(i).Attributes().Get(overflowKey)
+			require.False(t, ok)
+		}
+		// gauges are still folded
+		gauge := ms.At(1).Gauge().DataPoints()
+		require.Equal(t, 3, gauge.Len())
+		require.Equal(t, map[string]any{overflowKey: true}, gauge.At(2).Attributes().AsRaw())
+	}
+
+	metadatatest.AssertEqualCardinalitylimitDatapointsLimited(t, tel, []metricdata.DataPoint[int64]{{
+		Value:      3,
+		Attributes: attribute.NewSet(attribute.String("action", "drop")),
+	}, {
+		Value:      3,
+		Attributes: attribute.NewSet(attribute.String("action", "overflow")),
+	}}, metricdatatest.IgnoreTimestamp())
+}
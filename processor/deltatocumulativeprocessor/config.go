@@ -20,6 +20,25 @@ var _ xconfmap.Validator = (*Config)(nil)
 type Config struct {
 	MaxStale   time.Duration `mapstructure:"max_stale"`
 	MaxStreams int           `mapstructure:"max_streams"`
+
+	// ReorderWindow is how much older than the latest sample of a stream a
+	// sample may be to still be accumulated, as long as the interval it covers
+	// was not accumulated already. Zero drops all out of order samples.
+	ReorderWindow time.Duration `mapstructure:"reorder_window"`
+
+	// Storage is the ID of an optional storage extension. If set, the tracked
+	// streams are periodically written to it and restored on startup.
+	Storage *component.ID `mapstructure:"storage"`
+	// Snapshot configures how streams are persisted to Storage.
+	Snapshot SnapshotConfig `mapstructure:"snapshot"`
+}
+
+type SnapshotConfig struct {
+	// Interval at which the tracked streams are written to storage.
+	Interval time.Duration `mapstructure:"interval"`
+	// MaxAge is the age after which a snapshot is considered outdated and is
+	// discarded instead of restored.
+	MaxAge time.Duration `mapstructure:"max_age"`
 }
 
 func (c *Config) Validate() error {
@@ -29,6 +48,17 @@ func (c *Config) Validate() error {
 	if c.MaxStreams < 0 {
 		return fmt.Errorf("max_streams must be a positive number (got %d)", c.MaxStreams)
 	}
+	if c.ReorderWindow < 0 {
+		return fmt.Errorf("reorder_window must not be negative (got %s)", c.ReorderWindow)
+	}
+	if c.Storage != nil {
+		if c.Snapshot.Interval <= 0 {
+			return fmt.Errorf("snapshot::interval must be a positive duration (got %s)", c.Snapshot.Interval)
+		}
+		if c.Snapshot.MaxAge <= 0 {
+			return fmt.Errorf("snapshot::max_age must be a positive duration (got %s)", c.Snapshot.MaxAge)
+		}
+	}
 	return nil
 }
 
@@ -39,6 +69,11 @@ func createDefaultConfig() component.Config {
 		// TODO: find good default
 		// https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/31603
 		MaxStreams: math.MaxInt,
+
+		Snapshot: SnapshotConfig{
+			Interval: time.Minute,
+			MaxAge:   5 * time.Minute,
+		},
 	}
 }
 
@@ -24,6 +24,9 @@ func TestLoadConfig(t *testing.T) {
 	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
 	require.NoError(t, err)
 
+	defaultSnapshot := SnapshotConfig{Interval: time.Minute, MaxAge: 5 * time.Minute}
+	storageID := component.MustNewID("file_storage")
+
 	tests := []struct {
 		id       component.ID
 		expected component.Config
@@ -31,8 +34,10 @@ func TestLoadConfig(t *testing.T) {
 		{
 			id: component.NewIDWithName(metadata.Type, "all"),
 			expected: &Config{
-				MaxStale:   1 * time.Minute,
-				MaxStreams: 10,
+				MaxStale:      1 * time.Minute,
+				MaxStreams:    10,
+				ReorderWindow: 30 * time.Second,
+				Snapshot:      defaultSnapshot,
 			},
 		},
 		{
@@ -40,6 +45,7 @@ func TestLoadConfig(t *testing.T) {
 			expected: &Config{
 				MaxStale:   2 * time.Minute,
 				MaxStreams: math.MaxInt,
+				Snapshot:   defaultSnapshot,
 			},
 		},
 		{
@@ -47,6 +53,19 @@ func TestLoadConfig(t *testing.T) {
 			expected: &Config{
 				MaxStale:   5 * time.Minute,
 				MaxStreams: 20,
+				Snapshot:   defaultSnapshot,
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "storage"),
+			expected: &Config{
+				MaxStale:   5 * time.Minute,
+				MaxStreams: math.MaxInt,
+				Storage:    &storageID,
+				Snapshot: SnapshotConfig{
+					Interval: 30 * time.Second,
+					MaxAge:   time.Hour,
+				},
 			},
 		},
 	}
@@ -65,3 +84,20 @@ func TestLoadConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateSnapshot(t *testing.T) {
+	storageID := component.MustNewID("file_storage")
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Snapshot = SnapshotConfig{}
+	require.NoError(t, cfg.Validate(), "snapshot is only validated if storage is set")
+
+	cfg.Storage = &storageID
+	require.EqualError(t, cfg.Validate(), "snapshot::interval must be a positive duration (got 0s)")
+
+	cfg.Snapshot.Interval = time.Minute
+	require.EqualError(t, cfg.Validate(), "snapshot::max_age must be a positive duration (got 0s)")
+
+	cfg.Snapshot.MaxAge = time.Minute
+	require.NoError(t, cfg.Validate())
+}
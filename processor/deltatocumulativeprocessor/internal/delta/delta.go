@@ -4,12 +4,15 @@
 package delta // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/delta"
 
 import (
+	"cmp"
 	"fmt"
+	"slices"
+	"time"
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 
-	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/data"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/data"
 )
 
 type ErrOlderStart struct {
@@ -41,38 +44,131 @@ type Type[Self any] interface {
 
 type Aggregator struct {
 	data.Aggregator
+
+	// Window is how much older than the latest sample of a stream a sample
+	// may be to still be aggregated, as long as it does not overlap intervals
+	// already aggregated. Zero rejects all out of order samples.
+	Window time.Duration
+}
+
+// Interval is the time range (Start, End] covered by a delta sample.
+type Interval struct {
+	Start pcommon.Timestamp
+	End   pcommon.Timestamp
 }
 
-func Aggregate[T Type[T]](state, dp T, aggregate func(state, dp T) error) error {
+func (iv Interval) overlaps(other Interval) bool {
+	return iv.Start < other.End && other.Start < iv.End
+}
+
+// Intervals are the sorted, non-overlapping intervals aggregated into a
+// stream. Intervals older than the reorder window are collapsed into one.
+//
+// Empty Intervals of an existing state are assumed to span the whole state.
+type Intervals []Interval
+
+func (ivs *Intervals) init(start, end pcommon.Timestamp) {
+	if len(*ivs) == 0 {
+		*ivs = append(*ivs, Interval{Start: start, End: end})
+	}
+}
+
+func (ivs Intervals) overlaps(iv Interval) bool {
+	for _, seen := range ivs {
+		if seen.overlaps(iv) {
+			return true
+		}
+	}
+	return false
+}
+
+// add inserts iv, merging it with touching or overlapping intervals. All
+// intervals ending before oldest are collapsed into one, as samples that old
+// are rejected anyway.
+func (ivs *Intervals) add(iv Interval, oldest pcommon.Timestamp) {
+	i, _ := slices.BinarySearchFunc(*ivs, iv.Start, func(seen Interval, start pcommon.Timestamp) int {
+		return cmp.Compare(seen.Start, start)
+	})
+	*ivs = slices.Insert(*ivs, i, iv)
+
+	merged := (*ivs)[:1]
+	for _, next := range (*ivs)[1:] {
+		last := &merged[len(merged)-1]
+		if next.Start <= last.End || next.End < oldest {
+			last.End = max(last.End, next.End)
+			continue
+		}
+		merged = append(merged, next)
+	}
+	*ivs = merged
+}
+
+// Aggregate aggregates dp into state, if dp is newer than state.
+//
+// If seen is non-nil, older samples are aggregated as well, as long as they
+// are within window and do not overlap any of the seen intervals. late
+// reports whether dp was older than state, regardless of whether it was
+// aggregated.
+func Aggregate[T Type[T]](state, dp T, seen *Intervals, window time.Duration, aggregate func(state, dp T) error) (late bool, err error) {
+	iv := Interval{Start: dp.StartTimestamp(), End: dp.Timestamp()}
+
 	switch {
 	case state.Timestamp() == 0:
 		// first sample of series, no state to aggregate with
 		dp.CopyTo(state)
-		return nil
+		if seen != nil {
+			*seen = append((*seen)[:0], iv)
+		}
+		return false, nil
 	case dp.StartTimestamp() < state.StartTimestamp():
 		// belongs to older series
-		return ErrOlderStart{Start: state.StartTimestamp(), Sample: dp.StartTimestamp()}
-	case dp.Timestamp() <= state.Timestamp():
-		// out of order
-		return ErrOutOfOrder{Last: state.Timestamp(), Sample: dp.Timestamp()}
+		return false, ErrOlderStart{Start: state.StartTimestamp(), Sample: dp.StartTimestamp()}
+	}
+
+	last := state.Timestamp()
+	if seen != nil {
+		seen.init(state.StartTimestamp(), last)
+	}
+
+	if dp.Timestamp() <= last {
+		late = true
+		if seen == nil || iv.Start >= iv.End || dp.Timestamp() < floor(last, window) || seen.overlaps(iv) {
+			// out of order
+			return late, ErrOutOfOrder{Last: last, Sample: dp.Timestamp()}
+		}
 	}
 
 	if err := aggregate(state, dp); err != nil {
-		return err
+		return late, err
+	}
+
+	if !late {
+		state.SetTimestamp(dp.Timestamp())
 	}
+	if seen != nil {
+		seen.add(iv, floor(state.Timestamp(), window))
+	}
+	return late, nil
+}
 
-	state.SetTimestamp(dp.Timestamp())
-	return nil
+// floor returns the oldest timestamp samples may have to still be within
+// window of last.
+func floor(last pcommon.Timestamp, window time.Duration) pcommon.Timestamp {
+	w := pcommon.Timestamp(window)
+	if last < w {
+		return 0
+	}
+	return last - w
 }
 
-func (aggr Aggregator) Numbers(state, dp pmetric.NumberDataPoint) error {
-	return Aggregate(state, dp, aggr.Aggregator.Numbers)
+func (aggr Aggregator) Numbers(state, dp pmetric.NumberDataPoint, seen *Intervals) (late bool, err error) {
+	return Aggregate(state, dp, seen, aggr.Window, aggr.Aggregator.Numbers)
 }
 
-func (aggr Aggregator) Histograms(state, dp pmetric.HistogramDataPoint) error {
-	return Aggregate(state, dp, aggr.Aggregator.Histograms)
+func (aggr Aggregator) Histograms(state, dp pmetric.HistogramDataPoint, seen *Intervals) (late bool, err error) {
+	return Aggregate(state, dp, seen, aggr.Window, aggr.Aggregator.Histograms)
 }
 
-func (aggr Aggregator) Exponential(state, dp pmetric.ExponentialHistogramDataPoint) error {
-	return Aggregate(state, dp, aggr.Aggregator.Exponential)
+func (aggr Aggregator) Exponential(state, dp pmetric.ExponentialHistogramDataPoint, seen *Intervals) (late bool, err error) {
+	return Aggregate(state, dp, seen, aggr.Window, aggr.Aggregator.Exponential)
 }
@@ -0,0 +1,161 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package delta_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/data"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/delta"
+)
+
+func TestReorderWindow(t *testing.T) {
+	type sample struct {
+		start, end int64
+		value      int64
+
+		late bool
+		err  error
+	}
+	sum := func(start, end, value int64) sample {
+		return sample{start: start, end: end, value: value}
+	}
+	late := func(s sample) sample {
+		s.late = true
+		return s
+	}
+	dropped := func(s sample, last int64) sample {
+		s.late = true
+		s.err = delta.ErrOutOfOrder{Last: ts(last), Sample: ts(s.end)}
+		return s
+	}
+
+	cases := []struct {
+		name    string
+		window  time.Duration
+		samples []sample
+		want    int64
+	}{{
+		name:   "in-order",
+		window: 10 * time.Second,
+		samples: []sample{
+			sum(0, 10, 1),
+			sum(10, 20, 2),
+			sum(20, 30, 3),
+		},
+		want: 6,
+	}, {
+		name:   "disabled",
+		window: 0,
+		samples: []sample{
+			sum(0, 10, 1),
+			sum(20, 30, 3),
+			dropped(sum(10, 20, 2), 30),
+		},
+		want: 4,
+	}, {
+		name:   "fills-gap",
+		window: 10 * time.Second,
+		samples: []sample{
+			sum(0, 10, 1),
+			sum(20, 30, 3),
+			late(sum(10, 20, 2)),
+			// gap is filled now, so this overlaps
+			dropped(sum(15, 20, 2), 30),
+		},
+		want: 6,
+	}, {
+		name:   "overlapping",
+		window: 20 * time.Second,
+		samples: []sample{
+			sum(0, 10, 1),
+			sum(20, 30, 3),
+			dropped(sum(5, 15, 2), 30),
+			dropped(sum(15, 25, 2), 30),
+			late(sum(10, 15, 2)),
+			late(sum(15, 20, 2)),
+		},
+		want: 8,
+	}, {
+		name:   "outside-window",
+		window: 5 * time.Second,
+		samples: []sample{
+			sum(0, 10, 1),
+			sum(20, 26, 3),
+			sum(28, 30, 1),
+			dropped(sum(10, 20, 2), 30),
+			late(sum(26, 28, 4)),
+		},
+		want: 9,
+	}, {
+		name:   "duplicate",
+		window: 10 * time.Second,
+		samples: []sample{
+			sum(0, 10, 1),
+			dropped(sum(0, 10, 1), 10),
+			dropped(sum(10, 10, 1), 10),
+		},
+		want: 1,
+	}}
+
+	for _, cs := range cases {
+		t.Run(cs.name, func(t *testing.T) {
+			aggr := delta.Aggregator{Aggregator: new(data.Adder), Window: cs.window}
+			var seen *delta.Intervals
+			if cs.window > 0 {
+				seen = new(delta.Intervals)
+			}
+
+			state := pmetric.NewNumberDataPoint()
+			var last pcommon.Timestamp
+			for _, s := range cs.samples {
+				dp := pmetric.NewNumberDataPoint()
+				dp.SetStartTimestamp(ts(s.start))
+				dp.SetTimestamp(ts(s.end))
+				dp.SetIntValue(s.value)
+
+				late, err := aggr.Numbers(state, dp, seen)
+				require.Equal(t, s.err, err)
+				require.Equal(t, s.late, late)
+				if !late {
+					last = ts(s.end)
+				}
+			}
+
+			require.Equal(t, cs.want, state.IntValue())
+			require.Equal(t, ts(0), state.StartTimestamp())
+			require.Equal(t, last, state.Timestamp())
+		})
+	}
+}
+
+func TestRestoredState(t *testing.T) {
+	aggr := delta.Aggregator{Aggregator: new(data.Adder), Window: time.Minute}
+
+	// state without known intervals, e.g. restored from a snapshot
+	state := pmetric.NewNumberDataPoint()
+	state.SetStartTimestamp(ts(0))
+	state.SetTimestamp(ts(30))
+	state.SetIntValue(5)
+
+	seen := new(delta.Intervals)
+	dp := pmetric.NewNumberDataPoint()
+	dp.SetStartTimestamp(ts(10))
+	dp.SetTimestamp(ts(20))
+	dp.SetIntValue(1)
+
+	late, err := aggr.Numbers(state, dp, seen)
+	require.True(t, late)
+	require.Equal(t, delta.ErrOutOfOrder{Last: ts(30), Sample: ts(20)}, err)
+	require.Equal(t, &delta.Intervals{{Start: ts(0), End: ts(30)}}, seen)
+}
+
+func ts(sec int64) pcommon.Timestamp {
+	return pcommon.NewTimestampFromTime(time.Unix(1000+sec, 0))
+}
@@ -106,6 +106,13 @@ func (m *Parallel[K, V]) LoadAndDelete(k K) (_ V, loaded bool) {
 	return v, loaded
 }
 
+// Range calls f sequentially for each key and value present in the map. If f
+// returns false, Range stops the iteration. Values stored or deleted
+// concurrently may or may not be visited.
+func (m *Parallel[K, V]) Range(f func(K, V) bool) {
+	m.elems.Range(f)
+}
+
 func (ctx Context) Size() int64 {
 	return ctx.total.Load()
 }
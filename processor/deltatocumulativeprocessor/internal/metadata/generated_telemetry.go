@@ -29,6 +29,7 @@ type TelemetryBuilder struct {
 	mu                               sync.Mutex
 	registrations                    []metric.Registration
 	DeltatocumulativeDatapoints      metric.Int64Counter
+	DeltatocumulativeDatapointsLate  metric.Int64Counter
 	DeltatocumulativeStreamsLimit    metric.Int64Gauge
 	DeltatocumulativeStreamsMaxStale metric.Int64Gauge
 	DeltatocumulativeStreamsTracked  metric.Int64ObservableUpDownCounter
@@ -94,6 +95,12 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...Teleme
 		metric.WithUnit("{datapoint}"),
 	)
 	errs = errors.Join(errs, err)
+	builder.DeltatocumulativeDatapointsLate, err = builder.meter.Int64Counter(
+		"otelcol_deltatocumulative_datapoints_late",
+		metric.WithDescription("total number of datapoints older than the latest datapoint of their stream. 'outcome' is 'accepted' if aggregated within the reorder window, 'dropped' otherwise [Development]"),
+		metric.WithUnit("{datapoint}"),
+	)
+	errs = errors.Join(errs, err)
 	builder.DeltatocumulativeStreamsLimit, err = builder.meter.Int64Gauge(
 		"otelcol_deltatocumulative_streams_limit",
 		metric.WithDescription("upper limit of tracked streams [Development]"),
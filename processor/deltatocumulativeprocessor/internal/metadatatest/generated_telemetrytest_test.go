@@ -26,11 +26,15 @@ func TestSetupTelemetry(t *testing.T) {
 		return nil
 	}))
 	tb.DeltatocumulativeDatapoints.Add(context.Background(), 1)
+	tb.DeltatocumulativeDatapointsLate.Add(context.Background(), 1)
 	tb.DeltatocumulativeStreamsLimit.Record(context.Background(), 1)
 	tb.DeltatocumulativeStreamsMaxStale.Record(context.Background(), 1)
 	AssertEqualDeltatocumulativeDatapoints(t, testTel,
 		[]metricdata.DataPoint[int64]{{Value: 1}},
 		metricdatatest.IgnoreTimestamp())
+	AssertEqualDeltatocumulativeDatapointsLate(t, testTel,
+		[]metricdata.DataPoint[int64]{{Value: 1}},
+		metricdatatest.IgnoreTimestamp())
 	AssertEqualDeltatocumulativeStreamsLimit(t, testTel,
 		[]metricdata.DataPoint[int64]{{Value: 1}},
 		metricdatatest.IgnoreTimestamp())
@@ -47,6 +47,10 @@ func (m *Metrics) Datapoints() Counter {
 	return Counter{Int64Counter: m.DeltatocumulativeDatapoints}
 }
 
+func (m *Metrics) Late() Counter {
+	return Counter{Int64Counter: m.DeltatocumulativeDatapointsLate}
+}
+
 func (m *Metrics) WithTracked(streams func() int) {
 	*m.tracked = streams
 }
@@ -55,6 +59,14 @@ func Error(msg string) attribute.KeyValue {
 	return attribute.String("error", msg)
 }
 
+// Outcome reports whether a late datapoint was accepted or dropped
+func Outcome(err error) attribute.KeyValue {
+	if err != nil {
+		return attribute.String("outcome", "dropped")
+	}
+	return attribute.String("outcome", "accepted")
+}
+
 func Cause(err error) attribute.KeyValue {
 	for {
 		uw := errors.Unwrap(err)
@@ -5,17 +5,20 @@ package deltatocumulativeprocessor // import "github.com/open-telemetry/opentele
 
 import (
 	"context"
+	"errors"
 	"sync"
 	"time"
 
 	"github.com/puzpuzpuz/xsync/v3"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/processor"
+	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/data"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/identity"
-	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/data"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/delta"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/maps"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/metrics"
@@ -27,31 +30,43 @@ var _ processor.Metrics = (*deltaToCumulativeProcessor)(nil)
 type deltaToCumulativeProcessor struct {
 	next consumer.Metrics
 	cfg  Config
+	id   component.ID
+	log  *zap.Logger
 
 	last state
-	aggr data.Aggregator
+	aggr delta.Aggregator
+	// intervals accumulated per stream, only set if cfg.ReorderWindow is
+	// configured
+	seen *xsync.MapOf[identity.Stream, *delta.Intervals]
 
 	ctx    context.Context
 	cancel context.CancelFunc
 
 	stale *xsync.MapOf[identity.Stream, time.Time]
 	tel   telemetry.Metrics
+
+	// only set if cfg.Storage is configured
+	storage storage.Client
+	descs   *xsync.MapOf[identity.Metric, descriptor]
+	saving  sync.WaitGroup
 }
 
-func newProcessor(cfg *Config, tel telemetry.Metrics, next consumer.Metrics) *deltaToCumulativeProcessor {
+func newProcessor(cfg *Config, set processor.Settings, tel telemetry.Metrics, next consumer.Metrics) *deltaToCumulativeProcessor {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	limit := maps.Limit(int64(cfg.MaxStreams))
 	proc := deltaToCumulativeProcessor{
 		next: next,
 		cfg:  *cfg,
+		id:   set.ID,
+		log:  set.Logger,
 		last: state{
 			ctx:  limit,
 			nums: maps.New[identity.Stream, *mutex[pmetric.NumberDataPoint]](limit),
 			hist: maps.New[identity.Stream, *mutex[pmetric.HistogramDataPoint]](limit),
 			expo: maps.New[identity.Stream, *mutex[pmetric.ExponentialHistogramDataPoint]](limit),
 		},
-		aggr:   delta.Aggregator{Aggregator: new(data.Adder)},
+		aggr:   delta.Aggregator{Aggregator: new(data.Adder), Window: cfg.ReorderWindow},
 		ctx:    ctx,
 		cancel: cancel,
 
@@ -59,6 +74,13 @@ func newProcessor(cfg *Config, tel telemetry.Metrics, next consumer.Metrics) *de
 		tel:   tel,
 	}
 
+	if cfg.ReorderWindow > 0 {
+		proc.seen = xsync.NewMapOf[identity.Stream, *delta.Intervals]()
+	}
+	if cfg.Storage != nil {
+		proc.descs = xsync.NewMapOf[identity.Metric, descriptor]()
+	}
+
 	tel.WithTracked(proc.last.Size)
 	cfg.Metrics(tel)
 
@@ -90,6 +112,11 @@ func (p *deltaToCumulativeProcessor) ConsumeMetrics(ctx context.Context, md pmet
 			return keep
 		}
 
+		if p.descs != nil {
+			// remember resource, scope and metric for snapshots
+			p.descs.LoadOrCompute(m.Ident(), func() descriptor { return describe(m) })
+		}
+
 		// aggregate the datapoints.
 		// using filter here, as the pmetric.*DataPoint are reference types so
 		// we can modify them using their "value".
@@ -99,7 +126,10 @@ func (p *deltaToCumulativeProcessor) ConsumeMetrics(ctx context.Context, md pmet
 			var attrs telemetry.Attributes
 			defer func() { p.tel.Datapoints().Inc(ctx, attrs...) }()
 
-			var err error
+			var (
+				late bool
+				err  error
+			)
 			switch dp := dp.(type) {
 			case pmetric.NumberDataPoint:
 				last, loaded := p.last.nums.LoadOrStore(id, zero.nums)
@@ -118,7 +148,7 @@ func (p *deltaToCumulativeProcessor) ConsumeMetrics(ctx context.Context, md pmet
 				}
 
 				last.use(func(last pmetric.NumberDataPoint) {
-					err = p.aggr.Numbers(last, dp)
+					late, err = p.aggr.Numbers(last, dp, p.intervals(id))
 					last.CopyTo(dp)
 				})
 			case pmetric.HistogramDataPoint:
@@ -138,7 +168,7 @@ func (p *deltaToCumulativeProcessor) ConsumeMetrics(ctx context.Context, md pmet
 				}
 
 				last.use(func(last pmetric.HistogramDataPoint) {
-					err = p.aggr.Histograms(last, dp)
+					late, err = p.aggr.Histograms(last, dp, p.intervals(id))
 					last.CopyTo(dp)
 				})
 			case pmetric.ExponentialHistogramDataPoint:
@@ -158,15 +188,24 @@ func (p *deltaToCumulativeProcessor) ConsumeMetrics(ctx context.Context, md pmet
 				}
 
 				last.use(func(last pmetric.ExponentialHistogramDataPoint) {
-					err = p.aggr.Exponential(last, dp)
+					late, err = p.aggr.Exponential(last, dp, p.intervals(id))
 					last.CopyTo(dp)
 				})
 			}
 
+			if late {
+				p.tel.Late().Inc(ctx, telemetry.Outcome(err))
+			}
 			if err != nil {
 				attrs.Set(telemetry.Cause(err))
 				return drop
 			}
+			if late {
+				// accepted late samples are held in state and only show up
+				// with the next in-order sample. emitting them now would
+				// repeat the latest timestamp with a different value
+				return drop
+			}
 
 			return keep
 		})
@@ -184,7 +223,37 @@ func (p *deltaToCumulativeProcessor) ConsumeMetrics(ctx context.Context, md pmet
 	return p.next.ConsumeMetrics(ctx, md)
 }
 
-func (p *deltaToCumulativeProcessor) Start(_ context.Context, _ component.Host) error {
+func (p *deltaToCumulativeProcessor) Start(ctx context.Context, host component.Host) error {
+	if p.cfg.Storage != nil {
+		client, err := getStorageClient(ctx, host, *p.cfg.Storage, p.id)
+		if err != nil {
+			return err
+		}
+		p.storage = client
+
+		if err := p.load(ctx, time.Now()); err != nil {
+			return err
+		}
+
+		// periodically write snapshots
+		p.saving.Add(1)
+		go func() {
+			defer p.saving.Done()
+			tick := time.NewTicker(p.cfg.Snapshot.Interval)
+			defer tick.Stop()
+			for {
+				select {
+				case <-p.ctx.Done():
+					return
+				case <-tick.C:
+					if err := p.save(p.ctx); err != nil {
+						p.log.Warn("failed to persist streams", zap.Error(err))
+					}
+				}
+			}
+		}()
+	}
+
 	if p.cfg.MaxStale != 0 {
 		// delete stale streams once per minute
 		go func() {
@@ -201,6 +270,9 @@ func (p *deltaToCumulativeProcessor) Start(_ context.Context, _ component.Host)
 							p.last.nums.LoadAndDelete(id)
 							p.last.hist.LoadAndDelete(id)
 							p.last.expo.LoadAndDelete(id)
+							if p.seen != nil {
+								p.seen.Delete(id)
+							}
 							p.stale.Delete(id)
 						}
 						return true
@@ -213,9 +285,26 @@ func (p *deltaToCumulativeProcessor) Start(_ context.Context, _ component.Host)
 	return nil
 }
 
-func (p *deltaToCumulativeProcessor) Shutdown(_ context.Context) error {
+func (p *deltaToCumulativeProcessor) Shutdown(ctx context.Context) error {
 	p.cancel()
-	return nil
+	if p.storage == nil {
+		return nil
+	}
+
+	// write a final snapshot, so restarts pick up where we left off
+	p.saving.Wait()
+	err := p.save(ctx)
+	return errors.Join(err, p.storage.Close(ctx))
+}
+
+// intervals returns the intervals accumulated into stream id, or nil if no
+// reorder window is configured.
+func (p *deltaToCumulativeProcessor) intervals(id identity.Stream) *delta.Intervals {
+	if p.seen == nil {
+		return nil
+	}
+	seen, _ := p.seen.LoadOrCompute(id, func() *delta.Intervals { return new(delta.Intervals) })
+	return seen
 }
 
 func (*deltaToCumulativeProcessor) Capabilities() consumer.Capabilities {
@@ -0,0 +1,250 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package deltatocumulativeprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor"
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/identity"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/metrics"
+)
+
+// snapshotKey is the storage key the snapshot is written to
+const snapshotKey = "snapshot"
+
+// descriptor is a copy of the resource, scope and metric (without datapoints)
+// of tracked streams. Streams only keep their datapoint, so descriptors are
+// required to write a snapshot from which stream identities can be recomputed.
+type descriptor struct {
+	res    pcommon.Resource
+	scope  pcommon.InstrumentationScope
+	metric pmetric.Metric
+}
+
+func describe(m metrics.Metric) descriptor {
+	d := descriptor{
+		res:    pcommon.NewResource(),
+		scope:  pcommon.NewInstrumentationScope(),
+		metric: pmetric.NewMetric(),
+	}
+	m.Resource().CopyTo(d.res)
+	m.Scope().CopyTo(d.scope)
+
+	d.metric.SetName(m.Name())
+	d.metric.SetUnit(m.Unit())
+	switch m.Type() {
+	case pmetric.MetricTypeSum:
+		sum := d.metric.SetEmptySum()
+		sum.SetIsMonotonic(m.Sum().IsMonotonic())
+		sum.SetAggregationTemporality(m.Sum().AggregationTemporality())
+	case pmetric.MetricTypeHistogram:
+		d.metric.SetEmptyHistogram().SetAggregationTemporality(m.Histogram().AggregationTemporality())
+	case pmetric.MetricTypeExponentialHistogram:
+		d.metric.SetEmptyExponentialHistogram().SetAggregationTemporality(m.ExponentialHistogram().AggregationTemporality())
+	}
+	return d
+}
+
+// snapshot encodes all tracked streams as delta metrics in OTLP protobuf,
+// prefixed by the time the snapshot was taken.
+//
+// Descriptors of metrics without tracked streams are forgotten.
+func (p *deltaToCumulativeProcessor) snapshot(now time.Time) ([]byte, error) {
+	md := pmetric.NewMetrics()
+
+	var (
+		rms = make(map[identity.Resource]pmetric.ResourceMetrics)
+		sms = make(map[identity.Scope]pmetric.ScopeMetrics)
+		ms  = make(map[identity.Metric]pmetric.Metric)
+	)
+	metric := func(id identity.Stream) (pmetric.Metric, bool) {
+		mid := id.Metric()
+		if m, ok := ms[mid]; ok {
+			return m, true
+		}
+		desc, ok := p.descs.Load(mid)
+		if !ok {
+			// stream was created concurrently, will be part of the next snapshot
+			return pmetric.Metric{}, false
+		}
+
+		sid := mid.Scope()
+		sm, ok := sms[sid]
+		if !ok {
+			rid := sid.Resource()
+			rm, ok := rms[rid]
+			if !ok {
+				rm = md.ResourceMetrics().AppendEmpty()
+				desc.res.CopyTo(rm.Resource())
+				rms[rid] = rm
+			}
+			sm = rm.ScopeMetrics().AppendEmpty()
+			desc.scope.CopyTo(sm.Scope())
+			sms[sid] = sm
+		}
+
+		m := sm.Metrics().AppendEmpty()
+		desc.metric.CopyTo(m)
+		ms[mid] = m
+		return m, true
+	}
+
+	p.last.nums.Range(func(id identity.Stream, last *mutex[pmetric.NumberDataPoint]) bool {
+		if m, ok := metric(id); ok {
+			last.use(func(last pmetric.NumberDataPoint) {
+				last.CopyTo(m.Sum().DataPoints().AppendEmpty())
+			})
+		}
+		return true
+	})
+	p.last.hist.Range(func(id identity.Stream, last *mutex[pmetric.HistogramDataPoint]) bool {
+		if m, ok := metric(id); ok {
+			last.use(func(last pmetric.HistogramDataPoint) {
+				last.CopyTo(m.Histogram().DataPoints().AppendEmpty())
+			})
+		}
+		return true
+	})
+	p.last.expo.Range(func(id identity.Stream, last *mutex[pmetric.ExponentialHistogramDataPoint]) bool {
+		if m, ok := metric(id); ok {
+			last.use(func(last pmetric.ExponentialHistogramDataPoint) {
+				last.CopyTo(m.ExponentialHistogram().DataPoints().AppendEmpty())
+			})
+		}
+		return true
+	})
+
+	p.descs.Range(func(id identity.Metric, _ descriptor) bool {
+		if _, ok := ms[id]; !ok {
+			// no streams left. if streams are added concurrently, the
+			// descriptor is added again on the next ConsumeMetrics.
+			p.descs.Delete(id)
+		}
+		return true
+	})
+
+	data, err := (&pmetric.ProtoMarshaler{}).MarshalMetrics(md)
+	if err != nil {
+		return nil, err
+	}
+	buf := make([]byte, 8, 8+len(data))
+	binary.BigEndian.PutUint64(buf, uint64(now.UnixNano()))
+	return append(buf, data...), nil
+}
+
+// decodeSnapshot returns the time a snapshot was taken and its streams.
+func decodeSnapshot(data []byte) (time.Time, pmetric.Metrics, error) {
+	if len(data) < 8 {
+		return time.Time{}, pmetric.Metrics{}, errors.New("snapshot is truncated")
+	}
+	taken := time.Unix(0, int64(binary.BigEndian.Uint64(data[:8])))
+	md, err := (&pmetric.ProtoUnmarshaler{}).UnmarshalMetrics(data[8:])
+	if err != nil {
+		return time.Time{}, pmetric.Metrics{}, err
+	}
+	return taken, md, nil
+}
+
+// restore tracks the streams of a decoded snapshot. Streams already tracked
+// are kept as is. Returns the number of restored streams.
+func (p *deltaToCumulativeProcessor) restore(md pmetric.Metrics, now time.Time) int {
+	var restored int
+	rms := md.ResourceMetrics()
+	for i := range rms.Len() {
+		rm := rms.At(i)
+		sms := rm.ScopeMetrics()
+		for j := range sms.Len() {
+			sm := sms.At(j)
+			ms := sm.Metrics()
+			for k := range ms.Len() {
+				m := metrics.From(rm.Resource(), sm.Scope(), ms.At(k))
+				p.descs.LoadOrCompute(m.Ident(), func() descriptor { return describe(m) })
+
+				m.Filter(func(id identity.Stream, dp any) bool {
+					var stored bool
+					switch dp := dp.(type) {
+					case pmetric.NumberDataPoint:
+						last, loaded := p.last.nums.LoadOrStore(id, guard(dp))
+						stored = !loaded && last != nil
+					case pmetric.HistogramDataPoint:
+						last, loaded := p.last.hist.LoadOrStore(id, guard(dp))
+						stored = !loaded && last != nil
+					case pmetric.ExponentialHistogramDataPoint:
+						last, loaded := p.last.expo.LoadOrStore(id, guard(dp))
+						stored = !loaded && last != nil
+					}
+					if stored {
+						p.stale.Store(id, now)
+						restored++
+					}
+					return true
+				})
+			}
+		}
+	}
+	return restored
+}
+
+// load restores the snapshot from storage, unless it is older than
+// [SnapshotConfig.MaxAge]. Broken snapshots are discarded.
+func (p *deltaToCumulativeProcessor) load(ctx context.Context, now time.Time) error {
+	data, err := p.storage.Get(ctx, snapshotKey)
+	if err != nil {
+		return fmt.Errorf("failed to read snapshot: %w", err)
+	}
+	if data == nil {
+		return nil
+	}
+
+	taken, md, err := decodeSnapshot(data)
+	if err != nil {
+		p.log.Warn("discarding unreadable snapshot", zap.Error(err))
+		return nil
+	}
+	if age := now.Sub(taken); age > p.cfg.Snapshot.MaxAge {
+		p.log.Info("discarding outdated snapshot",
+			zap.Duration("age", age),
+			zap.Duration("max_age", p.cfg.Snapshot.MaxAge),
+		)
+		return nil
+	}
+
+	n := p.restore(md, now)
+	p.log.Debug("restored snapshot", zap.Int("streams", n), zap.Time("taken", taken))
+	return nil
+}
+
+// save writes a snapshot of all tracked streams to storage.
+func (p *deltaToCumulativeProcessor) save(ctx context.Context) error {
+	data, err := p.snapshot(time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+	if err := p.storage.Set(ctx, snapshotKey, data); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return nil
+}
+
+func getStorageClient(ctx context.Context, host component.Host, storageID, componentID component.ID) (storage.Client, error) {
+	ext, ok := host.GetExtensions()[storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension %q not found", storageID)
+	}
+	storageExt, ok := ext.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("extension %q is not a storage extension", storageID)
+	}
+	return storageExt.GetClient(ctx, component.KindProcessor, componentID, "")
+}
@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package deltatocumulativeprocessor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+)
+
+func TestSnapshot(t *testing.T) {
+	start := pcommon.NewTimestampFromTime(time.Unix(1000, 0))
+	at := func(s int64) pcommon.Timestamp {
+		return pcommon.NewTimestampFromTime(time.Unix(1000+s, 0))
+	}
+
+	deltas := func(from, to pcommon.Timestamp, value int64, count uint64) pmetric.Metrics {
+		md := pmetric.NewMetrics()
+		rm := md.ResourceMetrics().AppendEmpty()
+		rm.Resource().Attributes().PutStr("service.name", "test")
+		sm := rm.ScopeMetrics().AppendEmpty()
+		sm.Scope().SetName("test")
+
+		sum := sm.Metrics().AppendEmpty()
+		sum.SetName("requests")
+		sum.SetEmptySum().SetIsMonotonic(true)
+		sum.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+		num := sum.Sum().DataPoints().AppendEmpty()
+		num.Attributes().PutStr("method", "GET")
+		num.SetStartTimestamp(from)
+		num.SetTimestamp(to)
+		num.SetIntValue(value)
+
+		hist := sm.Metrics().AppendEmpty()
+		hist.SetName("latency")
+		hist.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+		dp := hist.Histogram().DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(from)
+		dp.SetTimestamp(to)
+		dp.ExplicitBounds().FromRaw([]float64{1})
+		dp.BucketCounts().FromRaw([]uint64{count, 0})
+		dp.SetCount(count)
+		dp.SetSum(float64(count))
+		return md
+	}
+
+	type result struct {
+		start pcommon.Timestamp
+		value int64
+		count uint64
+	}
+	read := func(t *testing.T, md pmetric.Metrics) result {
+		ms := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+		require.Equal(t, 2, ms.Len())
+		num := ms.At(0).Sum().DataPoints().At(0)
+		hist := ms.At(1).Histogram().DataPoints().At(0)
+		require.Equal(t, num.StartTimestamp(), hist.StartTimestamp())
+		require.Equal(t, num.IntValue(), int64(hist.Count()))
+		return result{start: num.StartTimestamp(), value: num.IntValue(), count: hist.Count()}
+	}
+
+	run := func(t *testing.T, cfg *Config, host component.Host, in pmetric.Metrics) result {
+		sink := new(consumertest.MetricsSink)
+		proc, _ := setup(t, cfg, sink)
+		require.NoError(t, proc.Start(t.Context(), host))
+		require.NoError(t, proc.ConsumeMetrics(t.Context(), in))
+		require.NoError(t, proc.Shutdown(t.Context()))
+		require.Len(t, sink.AllMetrics(), 1)
+		return read(t, sink.AllMetrics()[0])
+	}
+
+	setupStorage := func(t *testing.T) (*Config, component.Host) {
+		host := storagetest.NewStorageHost().WithFileBackedStorageExtension("state", t.TempDir())
+		id := storagetest.NewStorageID("state")
+
+		cfg := createDefaultConfig().(*Config)
+		cfg.Storage = &id
+		return cfg, host
+	}
+
+	t.Run("restore", func(t *testing.T) {
+		cfg, host := setupStorage(t)
+
+		got := run(t, cfg, host, deltas(start, at(10), 1, 1))
+		require.Equal(t, result{start: start, value: 1, count: 1}, got)
+
+		// restarted processor continues accumulating from the snapshot
+		got = run(t, cfg, host, deltas(at(10), at(20), 2, 2))
+		require.Equal(t, result{start: start, value: 3, count: 3}, got)
+	})
+
+	t.Run("outdated", func(t *testing.T) {
+		cfg, host := setupStorage(t)
+		cfg.Snapshot.MaxAge = time.Nanosecond
+
+		got := run(t, cfg, host, deltas(start, at(10), 1, 1))
+		require.Equal(t, result{start: start, value: 1, count: 1}, got)
+
+		// snapshot is too old, accumulation starts over
+		got = run(t, cfg, host, deltas(at(10), at(20), 2, 2))
+		require.Equal(t, result{start: at(10), value: 2, count: 2}, got)
+	})
+
+	t.Run("missing-extension", func(t *testing.T) {
+		cfg, _ := setupStorage(t)
+		proc, _ := setup(t, cfg, new(consumertest.MetricsSink))
+		err := proc.Start(t.Context(), storagetest.NewStorageHost())
+		require.ErrorContains(t, err, "not found")
+	})
+}
+
+func TestDecodeSnapshot(t *testing.T) {
+	_, _, err := decodeSnapshot([]byte{1, 2, 3})
+	require.EqualError(t, err, "snapshot is truncated")
+
+	_, _, err = decodeSnapshot([]byte{0, 0, 0, 0, 0, 0, 0, 1, 0xff})
+	require.Error(t, err)
+}
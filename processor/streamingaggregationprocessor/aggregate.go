@@ -0,0 +1,373 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package streamingaggregationprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/streamingaggregationprocessor"
+
+import (
+	"errors"
+	"regexp"
+	"slices"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/data"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/identity"
+)
+
+// rule is the compiled form of a [MetricConfig]
+type rule struct {
+	name  *regexp.Regexp
+	drop  []string
+	gauge GaugeAggregation
+}
+
+func compile(cfgs []MetricConfig) ([]rule, error) {
+	rules := make([]rule, 0, len(cfgs))
+	for _, cfg := range cfgs {
+		re, err := regexp.Compile("^(?:" + cfg.Name + ")$")
+		if err != nil {
+			return nil, err
+		}
+		gauge := cfg.Gauge
+		if gauge == "" {
+			gauge = GaugeLast
+		}
+		rules = append(rules, rule{name: re, drop: cfg.DropAttributes, gauge: gauge})
+	}
+	return rules, nil
+}
+
+// reduce removes the dropped attributes from attrs
+func (r *rule) reduce(attrs pcommon.Map) {
+	attrs.RemoveIf(func(k string, _ pcommon.Value) bool {
+		return slices.Contains(r.drop, k)
+	})
+}
+
+type point[Self any] interface {
+	pmetric.NumberDataPoint | pmetric.HistogramDataPoint | pmetric.ExponentialHistogramDataPoint
+
+	Attributes() pcommon.Map
+	StartTimestamp() pcommon.Timestamp
+	SetStartTimestamp(pcommon.Timestamp)
+	Timestamp() pcommon.Timestamp
+	SetTimestamp(pcommon.Timestamp)
+	CopyTo(Self)
+}
+
+// streams holds the datapoints of a single aggregated metric
+type streams[DP point[DP]] struct {
+	// window holds the aggregated datapoint of each output stream
+	window map[identity.Stream]DP
+	// inputs holds the latest sample of each cumulative input stream
+	inputs map[identity.Stream]input[DP]
+}
+
+type input[DP any] struct {
+	out  identity.Stream
+	dp   DP
+	seen time.Time
+}
+
+func newStreams[DP point[DP]]() streams[DP] {
+	return streams[DP]{
+		window: make(map[identity.Stream]DP),
+		inputs: make(map[identity.Stream]input[DP]),
+	}
+}
+
+// merge aggregates dp into the output stream using fn. dp must not be used
+// afterwards.
+func (s streams[DP]) merge(out identity.Stream, dp DP, fn func(state, dp DP) error) error {
+	state, ok := s.window[out]
+	if !ok {
+		s.window[out] = dp
+		return nil
+	}
+	widen(state, dp)
+	return fn(state, dp)
+}
+
+// latest records dp as the latest sample of the cumulative input stream.
+// Samples not newer than the recorded one are ignored.
+func (s streams[DP]) latest(in, out identity.Stream, dp DP, now time.Time) (prev DP, ok, newer bool) {
+	last, ok := s.inputs[in]
+	if ok && dp.Timestamp() <= last.dp.Timestamp() {
+		return last.dp, true, false
+	}
+	s.inputs[in] = input[DP]{out: out, dp: dp, seen: now}
+	return last.dp, ok, true
+}
+
+// widen extends the time range of state to also cover dp
+func widen[DP point[DP]](state, dp DP) {
+	if dp.StartTimestamp() < state.StartTimestamp() {
+		state.SetStartTimestamp(dp.StartTimestamp())
+	}
+	if dp.Timestamp() > state.Timestamp() {
+		state.SetTimestamp(dp.Timestamp())
+	}
+}
+
+// aggregate holds the state of a single aggregated metric
+type aggregate struct {
+	res    pcommon.Resource
+	scope  pcommon.InstrumentationScope
+	metric pmetric.Metric
+	rule   *rule
+
+	nums streams[pmetric.NumberDataPoint]
+	hist streams[pmetric.HistogramDataPoint]
+	expo streams[pmetric.ExponentialHistogramDataPoint]
+}
+
+func newAggregate(res pcommon.Resource, scope pcommon.InstrumentationScope, m pmetric.Metric, r *rule) *aggregate {
+	a := &aggregate{
+		res:    pcommon.NewResource(),
+		scope:  pcommon.NewInstrumentationScope(),
+		metric: pmetric.NewMetric(),
+		rule:   r,
+
+		nums: newStreams[pmetric.NumberDataPoint](),
+		hist: newStreams[pmetric.HistogramDataPoint](),
+		expo: newStreams[pmetric.ExponentialHistogramDataPoint](),
+	}
+	res.CopyTo(a.res)
+	scope.CopyTo(a.scope)
+
+	a.metric.SetName(m.Name())
+	a.metric.SetDescription(m.Description())
+	a.metric.SetUnit(m.Unit())
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		a.metric.SetEmptyGauge()
+	case pmetric.MetricTypeSum:
+		sum := a.metric.SetEmptySum()
+		sum.SetAggregationTemporality(m.Sum().AggregationTemporality())
+		sum.SetIsMonotonic(m.Sum().IsMonotonic())
+	case pmetric.MetricTypeHistogram:
+		a.metric.SetEmptyHistogram().SetAggregationTemporality(m.Histogram().AggregationTemporality())
+	case pmetric.MetricTypeExponentialHistogram:
+		a.metric.SetEmptyExponentialHistogram().SetAggregationTemporality(m.ExponentialHistogram().AggregationTemporality())
+	}
+	return a
+}
+
+// aggregatable reports whether m can be aggregated. Summaries, metrics without
+// aggregation temporality and cumulative histograms are not: the increase of a
+// cumulative histogram cannot be derived across resets and bucket changes, so
+// merging the latest samples would let the aggregate go down.
+func aggregatable(m pmetric.Metric) bool {
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		return true
+	case pmetric.MetricTypeSum:
+		temporality := m.Sum().AggregationTemporality()
+		return temporality == pmetric.AggregationTemporalityDelta ||
+			temporality == pmetric.AggregationTemporalityCumulative
+	case pmetric.MetricTypeHistogram:
+		return m.Histogram().AggregationTemporality() == pmetric.AggregationTemporalityDelta
+	case pmetric.MetricTypeExponentialHistogram:
+		return m.ExponentialHistogram().AggregationTemporality() == pmetric.AggregationTemporalityDelta
+	}
+	return false
+}
+
+func (a *aggregate) cumulative() bool {
+	return a.metric.Type() == pmetric.MetricTypeSum &&
+		a.metric.Sum().AggregationTemporality() == pmetric.AggregationTemporalityCumulative
+}
+
+// consume aggregates the datapoints of m, which must have the identity id.
+func (a *aggregate) consume(id identity.Metric, m pmetric.Metric, now time.Time) error {
+	var errs error
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		dps := m.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := pmetric.NewNumberDataPoint()
+			_, out := reduce(a.rule, id, dps.At(i), dp)
+			errs = errors.Join(errs, a.nums.merge(out, dp, a.gauge))
+		}
+	case pmetric.MetricTypeSum:
+		dps := m.Sum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := pmetric.NewNumberDataPoint()
+			in, out := reduce(a.rule, id, dps.At(i), dp)
+			if a.cumulative() {
+				a.cumulativeSum(in, out, dp, now)
+				continue
+			}
+			errs = errors.Join(errs, a.nums.merge(out, dp, addNumbers))
+		}
+	case pmetric.MetricTypeHistogram:
+		dps := m.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := pmetric.NewHistogramDataPoint()
+			_, out := reduce(a.rule, id, dps.At(i), dp)
+			errs = errors.Join(errs, a.hist.merge(out, dp, data.Adder{}.Histograms))
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		dps := m.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := pmetric.NewExponentialHistogramDataPoint()
+			_, out := reduce(a.rule, id, dps.At(i), dp)
+			errs = errors.Join(errs, a.expo.merge(out, dp, data.Adder{}.Exponential))
+		}
+	}
+	return errs
+}
+
+// reduce copies dp into the empty datapoint into and drops the configured
+// attributes from it. It returns the identities of the input stream dp belongs
+// to and of the output stream it is aggregated into.
+func reduce[DP point[DP]](r *rule, id identity.Metric, dp, into DP) (in, out identity.Stream) {
+	in = identity.OfStream(id, dp)
+	dp.CopyTo(into)
+	r.reduce(into.Attributes())
+	return in, identity.OfStream(id, into)
+}
+
+// gauge aggregates gauge samples according to the configured [GaugeAggregation]
+func (a *aggregate) gauge(state, dp pmetric.NumberDataPoint) error {
+	var replace bool
+	switch a.rule.gauge {
+	case GaugeMin:
+		replace = number(dp) < number(state)
+	case GaugeMax:
+		replace = number(dp) > number(state)
+	default:
+		// state was already widened to dp, so dp is the latest if it is not
+		// older than state
+		replace = dp.Timestamp() >= state.Timestamp()
+	}
+	if replace {
+		setNumber(state, dp)
+	}
+	return nil
+}
+
+// cumulativeSum adds the increase of the input stream since its last sample to
+// the output stream. For monotonic sums, a reset of the input stream counts
+// its entire value as increase, so the output stays monotonic.
+func (a *aggregate) cumulativeSum(in, out identity.Stream, dp pmetric.NumberDataPoint, now time.Time) {
+	prev, ok, newer := a.nums.latest(in, out, dp, now)
+	if !newer {
+		return
+	}
+
+	inc := pmetric.NewNumberDataPoint()
+	setNumber(inc, dp)
+	if ok {
+		reset := dp.StartTimestamp() != prev.StartTimestamp() || number(dp) < number(prev)
+		if !reset || !a.metric.Sum().IsMonotonic() {
+			subNumbers(inc, prev)
+		}
+	}
+
+	total, ok := a.nums.window[out]
+	if !ok {
+		total = pmetric.NewNumberDataPoint()
+		dp.CopyTo(total)
+		setNumber(total, inc)
+		a.nums.window[out] = total
+		return
+	}
+	widen(total, dp)
+	_ = addNumbers(total, inc)
+}
+
+// flush appends the aggregated datapoints to m and resets the window. Cumulative
+// input streams last seen before stale are removed.
+func (a *aggregate) flush(m pmetric.Metric, stale time.Time) {
+	a.metric.CopyTo(m)
+
+	switch a.metric.Type() {
+	case pmetric.MetricTypeGauge:
+		emit(a.nums.window, m.Gauge().DataPoints())
+		clear(a.nums.window)
+	case pmetric.MetricTypeSum:
+		if a.cumulative() {
+			a.expireSums(stale)
+		}
+		emit(a.nums.window, m.Sum().DataPoints())
+		if !a.cumulative() {
+			clear(a.nums.window)
+		}
+	case pmetric.MetricTypeHistogram:
+		emit(a.hist.window, m.Histogram().DataPoints())
+		clear(a.hist.window)
+	case pmetric.MetricTypeExponentialHistogram:
+		emit(a.expo.window, m.ExponentialHistogram().DataPoints())
+		clear(a.expo.window)
+	}
+}
+
+// empty reports whether the aggregate holds no state anymore
+func (a *aggregate) empty() bool {
+	return len(a.nums.window) == 0 && len(a.nums.inputs) == 0
+}
+
+// expireSums removes stale input streams of cumulative sums. The last value of
+// a non-monotonic input stream is no longer part of the output, output streams
+// without any input streams are removed.
+func (a *aggregate) expireSums(stale time.Time) {
+	live := make(map[identity.Stream]struct{}, len(a.nums.window))
+	for id, in := range a.nums.inputs {
+		if in.seen.Before(stale) {
+			if total, ok := a.nums.window[in.out]; ok && !a.metric.Sum().IsMonotonic() {
+				subNumbers(total, in.dp)
+			}
+			delete(a.nums.inputs, id)
+			continue
+		}
+		live[in.out] = struct{}{}
+	}
+	for id := range a.nums.window {
+		if _, ok := live[id]; !ok {
+			delete(a.nums.window, id)
+		}
+	}
+}
+
+func emit[DP point[DP], S interface{ AppendEmpty() DP }](window map[identity.Stream]DP, dps S) {
+	for _, dp := range window {
+		dp.CopyTo(dps.AppendEmpty())
+	}
+}
+
+func number(dp pmetric.NumberDataPoint) float64 {
+	if dp.ValueType() == pmetric.NumberDataPointValueTypeInt {
+		return float64(dp.IntValue())
+	}
+	return dp.DoubleValue()
+}
+
+func setNumber(state, dp pmetric.NumberDataPoint) {
+	if dp.ValueType() == pmetric.NumberDataPointValueTypeInt {
+		state.SetIntValue(dp.IntValue())
+		return
+	}
+	state.SetDoubleValue(dp.DoubleValue())
+}
+
+// addNumbers adds dp to state. If only one of them is a double, the sum is a
+// double.
+func addNumbers(state, dp pmetric.NumberDataPoint) error {
+	if state.ValueType() != dp.ValueType() {
+		state.SetDoubleValue(number(state))
+		dp.SetDoubleValue(number(dp))
+	}
+	return data.Adder{}.Numbers(state, dp)
+}
+
+// subNumbers subtracts dp from state
+func subNumbers(state, dp pmetric.NumberDataPoint) {
+	if state.ValueType() == pmetric.NumberDataPointValueTypeInt && dp.ValueType() == pmetric.NumberDataPointValueTypeInt {
+		state.SetIntValue(state.IntValue() - dp.IntValue())
+		return
+	}
+	state.SetDoubleValue(number(state) - number(dp))
+}
@@ -0,0 +1,289 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package streamingaggregationprocessor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/identity"
+)
+
+// sample is a datapoint of the input stream instance, aggregated by route
+type sample struct {
+	route, instance string
+	start, ts       int64
+	value           int64
+}
+
+func (s sample) attrs(m pcommon.Map) {
+	m.PutStr("route", s.route)
+	m.PutStr("instance", s.instance)
+}
+
+func numberMetric(m pmetric.Metric, samples ...sample) {
+	var dps pmetric.NumberDataPointSlice
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		dps = m.Gauge().DataPoints()
+	case pmetric.MetricTypeSum:
+		dps = m.Sum().DataPoints()
+	}
+	dps.RemoveIf(func(pmetric.NumberDataPoint) bool { return true })
+	for _, s := range samples {
+		dp := dps.AppendEmpty()
+		s.attrs(dp.Attributes())
+		dp.SetStartTimestamp(ts(s.start))
+		dp.SetTimestamp(ts(s.ts))
+		dp.SetIntValue(s.value)
+	}
+}
+
+func histogramMetric(m pmetric.Metric, samples ...sample) {
+	dps := m.Histogram().DataPoints()
+	dps.RemoveIf(func(pmetric.HistogramDataPoint) bool { return true })
+	for _, s := range samples {
+		dp := dps.AppendEmpty()
+		s.attrs(dp.Attributes())
+		dp.SetStartTimestamp(ts(s.start))
+		dp.SetTimestamp(ts(s.ts))
+		dp.ExplicitBounds().FromRaw([]float64{10})
+		dp.BucketCounts().FromRaw([]uint64{uint64(s.value), 1})
+		dp.SetCount(uint64(s.value) + 1)
+	}
+}
+
+type testAggregate struct {
+	*aggregate
+	id identity.Metric
+}
+
+func newTestAggregate(t *testing.T, m pmetric.Metric, gauge GaugeAggregation) testAggregate {
+	rules, err := compile([]MetricConfig{{Name: ".*", DropAttributes: []string{"instance"}, Gauge: gauge}})
+	require.NoError(t, err)
+
+	res := pcommon.NewResource()
+	scope := pcommon.NewInstrumentationScope()
+	return testAggregate{
+		aggregate: newAggregate(res, scope, m, &rules[0]),
+		id:        identity.OfResourceMetric(res, scope, m),
+	}
+}
+
+// flushNumbers returns the aggregated value, start and timestamp by route
+func (a testAggregate) flushNumbers(t *testing.T, stale int64) map[string][3]int64 {
+	m := pmetric.NewMetric()
+	a.flush(m, now(stale))
+
+	var dps pmetric.NumberDataPointSlice
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		dps = m.Gauge().DataPoints()
+	case pmetric.MetricTypeSum:
+		dps = m.Sum().DataPoints()
+	}
+
+	got := make(map[string][3]int64)
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		require.Equal(t, 1, dp.Attributes().Len())
+		route, _ := dp.Attributes().Get("route")
+		got[route.Str()] = [3]int64{dp.IntValue(), sec(dp.StartTimestamp()), sec(dp.Timestamp())}
+	}
+	return got
+}
+
+func TestGauge(t *testing.T) {
+	samples := []sample{
+		{route: "/a", instance: "1", ts: 10, value: 5},
+		{route: "/a", instance: "2", ts: 20, value: 3},
+		{route: "/a", instance: "3", ts: 15, value: 7},
+		{route: "/b", instance: "1", ts: 10, value: 1},
+	}
+
+	cases := []struct {
+		gauge GaugeAggregation
+		want  int64
+	}{
+		{gauge: GaugeLast, want: 3},
+		{gauge: GaugeMin, want: 3},
+		{gauge: GaugeMax, want: 7},
+	}
+	for _, cs := range cases {
+		t.Run(string(cs.gauge), func(t *testing.T) {
+			m := pmetric.NewMetric()
+			m.SetName("gauge")
+			m.SetEmptyGauge()
+			a := newTestAggregate(t, m, cs.gauge)
+
+			numberMetric(m, samples...)
+			require.NoError(t, a.consume(a.id, m, now(0)))
+
+			require.Equal(t, map[string][3]int64{
+				"/a": {cs.want, 0, 20},
+				"/b": {1, 0, 10},
+			}, a.flushNumbers(t, 0))
+
+			// gauges only live for a single window
+			require.Empty(t, a.flushNumbers(t, 0))
+			require.True(t, a.empty())
+		})
+	}
+}
+
+func TestDeltaSum(t *testing.T) {
+	m := pmetric.NewMetric()
+	m.SetName("requests")
+	m.SetEmptySum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	a := newTestAggregate(t, m, "")
+
+	numberMetric(m,
+		sample{route: "/a", instance: "1", start: 0, ts: 10, value: 1},
+		sample{route: "/a", instance: "2", start: 5, ts: 15, value: 2},
+	)
+	require.NoError(t, a.consume(a.id, m, now(0)))
+	numberMetric(m, sample{route: "/a", instance: "1", start: 10, ts: 20, value: 3})
+	require.NoError(t, a.consume(a.id, m, now(0)))
+
+	require.Equal(t, map[string][3]int64{"/a": {6, 0, 20}}, a.flushNumbers(t, 0))
+
+	numberMetric(m, sample{route: "/a", instance: "2", start: 15, ts: 25, value: 4})
+	require.NoError(t, a.consume(a.id, m, now(0)))
+	require.Equal(t, map[string][3]int64{"/a": {4, 15, 25}}, a.flushNumbers(t, 0))
+
+	require.Empty(t, a.flushNumbers(t, 0))
+	require.True(t, a.empty())
+}
+
+func TestCumulativeSum(t *testing.T) {
+	for _, monotonic := range []bool{true, false} {
+		name := "monotonic"
+		if !monotonic {
+			name = "non-monotonic"
+		}
+		t.Run(name, func(t *testing.T) {
+			m := pmetric.NewMetric()
+			m.SetName("requests")
+			sum := m.SetEmptySum()
+			sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+			sum.SetIsMonotonic(monotonic)
+			a := newTestAggregate(t, m, "")
+
+			numberMetric(m,
+				sample{route: "/a", instance: "1", start: 0, ts: 10, value: 5},
+				sample{route: "/a", instance: "2", start: 2, ts: 10, value: 3},
+			)
+			require.NoError(t, a.consume(a.id, m, now(10)))
+			require.Equal(t, map[string][3]int64{"/a": {8, 0, 10}}, a.flushNumbers(t, 0))
+
+			numberMetric(m,
+				sample{route: "/a", instance: "1", start: 0, ts: 20, value: 7},
+				// instance 2 restarted
+				sample{route: "/a", instance: "2", start: 15, ts: 20, value: 1},
+				// duplicate of an earlier sample
+				sample{route: "/a", instance: "1", start: 0, ts: 10, value: 5},
+			)
+			require.NoError(t, a.consume(a.id, m, now(20)))
+
+			// monotonic: 8 + (7-5) + 1
+			// non-monotonic: 7 + 1
+			want := int64(11)
+			if !monotonic {
+				want = 8
+			}
+			require.Equal(t, map[string][3]int64{"/a": {want, 0, 20}}, a.flushNumbers(t, 0))
+
+			// cumulative streams are emitted until stale
+			numberMetric(m, sample{route: "/a", instance: "1", start: 0, ts: 30, value: 9})
+			require.NoError(t, a.consume(a.id, m, now(30)))
+
+			// monotonic: instance 2 goes stale, its count remains part of the total
+			// non-monotonic: instance 2 goes stale, only instance 1 remains
+			want = 13
+			if !monotonic {
+				want = 9
+			}
+			require.Equal(t, map[string][3]int64{"/a": {want, 0, 30}}, a.flushNumbers(t, 25))
+
+			require.Empty(t, a.flushNumbers(t, 35))
+			require.True(t, a.empty())
+		})
+	}
+}
+
+func TestHistograms(t *testing.T) {
+	m := pmetric.NewMetric()
+	m.SetName("latency")
+	m.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	a := newTestAggregate(t, m, "")
+
+	flush := func(t *testing.T) map[string][]uint64 {
+		out := pmetric.NewMetric()
+		a.flush(out, now(0))
+
+		got := make(map[string][]uint64)
+		dps := out.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			route, _ := dp.Attributes().Get("route")
+			got[route.Str()] = append(dp.BucketCounts().AsRaw(), dp.Count())
+		}
+		return got
+	}
+
+	histogramMetric(m,
+		sample{route: "/a", instance: "1", start: 0, ts: 10, value: 2},
+		sample{route: "/a", instance: "2", start: 0, ts: 10, value: 3},
+		sample{route: "/b", instance: "1", start: 0, ts: 10, value: 4},
+	)
+	require.NoError(t, a.consume(a.id, m, now(10)))
+	require.Equal(t, map[string][]uint64{
+		"/a": {5, 2, 7},
+		"/b": {4, 1, 5},
+	}, flush(t))
+	require.Empty(t, flush(t))
+}
+
+func TestExponential(t *testing.T) {
+	m := pmetric.NewMetric()
+	m.SetName("latency")
+	m.SetEmptyExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	a := newTestAggregate(t, m, "")
+
+	dps := m.ExponentialHistogram().DataPoints()
+	for i, scale := range []int32{1, 0} {
+		dp := dps.AppendEmpty()
+		sample{route: "/a", instance: string(rune('1' + i))}.attrs(dp.Attributes())
+		dp.SetTimestamp(ts(10))
+		dp.SetScale(scale)
+		dp.Positive().BucketCounts().FromRaw([]uint64{1, 1, 1, 1})
+		dp.SetCount(4)
+	}
+	require.NoError(t, a.consume(a.id, m, now(10)))
+
+	out := pmetric.NewMetric()
+	a.flush(out, now(0))
+	require.Equal(t, 1, out.ExponentialHistogram().DataPoints().Len())
+
+	dp := out.ExponentialHistogram().DataPoints().At(0)
+	require.Equal(t, int32(0), dp.Scale())
+	require.Equal(t, uint64(8), dp.Count())
+	require.Equal(t, []uint64{3, 3, 1, 1}, dp.Positive().BucketCounts().AsRaw())
+}
+
+func ts(sec int64) pcommon.Timestamp {
+	return pcommon.NewTimestampFromTime(now(sec))
+}
+
+func sec(ts pcommon.Timestamp) int64 {
+	return ts.AsTime().Unix() - 1000
+}
+
+func now(sec int64) time.Time {
+	return time.Unix(1000+sec, 0)
+}
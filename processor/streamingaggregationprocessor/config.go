@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package streamingaggregationprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/streamingaggregationprocessor"
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+)
+
+var _ xconfmap.Validator = (*Config)(nil)
+
+type Config struct {
+	// Interval is the length of the aggregation window. The aggregated
+	// streams are emitted once per interval.
+	Interval time.Duration `mapstructure:"interval"`
+	// MaxStale is the time after which a cumulative input stream that received
+	// no samples is no longer part of its aggregate.
+	MaxStale time.Duration `mapstructure:"max_stale"`
+
+	// Metrics lists the aggregations to apply. The first entry matching a
+	// metric applies to it, metrics matching no entry pass through unmodified.
+	Metrics []MetricConfig `mapstructure:"metrics"`
+}
+
+type MetricConfig struct {
+	// Name is a regular expression the whole metric name must match.
+	Name string `mapstructure:"name"`
+	// DropAttributes are the datapoint attributes removed before aggregating.
+	// Streams only differing in these attributes are aggregated into one.
+	DropAttributes []string `mapstructure:"drop_attributes"`
+	// Gauge is how gauge samples are aggregated within the interval.
+	Gauge GaugeAggregation `mapstructure:"gauge"`
+}
+
+type GaugeAggregation string
+
+const (
+	GaugeLast GaugeAggregation = "last"
+	GaugeMin  GaugeAggregation = "min"
+	GaugeMax  GaugeAggregation = "max"
+)
+
+func (c *Config) Validate() error {
+	if c.Interval <= 0 {
+		return fmt.Errorf("interval must be a positive duration (got %s)", c.Interval)
+	}
+	if c.MaxStale < c.Interval {
+		return fmt.Errorf("max_stale must not be shorter than interval (got %s < %s)", c.MaxStale, c.Interval)
+	}
+	for i, m := range c.Metrics {
+		if err := m.validate(); err != nil {
+			return fmt.Errorf("metrics::%d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (m MetricConfig) validate() error {
+	if m.Name == "" {
+		return errors.New("name must not be empty")
+	}
+	if _, err := regexp.Compile(m.Name); err != nil {
+		return fmt.Errorf("name must be a valid regular expression: %w", err)
+	}
+	switch m.Gauge {
+	case "", GaugeLast, GaugeMin, GaugeMax:
+	default:
+		return fmt.Errorf("gauge must be one of %q, %q or %q (got %q)", GaugeLast, GaugeMin, GaugeMax, m.Gauge)
+	}
+	return nil
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		Interval: time.Minute,
+		MaxStale: 5 * time.Minute,
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package streamingaggregationprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/streamingaggregationprocessor"
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/streamingaggregationprocessor/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id       component.ID
+		expected component.Config
+	}{
+		{
+			id: component.NewIDWithName(metadata.Type, "all"),
+			expected: &Config{
+				Interval: 30 * time.Second,
+				MaxStale: 10 * time.Minute,
+				Metrics: []MetricConfig{{
+					Name:           `http\.server\.request\.duration`,
+					DropAttributes: []string{"client.address", "client.port"},
+				}, {
+					Name:           `system\.memory\..*`,
+					DropAttributes: []string{"host.name"},
+					Gauge:          GaugeMax,
+				}},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "defaults"),
+			expected: &Config{
+				Interval: time.Minute,
+				MaxStale: 5 * time.Minute,
+				Metrics: []MetricConfig{{
+					Name:           "requests",
+					DropAttributes: []string{"instance"},
+				}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			assert.NoError(t, xconfmap.Validate(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		err    string
+	}{
+		{
+			name:   "interval",
+			modify: func(c *Config) { c.Interval = 0 },
+			err:    "interval must be a positive duration (got 0s)",
+		},
+		{
+			name:   "max_stale",
+			modify: func(c *Config) { c.MaxStale = time.Second },
+			err:    "max_stale must not be shorter than interval (got 1s < 1m0s)",
+		},
+		{
+			name:   "name",
+			modify: func(c *Config) { c.Metrics[0].Name = "" },
+			err:    "metrics::0: name must not be empty",
+		},
+		{
+			name:   "regexp",
+			modify: func(c *Config) { c.Metrics[0].Name = "(" },
+			err:    "metrics::0: name must be a valid regular expression: error parsing regexp: missing closing ): `(`",
+		},
+		{
+			name:   "gauge",
+			modify: func(c *Config) { c.Metrics[0].Gauge = "avg" },
+			err:    `metrics::0: gauge must be one of "last", "min" or "max" (got "avg")`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Metrics = []MetricConfig{{Name: "requests"}}
+			require.NoError(t, cfg.Validate())
+
+			tt.modify(cfg)
+			require.EqualError(t, cfg.Validate(), tt.err)
+		})
+	}
+}
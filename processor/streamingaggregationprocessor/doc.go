@@ -0,0 +1,9 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// package streamingaggregationprocessor implements a processor which drops
+// attributes from metric streams and aggregates the resulting streams across
+// batches, periodically exporting the aggregates
+package streamingaggregationprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/streamingaggregationprocessor"
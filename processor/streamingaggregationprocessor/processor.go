@@ -0,0 +1,194 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package streamingaggregationprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/streamingaggregationprocessor"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/processor"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/identity"
+)
+
+var _ processor.Metrics = (*aggregationProcessor)(nil)
+
+type aggregationProcessor struct {
+	next  consumer.Metrics
+	cfg   Config
+	log   *zap.Logger
+	rules []rule
+
+	mtx   sync.Mutex
+	aggrs map[identity.Metric]*aggregate
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   sync.WaitGroup
+}
+
+func newProcessor(cfg *Config, log *zap.Logger, next consumer.Metrics) (*aggregationProcessor, error) {
+	rules, err := compile(cfg.Metrics)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &aggregationProcessor{
+		next:  next,
+		cfg:   *cfg,
+		log:   log,
+		rules: rules,
+
+		aggrs: make(map[identity.Metric]*aggregate),
+
+		ctx:    ctx,
+		cancel: cancel,
+	}, nil
+}
+
+func (p *aggregationProcessor) Start(_ context.Context, _ component.Host) error {
+	p.done.Add(1)
+	go func() {
+		defer p.done.Done()
+
+		tick := time.NewTicker(p.cfg.Interval)
+		defer tick.Stop()
+		for {
+			select {
+			case <-p.ctx.Done():
+				return
+			case now := <-tick.C:
+				p.export(p.ctx, now)
+			}
+		}
+	}()
+	return nil
+}
+
+func (p *aggregationProcessor) Shutdown(ctx context.Context) error {
+	p.cancel()
+	p.done.Wait()
+	// export the current window instead of losing it. p.ctx is already
+	// cancelled, so the shutdown context is used
+	p.export(ctx, time.Now())
+	return nil
+}
+
+func (*aggregationProcessor) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: true}
+}
+
+func (p *aggregationProcessor) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	now := time.Now()
+
+	p.mtx.Lock()
+	md.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+		rm.ScopeMetrics().RemoveIf(func(sm pmetric.ScopeMetrics) bool {
+			sm.Metrics().RemoveIf(func(m pmetric.Metric) bool {
+				r := p.match(m)
+				if r == nil || !aggregatable(m) {
+					return false
+				}
+
+				id := identity.OfResourceMetric(rm.Resource(), sm.Scope(), m)
+				a, ok := p.aggrs[id]
+				if !ok {
+					a = newAggregate(rm.Resource(), sm.Scope(), m, r)
+					p.aggrs[id] = a
+				}
+				if err := a.consume(id, m, now); err != nil {
+					p.log.Warn("failed to aggregate datapoints", zap.String("metric", m.Name()), zap.Error(err))
+				}
+				return true
+			})
+			return sm.Metrics().Len() == 0
+		})
+		return rm.ScopeMetrics().Len() == 0
+	})
+	p.mtx.Unlock()
+
+	if md.ResourceMetrics().Len() == 0 {
+		return nil
+	}
+	return p.next.ConsumeMetrics(ctx, md)
+}
+
+// match returns the first rule matching the name of m, or nil
+func (p *aggregationProcessor) match(m pmetric.Metric) *rule {
+	for i := range p.rules {
+		if p.rules[i].name.MatchString(m.Name()) {
+			return &p.rules[i]
+		}
+	}
+	return nil
+}
+
+func (p *aggregationProcessor) export(ctx context.Context, now time.Time) {
+	md := p.flush(now)
+	if md.ResourceMetrics().Len() == 0 {
+		return
+	}
+	if err := p.next.ConsumeMetrics(ctx, md); err != nil {
+		p.log.Error("failed to export aggregated metrics", zap.Error(err))
+	}
+}
+
+// flush returns the aggregated metrics of the current window and starts a new
+// one.
+func (p *aggregationProcessor) flush(now time.Time) pmetric.Metrics {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	md := pmetric.NewMetrics()
+	rms := make(map[identity.Resource]pmetric.ResourceMetrics)
+	sms := make(map[identity.Scope]pmetric.ScopeMetrics)
+
+	stale := now.Add(-p.cfg.MaxStale)
+	for id, a := range p.aggrs {
+		m := pmetric.NewMetric()
+		a.flush(m, stale)
+		if a.empty() {
+			delete(p.aggrs, id)
+		}
+		if datapoints(m) == 0 {
+			continue
+		}
+
+		scopeID := id.Scope()
+		sm, ok := sms[scopeID]
+		if !ok {
+			rm, ok := rms[scopeID.Resource()]
+			if !ok {
+				rm = md.ResourceMetrics().AppendEmpty()
+				a.res.CopyTo(rm.Resource())
+				rms[scopeID.Resource()] = rm
+			}
+			sm = rm.ScopeMetrics().AppendEmpty()
+			a.scope.CopyTo(sm.Scope())
+			sms[scopeID] = sm
+		}
+		m.MoveTo(sm.Metrics().AppendEmpty())
+	}
+	return md
+}
+
+func datapoints(m pmetric.Metric) int {
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		return m.Gauge().DataPoints().Len()
+	case pmetric.MetricTypeSum:
+		return m.Sum().DataPoints().Len()
+	case pmetric.MetricTypeHistogram:
+		return m.Histogram().DataPoints().Len()
+	case pmetric.MetricTypeExponentialHistogram:
+		return m.ExponentialHistogram().DataPoints().Len()
+	}
+	return 0
+}
@@ -0,0 +1,130 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package streamingaggregationprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/streamingaggregationprocessor"
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/processor/processortest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/streamingaggregationprocessor/internal/metadata"
+)
+
+func TestProcessor(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Metrics = []MetricConfig{{Name: "requests|latency|duration", DropAttributes: []string{"instance"}}}
+
+	next := new(consumertest.MetricsSink)
+	proc, err := NewFactory().CreateMetrics(t.Context(), processortest.NewNopSettings(metadata.Type), cfg, next)
+	require.NoError(t, err)
+	p := proc.(*aggregationProcessor)
+
+	md := pmetric.NewMetrics()
+	for _, svc := range []string{"a", "b"} {
+		rm := md.ResourceMetrics().AppendEmpty()
+		rm.Resource().Attributes().PutStr("service.name", svc)
+		ms := rm.ScopeMetrics().AppendEmpty().Metrics()
+
+		requests := ms.AppendEmpty()
+		requests.SetName("requests")
+		requests.SetEmptySum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+		numberMetric(requests,
+			sample{route: "/", instance: "1", ts: 10, value: 1},
+			sample{route: "/", instance: "2", ts: 10, value: 2},
+		)
+
+		// not matched
+		other := ms.AppendEmpty()
+		other.SetName("other")
+		other.SetEmptyGauge()
+		numberMetric(other, sample{route: "/", instance: "1", ts: 10, value: 1})
+
+		// summaries are not aggregated
+		latency := ms.AppendEmpty()
+		latency.SetName("latency")
+		latency.SetEmptySummary().DataPoints().AppendEmpty()
+
+		// neither are cumulative histograms
+		duration := ms.AppendEmpty()
+		duration.SetName("duration")
+		duration.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		histogramMetric(duration, sample{route: "/", instance: "1", ts: 10, value: 1})
+	}
+	require.NoError(t, p.ConsumeMetrics(t.Context(), md))
+
+	require.Len(t, next.AllMetrics(), 1)
+	passed := next.AllMetrics()[0]
+	require.Equal(t, 2, passed.ResourceMetrics().Len())
+	for i := 0; i < passed.ResourceMetrics().Len(); i++ {
+		ms := passed.ResourceMetrics().At(i).ScopeMetrics().At(0).Metrics()
+		require.Equal(t, 3, ms.Len())
+		require.Equal(t, "other", ms.At(0).Name())
+		require.Equal(t, "latency", ms.At(1).Name())
+		require.Equal(t, "duration", ms.At(2).Name())
+	}
+
+	out := p.flush(time.Now())
+	require.Equal(t, 2, out.ResourceMetrics().Len())
+	for i := 0; i < out.ResourceMetrics().Len(); i++ {
+		ms := out.ResourceMetrics().At(i).ScopeMetrics().At(0).Metrics()
+		require.Equal(t, 1, ms.Len())
+		require.Equal(t, "requests", ms.At(0).Name())
+
+		dps := ms.At(0).Sum().DataPoints()
+		require.Equal(t, 1, dps.Len())
+		require.Equal(t, int64(3), dps.At(0).IntValue())
+		require.Equal(t, map[string]any{"route": "/"}, dps.At(0).Attributes().AsRaw())
+	}
+
+	require.Equal(t, 0, p.flush(time.Now()).ResourceMetrics().Len())
+	require.Empty(t, p.aggrs)
+}
+
+func TestNothingAggregated(t *testing.T) {
+	next := new(consumertest.MetricsSink)
+	proc, err := NewFactory().CreateMetrics(t.Context(), processortest.NewNopSettings(metadata.Type), createDefaultConfig(), next)
+	require.NoError(t, err)
+
+	md := pmetric.NewMetrics()
+	m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("requests")
+	m.SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(1)
+
+	require.NoError(t, proc.ConsumeMetrics(t.Context(), md))
+	require.Equal(t, 1, next.DataPointCount())
+}
+
+func TestShutdownExportsWindow(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Interval = time.Hour
+	cfg.Metrics = []MetricConfig{{Name: "requests", DropAttributes: []string{"instance"}}}
+
+	next := new(consumertest.MetricsSink)
+	proc, err := NewFactory().CreateMetrics(t.Context(), processortest.NewNopSettings(metadata.Type), cfg, next)
+	require.NoError(t, err)
+	require.NoError(t, proc.Start(t.Context(), componenttest.NewNopHost()))
+
+	md := pmetric.NewMetrics()
+	requests := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	requests.SetName("requests")
+	requests.SetEmptySum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	numberMetric(requests,
+		sample{route: "/", instance: "1", ts: 10, value: 1},
+		sample{route: "/", instance: "2", ts: 10, value: 2},
+	)
+	require.NoError(t, proc.ConsumeMetrics(t.Context(), md))
+	require.Empty(t, next.AllMetrics())
+
+	// the window is exported on shutdown instead of waiting for the interval
+	require.NoError(t, proc.Shutdown(t.Context()))
+	require.Len(t, next.AllMetrics(), 1)
+	dps := next.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints()
+	require.Equal(t, 1, dps.Len())
+	require.Equal(t, int64(3), dps.At(0).IntValue())
+}
@@ -0,0 +1,135 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/metrics"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
+)
+
+// minExponentialScale and maxExponentialScale are the scales supported by
+// the OpenTelemetry data model.
+const (
+	minExponentialScale = -10
+	maxExponentialScale = 20
+)
+
+type downscaleExponentialHistogramArguments struct {
+	MaxScale int64
+	MaxSize  ottl.Optional[int64]
+}
+
+func newDownscaleExponentialHistogramFactory() ottl.Factory[ottldatapoint.TransformContext] {
+	return ottl.NewFactory("downscale_exponential_histogram", &downscaleExponentialHistogramArguments{}, createDownscaleExponentialHistogramFunction)
+}
+
+func createDownscaleExponentialHistogramFunction(_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[ottldatapoint.TransformContext], error) {
+	args, ok := oArgs.(*downscaleExponentialHistogramArguments)
+	if !ok {
+		return nil, errors.New("downscaleExponentialHistogramFactory args must be of type *downscaleExponentialHistogramArguments")
+	}
+
+	return downscaleExponentialHistogram(args.MaxScale, args.MaxSize)
+}
+
+func downscaleExponentialHistogram(maxScale int64, maxSize ottl.Optional[int64]) (ottl.ExprFunc[ottldatapoint.TransformContext], error) {
+	if maxScale < minExponentialScale || maxScale > maxExponentialScale {
+		return nil, fmt.Errorf("max_scale must be between %d and %d, got %d", minExponentialScale, maxExponentialScale, maxScale)
+	}
+	var size int
+	if !maxSize.IsEmpty() {
+		if maxSize.Get() <= 0 {
+			return nil, fmt.Errorf("max_size must be positive, got %d", maxSize.Get())
+		}
+		size = int(maxSize.Get())
+	}
+
+	return func(_ context.Context, tCtx ottldatapoint.TransformContext) (any, error) {
+		dataPoint, ok := tCtx.GetDataPoint().(pmetric.ExponentialHistogramDataPoint)
+		if !ok {
+			return nil, nil
+		}
+
+		downscaleExponentialHistogramDataPoint(dataPoint, int32(maxScale), size)
+		return nil, nil
+	}, nil
+}
+
+// downscaleExponentialHistogramDataPoint lowers the scale of dp to at most
+// maxScale and further until neither the positive nor the negative buckets
+// span more than maxSize buckets. A maxSize of 0 does not limit the size.
+func downscaleExponentialHistogramDataPoint(dp pmetric.ExponentialHistogramDataPoint, maxScale int32, maxSize int) {
+	scale := dp.Scale()
+	target := min(scale, maxScale)
+	if maxSize > 0 {
+		for target > minExponentialScale &&
+			(bucketSpan(dp.Positive(), scale-target) > maxSize || bucketSpan(dp.Negative(), scale-target) > maxSize) {
+			target--
+		}
+	}
+	if target == scale {
+		return
+	}
+
+	downscaleBuckets(dp.Positive(), scale-target)
+	downscaleBuckets(dp.Negative(), scale-target)
+	dp.SetScale(target)
+}
+
+// nonEmptyRange returns the first and last position of bs holding a non-zero
+// count, or -1, -1 if all buckets are empty.
+func nonEmptyRange(bs pmetric.ExponentialHistogramDataPointBuckets) (first, last int) {
+	first, last = -1, -1
+	counts := bs.BucketCounts()
+	for i := 0; i < counts.Len(); i++ {
+		if counts.At(i) == 0 {
+			continue
+		}
+		if first < 0 {
+			first = i
+		}
+		last = i
+	}
+	return first, last
+}
+
+// bucketSpan returns the number of buckets needed to hold the non-empty
+// buckets of bs after lowering its scale by by.
+func bucketSpan(bs pmetric.ExponentialHistogramDataPointBuckets, by int32) int {
+	first, last := nonEmptyRange(bs)
+	if first < 0 {
+		return 0
+	}
+	lo := (bs.Offset() + int32(first)) >> by
+	hi := (bs.Offset() + int32(last)) >> by
+	return int(hi-lo) + 1
+}
+
+// downscaleBuckets lowers the scale of bs by by, merging each 2^by adjacent
+// buckets into one. Leading and trailing empty buckets are dropped.
+func downscaleBuckets(bs pmetric.ExponentialHistogramDataPointBuckets, by int32) {
+	first, last := nonEmptyRange(bs)
+	if first < 0 {
+		bs.SetOffset(bs.Offset() >> by)
+		bs.BucketCounts().FromRaw(nil)
+		return
+	}
+
+	counts := bs.BucketCounts()
+	lo := (bs.Offset() + int32(first)) >> by
+	hi := (bs.Offset() + int32(last)) >> by
+	merged := make([]uint64, hi-lo+1)
+	for i := first; i <= last; i++ {
+		merged[((bs.Offset()+int32(i))>>by)-lo] += counts.At(i)
+	}
+
+	bs.SetOffset(lo)
+	counts.FromRaw(merged)
+}
@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
+)
+
+func TestDownscaleExponentialHistogram(t *testing.T) {
+	type buckets struct {
+		offset int32
+		counts []uint64
+	}
+	tests := []struct {
+		name          string
+		scale         int32
+		positive      buckets
+		negative      buckets
+		maxScale      int64
+		maxSize       ottl.Optional[int64]
+		expectedScale int32
+		expectedPos   buckets
+		expectedNeg   buckets
+	}{
+		{
+			name:          "downscale to max scale",
+			scale:         2,
+			positive:      buckets{offset: 0, counts: []uint64{1, 1, 1, 1, 1}},
+			negative:      buckets{offset: -3, counts: []uint64{1, 2, 3}},
+			maxScale:      1,
+			expectedScale: 1,
+			expectedPos:   buckets{offset: 0, counts: []uint64{2, 2, 1}},
+			expectedNeg:   buckets{offset: -2, counts: []uint64{1, 5}},
+		},
+		{
+			name:          "downscale to max size",
+			scale:         3,
+			positive:      buckets{offset: 0, counts: []uint64{1, 1, 1, 1, 1, 1, 1, 1, 1, 1}},
+			maxScale:      3,
+			maxSize:       ottl.NewTestingOptional[int64](4),
+			expectedScale: 1,
+			expectedPos:   buckets{offset: 0, counts: []uint64{4, 4, 2}},
+			expectedNeg:   buckets{offset: 0},
+		},
+		{
+			name:          "drop empty buckets",
+			scale:         1,
+			positive:      buckets{offset: 0, counts: []uint64{0, 0, 1, 1, 0}},
+			maxScale:      0,
+			expectedScale: 0,
+			expectedPos:   buckets{offset: 1, counts: []uint64{2}},
+			expectedNeg:   buckets{offset: 0},
+		},
+		{
+			name:          "within limits - no change",
+			scale:         0,
+			positive:      buckets{offset: 1, counts: []uint64{1, 2}},
+			maxScale:      5,
+			maxSize:       ottl.NewTestingOptional[int64](2),
+			expectedScale: 0,
+			expectedPos:   buckets{offset: 1, counts: []uint64{1, 2}},
+			expectedNeg:   buckets{offset: 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := downscaleExponentialHistogram(tt.maxScale, tt.maxSize)
+			require.NoError(t, err)
+
+			metric := pmetric.NewMetric()
+			metric.SetName("test_exponential_histogram")
+			dp := metric.SetEmptyExponentialHistogram().DataPoints().AppendEmpty()
+			dp.SetScale(tt.scale)
+			dp.SetZeroCount(3)
+			dp.Positive().SetOffset(tt.positive.offset)
+			dp.Positive().BucketCounts().FromRaw(tt.positive.counts)
+			dp.Negative().SetOffset(tt.negative.offset)
+			dp.Negative().BucketCounts().FromRaw(tt.negative.counts)
+
+			ctx := ottldatapoint.NewTransformContext(dp, metric, pmetric.NewMetricSlice(), pcommon.NewInstrumentationScope(), pcommon.NewResource(), pmetric.NewScopeMetrics(), pmetric.NewResourceMetrics())
+			result, err := exprFunc(t.Context(), ctx)
+			assert.NoError(t, err)
+			assert.Nil(t, result)
+
+			assert.Equal(t, tt.expectedScale, dp.Scale())
+			assert.Equal(t, uint64(3), dp.ZeroCount())
+			assert.Equal(t, tt.expectedPos.offset, dp.Positive().Offset())
+			assert.Equal(t, tt.expectedPos.counts, dp.Positive().BucketCounts().AsRaw())
+			assert.Equal(t, tt.expectedNeg.offset, dp.Negative().Offset())
+			assert.Equal(t, tt.expectedNeg.counts, dp.Negative().BucketCounts().AsRaw())
+		})
+	}
+}
+
+func TestDownscaleExponentialHistogramInvalidArgs(t *testing.T) {
+	_, err := downscaleExponentialHistogram(21, ottl.Optional[int64]{})
+	assert.EqualError(t, err, "max_scale must be between -10 and 20, got 21")
+
+	_, err = downscaleExponentialHistogram(0, ottl.NewTestingOptional[int64](0))
+	assert.EqualError(t, err, "max_size must be positive, got 0")
+
+	factory := newDownscaleExponentialHistogramFactory()
+	_, err = factory.CreateFunction(ottl.FunctionContext{}, "invalid")
+	assert.ErrorContains(t, err, "downscaleExponentialHistogramFactory args must be of type *downscaleExponentialHistogramArguments")
+}
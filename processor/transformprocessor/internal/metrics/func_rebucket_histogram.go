@@ -0,0 +1,161 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/metrics"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
+)
+
+type rebucketHistogramArguments struct {
+	Bounds []float64
+}
+
+func newRebucketHistogramFactory() ottl.Factory[ottldatapoint.TransformContext] {
+	return ottl.NewFactory("rebucket_histogram", &rebucketHistogramArguments{}, createRebucketHistogramFunction)
+}
+
+func createRebucketHistogramFunction(_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[ottldatapoint.TransformContext], error) {
+	args, ok := oArgs.(*rebucketHistogramArguments)
+	if !ok {
+		return nil, errors.New("rebucketHistogramFactory args must be of type *rebucketHistogramArguments")
+	}
+
+	return rebucketHistogram(args.Bounds)
+}
+
+func rebucketHistogram(bounds []float64) (ottl.ExprFunc[ottldatapoint.TransformContext], error) {
+	if len(bounds) == 0 {
+		return nil, errors.New("bounds cannot be empty")
+	}
+	for i, bound := range bounds {
+		if math.IsNaN(bound) || math.IsInf(bound, 0) {
+			return nil, fmt.Errorf("bounds must be finite: %v", bounds)
+		}
+		if i > 0 && bound <= bounds[i-1] {
+			return nil, fmt.Errorf("bounds must be strictly increasing: %v", bounds)
+		}
+	}
+
+	return func(_ context.Context, tCtx ottldatapoint.TransformContext) (any, error) {
+		dataPoint, ok := tCtx.GetDataPoint().(pmetric.HistogramDataPoint)
+		if !ok {
+			return nil, nil
+		}
+
+		rebucketHistogramDataPoint(dataPoint, bounds)
+		return nil, nil
+	}, nil
+}
+
+// rebucketHistogramDataPoint redistributes the bucket counts of dp onto the
+// given bounds. The count of a source bucket is split across the target buckets
+// it overlaps, proportionally to the overlap, assuming values are evenly
+// distributed within the source bucket.
+func rebucketHistogramDataPoint(dp pmetric.HistogramDataPoint, bounds []float64) {
+	srcBounds := dp.ExplicitBounds().AsRaw()
+	srcCounts := dp.BucketCounts().AsRaw()
+
+	if len(srcCounts) == 0 || len(srcCounts) != len(srcBounds)+1 {
+		return
+	}
+	if slices.Equal(srcBounds, bounds) {
+		return
+	}
+
+	var total uint64
+	alloc := make([]float64, len(bounds)+1)
+	for i, count := range srcCounts {
+		if count == 0 {
+			continue
+		}
+		total += count
+
+		lower, upper := math.Inf(-1), math.Inf(1)
+		if i > 0 {
+			lower = srcBounds[i-1]
+		}
+		if i < len(srcBounds) {
+			upper = srcBounds[i]
+		}
+
+		// no value is below min or above max, use them to narrow the bucket
+		if dp.HasMin() && dp.Min() > lower {
+			lower = min(dp.Min(), upper)
+		}
+		if dp.HasMax() && dp.Max() < upper {
+			upper = max(dp.Max(), lower)
+		}
+
+		distributeBucketCount(alloc, bounds, lower, upper, float64(count))
+	}
+
+	dp.ExplicitBounds().FromRaw(bounds)
+	dp.BucketCounts().FromRaw(roundBucketCounts(alloc, total))
+}
+
+// distributeBucketCount adds count to the target buckets overlapping the
+// source bucket (lower, upper]. Source buckets with one unbounded side are
+// assigned to the target bucket containing their finite bound.
+func distributeBucketCount(alloc, bounds []float64, lower, upper, count float64) {
+	// first is the target bucket containing values just above lower,
+	// last is the target bucket containing upper
+	first := sort.SearchFloat64s(bounds, lower)
+	if first < len(bounds) && bounds[first] == lower {
+		first++
+	}
+	last := sort.SearchFloat64s(bounds, upper)
+
+	switch {
+	case math.IsInf(upper, 1):
+		alloc[first] += count
+		return
+	case math.IsInf(lower, -1), lower == upper, first >= last:
+		alloc[last] += count
+		return
+	}
+
+	width := upper - lower
+	for j := first; j <= last; j++ {
+		lo, hi := lower, upper
+		if j > 0 {
+			lo = max(lo, bounds[j-1])
+		}
+		if j < len(bounds) {
+			hi = min(hi, bounds[j])
+		}
+		alloc[j] += count * (hi - lo) / width
+	}
+}
+
+// roundBucketCounts rounds the fractional bucket counts, preserving the total
+// count.
+func roundBucketCounts(alloc []float64, total uint64) []uint64 {
+	counts := make([]uint64, len(alloc))
+
+	var (
+		acc  float64
+		prev uint64
+	)
+	for j, a := range alloc {
+		acc += a
+		cur := min(uint64(math.Round(acc)), total)
+		if j == len(alloc)-1 {
+			cur = total
+		}
+		cur = max(cur, prev)
+		counts[j] = cur - prev
+		prev = cur
+	}
+	return counts
+}
@@ -0,0 +1,152 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
+)
+
+func TestRebucketHistogram(t *testing.T) {
+	tests := []struct {
+		name           string
+		inputBounds    []float64
+		inputCounts    []uint64
+		inputMin       *float64
+		inputMax       *float64
+		bounds         []float64
+		expectedBounds []float64
+		expectedCounts []uint64
+	}{
+		{
+			name:           "split bucket evenly",
+			inputBounds:    []float64{0, 10},
+			inputCounts:    []uint64{0, 10, 0},
+			bounds:         []float64{0, 5, 10},
+			expectedBounds: []float64{0, 5, 10},
+			expectedCounts: []uint64{0, 5, 5, 0},
+		},
+		{
+			name:           "merge buckets",
+			inputBounds:    []float64{1, 2, 3, 4},
+			inputCounts:    []uint64{1, 2, 3, 4, 0},
+			bounds:         []float64{2, 4},
+			expectedBounds: []float64{2, 4},
+			expectedCounts: []uint64{3, 7, 0},
+		},
+		{
+			name:           "rounding preserves count",
+			inputBounds:    []float64{0, 3},
+			inputCounts:    []uint64{0, 10, 0},
+			bounds:         []float64{1, 2},
+			expectedBounds: []float64{1, 2},
+			expectedCounts: []uint64{3, 4, 3},
+		},
+		{
+			name:           "unbounded buckets without min and max",
+			inputBounds:    []float64{10},
+			inputCounts:    []uint64{4, 4},
+			bounds:         []float64{5, 15},
+			expectedBounds: []float64{5, 15},
+			expectedCounts: []uint64{0, 8, 0},
+		},
+		{
+			name:           "unbounded buckets narrowed by min and max",
+			inputBounds:    []float64{10},
+			inputCounts:    []uint64{4, 4},
+			inputMin:       ptr(0.0),
+			inputMax:       ptr(20.0),
+			bounds:         []float64{5, 15},
+			expectedBounds: []float64{5, 15},
+			expectedCounts: []uint64{2, 4, 2},
+		},
+		{
+			name:           "same bounds - no change",
+			inputBounds:    []float64{1, 2},
+			inputCounts:    []uint64{1, 2, 3},
+			bounds:         []float64{1, 2},
+			expectedBounds: []float64{1, 2},
+			expectedCounts: []uint64{1, 2, 3},
+		},
+		{
+			name:           "malformed histogram (mismatched lengths) - no change",
+			inputBounds:    []float64{1, 2},
+			inputCounts:    []uint64{1, 2},
+			bounds:         []float64{5},
+			expectedBounds: []float64{1, 2},
+			expectedCounts: []uint64{1, 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := rebucketHistogram(tt.bounds)
+			require.NoError(t, err)
+
+			metric := pmetric.NewMetric()
+			metric.SetName("test_histogram")
+			dp := metric.SetEmptyHistogram().DataPoints().AppendEmpty()
+			dp.ExplicitBounds().FromRaw(tt.inputBounds)
+			dp.BucketCounts().FromRaw(tt.inputCounts)
+			if tt.inputMin != nil {
+				dp.SetMin(*tt.inputMin)
+			}
+			if tt.inputMax != nil {
+				dp.SetMax(*tt.inputMax)
+			}
+
+			ctx := ottldatapoint.NewTransformContext(dp, metric, pmetric.NewMetricSlice(), pcommon.NewInstrumentationScope(), pcommon.NewResource(), pmetric.NewScopeMetrics(), pmetric.NewResourceMetrics())
+			result, err := exprFunc(t.Context(), ctx)
+			assert.NoError(t, err)
+			assert.Nil(t, result)
+
+			assert.Equal(t, tt.expectedBounds, dp.ExplicitBounds().AsRaw())
+			assert.Equal(t, tt.expectedCounts, dp.BucketCounts().AsRaw())
+		})
+	}
+}
+
+func TestRebucketHistogramInvalidBounds(t *testing.T) {
+	_, err := rebucketHistogram(nil)
+	assert.EqualError(t, err, "bounds cannot be empty")
+
+	_, err = rebucketHistogram([]float64{1, 1})
+	assert.ErrorContains(t, err, "bounds must be strictly increasing")
+
+	_, err = rebucketHistogram([]float64{2, 1})
+	assert.ErrorContains(t, err, "bounds must be strictly increasing")
+}
+
+func TestRebucketHistogramNonHistogramDataPoint(t *testing.T) {
+	metric := pmetric.NewMetric()
+	dp := metric.SetEmptyGauge().DataPoints().AppendEmpty()
+	dp.SetDoubleValue(10.0)
+
+	exprFunc, err := rebucketHistogram([]float64{1})
+	require.NoError(t, err)
+
+	ctx := ottldatapoint.NewTransformContext(dp, metric, pmetric.NewMetricSlice(), pcommon.NewInstrumentationScope(), pcommon.NewResource(), pmetric.NewScopeMetrics(), pmetric.NewResourceMetrics())
+	result, err := exprFunc(t.Context(), ctx)
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, 10.0, dp.DoubleValue())
+}
+
+func TestRebucketHistogramFactoryWithInvalidArgs(t *testing.T) {
+	factory := newRebucketHistogramFactory()
+
+	_, err := factory.CreateFunction(ottl.FunctionContext{}, "invalid")
+	assert.ErrorContains(t, err, "rebucketHistogramFactory args must be of type *rebucketHistogramArguments")
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
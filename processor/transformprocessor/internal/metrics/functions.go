@@ -28,6 +28,8 @@ func DataPointFunctions() map[string]ottl.Factory[ottldatapoint.TransformContext
 		newConvertSummarySumValToSumFactory(),
 		newConvertSummaryCountValToSumFactory(),
 		newMergeHistogramBucketsFactory(),
+		newRebucketHistogramFactory(),
+		newDownscaleExponentialHistogramFactory(),
 	)
 
 	maps.Copy(functions, datapointFunctions)
@@ -37,6 +37,8 @@ func Test_DataPointFunctions(t *testing.T) {
 			expected["convert_summary_sum_val_to_sum"] = newConvertSummarySumValToSumFactory()
 			expected["convert_summary_count_val_to_sum"] = newConvertSummaryCountValToSumFactory()
 			expected["merge_histogram_buckets"] = newMergeHistogramBucketsFactory()
+			expected["rebucket_histogram"] = newRebucketHistogramFactory()
+			expected["downscale_exponential_histogram"] = newDownscaleExponentialHistogramFactory()
 
 			actual := DataPointFunctions()
 
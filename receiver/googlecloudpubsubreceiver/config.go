@@ -6,10 +6,16 @@ package googlecloudpubsubreceiver // import "github.com/open-telemetry/opentelem
 import (
 	"fmt"
 	"regexp"
+	"time"
 
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/ackextension"
 )
 
+// streamAckDeadline is the acknowledgement deadline of the messages of the stream, see internal.StreamHandler.
+const streamAckDeadline = 60 * time.Second
+
 var subscriptionMatcher = regexp.MustCompile(`projects/[a-z][a-z0-9\-]*/subscriptions/`)
 
 type Config struct {
@@ -36,6 +42,9 @@ type Config struct {
 
 	// The client id that will be used by Pubsub to make load balancing decisions
 	ClientID string `mapstructure:"client_id"`
+
+	// Defer acknowledging messages until their data has been acknowledged at the end of the pipelines
+	EndToEndAck ackextension.EndToEndConfig `mapstructure:"end_to_end_ack"`
 }
 
 func (config *Config) validate() error {
@@ -48,5 +57,8 @@ func (config *Config) validate() error {
 	default:
 		return fmt.Errorf("compression %v is not supported.  supported compression formats include [gzip]", config.Compression)
 	}
+	if config.EndToEndAck.Extension != nil && config.EndToEndAck.Timeout >= streamAckDeadline {
+		return fmt.Errorf("end_to_end_ack timeout must be shorter than the %s stream acknowledgement deadline", streamAckDeadline)
+	}
 	return nil
 }
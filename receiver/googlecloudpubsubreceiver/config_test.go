@@ -15,6 +15,7 @@ import (
 	"go.opentelemetry.io/collector/confmap/xconfmap"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/ackextension"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/googlecloudpubsubreceiver/internal/metadata"
 )
 
@@ -24,14 +25,18 @@ func TestLoadConfig(t *testing.T) {
 	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
 	require.NoError(t, err)
 
+	ackExtensionID := component.MustNewID("ack")
+
 	tests := []struct {
 		id          component.ID
 		expected    component.Config
 		expectedErr error
 	}{
 		{
-			id:       component.NewIDWithName(metadata.Type, ""),
-			expected: &Config{},
+			id: component.NewIDWithName(metadata.Type, ""),
+			expected: &Config{
+				EndToEndAck: ackextension.EndToEndConfig{Timeout: 30 * time.Second},
+			},
 		},
 		{
 			id: component.NewIDWithName(metadata.Type, "customname"),
@@ -42,6 +47,17 @@ func TestLoadConfig(t *testing.T) {
 					Timeout: 20 * time.Second,
 				},
 				Subscription: "projects/my-project/subscriptions/otlp-subscription",
+				EndToEndAck:  ackextension.EndToEndConfig{Timeout: 30 * time.Second},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "end_to_end_ack"),
+			expected: &Config{
+				Subscription: "projects/my-project/subscriptions/otlp-subscription",
+				EndToEndAck: ackextension.EndToEndConfig{
+					Extension: &ackExtensionID,
+					Timeout:   20 * time.Second,
+				},
 			},
 		},
 	}
@@ -71,3 +87,14 @@ func TestConfigValidation(t *testing.T) {
 	c.Subscription = "projects/my-project/subscriptions/my-subscription"
 	assert.NoError(t, c.validate())
 }
+
+func TestConfigValidationEndToEndAck(t *testing.T) {
+	factory := NewFactory()
+	c := factory.CreateDefaultConfig().(*Config)
+	c.Subscription = "projects/my-project/subscriptions/my-subscription"
+	ackExtensionID := component.MustNewID("ack")
+	c.EndToEndAck.Extension = &ackExtensionID
+	assert.NoError(t, c.validate())
+	c.EndToEndAck.Timeout = time.Minute
+	assert.EqualError(t, c.validate(), "end_to_end_ack timeout must be shorter than the 1m0s stream acknowledgement deadline")
+}
@@ -6,18 +6,22 @@ package googlecloudpubsubreceiver // import "github.com/open-telemetry/opentelem
 import (
 	"context"
 	"strings"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/receiver/receiverhelper"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/ackextension"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/googlecloudpubsubreceiver/internal/metadata"
 )
 
 const (
 	reportTransport      = "pubsub"
 	reportFormatProtobuf = "protobuf"
+
+	defaultEndToEndAckTimeout = 30 * time.Second
 )
 
 func NewFactory() receiver.Factory {
@@ -38,7 +42,9 @@ type pubsubReceiverFactory struct {
 }
 
 func (*pubsubReceiverFactory) CreateDefaultConfig() component.Config {
-	return &Config{}
+	return &Config{
+		EndToEndAck: ackextension.EndToEndConfig{Timeout: defaultEndToEndAckTimeout},
+	}
 }
 
 func (factory *pubsubReceiverFactory) ensureReceiver(settings receiver.Settings, config component.Config) (*pubsubReceiver, error) {
@@ -24,6 +24,7 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/ackextension"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/googlecloudpubsubreceiver/internal"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/googlecloudpubsubreceiver/internal/metadata"
@@ -43,6 +44,7 @@ type pubsubReceiver struct {
 	metricsUnmarshaler pmetric.Unmarshaler
 	logsUnmarshaler    plog.Unmarshaler
 	handler            *internal.StreamHandler
+	acks               *ackextension.EndToEnd
 	startOnce          sync.Once
 	telemetryBuilder   *metadata.TelemetryBuilder
 }
@@ -112,6 +114,12 @@ func (receiver *pubsubReceiver) Start(ctx context.Context, host component.Host)
 		createHandlerFn = receiver.createMultiplexingReceiverHandler
 	}
 
+	acks, err := ackextension.NewEndToEnd(host, receiver.config.EndToEndAck, receiver.settings.ID)
+	if err != nil {
+		return err
+	}
+	receiver.acks = acks
+
 	var startErr error
 	receiver.startOnce.Do(func() {
 		client, err := newSubscriberClient(ctx, receiver.config, receiver.userAgent)
@@ -240,9 +248,12 @@ func (receiver *pubsubReceiver) handleTrace(ctx context.Context, payload []byte,
 	}
 	count := otlpData.SpanCount()
 	ctx = receiver.obsrecv.StartTracesOp(ctx)
-	err = receiver.tracesConsumer.ConsumeTraces(ctx, otlpData)
+	err = receiver.acks.Consume(ctx, func(ctx context.Context) error {
+		ackextension.MarkTraces(ctx, otlpData)
+		return receiver.tracesConsumer.ConsumeTraces(ctx, otlpData)
+	})
 	receiver.obsrecv.EndTracesOp(ctx, reportFormatProtobuf, count, err)
-	return nil
+	return receiver.consumeError(err)
 }
 
 func (receiver *pubsubReceiver) handleMetric(ctx context.Context, payload []byte, compression buildInCompression) error {
@@ -260,9 +271,12 @@ func (receiver *pubsubReceiver) handleMetric(ctx context.Context, payload []byte
 	}
 	count := otlpData.MetricCount()
 	ctx = receiver.obsrecv.StartMetricsOp(ctx)
-	err = receiver.metricsConsumer.ConsumeMetrics(ctx, otlpData)
+	err = receiver.acks.Consume(ctx, func(ctx context.Context) error {
+		ackextension.MarkMetrics(ctx, otlpData)
+		return receiver.metricsConsumer.ConsumeMetrics(ctx, otlpData)
+	})
 	receiver.obsrecv.EndMetricsOp(ctx, reportFormatProtobuf, count, err)
-	return nil
+	return receiver.consumeError(err)
 }
 
 func (receiver *pubsubReceiver) handleLog(ctx context.Context, payload []byte, compression buildInCompression) error {
@@ -280,9 +294,21 @@ func (receiver *pubsubReceiver) handleLog(ctx context.Context, payload []byte, c
 	}
 	count := otlpData.LogRecordCount()
 	ctx = receiver.obsrecv.StartLogsOp(ctx)
-	err = receiver.logsConsumer.ConsumeLogs(ctx, otlpData)
+	err = receiver.acks.Consume(ctx, func(ctx context.Context) error {
+		ackextension.MarkLogs(ctx, otlpData)
+		return receiver.logsConsumer.ConsumeLogs(ctx, otlpData)
+	})
 	receiver.obsrecv.EndLogsOp(ctx, reportFormatProtobuf, count, err)
-	return nil
+	return receiver.consumeError(err)
+}
+
+// consumeError returns the error preventing the message to be acknowledged. Unless end-to-end acknowledgement
+// is enabled, messages are acknowledged even if consuming their data failed, leaving the flow control to Pubsub.
+func (receiver *pubsubReceiver) consumeError(err error) error {
+	if receiver.acks == nil {
+		return nil
+	}
+	return err
 }
 
 func (receiver *pubsubReceiver) increaseEncodingErrorMetric(ctx context.Context, signal string) {
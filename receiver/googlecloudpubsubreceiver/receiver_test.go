@@ -5,6 +5,7 @@ package googlecloudpubsubreceiver
 
 import (
 	"context"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -13,14 +14,17 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/extension/extensiontest"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/receiver/receiverhelper"
 	"go.opentelemetry.io/collector/receiver/receivertest"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/ackextension"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/googlecloudpubsubreceiver/internal/metadata"
 )
 
@@ -360,3 +364,107 @@ func TestEncodingExtensionMismatch(t *testing.T) {
 	assert.Nil(t, receiver.metricsConsumer)
 	assert.NotNil(t, receiver.logsConsumer)
 }
+
+type extensionsHost map[component.ID]component.Component
+
+func (h extensionsHost) GetExtensions() map[component.ID]component.Component {
+	return h
+}
+
+func TestReceiverEndToEndAck(t *testing.T) {
+	ctx := t.Context()
+	srv := pstest.NewServer()
+	defer srv.Close()
+	_, err := srv.GServer.CreateTopic(ctx, &pb.Topic{
+		Name: "projects/my-project/topics/otlp",
+	})
+	require.NoError(t, err)
+	_, err = srv.GServer.CreateSubscription(ctx, &pb.Subscription{
+		Topic:              "projects/my-project/topics/otlp",
+		Name:               "projects/my-project/subscriptions/otlp",
+		AckDeadlineSeconds: 10,
+	})
+	require.NoError(t, err)
+
+	factory := ackextension.NewFactory()
+	ext, err := factory.Create(ctx, extensiontest.NewNopSettings(factory.Type()), factory.CreateDefaultConfig())
+	require.NoError(t, err)
+	ackExtensionID := component.MustNewID("ack")
+
+	settings := receivertest.NewNopSettings(metadata.Type)
+	obsrecv, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
+		ReceiverID:             component.NewID(metadata.Type),
+		Transport:              reportTransport,
+		ReceiverCreateSettings: settings,
+	})
+	require.NoError(t, err)
+
+	receiver := &pubsubReceiver{
+		settings:  settings,
+		obsrecv:   obsrecv,
+		userAgent: "test-user-agent",
+
+		config: &Config{
+			Endpoint:  srv.Addr,
+			Insecure:  true,
+			ProjectID: "my-project",
+			TimeoutSettings: exporterhelper.TimeoutConfig{
+				Timeout: 1 * time.Second,
+			},
+			Subscription: "projects/my-project/subscriptions/otlp",
+			Encoding:     "otlp_proto_log",
+			EndToEndAck: ackextension.EndToEndConfig{
+				Extension: &ackExtensionID,
+				Timeout:   100 * time.Millisecond,
+			},
+		},
+	}
+
+	// The pipeline only acknowledges the data once acknowledge is set.
+	var acknowledge atomic.Bool
+	var consumed atomic.Int32
+	receiver.logsConsumer, err = consumer.NewLogs(func(_ context.Context, ld plog.Logs) error {
+		if acknowledge.Load() {
+			ackextension.AckResources(ext.(ackextension.AckExtension), ackextension.TakeLogsAcks(ld))
+		}
+		consumed.Add(1)
+		return nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, receiver.Start(ctx, extensionsHost{ackExtensionID: ext}))
+
+	unacked := srv.Publish("projects/my-project/topics/otlp", createLogExport(), nil)
+	assert.Eventually(t, func() bool {
+		return consumed.Load() == 1
+	}, 10*time.Second, 10*time.Millisecond)
+
+	acknowledge.Store(true)
+	acked := srv.Publish("projects/my-project/topics/otlp", createLogExport(), nil)
+	assert.Eventually(t, func() bool {
+		return consumed.Load() == 2
+	}, 10*time.Second, 10*time.Millisecond)
+
+	// Shutting down flushes the pending acknowledgements.
+	require.NoError(t, receiver.Shutdown(ctx))
+	assert.Eventually(t, func() bool {
+		return srv.Message(acked).Acks == 1
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.Zero(t, srv.Message(unacked).Acks)
+}
+
+func TestReceiverEndToEndAckMissingExtension(t *testing.T) {
+	ctx := t.Context()
+	srv, receiver := createBaseReceiver()
+	defer func() {
+		assert.NoError(t, srv.Close())
+		assert.NoError(t, receiver.Shutdown(ctx))
+	}()
+
+	ackExtensionID := component.MustNewID("ack")
+	receiver.logsConsumer = consumertest.NewNop()
+	receiver.config.EndToEndAck = ackextension.EndToEndConfig{
+		Extension: &ackExtensionID,
+		Timeout:   time.Second,
+	}
+	assert.EqualError(t, receiver.Start(ctx, fakeHost{}), `specified ack extension with id "ack" could not be found`)
+}
@@ -4,10 +4,14 @@
 package kafkareceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkareceiver"
 
 import (
+	"errors"
+	"time"
+
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configretry"
 	"go.opentelemetry.io/collector/confmap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/ackextension"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/kafka/configkafka"
 )
 
@@ -58,6 +62,18 @@ type Config struct {
 	// returns an error.
 	ErrorBackOff configretry.BackOffConfig `mapstructure:"error_backoff"`
 
+	// DeadLetter controls publishing records that fail with a permanent
+	// error to a dead-letter topic.
+	DeadLetter DeadLetterConfig `mapstructure:"dead_letter"`
+
+	// EndToEndAck controls deferring marking messages as consumed until
+	// their data has been acknowledged at the end of the pipelines.
+	EndToEndAck ackextension.EndToEndConfig `mapstructure:"end_to_end_ack"`
+
+	// RateLimit controls the rate at which records are consumed, and
+	// pausing fetching while the pipeline refuses records.
+	RateLimit RateLimitConfig `mapstructure:"rate_limit"`
+
 	// Telemetry controls optional telemetry configuration.
 	Telemetry TelemetryConfig `mapstructure:"telemetry"`
 }
@@ -119,6 +135,33 @@ func (c *Config) Unmarshal(conf *confmap.Conf) error {
 	return conf.Unmarshal(c)
 }
 
+func (c *Config) Validate() error {
+	if c.EndToEndAck.Extension != nil && !c.MessageMarking.After {
+		return errors.New("end_to_end_ack requires message_marking::after to be enabled")
+	}
+	if c.RateLimit.RecordsPerSecond < 0 {
+		return errors.New("rate_limit::records_per_second must not be negative")
+	}
+	if c.RateLimit.BytesPerSecond < 0 {
+		return errors.New("rate_limit::bytes_per_second must not be negative")
+	}
+	if c.RateLimit.PauseOnBackpressure && !c.ErrorBackOff.Enabled {
+		return errors.New("rate_limit::pause_on_backpressure requires error_backoff to be enabled")
+	}
+	if !c.DeadLetter.Enabled {
+		return nil
+	}
+	if c.DeadLetter.Topic == "" {
+		return errors.New("dead_letter::topic must be specified when dead_letter is enabled")
+	}
+	for _, topic := range []string{c.Logs.Topic, c.Metrics.Topic, c.Traces.Topic, c.Profiles.Topic} {
+		if c.DeadLetter.Topic == topic {
+			return errors.New("dead_letter::topic must differ from the consumed topics")
+		}
+	}
+	return nil
+}
+
 // TopicEncodingConfig holds signal-specific topic and encoding configuration.
 type TopicEncodingConfig struct {
 	// Topic holds the name of the Kafka topic from which messages of the
@@ -155,6 +198,47 @@ type MessageMarking struct {
 	OnPermanentError bool `mapstructure:"on_permanent_error"`
 }
 
+// DeadLetterConfig configures the topic records are published to when they
+// fail to be unmarshaled or the next consumer returns a permanent error.
+type DeadLetterConfig struct {
+	// Enabled controls whether records are published to the dead-letter topic.
+	Enabled bool `mapstructure:"enabled"`
+
+	// Topic holds the name of the dead-letter topic.
+	Topic string `mapstructure:"topic"`
+
+	// Timeout is the maximum time to wait for a record to be published
+	// (default 5s).
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// Producer holds the configuration of the producer publishing records
+	// to the dead-letter topic.
+	Producer configkafka.ProducerConfig `mapstructure:"producer"`
+
+	_ struct{} // avoids unkeyed_literal_initialization
+}
+
+// RateLimitConfig configures the rate at which records are consumed.
+type RateLimitConfig struct {
+	// RecordsPerSecond is the maximum number of records consumed per
+	// second across all partitions. Zero means no limit.
+	RecordsPerSecond float64 `mapstructure:"records_per_second"`
+
+	// BytesPerSecond is the maximum number of record bytes (keys and
+	// values) consumed per second across all partitions. Zero means no
+	// limit.
+	BytesPerSecond int `mapstructure:"bytes_per_second"`
+
+	// PauseOnBackpressure controls whether fetching from a partition is
+	// paused while a record is retried after the next consumer returned a
+	// non-permanent error, e.g. a refusal by the memory_limiter processor.
+	// Fetching is resumed once the record is consumed or given up on.
+	// Only supported by the franz-go client.
+	PauseOnBackpressure bool `mapstructure:"pause_on_backpressure"`
+
+	_ struct{} // avoids unkeyed_literal_initialization
+}
+
 type HeaderExtraction struct {
 	ExtractHeaders bool     `mapstructure:"extract_headers"`
 	Headers        []string `mapstructure:"headers"`
@@ -16,6 +16,7 @@ import (
 	"go.opentelemetry.io/collector/confmap/confmaptest"
 	"go.opentelemetry.io/collector/confmap/xconfmap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/ackextension"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/kafka/configkafka"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkareceiver/internal/metadata"
 )
@@ -66,6 +67,13 @@ func TestLoadConfig(t *testing.T) {
 				ErrorBackOff: configretry.BackOffConfig{
 					Enabled: false,
 				},
+				DeadLetter: DeadLetterConfig{
+					Timeout:  5 * time.Second,
+					Producer: configkafka.NewDefaultProducerConfig(),
+				},
+				EndToEndAck: ackextension.EndToEndConfig{
+					Timeout: 30 * time.Second,
+				},
 				Telemetry: TelemetryConfig{
 					Metrics: MetricsConfig{
 						KafkaReceiverRecordsDelay: MetricConfig{
@@ -100,6 +108,13 @@ func TestLoadConfig(t *testing.T) {
 				ErrorBackOff: configretry.BackOffConfig{
 					Enabled: false,
 				},
+				DeadLetter: DeadLetterConfig{
+					Timeout:  5 * time.Second,
+					Producer: configkafka.NewDefaultProducerConfig(),
+				},
+				EndToEndAck: ackextension.EndToEndConfig{
+					Timeout: 30 * time.Second,
+				},
 			},
 		},
 		{
@@ -127,6 +142,13 @@ func TestLoadConfig(t *testing.T) {
 				ErrorBackOff: configretry.BackOffConfig{
 					Enabled: false,
 				},
+				DeadLetter: DeadLetterConfig{
+					Timeout:  5 * time.Second,
+					Producer: configkafka.NewDefaultProducerConfig(),
+				},
+				EndToEndAck: ackextension.EndToEndConfig{
+					Timeout: 30 * time.Second,
+				},
 			},
 		},
 		{
@@ -181,6 +203,13 @@ func TestLoadConfig(t *testing.T) {
 					MaxElapsedTime:  1 * time.Minute,
 					Multiplier:      1.5,
 				},
+				DeadLetter: DeadLetterConfig{
+					Timeout:  5 * time.Second,
+					Producer: configkafka.NewDefaultProducerConfig(),
+				},
+				EndToEndAck: ackextension.EndToEndConfig{
+					Timeout: 30 * time.Second,
+				},
 			},
 		},
 		{
@@ -212,6 +241,13 @@ func TestLoadConfig(t *testing.T) {
 				ErrorBackOff: configretry.BackOffConfig{
 					Enabled: false,
 				},
+				DeadLetter: DeadLetterConfig{
+					Timeout:  5 * time.Second,
+					Producer: configkafka.NewDefaultProducerConfig(),
+				},
+				EndToEndAck: ackextension.EndToEndConfig{
+					Timeout: 30 * time.Second,
+				},
 			},
 		},
 		{
@@ -243,6 +279,13 @@ func TestLoadConfig(t *testing.T) {
 				ErrorBackOff: configretry.BackOffConfig{
 					Enabled: false,
 				},
+				DeadLetter: DeadLetterConfig{
+					Timeout:  5 * time.Second,
+					Producer: configkafka.NewDefaultProducerConfig(),
+				},
+				EndToEndAck: ackextension.EndToEndConfig{
+					Timeout: 30 * time.Second,
+				},
 			},
 		},
 		{
@@ -274,6 +317,13 @@ func TestLoadConfig(t *testing.T) {
 				ErrorBackOff: configretry.BackOffConfig{
 					Enabled: false,
 				},
+				DeadLetter: DeadLetterConfig{
+					Timeout:  5 * time.Second,
+					Producer: configkafka.NewDefaultProducerConfig(),
+				},
+				EndToEndAck: ackextension.EndToEndConfig{
+					Timeout: 30 * time.Second,
+				},
 			},
 		},
 		{
@@ -305,6 +355,137 @@ func TestLoadConfig(t *testing.T) {
 				ErrorBackOff: configretry.BackOffConfig{
 					Enabled: false,
 				},
+				DeadLetter: DeadLetterConfig{
+					Timeout:  5 * time.Second,
+					Producer: configkafka.NewDefaultProducerConfig(),
+				},
+				EndToEndAck: ackextension.EndToEndConfig{
+					Timeout: 30 * time.Second,
+				},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "dead_letter"),
+			expected: &Config{
+				ClientConfig:   configkafka.NewDefaultClientConfig(),
+				ConsumerConfig: configkafka.NewDefaultConsumerConfig(),
+				Logs: TopicEncodingConfig{
+					Topic:    "otlp_logs",
+					Encoding: "otlp_proto",
+				},
+				Metrics: TopicEncodingConfig{
+					Topic:    "otlp_metrics",
+					Encoding: "otlp_proto",
+				},
+				Traces: TopicEncodingConfig{
+					Topic:    "otlp_spans",
+					Encoding: "otlp_proto",
+				},
+				Profiles: TopicEncodingConfig{
+					Topic:    "otlp_profiles",
+					Encoding: "otlp_proto",
+				},
+				MessageMarking: MessageMarking{
+					After: true,
+				},
+				ErrorBackOff: configretry.BackOffConfig{
+					Enabled: false,
+				},
+				DeadLetter: DeadLetterConfig{
+					Enabled: true,
+					Topic:   "otlp_dead_letter",
+					Timeout: 10 * time.Second,
+					Producer: func() configkafka.ProducerConfig {
+						config := configkafka.NewDefaultProducerConfig()
+						config.RequiredAcks = configkafka.WaitForAll
+						return config
+					}(),
+				},
+				EndToEndAck: ackextension.EndToEndConfig{
+					Timeout: 30 * time.Second,
+				},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "end_to_end_ack"),
+			expected: &Config{
+				ClientConfig:   configkafka.NewDefaultClientConfig(),
+				ConsumerConfig: configkafka.NewDefaultConsumerConfig(),
+				Logs: TopicEncodingConfig{
+					Topic:    "otlp_logs",
+					Encoding: "otlp_proto",
+				},
+				Metrics: TopicEncodingConfig{
+					Topic:    "otlp_metrics",
+					Encoding: "otlp_proto",
+				},
+				Traces: TopicEncodingConfig{
+					Topic:    "otlp_spans",
+					Encoding: "otlp_proto",
+				},
+				Profiles: TopicEncodingConfig{
+					Topic:    "otlp_profiles",
+					Encoding: "otlp_proto",
+				},
+				MessageMarking: MessageMarking{
+					After: true,
+				},
+				ErrorBackOff: configretry.BackOffConfig{
+					Enabled: false,
+				},
+				DeadLetter: DeadLetterConfig{
+					Timeout:  5 * time.Second,
+					Producer: configkafka.NewDefaultProducerConfig(),
+				},
+				EndToEndAck: ackextension.EndToEndConfig{
+					Extension: func() *component.ID {
+						id := component.MustNewID("ack")
+						return &id
+					}(),
+					Timeout: time.Minute,
+				},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "rate_limit"),
+			expected: &Config{
+				ClientConfig:   configkafka.NewDefaultClientConfig(),
+				ConsumerConfig: configkafka.NewDefaultConsumerConfig(),
+				Logs: TopicEncodingConfig{
+					Topic:    "otlp_logs",
+					Encoding: "otlp_proto",
+				},
+				Metrics: TopicEncodingConfig{
+					Topic:    "otlp_metrics",
+					Encoding: "otlp_proto",
+				},
+				Traces: TopicEncodingConfig{
+					Topic:    "otlp_spans",
+					Encoding: "otlp_proto",
+				},
+				Profiles: TopicEncodingConfig{
+					Topic:    "otlp_profiles",
+					Encoding: "otlp_proto",
+				},
+				ErrorBackOff: configretry.BackOffConfig{
+					Enabled:         true,
+					InitialInterval: 1 * time.Second,
+					MaxInterval:     10 * time.Second,
+					MaxElapsedTime:  1 * time.Minute,
+					Multiplier:      1.5,
+				},
+				DeadLetter: DeadLetterConfig{
+					Timeout:  5 * time.Second,
+					Producer: configkafka.NewDefaultProducerConfig(),
+				},
+				EndToEndAck: ackextension.EndToEndConfig{
+					Timeout: 30 * time.Second,
+				},
+				RateLimit: RateLimitConfig{
+					RecordsPerSecond:    1000,
+					BytesPerSecond:      1048576,
+					PauseOnBackpressure: true,
+				},
 			},
 		},
 	}
@@ -323,3 +504,67 @@ func TestLoadConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigValidate(t *testing.T) {
+	ackExtensionID := component.MustNewID("ack")
+	tests := map[string]struct {
+		deadLetter     DeadLetterConfig
+		messageMarking MessageMarking
+		endToEndAck    ackextension.EndToEndConfig
+		errorBackOff   configretry.BackOffConfig
+		rateLimit      RateLimitConfig
+		expectedErr    string
+	}{
+		"dead_letter_disabled": {
+			deadLetter: DeadLetterConfig{Topic: "otlp_spans"},
+		},
+		"dead_letter_without_topic": {
+			deadLetter:  DeadLetterConfig{Enabled: true},
+			expectedErr: "dead_letter::topic must be specified when dead_letter is enabled",
+		},
+		"dead_letter_consumed_topic": {
+			deadLetter:  DeadLetterConfig{Enabled: true, Topic: "otlp_spans"},
+			expectedErr: "dead_letter::topic must differ from the consumed topics",
+		},
+		"end_to_end_ack": {
+			messageMarking: MessageMarking{After: true},
+			endToEndAck:    ackextension.EndToEndConfig{Extension: &ackExtensionID, Timeout: time.Second},
+		},
+		"end_to_end_ack_marking_before": {
+			endToEndAck: ackextension.EndToEndConfig{Extension: &ackExtensionID, Timeout: time.Second},
+			expectedErr: "end_to_end_ack requires message_marking::after to be enabled",
+		},
+		"rate_limit": {
+			errorBackOff: configretry.BackOffConfig{Enabled: true},
+			rateLimit:    RateLimitConfig{RecordsPerSecond: 100, BytesPerSecond: 1024, PauseOnBackpressure: true},
+		},
+		"rate_limit_negative_records": {
+			rateLimit:   RateLimitConfig{RecordsPerSecond: -1},
+			expectedErr: "rate_limit::records_per_second must not be negative",
+		},
+		"rate_limit_negative_bytes": {
+			rateLimit:   RateLimitConfig{BytesPerSecond: -1},
+			expectedErr: "rate_limit::bytes_per_second must not be negative",
+		},
+		"rate_limit_pause_without_error_backoff": {
+			rateLimit:   RateLimitConfig{PauseOnBackpressure: true},
+			expectedErr: "rate_limit::pause_on_backpressure requires error_backoff to be enabled",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.DeadLetter = tt.deadLetter
+			cfg.MessageMarking = tt.messageMarking
+			cfg.EndToEndAck = tt.endToEndAck
+			cfg.ErrorBackOff = tt.errorBackOff
+			cfg.RateLimit = tt.rateLimit
+			err := cfg.Validate()
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
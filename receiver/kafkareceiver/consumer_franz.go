@@ -6,6 +6,7 @@ package kafkareceiver // import "github.com/open-telemetry/opentelemetry-collect
 import (
 	"context"
 	"errors"
+	"fmt"
 	"maps"
 	"net"
 	"sync"
@@ -55,6 +56,8 @@ type franzConsumer struct {
 	telemetryBuilder *metadata.TelemetryBuilder
 	newConsumeFn     newConsumeMessageFunc
 	consumeMessage   consumeMessageFunc
+	deadLetter       *deadLetter
+	rateLimiter      *rateLimiter
 
 	mu             sync.RWMutex
 	started        chan struct{}
@@ -136,6 +139,7 @@ func newFranzKafkaConsumer(
 		newConsumeFn:     newConsumeFn,
 		settings:         set,
 		telemetryBuilder: telemetryBuilder,
+		rateLimiter:      newRateLimiter(config.RateLimit),
 		started:          make(chan struct{}),
 		consumerClosed:   make(chan struct{}),
 		closing:          make(chan struct{}),
@@ -159,7 +163,7 @@ func (c *franzConsumer) reportRecoverable(err error) {
 	componentstatus.ReportStatus(c.host, componentstatus.NewRecoverableErrorEvent(err))
 }
 
-func (c *franzConsumer) Start(ctx context.Context, host component.Host) error {
+func (c *franzConsumer) Start(ctx context.Context, host component.Host) (err error) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	select {
@@ -170,6 +174,12 @@ func (c *franzConsumer) Start(ctx context.Context, host component.Host) error {
 	default:
 		close(c.started)
 	}
+	defer func() {
+		// The consume loop is not started, so Shutdown must not wait for it.
+		if err != nil {
+			close(c.consumerClosed)
+		}
+	}()
 
 	// Parity with Sarama: report "Starting" as soon as Start() is called.
 	c.host = host
@@ -226,6 +236,16 @@ func (c *franzConsumer) Start(ctx context.Context, host component.Host) error {
 	}
 	c.consumeMessage = cm
 
+	if c.config.DeadLetter.Enabled {
+		producer, err := kafka.NewFranzSyncProducer(
+			ctx, c.config.ClientConfig, c.config.DeadLetter.Producer, c.config.DeadLetter.Timeout, c.settings.Logger,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create dead-letter producer: %w", err)
+		}
+		c.deadLetter = newDeadLetter(c.config, c.settings, c.telemetryBuilder, franzDeadLetterProducer{producer})
+	}
+
 	go c.consumeLoop(context.Background())
 	return nil
 }
@@ -233,6 +253,9 @@ func (c *franzConsumer) Start(ctx context.Context, host component.Host) error {
 func (c *franzConsumer) consumeLoop(ctx context.Context) {
 	// Parity with Sarama: when the loop exits, report Stopped.
 	defer func() {
+		// No message is being handled anymore, so the dead-letter producer
+		// can be closed.
+		c.deadLetter.close()
 		c.stoppedOnce.Do(func() { c.reportStatus(componentstatus.StatusStopped) })
 		close(c.consumerClosed)
 	}()
@@ -323,6 +346,9 @@ func (c *franzConsumer) consume(ctx context.Context, size int) bool {
 			fatalOffset := int64(-1)
 			var lastProcessed *kgo.Record
 			for _, msg := range msgs {
+				if err := c.rateLimiter.wait(pc.ctx, wrapFranzMsg(msg)); err != nil {
+					break // The partition has been lost or reassigned.
+				}
 				if !c.config.MessageMarking.After {
 					c.client.MarkCommitRecords(msg)
 				}
@@ -361,8 +387,8 @@ func (c *franzConsumer) consume(ctx context.Context, size int) bool {
 				// the partition is rebalanced to another consumer in the group.
 				//
 				// Ideally, we would attempt to re-process permanent errors
-				// for up to N times and then pause processing, or even better,
-				// produce the message to a dead letter topic.
+				// for up to N times and then pause processing. Configuring a
+				// dead-letter topic avoids pausing for permanent errors.
 				pc.logger.Error("unable to process message: pausing consumption of this topic / partition on this consumer instance due to message_marking configuration",
 					zap.Int64("offset", fatalOffset),
 				)
@@ -526,6 +552,12 @@ func (c *franzConsumer) handleMessage(pc *pc, msg kafkaMessage) error {
 	if pc.backOff != nil {
 		defer pc.backOff.Reset()
 	}
+	var pausedAt time.Time
+	defer func() {
+		if !pausedAt.IsZero() {
+			c.resumeFetch(pc, msg, pausedAt)
+		}
+	}()
 
 	for {
 		err := c.consumeMessage(pc.ctx, msg, pc.attrs)
@@ -548,6 +580,18 @@ func (c *franzConsumer) handleMessage(pc *pc, msg kafkaMessage) error {
 					zap.Error(err),
 					zap.Duration("delay", backOffDelay),
 				)
+				// Stop fetching records of the partition while the pipeline
+				// refuses them, they would only be buffered in memory.
+				if c.config.RateLimit.PauseOnBackpressure && pausedAt.IsZero() {
+					pausedAt = time.Now()
+					c.client.PauseFetchPartitions(map[string][]int32{
+						msg.topic(): {msg.partition()},
+					})
+					c.telemetryBuilder.KafkaReceiverFetchPausedPartitions.Add(
+						context.Background(), 1, metric.WithAttributeSet(pc.attrs),
+					)
+					pc.logger.Debug("paused fetching due to backpressure from the next consumer")
+				}
 				select {
 				case <-pc.ctx.Done():
 					return context.Cause(pc.ctx)
@@ -560,6 +604,12 @@ func (c *franzConsumer) handleMessage(pc *pc, msg kafkaMessage) error {
 			)
 		}
 
+		// Messages failing with a permanent error are published to the
+		// dead-letter topic if configured, and then marked as consumed.
+		if err = c.deadLetter.publish(pc.ctx, msg, err); err == nil {
+			return nil
+		}
+
 		isPermanent := consumererror.IsPermanent(err)
 		shouldMark := (!isPermanent && c.config.MessageMarking.OnError) || (isPermanent && c.config.MessageMarking.OnPermanentError)
 
@@ -575,6 +625,26 @@ func (c *franzConsumer) handleMessage(pc *pc, msg kafkaMessage) error {
 	}
 }
 
+// resumeFetch resumes fetching records of the partition of msg, paused at
+// pausedAt by handleMessage.
+func (c *franzConsumer) resumeFetch(pc *pc, msg kafkaMessage, pausedAt time.Time) {
+	c.client.ResumeFetchPartitions(map[string][]int32{
+		msg.topic(): {msg.partition()},
+	})
+	c.telemetryBuilder.KafkaReceiverFetchPausedPartitions.Add(
+		context.Background(), -1, metric.WithAttributeSet(pc.attrs),
+	)
+	paused := time.Since(pausedAt)
+	c.telemetryBuilder.KafkaReceiverFetchPausedDuration.Add(
+		context.Background(),
+		paused.Seconds(),
+		metric.WithAttributeSet(pc.attrs),
+	)
+	pc.logger.Debug("resumed fetching after backpressure from the next consumer",
+		zap.Duration("paused", paused),
+	)
+}
+
 // The methods below implement the relevant franz-go hook interfaces
 // record the metrics defined in the metadata telemetry.
 
@@ -14,6 +14,7 @@ import (
 // kafkaMessage provides a generic interface for Kafka messages that abstracts
 // over both Sarama and Franz-go record types.
 type kafkaMessage interface {
+	key() []byte
 	value() []byte
 	headers() messageHeaders
 	topic() string
@@ -42,6 +43,10 @@ func wrapSaramaMsg(message *sarama.ConsumerMessage) saramaMessage {
 	return saramaMessage{msg: message}
 }
 
+func (w saramaMessage) key() []byte {
+	return w.msg.Key
+}
+
 func (w saramaMessage) value() []byte {
 	return w.msg.Value
 }
@@ -99,6 +104,10 @@ func wrapFranzMsg(record *kgo.Record) franzMessage {
 	return franzMessage{record: record}
 }
 
+func (w franzMessage) key() []byte {
+	return w.record.Key
+}
+
 func (w franzMessage) value() []byte {
 	return w.record.Value
 }
@@ -7,6 +7,7 @@ import (
 	"context"
 	"encoding/binary"
 	"errors"
+	"fmt"
 	"sync"
 	"time"
 
@@ -54,6 +55,7 @@ type saramaConsumer struct {
 	settings         receiver.Settings
 	telemetryBuilder *metadata.TelemetryBuilder
 	newConsumeFn     newConsumeMessageFunc
+	deadLetter       *deadLetter
 
 	mu                sync.Mutex
 	started           bool
@@ -62,7 +64,7 @@ type saramaConsumer struct {
 	consumeLoopClosed chan struct{}
 }
 
-func (c *saramaConsumer) Start(_ context.Context, host component.Host) error {
+func (c *saramaConsumer) Start(ctx context.Context, host component.Host) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	if c.shutdown {
@@ -89,6 +91,10 @@ func (c *saramaConsumer) Start(_ context.Context, host component.Host) error {
 		messageMarking:    c.config.MessageMarking,
 		telemetryBuilder:  c.telemetryBuilder,
 		backOff:           newExponentialBackOff(c.config.ErrorBackOff),
+		rateLimiter:       newRateLimiter(c.config.RateLimit),
+	}
+	if c.config.RateLimit.PauseOnBackpressure {
+		c.settings.Logger.Warn("rate_limit::pause_on_backpressure is only supported by the franz-go client, ignoring")
 	}
 	consumeMessage, err := c.newConsumeFn(host, obsrecv, c.telemetryBuilder)
 	if err != nil {
@@ -96,6 +102,17 @@ func (c *saramaConsumer) Start(_ context.Context, host component.Host) error {
 	}
 	handler.consumeMessage = consumeMessage
 
+	if c.config.DeadLetter.Enabled {
+		producer, err := kafka.NewSaramaSyncProducer(
+			ctx, c.config.ClientConfig, c.config.DeadLetter.Producer, c.config.DeadLetter.Timeout,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create dead-letter producer: %w", err)
+		}
+		c.deadLetter = newDeadLetter(c.config, c.settings, c.telemetryBuilder, saramaDeadLetterProducer{producer})
+		handler.deadLetter = c.deadLetter
+	}
+
 	c.consumeLoopClosed = make(chan struct{})
 	c.started = true
 	c.closing = make(chan struct{})
@@ -105,6 +122,9 @@ func (c *saramaConsumer) Start(_ context.Context, host component.Host) error {
 
 func (c *saramaConsumer) consumeLoop(handler sarama.ConsumerGroupHandler, host component.Host) {
 	defer close(c.consumeLoopClosed)
+	// The dead-letter producer is closed once the consumer group is closed,
+	// and so no message is being handled anymore.
+	defer c.deadLetter.close()
 	defer componentstatus.ReportStatus(host, componentstatus.NewEvent(componentstatus.StatusStopped))
 	componentstatus.ReportStatus(host, componentstatus.NewEvent(componentstatus.StatusStarting))
 
@@ -199,8 +219,10 @@ type consumerGroupHandler struct {
 
 	autocommitEnabled bool
 	messageMarking    MessageMarking
+	deadLetter        *deadLetter
 	backOff           *backoff.ExponentialBackOff
 	backOffMutex      sync.Mutex
+	rateLimiter       *rateLimiter
 }
 
 func (c *consumerGroupHandler) Setup(session sarama.ConsumerGroupSession) error {
@@ -250,6 +272,10 @@ func (c *consumerGroupHandler) handleMessage(
 	claim sarama.ConsumerGroupClaim,
 	message *sarama.ConsumerMessage,
 ) error {
+	msg := wrapSaramaMsg(message)
+	if err := c.rateLimiter.wait(session.Context(), msg); err != nil {
+		return nil // The session's context is canceled.
+	}
 	if !c.messageMarking.After {
 		session.MarkMessage(message, "")
 	}
@@ -287,7 +313,6 @@ func (c *consumerGroupHandler) handleMessage(
 		metric.WithAttributeSet(attrs),
 		metric.WithAttributes(attribute.String("outcome", "success")),
 	)
-	msg := wrapSaramaMsg(message)
 	if err := c.consumeMessage(session.Context(), msg, attrs); err != nil {
 		if c.backOff != nil && !consumererror.IsPermanent(err) {
 			backOffDelay := c.getNextBackoff()
@@ -314,23 +339,27 @@ func (c *consumerGroupHandler) handleMessage(
 			)
 		}
 
-		isPermanent := consumererror.IsPermanent(err)
-		shouldMark := (!isPermanent && c.messageMarking.OnError) || (isPermanent && c.messageMarking.OnPermanentError)
+		// Messages failing with a permanent error are published to the
+		// dead-letter topic if configured, and then marked as consumed.
+		if err = c.deadLetter.publish(session.Context(), msg, err); err != nil {
+			isPermanent := consumererror.IsPermanent(err)
+			shouldMark := (!isPermanent && c.messageMarking.OnError) || (isPermanent && c.messageMarking.OnPermanentError)
 
-		if c.messageMarking.After && !shouldMark {
-			// Only return an error if messages are marked after successful processing
-			// and the error type is not configured to be marked.
-			return err
+			if c.messageMarking.After && !shouldMark {
+				// Only return an error if messages are marked after successful processing
+				// and the error type is not configured to be marked.
+				return err
+			}
+			// We're either marking messages as consumed ahead of time (disregarding outcome),
+			// or after processing but including errors. Either way we should not return an error,
+			// as that will restart the consumer unnecessarily.
+			c.logger.Error("failed to consume message, skipping due to message_marking config",
+				zap.Error(err),
+				zap.String("topic", message.Topic),
+				zap.Int32("partition", claim.Partition()),
+				zap.Int64("offset", message.Offset),
+			)
 		}
-		// We're either marking messages as consumed ahead of time (disregarding outcome),
-		// or after processing but including errors. Either way we should not return an error,
-		// as that will restart the consumer unnecessarily.
-		c.logger.Error("failed to consume message, skipping due to message_marking config",
-			zap.Error(err),
-			zap.String("topic", message.Topic),
-			zap.Int32("partition", claim.Partition()),
-			zap.Int64("offset", message.Offset),
-		)
 	}
 	if c.backOff != nil {
 		c.resetBackoff()
@@ -0,0 +1,187 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kafkareceiver
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/IBM/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkareceiver/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkareceiver/internal/metadatatest"
+)
+
+// fakeConsumerGroupSession records the offsets marked and reset by the
+// consumer group handler.
+type fakeConsumerGroupSession struct {
+	sarama.ConsumerGroupSession
+	ctx    context.Context
+	marked []int64
+	reset  []int64
+}
+
+func (s *fakeConsumerGroupSession) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeConsumerGroupSession) MarkMessage(msg *sarama.ConsumerMessage, _ string) {
+	s.marked = append(s.marked, msg.Offset)
+}
+
+func (s *fakeConsumerGroupSession) ResetOffset(_ string, _ int32, offset int64, _ string) {
+	s.reset = append(s.reset, offset)
+}
+
+func (*fakeConsumerGroupSession) Commit() {}
+
+type fakeConsumerGroupClaim struct {
+	sarama.ConsumerGroupClaim
+}
+
+func (fakeConsumerGroupClaim) Topic() string              { return "otlp_spans" }
+func (fakeConsumerGroupClaim) Partition() int32           { return 1 }
+func (fakeConsumerGroupClaim) HighWaterMarkOffset() int64 { return 100 }
+
+// fakeDeadLetterProducer records the records published to the dead-letter
+// topic, or fails publishing them with err.
+type fakeDeadLetterProducer struct {
+	err     error
+	topics  []string
+	records []deadLetterRecord
+}
+
+func (p *fakeDeadLetterProducer) produce(_ context.Context, topic string, record deadLetterRecord) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.topics = append(p.topics, topic)
+	p.records = append(p.records, record)
+	return nil
+}
+
+func (*fakeDeadLetterProducer) close() error {
+	return nil
+}
+
+func TestConsumerGroupHandler_DeadLetter(t *testing.T) {
+	errPermanent := consumererror.NewPermanent(errors.New("invalid data"))
+	message := &sarama.ConsumerMessage{
+		Topic:     "otlp_spans",
+		Partition: 1,
+		Offset:    42,
+		Key:       []byte("key"),
+		Value:     []byte("value"),
+		Headers:   []*sarama.RecordHeader{{Key: []byte("tenant"), Value: []byte("a")}},
+	}
+
+	tests := []struct {
+		name        string
+		consumeErr  error
+		produceErr  error
+		expectErr   string
+		published   bool
+		marked      []int64
+		telemetryDP []metricdata.DataPoint[int64]
+	}{
+		{
+			name:       "published",
+			consumeErr: errPermanent,
+			published:  true,
+			marked:     []int64{42},
+			telemetryDP: []metricdata.DataPoint[int64]{{
+				Value: 1,
+				Attributes: attribute.NewSet(
+					attribute.String("topic", "otlp_spans"),
+					attribute.Int64("partition", 1),
+					attribute.String("outcome", "success"),
+				),
+			}},
+		},
+		{
+			// the record is neither lost nor skipped, consuming it is
+			// retried after the consumer restarted
+			name:       "publishing fails",
+			consumeErr: errPermanent,
+			produceErr: errors.New("broker unavailable"),
+			expectErr:  "failed to publish message to dead-letter topic: broker unavailable",
+			telemetryDP: []metricdata.DataPoint[int64]{{
+				Value: 1,
+				Attributes: attribute.NewSet(
+					attribute.String("topic", "otlp_spans"),
+					attribute.Int64("partition", 1),
+					attribute.String("outcome", "failure"),
+				),
+			}},
+		},
+		{
+			// only permanent errors are published
+			name:       "transient error",
+			consumeErr: errors.New("next consumer unavailable"),
+			expectErr:  "next consumer unavailable",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set, tel, _ := mustNewSettings(t)
+			telemetryBuilder, err := metadata.NewTelemetryBuilder(set.TelemetrySettings)
+			require.NoError(t, err)
+
+			cfg := createDefaultConfig().(*Config)
+			cfg.DeadLetter.Enabled = true
+			cfg.DeadLetter.Topic = "otlp_dead_letter"
+			producer := &fakeDeadLetterProducer{err: tt.produceErr}
+
+			handler := &consumerGroupHandler{
+				logger:           set.Logger,
+				telemetryBuilder: telemetryBuilder,
+				consumeMessage: func(context.Context, kafkaMessage, attribute.Set) error {
+					return tt.consumeErr
+				},
+				autocommitEnabled: true,
+				messageMarking:    MessageMarking{After: true},
+				deadLetter:        newDeadLetter(cfg, set, telemetryBuilder, producer),
+			}
+
+			session := &fakeConsumerGroupSession{ctx: t.Context()}
+			err = handler.handleMessage(session, fakeConsumerGroupClaim{}, message)
+			if tt.expectErr != "" {
+				assert.ErrorContains(t, err, tt.expectErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.marked, session.marked)
+			assert.Empty(t, session.reset)
+
+			if !tt.published {
+				assert.Empty(t, producer.records)
+			} else {
+				require.Len(t, producer.records, 1)
+				assert.Equal(t, []string{"otlp_dead_letter"}, producer.topics)
+				record := producer.records[0]
+				assert.Equal(t, []byte("key"), record.key)
+				assert.Equal(t, []byte("value"), record.value)
+				assert.Equal(t, []header{
+					{key: "tenant", value: []byte("a")},
+					{key: deadLetterHeaderError, value: []byte(errPermanent.Error())},
+					{key: deadLetterHeaderReceiver, value: []byte(set.ID.String())},
+					{key: deadLetterHeaderTopic, value: []byte("otlp_spans")},
+					{key: deadLetterHeaderPartition, value: []byte("1")},
+					{key: deadLetterHeaderOffset, value: []byte("42")},
+				}, record.headers)
+			}
+
+			if tt.telemetryDP != nil {
+				metadatatest.AssertEqualKafkaReceiverDeadLetterRecords(t, tel, tt.telemetryDP, metricdatatest.IgnoreTimestamp())
+			}
+		})
+	}
+}
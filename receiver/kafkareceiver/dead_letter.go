@@ -0,0 +1,170 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kafkareceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkareceiver"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/IBM/sarama"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkareceiver/internal/metadata"
+)
+
+// Headers added to the records published to the dead-letter topic, which
+// describe why the record failed and where it was consumed from.
+const (
+	deadLetterHeaderError     = "otel.dead_letter.error"
+	deadLetterHeaderReceiver  = "otel.dead_letter.receiver"
+	deadLetterHeaderTopic     = "otel.dead_letter.topic"
+	deadLetterHeaderPartition = "otel.dead_letter.partition"
+	deadLetterHeaderOffset    = "otel.dead_letter.offset"
+)
+
+// deadLetterRecord is a record published to the dead-letter topic.
+type deadLetterRecord struct {
+	key     []byte
+	value   []byte
+	headers []header
+}
+
+// deadLetterProducer publishes records to the dead-letter topic, abstracting
+// over both Sarama and Franz-go producers.
+type deadLetterProducer interface {
+	produce(ctx context.Context, topic string, record deadLetterRecord) error
+	close() error
+}
+
+// deadLetter publishes the records failing with a permanent error to the
+// dead-letter topic, so that they are neither lost nor block their partition.
+type deadLetter struct {
+	topic            string
+	receiverID       string
+	producer         deadLetterProducer
+	logger           *zap.Logger
+	telemetryBuilder *metadata.TelemetryBuilder
+}
+
+func newDeadLetter(
+	config *Config,
+	set receiver.Settings,
+	telemetryBuilder *metadata.TelemetryBuilder,
+	producer deadLetterProducer,
+) *deadLetter {
+	return &deadLetter{
+		topic:            config.DeadLetter.Topic,
+		receiverID:       set.ID.String(),
+		producer:         producer,
+		logger:           set.Logger,
+		telemetryBuilder: telemetryBuilder,
+	}
+}
+
+// publish publishes message, which failed with err, to the dead-letter topic
+// if err is permanent. It returns nil if the message was published, so that
+// it is marked as consumed, or err joined with the error publishing it
+// otherwise. A nil deadLetter returns err.
+func (d *deadLetter) publish(ctx context.Context, message kafkaMessage, err error) error {
+	if d == nil || !consumererror.IsPermanent(err) {
+		return err
+	}
+
+	record := deadLetterRecord{key: message.key(), value: message.value()}
+	for h := range message.headers().all() {
+		record.headers = append(record.headers, h)
+	}
+	record.headers = append(record.headers,
+		header{key: deadLetterHeaderError, value: []byte(err.Error())},
+		header{key: deadLetterHeaderReceiver, value: []byte(d.receiverID)},
+		header{key: deadLetterHeaderTopic, value: []byte(message.topic())},
+		header{key: deadLetterHeaderPartition, value: []byte(strconv.FormatInt(int64(message.partition()), 10))},
+		header{key: deadLetterHeaderOffset, value: []byte(strconv.FormatInt(message.offset(), 10))},
+	)
+
+	attrs := metric.WithAttributes(
+		attribute.String("topic", message.topic()),
+		attribute.Int64("partition", int64(message.partition())),
+	)
+	if errProduce := d.producer.produce(ctx, d.topic, record); errProduce != nil {
+		d.telemetryBuilder.KafkaReceiverDeadLetterRecords.Add(ctx, 1, attrs,
+			metric.WithAttributes(attribute.String("outcome", "failure")),
+		)
+		return errors.Join(err, fmt.Errorf("failed to publish message to dead-letter topic: %w", errProduce))
+	}
+	d.telemetryBuilder.KafkaReceiverDeadLetterRecords.Add(ctx, 1, attrs,
+		metric.WithAttributes(attribute.String("outcome", "success")),
+	)
+	d.logger.Warn("failed to consume message, published it to the dead-letter topic",
+		zap.Error(err),
+		zap.String("topic", message.topic()),
+		zap.Int32("partition", message.partition()),
+		zap.Int64("offset", message.offset()),
+		zap.String("dead_letter_topic", d.topic),
+	)
+	return nil
+}
+
+// close closes the dead-letter producer. A nil deadLetter is ignored.
+func (d *deadLetter) close() {
+	if d == nil {
+		return
+	}
+	if err := d.producer.close(); err != nil {
+		d.logger.Error("failed to close dead-letter producer", zap.Error(err))
+	}
+}
+
+// saramaDeadLetterProducer publishes records with a Sarama SyncProducer.
+type saramaDeadLetterProducer struct {
+	producer sarama.SyncProducer
+}
+
+func (p saramaDeadLetterProducer) produce(_ context.Context, topic string, record deadLetterRecord) error {
+	msg := &sarama.ProducerMessage{
+		Topic: topic,
+		Value: sarama.ByteEncoder(record.value),
+	}
+	if record.key != nil {
+		msg.Key = sarama.ByteEncoder(record.key)
+	}
+	for _, h := range record.headers {
+		msg.Headers = append(msg.Headers, sarama.RecordHeader{Key: []byte(h.key), Value: h.value})
+	}
+	_, _, err := p.producer.SendMessage(msg)
+	return err
+}
+
+func (p saramaDeadLetterProducer) close() error {
+	return p.producer.Close()
+}
+
+// franzDeadLetterProducer publishes records with a franz-go client.
+type franzDeadLetterProducer struct {
+	client *kgo.Client
+}
+
+func (p franzDeadLetterProducer) produce(ctx context.Context, topic string, record deadLetterRecord) error {
+	rec := &kgo.Record{
+		Topic: topic,
+		Key:   record.key,
+		Value: record.value,
+	}
+	for _, h := range record.headers {
+		rec.Headers = append(rec.Headers, kgo.RecordHeader{Key: h.key, Value: h.value})
+	}
+	return p.client.ProduceSync(ctx, rec).FirstErr()
+}
+
+func (p franzDeadLetterProducer) close() error {
+	p.client.Close()
+	return nil
+}
@@ -5,6 +5,7 @@ package kafkareceiver // import "github.com/open-telemetry/opentelemetry-collect
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
@@ -12,6 +13,7 @@ import (
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/receiver/xreceiver"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/ackextension"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/kafka/configkafka"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkareceiver/internal/metadata"
 )
@@ -28,6 +30,9 @@ const (
 
 	defaultProfilesTopic    = "otlp_profiles"
 	defaultProfilesEncoding = "otlp_proto"
+
+	defaultDeadLetterTimeout  = 5 * time.Second
+	defaultEndToEndAckTimeout = 30 * time.Second
 )
 
 // NewFactory creates Kafka receiver factory.
@@ -70,6 +75,13 @@ func createDefaultConfig() component.Config {
 		HeaderExtraction: HeaderExtraction{
 			ExtractHeaders: false,
 		},
+		DeadLetter: DeadLetterConfig{
+			Timeout:  defaultDeadLetterTimeout,
+			Producer: configkafka.NewDefaultProducerConfig(),
+		},
+		EndToEndAck: ackextension.EndToEndConfig{
+			Timeout: defaultEndToEndAckTimeout,
+		},
 	}
 }
 
@@ -33,6 +33,9 @@ type TelemetryBuilder struct {
 	KafkaReceiverBytes                       metric.Int64Counter
 	KafkaReceiverBytesUncompressed           metric.Int64Counter
 	KafkaReceiverCurrentOffset               metric.Int64Gauge
+	KafkaReceiverDeadLetterRecords           metric.Int64Counter
+	KafkaReceiverFetchPausedDuration         metric.Float64Counter
+	KafkaReceiverFetchPausedPartitions       metric.Int64UpDownCounter
 	KafkaReceiverLatency                     metric.Int64Histogram
 	KafkaReceiverMessages                    metric.Int64Counter
 	KafkaReceiverOffsetLag                   metric.Int64Gauge
@@ -119,6 +122,24 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...Teleme
 		metric.WithUnit("1"),
 	)
 	errs = errors.Join(errs, err)
+	builder.KafkaReceiverDeadLetterRecords, err = builder.meter.Int64Counter(
+		"otelcol_kafka_receiver_dead_letter_records",
+		metric.WithDescription("The number of records that failed with a permanent error and were published to the dead-letter topic. [Development]"),
+		metric.WithUnit("1"),
+	)
+	errs = errors.Join(errs, err)
+	builder.KafkaReceiverFetchPausedDuration, err = builder.meter.Float64Counter(
+		"otelcol_kafka_receiver_fetch_paused_duration",
+		metric.WithDescription("The time in seconds fetching from a partition was paused while the next consumer refused records. [Development]"),
+		metric.WithUnit("s"),
+	)
+	errs = errors.Join(errs, err)
+	builder.KafkaReceiverFetchPausedPartitions, err = builder.meter.Int64UpDownCounter(
+		"otelcol_kafka_receiver_fetch_paused_partitions",
+		metric.WithDescription("The number of partitions fetching is paused for while the next consumer refuses records. [Development]"),
+		metric.WithUnit("1"),
+	)
+	errs = errors.Join(errs, err)
 	builder.KafkaReceiverLatency, err = builder.meter.Int64Histogram(
 		"otelcol_kafka_receiver_latency",
 		metric.WithDescription("The time it took in ms to receive a batch of messages. [Deprecated]"),
@@ -27,6 +27,9 @@ func TestSetupTelemetry(t *testing.T) {
 	tb.KafkaReceiverBytes.Add(context.Background(), 1)
 	tb.KafkaReceiverBytesUncompressed.Add(context.Background(), 1)
 	tb.KafkaReceiverCurrentOffset.Record(context.Background(), 1)
+	tb.KafkaReceiverDeadLetterRecords.Add(context.Background(), 1)
+	tb.KafkaReceiverFetchPausedDuration.Add(context.Background(), 1)
+	tb.KafkaReceiverFetchPausedPartitions.Add(context.Background(), 1)
 	tb.KafkaReceiverLatency.Record(context.Background(), 1)
 	tb.KafkaReceiverMessages.Add(context.Background(), 1)
 	tb.KafkaReceiverOffsetLag.Record(context.Background(), 1)
@@ -60,6 +63,15 @@ func TestSetupTelemetry(t *testing.T) {
 	AssertEqualKafkaReceiverCurrentOffset(t, testTel,
 		[]metricdata.DataPoint[int64]{{Value: 1}},
 		metricdatatest.IgnoreTimestamp())
+	AssertEqualKafkaReceiverDeadLetterRecords(t, testTel,
+		[]metricdata.DataPoint[int64]{{Value: 1}},
+		metricdatatest.IgnoreTimestamp())
+	AssertEqualKafkaReceiverFetchPausedDuration(t, testTel,
+		[]metricdata.DataPoint[float64]{{Value: 1}},
+		metricdatatest.IgnoreTimestamp())
+	AssertEqualKafkaReceiverFetchPausedPartitions(t, testTel,
+		[]metricdata.DataPoint[int64]{{Value: 1}},
+		metricdatatest.IgnoreTimestamp())
 	AssertEqualKafkaReceiverLatency(t, testTel,
 		[]metricdata.HistogramDataPoint[int64]{{}}, metricdatatest.IgnoreValue(),
 		metricdatatest.IgnoreTimestamp())
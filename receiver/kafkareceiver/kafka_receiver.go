@@ -26,6 +26,7 @@ import (
 	"go.opentelemetry.io/otel/metric"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/ackextension"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkareceiver/internal/metadata"
 )
 
@@ -166,17 +167,40 @@ func newReceiver(
 	config *Config,
 	set receiver.Settings,
 	topics []string,
-	consumeFn func(host component.Host,
-		obsrecv *receiverhelper.ObsReport,
-		telBldr *metadata.TelemetryBuilder,
-	) (consumeMessageFunc, error),
+	consumeFn newConsumeMessageFunc,
 ) (component.Component, error) {
+	consumeFn = withEndToEndAck(config, set, consumeFn)
 	if franzGoConsumerFeatureGate.IsEnabled() {
 		return newFranzKafkaConsumer(config, set, topics, consumeFn)
 	}
 	return newSaramaConsumer(config, set, topics, consumeFn)
 }
 
+// withEndToEndAck wraps the consumeMessageFunc returned by newConsumeFn so
+// that it waits for the data of each message to be acknowledged at the end of
+// the pipelines, if end_to_end_ack is configured. A message whose data is not
+// acknowledged in time fails with a non-permanent error.
+func withEndToEndAck(config *Config, set receiver.Settings, newConsumeFn newConsumeMessageFunc) newConsumeMessageFunc {
+	return func(host component.Host,
+		obsrecv *receiverhelper.ObsReport,
+		telBldr *metadata.TelemetryBuilder,
+	) (consumeMessageFunc, error) {
+		consumeMessage, err := newConsumeFn(host, obsrecv, telBldr)
+		if err != nil {
+			return nil, err
+		}
+		acks, err := ackextension.NewEndToEnd(host, config.EndToEndAck, set.ID)
+		if err != nil || acks == nil {
+			return consumeMessage, err
+		}
+		return func(ctx context.Context, message kafkaMessage, attrs attribute.Set) error {
+			return acks.Consume(ctx, func(ctx context.Context) error {
+				return consumeMessage(ctx, message, attrs)
+			})
+		}, nil
+	}
+}
+
 type logsHandler struct {
 	unmarshaler plog.Unmarshaler
 	obsrecv     *receiverhelper.ObsReport
@@ -193,6 +217,7 @@ func (h *logsHandler) unmarshalData(data []byte) (plog.Logs, int, error) {
 }
 
 func (h *logsHandler) consumeData(ctx context.Context, data plog.Logs) error {
+	ackextension.MarkLogs(ctx, data)
 	return h.consumer.ConsumeLogs(ctx, data)
 }
 
@@ -234,6 +259,7 @@ func (h *metricsHandler) unmarshalData(data []byte) (pmetric.Metrics, int, error
 }
 
 func (h *metricsHandler) consumeData(ctx context.Context, data pmetric.Metrics) error {
+	ackextension.MarkMetrics(ctx, data)
 	return h.consumer.ConsumeMetrics(ctx, data)
 }
 
@@ -275,6 +301,7 @@ func (h *tracesHandler) unmarshalData(data []byte) (ptrace.Traces, int, error) {
 }
 
 func (h *tracesHandler) consumeData(ctx context.Context, data ptrace.Traces) error {
+	ackextension.MarkTraces(ctx, data)
 	return h.consumer.ConsumeTraces(ctx, data)
 }
 
@@ -417,15 +444,26 @@ func newExponentialBackOff(config configretry.BackOffConfig) *backoff.Exponentia
 	return backOff
 }
 
+// contextWithHeaders returns a copy of ctx whose client metadata holds the
+// message headers. Headers do not override the metadata already held by ctx,
+// such as the ack IDs of end-to-end acknowledgement.
 func contextWithHeaders(ctx context.Context, headers messageHeaders) context.Context {
+	info := client.FromContext(ctx)
 	m := make(map[string][]string)
 	for header := range headers.all() {
 		key := header.key
+		if len(info.Metadata.Get(key)) > 0 {
+			continue
+		}
 		value := string(header.value)
 		m[key] = append(m[key], value)
 	}
 	if len(m) == 0 {
 		return ctx
 	}
-	return client.NewContext(ctx, client.Info{Metadata: client.NewMetadata(m)})
+	for key := range info.Metadata.Keys() {
+		m[key] = info.Metadata.Get(key)
+	}
+	info.Metadata = client.NewMetadata(m)
+	return client.NewContext(ctx, info)
 }
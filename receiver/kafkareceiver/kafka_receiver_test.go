@@ -28,6 +28,7 @@ import (
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/extension/extensiontest"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/pprofile"
@@ -42,6 +43,7 @@ import (
 	"go.uber.org/zap/zapcore"
 	"go.uber.org/zap/zaptest/observer"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/ackextension"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/kafka/kafkatest"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatatest/ptracetest"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkareceiver/internal/metadata"
@@ -599,6 +601,272 @@ func TestReceiver_MessageMarking(t *testing.T) {
 	}
 }
 
+func TestReceiver_DeadLetter(t *testing.T) {
+	runTestForClients(t, func(t *testing.T) {
+		if !franzGoConsumerFeatureGate.IsEnabled() {
+			// kfake rejects the record batches produced by Sarama as corrupt,
+			// as Sarama does not set their partition leader epoch to -1. The
+			// Sarama path is covered by TestConsumerGroupHandler_DeadLetter.
+			t.Skip("kfake does not accept records produced by Sarama")
+		}
+		kafkaClient, receiverConfig := mustNewFakeCluster(t,
+			kfake.SeedTopics(1, "otlp_spans", "otlp_dead_letter"), kfake.NumBrokers(1),
+		)
+
+		// Send some invalid data to the otlp_spans topic so unmarshaling fails
+		// permanently, and then send some valid data to show that the invalid
+		// data is published to the dead-letter topic instead of blocking the
+		// consumer.
+		traces := testdata.GenerateTraces(1)
+		data, err := (&ptrace.ProtoMarshaler{}).MarshalTraces(traces)
+		require.NoError(t, err)
+		results := kafkaClient.ProduceSync(t.Context(),
+			&kgo.Record{
+				Topic:   "otlp_spans",
+				Key:     []byte("key"),
+				Value:   []byte("junk"),
+				Headers: []kgo.RecordHeader{{Key: "origin", Value: []byte("test")}},
+			},
+			&kgo.Record{Topic: "otlp_spans", Value: data},
+		)
+		require.NoError(t, results.FirstErr())
+
+		var calls atomic.Int64
+		consumer := newTracesConsumer(func(_ context.Context, received ptrace.Traces) error {
+			calls.Add(1)
+			return ptracetest.CompareTraces(traces, received)
+		})
+
+		// Permanent errors would block the partition without a dead-letter topic.
+		receiverConfig.MessageMarking.After = true
+		receiverConfig.MessageMarking.OnPermanentError = false
+		receiverConfig.DeadLetter.Enabled = true
+		receiverConfig.DeadLetter.Topic = "otlp_dead_letter"
+		set, tel, _ := mustNewSettings(t)
+		f := NewFactory()
+		r, err := f.CreateTraces(t.Context(), set, receiverConfig, consumer)
+		require.NoError(t, err)
+		require.NoError(t, r.Start(t.Context(), componenttest.NewNopHost()))
+		t.Cleanup(func() {
+			assert.NoError(t, r.Shutdown(context.Background())) //nolint:usetesting
+		})
+
+		assert.Eventually(t, func() bool {
+			return calls.Load() == 1
+		}, time.Second, 100*time.Millisecond, "permanent error should not block consumption")
+
+		deadLetterClient, err := kgo.NewClient(
+			kgo.SeedBrokers(receiverConfig.Brokers...),
+			kgo.ConsumeTopics("otlp_dead_letter"),
+		)
+		require.NoError(t, err)
+		t.Cleanup(deadLetterClient.Close)
+		ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+		defer cancel()
+		fetches := deadLetterClient.PollFetches(ctx)
+		require.NoError(t, fetches.Err())
+		records := fetches.Records()
+		require.Len(t, records, 1)
+		assert.Equal(t, []byte("key"), records[0].Key)
+		assert.Equal(t, []byte("junk"), records[0].Value)
+
+		headers := make(map[string]string)
+		for _, h := range records[0].Headers {
+			headers[h.Key] = string(h.Value)
+		}
+		assert.Equal(t, "test", headers["origin"])
+		assert.NotEmpty(t, headers[deadLetterHeaderError])
+		assert.Equal(t, set.ID.String(), headers[deadLetterHeaderReceiver])
+		assert.Equal(t, "otlp_spans", headers[deadLetterHeaderTopic])
+		assert.Equal(t, "0", headers[deadLetterHeaderPartition])
+		assert.Equal(t, "0", headers[deadLetterHeaderOffset])
+
+		metadatatest.AssertEqualKafkaReceiverDeadLetterRecords(t, tel, []metricdata.DataPoint[int64]{{
+			Value: 1,
+			Attributes: attribute.NewSet(
+				attribute.String("topic", "otlp_spans"),
+				attribute.Int64("partition", 0),
+				attribute.String("outcome", "success"),
+			),
+		}}, metricdatatest.IgnoreTimestamp())
+	})
+}
+
+func TestReceiver_EndToEndAck(t *testing.T) {
+	runTestForClients(t, func(t *testing.T) {
+		kafkaClient, receiverConfig := mustNewFakeCluster(t, kfake.SeedTopics(1, "otlp_spans"))
+
+		// Send some traces to the otlp_spans topic, with a resource attribute
+		// trying to spoof the ack ID of the message.
+		traces := testdata.GenerateTraces(1)
+		traces.ResourceSpans().At(0).Resource().Attributes().PutStr(ackextension.AckIDAttribute, "999")
+		data, err := (&ptrace.ProtoMarshaler{}).MarshalTraces(traces)
+		require.NoError(t, err)
+		results := kafkaClient.ProduceSync(t.Context(), &kgo.Record{
+			Topic: "otlp_spans",
+			Value: data,
+		})
+		require.NoError(t, results.FirstErr())
+
+		ackFactory := ackextension.NewFactory()
+		ackExt, err := ackFactory.Create(t.Context(), extensiontest.NewNopSettings(ackFactory.Type()), ackFactory.CreateDefaultConfig())
+		require.NoError(t, err)
+		ackExtensionID := component.MustNewID("ack")
+		host := extensionsHost{ackExtensionID: ackExt}
+
+		// The data of the first attempt is never acknowledged, e.g. because it
+		// was dropped by a processor, so consuming the message is retried once
+		// the end-to-end acknowledgement timed out.
+		var calls atomic.Int64
+		marked := make(chan []ackextension.ResourceAck, 2)
+		consumer := newTracesConsumer(func(_ context.Context, td ptrace.Traces) error {
+			acks := ackextension.TakeTracesAcks(td)
+			marked <- acks
+			if calls.Add(1) > 1 {
+				go ackextension.AckResources(ackExt.(ackextension.AckExtension), acks)
+			}
+			return nil
+		})
+
+		receiverConfig.MessageMarking.After = true
+		receiverConfig.EndToEndAck.Extension = &ackExtensionID
+		receiverConfig.EndToEndAck.Timeout = 100 * time.Millisecond
+		receiverConfig.ErrorBackOff.Enabled = true
+		receiverConfig.ErrorBackOff.InitialInterval = 10 * time.Millisecond
+		receiverConfig.ErrorBackOff.MaxInterval = 10 * time.Millisecond
+		receiverConfig.ErrorBackOff.MaxElapsedTime = 0
+		f := NewFactory()
+		set := receivertest.NewNopSettings(metadata.Type)
+		r, err := f.CreateTraces(t.Context(), set, receiverConfig, consumer)
+		require.NoError(t, err)
+		require.NoError(t, r.Start(t.Context(), host))
+		t.Cleanup(func() {
+			assert.NoError(t, r.Shutdown(context.Background())) //nolint:usetesting
+		})
+
+		// The acks are marked on the resources of the data, so that they
+		// survive batching, each attempt with a new ack ID.
+		assert.Equal(t, []ackextension.ResourceAck{{PartitionID: set.ID.String(), AckID: 1}}, <-marked)
+		assert.Equal(t, []ackextension.ResourceAck{{PartitionID: set.ID.String(), AckID: 2}}, <-marked)
+
+		// Verify that no retries are attempted once the data was acknowledged.
+		time.Sleep(200 * time.Millisecond)
+		assert.Equal(t, int64(2), calls.Load())
+	})
+}
+
+func TestReceiver_EndToEndAckMissingExtension(t *testing.T) {
+	runTestForClients(t, func(t *testing.T) {
+		_, receiverConfig := mustNewFakeCluster(t, kfake.SeedTopics(1, "otlp_spans"))
+		ackExtensionID := component.MustNewID("ack")
+		receiverConfig.MessageMarking.After = true
+		receiverConfig.EndToEndAck.Extension = &ackExtensionID
+
+		f := NewFactory()
+		r, err := f.CreateTraces(t.Context(), receivertest.NewNopSettings(metadata.Type), receiverConfig, consumertest.NewNop())
+		require.NoError(t, err)
+		err = r.Start(t.Context(), componenttest.NewNopHost())
+		assert.EqualError(t, err, `specified ack extension with id "ack" could not be found`)
+		assert.NoError(t, r.Shutdown(t.Context()))
+	})
+}
+
+func TestReceiver_RateLimit(t *testing.T) {
+	runTestForClients(t, func(t *testing.T) {
+		kafkaClient, receiverConfig := mustNewFakeCluster(t, kfake.SeedTopics(1, "otlp_spans"))
+
+		// Send more traces than the burst of the rate limiter.
+		traces := testdata.GenerateTraces(1)
+		data, err := (&ptrace.ProtoMarshaler{}).MarshalTraces(traces)
+		require.NoError(t, err)
+		records := make([]*kgo.Record, 15)
+		for i := range records {
+			records[i] = &kgo.Record{Topic: "otlp_spans", Value: data}
+		}
+		results := kafkaClient.ProduceSync(t.Context(), records...)
+		require.NoError(t, results.FirstErr())
+
+		var calls atomic.Int64
+		consumer := newTracesConsumer(func(context.Context, ptrace.Traces) error {
+			calls.Add(1)
+			return nil
+		})
+
+		// The first 10 records are consumed right away, the remaining 5 at
+		// a rate of 10 per second.
+		receiverConfig.RateLimit.RecordsPerSecond = 10
+		start := time.Now()
+		mustNewTracesReceiver(t, receiverConfig, consumer)
+
+		assert.Eventually(
+			t, func() bool { return calls.Load() == int64(len(records)) },
+			10*time.Second, 10*time.Millisecond,
+		)
+		assert.GreaterOrEqual(t, time.Since(start), 400*time.Millisecond)
+	})
+}
+
+func TestReceiver_PauseOnBackpressure(t *testing.T) {
+	setFranzGo(t, true)
+	kafkaClient, receiverConfig := mustNewFakeCluster(t, kfake.SeedTopics(1, "otlp_spans"))
+
+	traces := testdata.GenerateTraces(1)
+	data, err := (&ptrace.ProtoMarshaler{}).MarshalTraces(traces)
+	require.NoError(t, err)
+	results := kafkaClient.ProduceSync(t.Context(), &kgo.Record{Topic: "otlp_spans", Value: data})
+	require.NoError(t, results.FirstErr())
+
+	// Refuse the first few attempts, as the memory_limiter processor does.
+	var calls atomic.Int64
+	consumer := newTracesConsumer(func(context.Context, ptrace.Traces) error {
+		if calls.Add(1) <= 3 {
+			return errors.New("data refused due to high memory usage")
+		}
+		return nil
+	})
+
+	receiverConfig.ErrorBackOff.Enabled = true
+	receiverConfig.ErrorBackOff.InitialInterval = 10 * time.Millisecond
+	receiverConfig.ErrorBackOff.MaxInterval = 10 * time.Millisecond
+	receiverConfig.RateLimit.PauseOnBackpressure = true
+	set, tel, _ := mustNewSettings(t)
+	f := NewFactory()
+	r, err := f.CreateTraces(t.Context(), set, receiverConfig, consumer)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(t.Context(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, r.Shutdown(context.Background())) //nolint:usetesting
+	})
+
+	assert.Eventually(
+		t, func() bool { return calls.Load() == 4 },
+		10*time.Second, 10*time.Millisecond,
+	)
+
+	// Fetching is resumed once the record is consumed.
+	results = kafkaClient.ProduceSync(t.Context(), &kgo.Record{Topic: "otlp_spans", Value: data})
+	require.NoError(t, results.FirstErr())
+	assert.Eventually(
+		t, func() bool { return calls.Load() == 5 },
+		10*time.Second, 10*time.Millisecond,
+	)
+
+	metadatatest.AssertEqualKafkaReceiverFetchPausedDuration(t, tel, []metricdata.DataPoint[float64]{{
+		Attributes: attribute.NewSet(
+			attribute.String("topic", "otlp_spans"),
+			attribute.Int64("partition", 0),
+		),
+	}}, metricdatatest.IgnoreTimestamp(), metricdatatest.IgnoreValue())
+	// The partition is no longer paused.
+	metadatatest.AssertEqualKafkaReceiverFetchPausedPartitions(t, tel, []metricdata.DataPoint[int64]{{
+		Attributes: attribute.NewSet(
+			attribute.String("topic", "otlp_spans"),
+			attribute.Int64("partition", 0),
+		),
+		Value: 0,
+	}}, metricdatatest.IgnoreTimestamp())
+}
+
 func TestNewLogsReceiver(t *testing.T) {
 	runTestForClients(t, func(t *testing.T) {
 		kafkaClient, receiverConfig := mustNewFakeCluster(t, kfake.SeedTopics(1, "otlp_logs"))
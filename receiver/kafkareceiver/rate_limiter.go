@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kafkareceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkareceiver"
+
+import (
+	"context"
+	"math"
+
+	"golang.org/x/time/rate"
+)
+
+// rateLimiter limits the rate at which records are consumed across all
+// the partitions of a consumer. A nil *rateLimiter imposes no limit.
+type rateLimiter struct {
+	records *rate.Limiter
+	bytes   *rate.Limiter
+}
+
+// newRateLimiter returns a rateLimiter for cfg, or nil if no limit is
+// configured. The limiters allow bursts of up to one second worth of
+// records and bytes.
+func newRateLimiter(cfg RateLimitConfig) *rateLimiter {
+	if cfg.RecordsPerSecond <= 0 && cfg.BytesPerSecond <= 0 {
+		return nil
+	}
+	var l rateLimiter
+	if cfg.RecordsPerSecond > 0 {
+		burst := max(1, int(math.Ceil(cfg.RecordsPerSecond)))
+		l.records = rate.NewLimiter(rate.Limit(cfg.RecordsPerSecond), burst)
+	}
+	if cfg.BytesPerSecond > 0 {
+		l.bytes = rate.NewLimiter(rate.Limit(cfg.BytesPerSecond), cfg.BytesPerSecond)
+	}
+	return &l
+}
+
+// wait blocks until msg can be consumed without exceeding the limits, or
+// until ctx is done.
+func (l *rateLimiter) wait(ctx context.Context, msg kafkaMessage) error {
+	if l == nil {
+		return nil
+	}
+	if l.records != nil {
+		if err := l.records.Wait(ctx); err != nil {
+			return err
+		}
+	}
+	if l.bytes != nil {
+		// Records larger than the burst are waited for in several steps,
+		// as the limiter rejects waiting for more than the burst at once.
+		for n := len(msg.key()) + len(msg.value()); n > 0; {
+			step := min(n, l.bytes.Burst())
+			if err := l.bytes.WaitN(ctx, step); err != nil {
+				return err
+			}
+			n -= step
+		}
+	}
+	return nil
+}
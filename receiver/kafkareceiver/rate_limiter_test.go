@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kafkareceiver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter(t *testing.T) {
+	msg := wrapSaramaMsg(&sarama.ConsumerMessage{
+		Key:   []byte("key"),
+		Value: make([]byte, 97),
+	})
+
+	t.Run("unlimited", func(t *testing.T) {
+		l := newRateLimiter(RateLimitConfig{})
+		assert.Nil(t, l)
+		assert.NoError(t, l.wait(t.Context(), msg))
+	})
+
+	t.Run("records", func(t *testing.T) {
+		l := newRateLimiter(RateLimitConfig{RecordsPerSecond: 20})
+		start := time.Now()
+		for range 30 {
+			require.NoError(t, l.wait(t.Context(), msg))
+		}
+		// 20 records are allowed right away, the remaining 10 at 20/s.
+		assert.GreaterOrEqual(t, time.Since(start), 400*time.Millisecond)
+	})
+
+	t.Run("bytes", func(t *testing.T) {
+		l := newRateLimiter(RateLimitConfig{BytesPerSecond: 200})
+		start := time.Now()
+		for range 3 {
+			require.NoError(t, l.wait(t.Context(), msg))
+		}
+		// 200 bytes are allowed right away, the remaining 100 at 200/s.
+		assert.GreaterOrEqual(t, time.Since(start), 400*time.Millisecond)
+	})
+
+	t.Run("bytes_larger_than_burst", func(t *testing.T) {
+		l := newRateLimiter(RateLimitConfig{BytesPerSecond: 40})
+		start := time.Now()
+		require.NoError(t, l.wait(t.Context(), wrapSaramaMsg(&sarama.ConsumerMessage{
+			Value: make([]byte, 60),
+		})))
+		// 40 bytes are allowed right away, the remaining 20 at 40/s.
+		assert.GreaterOrEqual(t, time.Since(start), 400*time.Millisecond)
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		l := newRateLimiter(RateLimitConfig{RecordsPerSecond: 1})
+		require.NoError(t, l.wait(t.Context(), msg))
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+		assert.Error(t, l.wait(ctx, msg))
+	})
+}
@@ -0,0 +1,154 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqttreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/mqttreceiver"
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/config/configtls"
+)
+
+var _ component.Config = (*Config)(nil)
+
+// Config defines configuration for the MQTT receiver.
+type Config struct {
+	// Endpoint holds the URL of the MQTT broker, e.g. tcp://localhost:1883.
+	// The tcp, ssl, tls, ws and wss schemes are supported.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// ClientID holds the client identifier of the receiver. The broker
+	// keeps the session of the client across connections, unless
+	// CleanSession is set, so it must be unique and stable.
+	ClientID string `mapstructure:"client_id"`
+
+	// CleanSession makes the broker discard the session of the client
+	// when it disconnects, along with the messages which were not
+	// acknowledged.
+	CleanSession bool `mapstructure:"clean_session"`
+
+	// ConnectTimeout is the timeout for connecting to the broker.
+	ConnectTimeout time.Duration `mapstructure:"connect_timeout"`
+
+	// KeepAlive is the interval at which the client pings the broker
+	// when no other packets are sent.
+	KeepAlive time.Duration `mapstructure:"keep_alive"`
+
+	// TLS holds the TLS configuration of the connection, used by the
+	// ssl, tls and wss schemes.
+	TLS configtls.ClientConfig `mapstructure:"tls"`
+
+	// Auth holds the credentials of the client.
+	Auth AuthConfig `mapstructure:"auth"`
+
+	// Logs holds configuration about how logs should be consumed.
+	Logs SignalConfig `mapstructure:"logs"`
+
+	// Metrics holds configuration about how metrics should be consumed.
+	Metrics SignalConfig `mapstructure:"metrics"`
+
+	// Traces holds configuration about how traces should be consumed.
+	Traces SignalConfig `mapstructure:"traces"`
+
+	// ErrorBackOff controls the retries of messages for which the next
+	// consumer returned a non-permanent error.
+	ErrorBackOff configretry.BackOffConfig `mapstructure:"error_backoff"`
+}
+
+// AuthConfig holds the credentials of the client.
+type AuthConfig struct {
+	Username string              `mapstructure:"username"`
+	Password configopaque.String `mapstructure:"password"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// SignalConfig holds the subscriptions of a signal.
+type SignalConfig struct {
+	// Topics holds the topic filters to subscribe to.
+	Topics []TopicConfig `mapstructure:"topics"`
+
+	// QoS holds the maximum quality of service of the subscriptions:
+	// 0 (at most once), 1 (at least once) or 2 (exactly once).
+	QoS byte `mapstructure:"qos"`
+
+	// Encoding holds the encoding of the messages: otlp_proto, otlp_json,
+	// or the ID of an encoding extension.
+	Encoding string `mapstructure:"encoding"`
+}
+
+// TopicConfig holds a topic filter, and the resource attributes set from
+// the topic levels matched by its wildcards.
+type TopicConfig struct {
+	// Filter holds the topic filter, which may contain the '+' and '#'
+	// wildcards, or be a shared subscription ("$share/<group>/<filter>").
+	Filter string `mapstructure:"filter"`
+
+	// Attributes holds the names of the resource attributes set from the
+	// wildcards of the filter, in order. An empty name ignores the
+	// corresponding wildcard.
+	Attributes []string `mapstructure:"attributes"`
+}
+
+func (c SignalConfig) validate() error {
+	if len(c.Topics) == 0 {
+		return errors.New("topics must be specified")
+	}
+	for i, topic := range c.Topics {
+		if _, err := parseTopicFilter(topic); err != nil {
+			return fmt.Errorf("topics[%d]: %w", i, err)
+		}
+	}
+	if c.QoS > 2 {
+		return fmt.Errorf("qos %d must be 0, 1 or 2", c.QoS)
+	}
+	if c.Encoding == "" {
+		return errors.New("encoding must be specified")
+	}
+	return nil
+}
+
+// Validate checks the receiver configuration is valid.
+func (cfg *Config) Validate() error {
+	var errs []error
+	if cfg.Endpoint == "" {
+		errs = append(errs, errors.New("endpoint must be specified"))
+	} else if u, err := url.Parse(cfg.Endpoint); err != nil {
+		errs = append(errs, fmt.Errorf("endpoint is invalid: %w", err))
+	} else {
+		switch u.Scheme {
+		case "tcp", "ssl", "tls", "ws", "wss":
+		default:
+			errs = append(errs, fmt.Errorf("endpoint scheme %q must be one of tcp, ssl, tls, ws or wss", u.Scheme))
+		}
+	}
+	if cfg.ClientID == "" {
+		errs = append(errs, errors.New("client_id must be specified"))
+	}
+	if cfg.ConnectTimeout <= 0 {
+		errs = append(errs, errors.New("connect_timeout must be positive"))
+	}
+	if cfg.KeepAlive < time.Second {
+		errs = append(errs, errors.New("keep_alive must be at least 1s"))
+	}
+	if cfg.Auth.Password != "" && cfg.Auth.Username == "" {
+		errs = append(errs, errors.New("auth::password requires auth::username"))
+	}
+	if err := cfg.Logs.validate(); err != nil {
+		errs = append(errs, fmt.Errorf("logs::%w", err))
+	}
+	if err := cfg.Metrics.validate(); err != nil {
+		errs = append(errs, fmt.Errorf("metrics::%w", err))
+	}
+	if err := cfg.Traces.validate(); err != nil {
+		errs = append(errs, fmt.Errorf("traces::%w", err))
+	}
+	return errors.Join(errs...)
+}
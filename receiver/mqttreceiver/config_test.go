@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqttreceiver
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/mqttreceiver/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id          component.ID
+		expected    component.Config
+		expectedErr string
+	}{
+		{
+			id:       component.NewIDWithName(metadata.Type, ""),
+			expected: createDefaultConfig(),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "custom"),
+			expected: &Config{
+				Endpoint:       "ssl://broker:8883",
+				ClientID:       "otelcol-site-1",
+				CleanSession:   true,
+				ConnectTimeout: 5 * time.Second,
+				KeepAlive:      time.Minute,
+				TLS: configtls.ClientConfig{
+					Config: configtls.Config{CAFile: "ca.pem"},
+				},
+				Auth: AuthConfig{
+					Username: "user",
+					Password: "pass",
+				},
+				Logs: SignalConfig{
+					Topics:   []TopicConfig{{Filter: "devices/+/logs", Attributes: []string{"device.id"}}},
+					QoS:      2,
+					Encoding: "text_encoding",
+				},
+				Metrics: SignalConfig{
+					Topics: []TopicConfig{
+						{Filter: "$share/otelcol/devices/+/metrics", Attributes: []string{"device.id"}},
+						{Filter: "gateways/#"},
+					},
+					QoS:      1,
+					Encoding: "otlp_json",
+				},
+				Traces: SignalConfig{
+					Topics:   []TopicConfig{{Filter: "otlp/traces"}},
+					QoS:      1,
+					Encoding: "otlp_proto",
+				},
+				ErrorBackOff: func() configretry.BackOffConfig {
+					config := configretry.NewDefaultBackOffConfig()
+					config.Enabled = false
+					return config
+				}(),
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "invalid_endpoint"),
+			expectedErr: `endpoint scheme "http" must be one of tcp, ssl, tls, ws or wss` + "\n" +
+				"client_id must be specified\n" +
+				"connect_timeout must be positive\n" +
+				"keep_alive must be at least 1s",
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "invalid_topics"),
+			expectedErr: "logs::topics must be specified\n" +
+				`metrics::topics[0]: filter "devices/+/metrics" has 1 wildcards, but 2 attributes are specified` + "\n" +
+				`traces::topics[0]: filter "traces/#/spans" must only have '#' as its last level`,
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "invalid_auth"),
+			expectedErr: "auth::password requires auth::username",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			t.Parallel()
+
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			err = xconfmap.Validate(cfg)
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
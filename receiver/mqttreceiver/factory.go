@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqttreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/mqttreceiver"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/mqttreceiver/internal/metadata"
+)
+
+const (
+	defaultEndpoint       = "tcp://localhost:1883"
+	defaultClientID       = "otelcol"
+	defaultConnectTimeout = 10 * time.Second
+	defaultKeepAlive      = 30 * time.Second
+
+	defaultLogsTopic       = "otlp/logs"
+	defaultLogsEncoding    = "otlp_proto"
+	defaultMetricsTopic    = "otlp/metrics"
+	defaultMetricsEncoding = "otlp_proto"
+	defaultTracesTopic     = "otlp/traces"
+	defaultTracesEncoding  = "otlp_proto"
+	defaultQoS             = 1
+)
+
+// NewFactory creates MQTT receiver factory.
+func NewFactory() receiver.Factory {
+	return receiver.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		receiver.WithTraces(createTracesReceiver, metadata.TracesStability),
+		receiver.WithMetrics(createMetricsReceiver, metadata.MetricsStability),
+		receiver.WithLogs(createLogsReceiver, metadata.LogsStability),
+	)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		Endpoint:       defaultEndpoint,
+		ClientID:       defaultClientID,
+		ConnectTimeout: defaultConnectTimeout,
+		KeepAlive:      defaultKeepAlive,
+		Logs: SignalConfig{
+			Topics:   []TopicConfig{{Filter: defaultLogsTopic}},
+			QoS:      defaultQoS,
+			Encoding: defaultLogsEncoding,
+		},
+		Metrics: SignalConfig{
+			Topics:   []TopicConfig{{Filter: defaultMetricsTopic}},
+			QoS:      defaultQoS,
+			Encoding: defaultMetricsEncoding,
+		},
+		Traces: SignalConfig{
+			Topics:   []TopicConfig{{Filter: defaultTracesTopic}},
+			QoS:      defaultQoS,
+			Encoding: defaultTracesEncoding,
+		},
+		ErrorBackOff: configretry.NewDefaultBackOffConfig(),
+	}
+}
+
+func createTracesReceiver(
+	_ context.Context,
+	set receiver.Settings,
+	cfg component.Config,
+	nextConsumer consumer.Traces,
+) (receiver.Traces, error) {
+	r := receivers.GetOrAdd(cfg, func() component.Component {
+		return newMQTTReceiver(cfg.(*Config), set)
+	})
+	r.Unwrap().(*mqttReceiver).nextTraces = nextConsumer
+	return r, nil
+}
+
+func createMetricsReceiver(
+	_ context.Context,
+	set receiver.Settings,
+	cfg component.Config,
+	nextConsumer consumer.Metrics,
+) (receiver.Metrics, error) {
+	r := receivers.GetOrAdd(cfg, func() component.Component {
+		return newMQTTReceiver(cfg.(*Config), set)
+	})
+	r.Unwrap().(*mqttReceiver).nextMetrics = nextConsumer
+	return r, nil
+}
+
+func createLogsReceiver(
+	_ context.Context,
+	set receiver.Settings,
+	cfg component.Config,
+	nextConsumer consumer.Logs,
+) (receiver.Logs, error) {
+	r := receivers.GetOrAdd(cfg, func() component.Component {
+		return newMQTTReceiver(cfg.(*Config), set)
+	})
+	r.Unwrap().(*mqttReceiver).nextLogs = nextConsumer
+	return r, nil
+}
+
+// receivers shares a receiver, and so its MQTT client and session, across
+// the signals of a configuration.
+var receivers = sharedcomponent.NewSharedComponents()
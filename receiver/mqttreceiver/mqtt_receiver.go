@@ -0,0 +1,317 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqttreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/mqttreceiver"
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/encodingutil"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/messagehandler"
+)
+
+const (
+	transport = "mqtt"
+
+	// disconnectQuiesce is how long the client waits for pending work to
+	// complete when disconnecting, in milliseconds.
+	disconnectQuiesce = 250
+)
+
+type consumeMessageFunc func(ctx context.Context, msg mqtt.Message, attrs []topicAttribute) error
+
+// subscription is a topic filter subscribed to for a signal.
+type subscription struct {
+	filter  topicFilter
+	qos     byte
+	handler mqtt.MessageHandler
+}
+
+// mqttReceiver subscribes to the topic filters of the signals which have a
+// next consumer, sharing one client and session. Messages are acknowledged
+// once they were consumed by the next consumer, or if consuming them failed
+// with a permanent error. Other messages are left unacknowledged, for the
+// broker to redeliver them when the session resumes.
+type mqttReceiver struct {
+	config   *Config
+	settings receiver.Settings
+
+	nextLogs    consumer.Logs
+	nextMetrics consumer.Metrics
+	nextTraces  consumer.Traces
+
+	client        mqtt.Client
+	subscriptions []subscription
+	connections   atomic.Int64
+	ctx           context.Context
+	cancel        context.CancelFunc
+
+	// mu protects closed, so that no message handling starts after the
+	// receiver waits for the handlers in progress.
+	mu       sync.Mutex
+	closed   bool
+	handlers sync.WaitGroup
+}
+
+func newMQTTReceiver(config *Config, set receiver.Settings) *mqttReceiver {
+	return &mqttReceiver{
+		config:   config,
+		settings: set,
+	}
+}
+
+func (r *mqttReceiver) Start(ctx context.Context, host component.Host) error {
+	obsrecv, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
+		ReceiverID:             r.settings.ID,
+		Transport:              transport,
+		ReceiverCreateSettings: r.settings,
+	})
+	if err != nil {
+		return err
+	}
+	r.ctx, r.cancel = context.WithCancel(context.Background())
+	if err := r.addSubscriptions(host, obsrecv); err != nil {
+		return err
+	}
+
+	opts, err := r.clientOptions(ctx)
+	if err != nil {
+		return err
+	}
+	client := mqtt.NewClient(opts)
+	if err := waitToken(ctx, client.Connect()); err != nil {
+		return fmt.Errorf("failed to connect to MQTT broker: %w", err)
+	}
+	if err := r.subscribe(ctx, client); err != nil {
+		client.Disconnect(disconnectQuiesce)
+		return err
+	}
+	r.client = client
+	return nil
+}
+
+func (r *mqttReceiver) addSubscriptions(host component.Host, obsrecv *receiverhelper.ObsReport) error {
+	if r.nextLogs != nil {
+		unmarshaler, err := encodingutil.NewLogsUnmarshaler(r.config.Logs.Encoding, host)
+		if err != nil {
+			return err
+		}
+		handler := messagehandler.NewLogs(r.config.Logs.Encoding, unmarshaler, obsrecv, r.nextLogs)
+		r.addSignalSubscriptions(r.config.Logs, r.consumeMessageFunc(handler.Handle))
+	}
+	if r.nextMetrics != nil {
+		unmarshaler, err := encodingutil.NewMetricsUnmarshaler(r.config.Metrics.Encoding, host)
+		if err != nil {
+			return err
+		}
+		handler := messagehandler.NewMetrics(r.config.Metrics.Encoding, unmarshaler, obsrecv, r.nextMetrics)
+		r.addSignalSubscriptions(r.config.Metrics, r.consumeMessageFunc(handler.Handle))
+	}
+	if r.nextTraces != nil {
+		unmarshaler, err := encodingutil.NewTracesUnmarshaler(r.config.Traces.Encoding, host)
+		if err != nil {
+			return err
+		}
+		handler := messagehandler.NewTraces(r.config.Traces.Encoding, unmarshaler, obsrecv, r.nextTraces)
+		r.addSignalSubscriptions(r.config.Traces, r.consumeMessageFunc(handler.Handle))
+	}
+	return nil
+}
+
+func (r *mqttReceiver) addSignalSubscriptions(signal SignalConfig, consumeMessage consumeMessageFunc) {
+	for _, topic := range signal.Topics {
+		// The topic filters were validated as part of the config.
+		filter, _ := parseTopicFilter(topic)
+		r.subscriptions = append(r.subscriptions, subscription{
+			filter: filter,
+			qos:    signal.QoS,
+			handler: func(_ mqtt.Client, msg mqtt.Message) {
+				if !r.startHandling() {
+					// The message is redelivered when the session resumes.
+					return
+				}
+				defer r.handlers.Done()
+				r.handleMessage(msg, filter, consumeMessage)
+			},
+		})
+	}
+}
+
+func (r *mqttReceiver) clientOptions(ctx context.Context) (*mqtt.ClientOptions, error) {
+	opts := mqtt.NewClientOptions().
+		AddBroker(r.config.Endpoint).
+		SetClientID(r.config.ClientID).
+		SetCleanSession(r.config.CleanSession).
+		SetConnectTimeout(r.config.ConnectTimeout).
+		SetKeepAlive(r.config.KeepAlive).
+		SetAutoReconnect(true).
+		// Messages are acknowledged once they were consumed, and consumed
+		// concurrently so a slow consumer does not block the client.
+		SetAutoAckDisabled(true).
+		SetOrderMatters(false).
+		SetConnectionLostHandler(func(_ mqtt.Client, err error) {
+			r.settings.Logger.Warn("disconnected from MQTT broker", zap.Error(err))
+		}).
+		SetOnConnectHandler(func(client mqtt.Client) {
+			if r.connections.Add(1) == 1 {
+				return
+			}
+			r.settings.Logger.Info("reconnected to MQTT broker")
+			if r.config.CleanSession {
+				// The broker discarded the subscriptions with the session.
+				if err := r.subscribe(r.ctx, client); err != nil {
+					r.settings.Logger.Error("failed to resubscribe", zap.Error(err))
+				}
+			}
+		})
+	if r.config.Auth.Username != "" {
+		opts.SetUsername(r.config.Auth.Username)
+		opts.SetPassword(string(r.config.Auth.Password))
+	}
+	tlsConfig, err := r.config.TLS.LoadTLSConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load TLS config: %w", err)
+	}
+	if tlsConfig != nil {
+		opts.SetTLSConfig(tlsConfig)
+	}
+	return opts, nil
+}
+
+func (r *mqttReceiver) subscribe(ctx context.Context, client mqtt.Client) error {
+	for _, sub := range r.subscriptions {
+		if err := waitToken(ctx, client.Subscribe(sub.filter.filter, sub.qos, sub.handler)); err != nil {
+			return fmt.Errorf("failed to subscribe to %q: %w", sub.filter.filter, err)
+		}
+	}
+	return nil
+}
+
+// startHandling returns whether a message may be handled, in which case
+// the caller must call handlers.Done once it was handled.
+func (r *mqttReceiver) startHandling() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return false
+	}
+	r.handlers.Add(1)
+	return true
+}
+
+func (r *mqttReceiver) handleMessage(msg mqtt.Message, filter topicFilter, consumeMessage consumeMessageFunc) {
+	attrs := filter.topicAttributes(msg.Topic())
+	backOff := newExponentialBackOff(r.config.ErrorBackOff)
+	for {
+		err := consumeMessage(r.ctx, msg, attrs)
+		if err == nil {
+			msg.Ack()
+			return
+		}
+		if consumererror.IsPermanent(err) {
+			r.settings.Logger.Error("failed to consume message, dropping it",
+				zap.String("topic", msg.Topic()),
+				zap.Error(err),
+			)
+			msg.Ack()
+			return
+		}
+		if backOff != nil {
+			backOffDelay := backOff.NextBackOff()
+			if backOffDelay != backoff.Stop {
+				r.settings.Logger.Info("Backing off due to error from the next consumer.",
+					zap.Error(err),
+					zap.Duration("delay", backOffDelay),
+				)
+				select {
+				case <-r.ctx.Done():
+					return
+				case <-time.After(backOffDelay):
+					continue
+				}
+			}
+			r.settings.Logger.Warn("Stop error backoff because the configured max_elapsed_time is reached",
+				zap.Duration("max_elapsed_time", backOff.MaxElapsedTime),
+			)
+		}
+		r.settings.Logger.Error("failed to consume message, it will be redelivered when the session resumes",
+			zap.String("topic", msg.Topic()),
+			zap.Error(err),
+		)
+		return
+	}
+}
+
+func (r *mqttReceiver) Shutdown(context.Context) error {
+	if r.cancel == nil {
+		return nil
+	}
+	// Cancel the messages being consumed, and wait for their handlers to
+	// return before disconnecting, so the consumed messages are
+	// acknowledged.
+	r.mu.Lock()
+	r.closed = true
+	r.mu.Unlock()
+	r.cancel()
+	r.handlers.Wait()
+	if r.client != nil {
+		r.client.Disconnect(disconnectQuiesce)
+	}
+	return nil
+}
+
+func waitToken(ctx context.Context, token mqtt.Token) error {
+	select {
+	case <-token.Done():
+		return token.Error()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func newExponentialBackOff(config configretry.BackOffConfig) *backoff.ExponentialBackOff {
+	if !config.Enabled {
+		return nil
+	}
+	backOff := backoff.NewExponentialBackOff()
+	backOff.InitialInterval = config.InitialInterval
+	backOff.RandomizationFactor = config.RandomizationFactor
+	backOff.Multiplier = config.Multiplier
+	backOff.MaxInterval = config.MaxInterval
+	backOff.MaxElapsedTime = config.MaxElapsedTime
+	backOff.Reset()
+	return backOff
+}
+
+// consumeMessageFunc returns a consumeMessageFunc passing the payload of the
+// messages to handle, with the topic attributes set on its resources.
+func (r *mqttReceiver) consumeMessageFunc(handle func(context.Context, []byte, func(pcommon.Resource)) error) consumeMessageFunc {
+	return func(ctx context.Context, msg mqtt.Message, attrs []topicAttribute) error {
+		if r.settings.Logger.Core().Enabled(zap.DebugLevel) {
+			r.settings.Logger.Debug("mqtt message received",
+				zap.String("topic", msg.Topic()),
+				zap.Uint8("qos", msg.Qos()),
+				zap.Bool("duplicate", msg.Duplicate()),
+				zap.Bool("retained", msg.Retained()),
+			)
+		}
+		return handle(ctx, msg.Payload(), func(res pcommon.Resource) {
+			putTopicAttributes(res, attrs)
+		})
+	}
+}
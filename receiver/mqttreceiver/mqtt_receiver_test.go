@@ -0,0 +1,317 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqttreceiver
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	mochi "github.com/mochi-mqtt/server/v2"
+	"github.com/mochi-mqtt/server/v2/hooks/auth"
+	"github.com/mochi-mqtt/server/v2/listeners"
+	"github.com/mochi-mqtt/server/v2/packets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/mqttreceiver/internal/metadata"
+)
+
+func TestReceiver_logs(t *testing.T) {
+	broker, config := newTestBroker(t, nil)
+	sink := &consumertest.LogsSink{}
+	r := newMQTTReceiver(config, receivertest.NewNopSettings(metadata.Type))
+	r.nextLogs = sink
+	startReceiver(t, r, componenttest.NewNopHost())
+
+	logs := newLogs("log message")
+	payload, err := (&plog.ProtoMarshaler{}).MarshalLogs(logs)
+	require.NoError(t, err)
+	require.NoError(t, broker.server.Publish("otlp/logs", payload, false, 1))
+
+	require.Eventually(t, func() bool { return sink.LogRecordCount() == 1 }, 10*time.Second, 10*time.Millisecond)
+	assert.Equal(t, logs, sink.AllLogs()[0])
+	broker.assertAcknowledged(t, 1)
+}
+
+func TestReceiver_topicAttributes(t *testing.T) {
+	broker, config := newTestBroker(t, nil)
+	config.Logs.Encoding = "text_encoding"
+	config.Logs.Topics = []TopicConfig{
+		{Filter: "devices/+/telemetry", Attributes: []string{"device.id"}},
+		{Filter: "$share/otelcol/sites/+/+/#", Attributes: []string{"site", "", "sensor"}},
+	}
+	sink := &consumertest.LogsSink{}
+	r := newMQTTReceiver(config, receivertest.NewNopSettings(metadata.Type))
+	r.nextLogs = sink
+	startReceiver(t, r, extensionsHost{
+		component.MustNewID("text_encoding"): textLogsUnmarshalerExtension{},
+	})
+
+	require.NoError(t, broker.server.Publish("devices/device-1/telemetry", []byte("first"), false, 1))
+	require.Eventually(t, func() bool { return sink.LogRecordCount() == 1 }, 10*time.Second, 10*time.Millisecond)
+	require.NoError(t, broker.server.Publish("sites/paris/devices/temperature/1", []byte("second"), false, 1))
+	require.Eventually(t, func() bool { return sink.LogRecordCount() == 2 }, 10*time.Second, 10*time.Millisecond)
+
+	expected := newLogs("first")
+	expected.ResourceLogs().At(0).Resource().Attributes().PutStr("device.id", "device-1")
+	assert.Equal(t, expected, sink.AllLogs()[0])
+	expected = newLogs("second")
+	expected.ResourceLogs().At(0).Resource().Attributes().PutStr("site", "paris")
+	expected.ResourceLogs().At(0).Resource().Attributes().PutStr("sensor", "temperature/1")
+	assert.Equal(t, expected, sink.AllLogs()[1])
+	broker.assertAcknowledged(t, 2)
+}
+
+func TestReceiver_errorBackOff(t *testing.T) {
+	broker, config := newTestBroker(t, nil)
+	config.ErrorBackOff.InitialInterval = 10 * time.Millisecond
+	var calls atomic.Int64
+	sink := &consumertest.LogsSink{}
+	r := newMQTTReceiver(config, receivertest.NewNopSettings(metadata.Type))
+	r.nextLogs = newLogsConsumer(func(ctx context.Context, logs plog.Logs) error {
+		if calls.Add(1) == 1 {
+			return errors.New("transient error")
+		}
+		return sink.ConsumeLogs(ctx, logs)
+	})
+	startReceiver(t, r, componenttest.NewNopHost())
+
+	payload, err := (&plog.ProtoMarshaler{}).MarshalLogs(newLogs("log message"))
+	require.NoError(t, err)
+	require.NoError(t, broker.server.Publish("otlp/logs", payload, false, 1))
+
+	require.Eventually(t, func() bool { return sink.LogRecordCount() == 1 }, 10*time.Second, 10*time.Millisecond)
+	assert.Equal(t, int64(2), calls.Load())
+	broker.assertAcknowledged(t, 1)
+}
+
+func TestReceiver_errorNotAcknowledged(t *testing.T) {
+	broker, config := newTestBroker(t, nil)
+	config.ErrorBackOff.Enabled = false
+	var calls atomic.Int64
+	r := newMQTTReceiver(config, receivertest.NewNopSettings(metadata.Type))
+	r.nextLogs = newLogsConsumer(func(context.Context, plog.Logs) error {
+		calls.Add(1)
+		return errors.New("transient error")
+	})
+	startReceiver(t, r, componenttest.NewNopHost())
+
+	payload, err := (&plog.ProtoMarshaler{}).MarshalLogs(newLogs("log message"))
+	require.NoError(t, err)
+	require.NoError(t, broker.server.Publish("otlp/logs", payload, false, 1))
+
+	require.Eventually(t, func() bool { return calls.Load() == 1 }, 10*time.Second, 10*time.Millisecond)
+	assert.Never(t, func() bool { return broker.acks.Load() != 0 }, 200*time.Millisecond, 10*time.Millisecond)
+}
+
+func TestReceiver_permanentError(t *testing.T) {
+	broker, config := newTestBroker(t, nil)
+	var calls atomic.Int64
+	r := newMQTTReceiver(config, receivertest.NewNopSettings(metadata.Type))
+	r.nextLogs = newLogsConsumer(func(context.Context, plog.Logs) error {
+		calls.Add(1)
+		return consumererror.NewPermanent(errors.New("permanent error"))
+	})
+	startReceiver(t, r, componenttest.NewNopHost())
+
+	require.NoError(t, broker.server.Publish("otlp/logs", []byte("not protobuf"), false, 1))
+	payload, err := (&plog.ProtoMarshaler{}).MarshalLogs(newLogs("log message"))
+	require.NoError(t, err)
+	require.NoError(t, broker.server.Publish("otlp/logs", payload, false, 1))
+
+	// Both the message failing to unmarshal and the message failing to be
+	// consumed are acknowledged, and not redelivered.
+	broker.assertAcknowledged(t, 2)
+	assert.Equal(t, int64(1), calls.Load())
+}
+
+func TestReceiver_sharedClient(t *testing.T) {
+	broker, config := newTestBroker(t, nil)
+	factory := NewFactory()
+	set := receivertest.NewNopSettings(metadata.Type)
+	logsSink := &consumertest.LogsSink{}
+	logsReceiver, err := factory.CreateLogs(t.Context(), set, config, logsSink)
+	require.NoError(t, err)
+	metricsSink := &consumertest.MetricsSink{}
+	metricsReceiver, err := factory.CreateMetrics(t.Context(), set, config, metricsSink)
+	require.NoError(t, err)
+	startReceiver(t, logsReceiver, componenttest.NewNopHost())
+	startReceiver(t, metricsReceiver, componenttest.NewNopHost())
+
+	logsPayload, err := (&plog.ProtoMarshaler{}).MarshalLogs(newLogs("log message"))
+	require.NoError(t, err)
+	require.NoError(t, broker.server.Publish("otlp/logs", logsPayload, false, 1))
+	metrics := pmetric.NewMetrics()
+	metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptySum().DataPoints().AppendEmpty()
+	metricsPayload, err := (&pmetric.ProtoMarshaler{}).MarshalMetrics(metrics)
+	require.NoError(t, err)
+	require.NoError(t, broker.server.Publish("otlp/metrics", metricsPayload, false, 1))
+
+	require.Eventually(t, func() bool {
+		return logsSink.LogRecordCount() == 1 && metricsSink.DataPointCount() == 1
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.Equal(t, int64(1), broker.connects.Load())
+	broker.assertAcknowledged(t, 2)
+}
+
+func TestReceiver_auth(t *testing.T) {
+	broker, config := newTestBroker(t, &auth.Ledger{
+		Auth: auth.AuthRules{{Username: "user", Password: "secret", Allow: true}},
+	})
+
+	config.Auth.Username = "user"
+	config.Auth.Password = "wrong"
+	r := newMQTTReceiver(config, receivertest.NewNopSettings(metadata.Type))
+	r.nextLogs = consumertest.NewNop()
+	err := r.Start(t.Context(), componenttest.NewNopHost())
+	assert.ErrorContains(t, err, "failed to connect to MQTT broker")
+	assert.NoError(t, r.Shutdown(t.Context()))
+
+	config.Auth.Password = "secret"
+	sink := &consumertest.LogsSink{}
+	r = newMQTTReceiver(config, receivertest.NewNopSettings(metadata.Type))
+	r.nextLogs = sink
+	startReceiver(t, r, componenttest.NewNopHost())
+
+	payload, err := (&plog.ProtoMarshaler{}).MarshalLogs(newLogs("log message"))
+	require.NoError(t, err)
+	require.NoError(t, broker.server.Publish("otlp/logs", payload, false, 1))
+	require.Eventually(t, func() bool { return sink.LogRecordCount() == 1 }, 10*time.Second, 10*time.Millisecond)
+}
+
+func TestReceiver_startErrors(t *testing.T) {
+	t.Run("unknown encoding", func(t *testing.T) {
+		_, config := newTestBroker(t, nil)
+		config.Logs.Encoding = "unknown"
+		r := newMQTTReceiver(config, receivertest.NewNopSettings(metadata.Type))
+		r.nextLogs = consumertest.NewNop()
+		err := r.Start(t.Context(), componenttest.NewNopHost())
+		assert.EqualError(t, err, `unrecognized logs encoding "unknown"`)
+		assert.NoError(t, r.Shutdown(t.Context()))
+	})
+	t.Run("connection refused", func(t *testing.T) {
+		config := createDefaultConfig().(*Config)
+		config.Endpoint = "tcp://127.0.0.1:1"
+		r := newMQTTReceiver(config, receivertest.NewNopSettings(metadata.Type))
+		r.nextLogs = consumertest.NewNop()
+		err := r.Start(t.Context(), componenttest.NewNopHost())
+		assert.ErrorContains(t, err, "failed to connect to MQTT broker")
+		assert.NoError(t, r.Shutdown(t.Context()))
+	})
+}
+
+func TestReceiver_shutdownNotStarted(t *testing.T) {
+	r := newMQTTReceiver(createDefaultConfig().(*Config), receivertest.NewNopSettings(metadata.Type))
+	assert.NoError(t, r.Shutdown(t.Context()))
+}
+
+// testBroker is an embedded MQTT broker, counting the connections of the
+// receiver and the messages it acknowledged.
+type testBroker struct {
+	mochi.HookBase
+	server   *mochi.Server
+	connects atomic.Int64
+	acks     atomic.Int64
+}
+
+func (*testBroker) ID() string {
+	return "test"
+}
+
+func (*testBroker) Provides(b byte) bool {
+	return bytes.Contains([]byte{mochi.OnConnect, mochi.OnQosComplete}, []byte{b})
+}
+
+func (b *testBroker) OnConnect(cl *mochi.Client, _ packets.Packet) error {
+	if !cl.Net.Inline {
+		b.connects.Add(1)
+	}
+	return nil
+}
+
+func (b *testBroker) OnQosComplete(cl *mochi.Client, _ packets.Packet) {
+	if !cl.Net.Inline {
+		b.acks.Add(1)
+	}
+}
+
+// assertAcknowledged waits for the receiver to have acknowledged n
+// messages.
+func (b *testBroker) assertAcknowledged(tb testing.TB, n int64) {
+	assert.Eventually(tb, func() bool { return b.acks.Load() == n }, 10*time.Second, 10*time.Millisecond)
+}
+
+// newTestBroker starts an MQTT broker, and returns the default configuration
+// to connect to it. The broker allows all clients if ledger is nil.
+func newTestBroker(tb testing.TB, ledger *auth.Ledger) (*testBroker, *Config) {
+	broker := &testBroker{
+		server: mochi.New(&mochi.Options{
+			InlineClient: true,
+			Logger:       slog.New(slog.DiscardHandler),
+		}),
+	}
+	require.NoError(tb, broker.server.AddHook(broker, nil))
+	if ledger == nil {
+		require.NoError(tb, broker.server.AddHook(new(auth.AllowHook), nil))
+	} else {
+		require.NoError(tb, broker.server.AddHook(new(auth.Hook), &auth.Options{Ledger: ledger}))
+	}
+	listener := listeners.NewTCP(listeners.Config{ID: "tcp", Address: "127.0.0.1:0"})
+	require.NoError(tb, broker.server.AddListener(listener))
+	require.NoError(tb, broker.server.Serve())
+	tb.Cleanup(func() {
+		assert.NoError(tb, broker.server.Close())
+	})
+
+	config := createDefaultConfig().(*Config)
+	config.Endpoint = "tcp://" + listener.Address()
+	config.ClientID = tb.Name()
+	return broker, config
+}
+
+func startReceiver(tb testing.TB, r component.Component, host component.Host) {
+	require.NoError(tb, r.Start(tb.Context(), host))
+	tb.Cleanup(func() {
+		assert.NoError(tb, r.Shutdown(context.Background()))
+	})
+}
+
+func newLogsConsumer(consume consumer.ConsumeLogsFunc) consumer.Logs {
+	logs, _ := consumer.NewLogs(consume)
+	return logs
+}
+
+func newLogs(body string) plog.Logs {
+	logs := plog.NewLogs()
+	logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr(body)
+	return logs
+}
+
+type textLogsUnmarshalerExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+}
+
+func (textLogsUnmarshalerExtension) UnmarshalLogs(data []byte) (plog.Logs, error) {
+	return newLogs(string(data)), nil
+}
+
+type extensionsHost map[component.ID]component.Component
+
+func (h extensionsHost) GetExtensions() map[component.ID]component.Component {
+	return h
+}
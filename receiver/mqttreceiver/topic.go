@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqttreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/mqttreceiver"
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+const sharedSubscriptionPrefix = "$share/"
+
+// topicFilter is a parsed topic filter, which maps the topic levels
+// matched by its wildcards to resource attributes.
+type topicFilter struct {
+	// filter holds the filter subscribed to.
+	filter string
+	// levels holds the levels of the filter, without the shared
+	// subscription prefix.
+	levels []string
+	// attributes holds the attribute names of the wildcards, in order.
+	attributes []string
+}
+
+// topicAttribute is a resource attribute set from a topic level.
+type topicAttribute struct {
+	name  string
+	value string
+}
+
+func parseTopicFilter(cfg TopicConfig) (topicFilter, error) {
+	f := topicFilter{filter: cfg.Filter, attributes: cfg.Attributes}
+	filter := cfg.Filter
+	if strings.HasPrefix(filter, sharedSubscriptionPrefix) {
+		group, rest, ok := strings.Cut(strings.TrimPrefix(filter, sharedSubscriptionPrefix), "/")
+		if !ok || group == "" || strings.ContainsAny(group, "+#") {
+			return f, fmt.Errorf("filter %q has an invalid shared subscription group", cfg.Filter)
+		}
+		filter = rest
+	}
+	if filter == "" {
+		return f, errors.New("filter must be specified")
+	}
+	f.levels = strings.Split(filter, "/")
+	var wildcards int
+	for i, level := range f.levels {
+		switch {
+		case level == "+":
+			wildcards++
+		case level == "#":
+			if i != len(f.levels)-1 {
+				return f, fmt.Errorf("filter %q must only have '#' as its last level", cfg.Filter)
+			}
+			wildcards++
+		case strings.ContainsAny(level, "+#"):
+			return f, fmt.Errorf("filter %q must only have wildcards as entire levels", cfg.Filter)
+		}
+	}
+	if len(cfg.Attributes) > wildcards {
+		return f, fmt.Errorf("filter %q has %d wildcards, but %d attributes are specified", cfg.Filter, wildcards, len(cfg.Attributes))
+	}
+	return f, nil
+}
+
+// topicAttributes returns the resource attributes of a topic matched by
+// the filter.
+func (f topicFilter) topicAttributes(topic string) []topicAttribute {
+	if len(f.attributes) == 0 {
+		return nil
+	}
+	var attrs []topicAttribute
+	levels := strings.Split(topic, "/")
+	var wildcard int
+	for i, level := range f.levels {
+		if wildcard == len(f.attributes) || i >= len(levels) {
+			break
+		}
+		var value string
+		switch level {
+		case "+":
+			value = levels[i]
+		case "#":
+			value = strings.Join(levels[i:], "/")
+		default:
+			continue
+		}
+		if name := f.attributes[wildcard]; name != "" {
+			attrs = append(attrs, topicAttribute{name: name, value: value})
+		}
+		wildcard++
+	}
+	return attrs
+}
+
+// putTopicAttributes sets the topic attributes on a resource, replacing
+// existing attributes with the same names.
+func putTopicAttributes(resource pcommon.Resource, attrs []topicAttribute) {
+	for _, attr := range attrs {
+		resource.Attributes().PutStr(attr.name, attr.value)
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqttreceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTopicFilter(t *testing.T) {
+	tests := []struct {
+		name     string
+		topic    TopicConfig
+		received string
+		expected []topicAttribute
+	}{
+		{
+			name:     "no attributes",
+			topic:    TopicConfig{Filter: "devices/+/telemetry"},
+			received: "devices/device-1/telemetry",
+		},
+		{
+			name:     "single level wildcard",
+			topic:    TopicConfig{Filter: "devices/+/telemetry", Attributes: []string{"device.id"}},
+			received: "devices/device-1/telemetry",
+			expected: []topicAttribute{{name: "device.id", value: "device-1"}},
+		},
+		{
+			name:     "multi level wildcard",
+			topic:    TopicConfig{Filter: "sites/+/#", Attributes: []string{"site", "sensor"}},
+			received: "sites/paris/floor-1/temperature",
+			expected: []topicAttribute{{name: "site", value: "paris"}, {name: "sensor", value: "floor-1/temperature"}},
+		},
+		{
+			name:     "multi level wildcard matching parent",
+			topic:    TopicConfig{Filter: "sites/+/#", Attributes: []string{"site", "sensor"}},
+			received: "sites/paris",
+			expected: []topicAttribute{{name: "site", value: "paris"}},
+		},
+		{
+			name:     "ignored wildcard",
+			topic:    TopicConfig{Filter: "+/devices/+", Attributes: []string{"", "device.id"}},
+			received: "tenant/devices/device-1",
+			expected: []topicAttribute{{name: "device.id", value: "device-1"}},
+		},
+		{
+			name:     "fewer attributes than wildcards",
+			topic:    TopicConfig{Filter: "+/devices/+", Attributes: []string{"tenant"}},
+			received: "tenant-1/devices/device-1",
+			expected: []topicAttribute{{name: "tenant", value: "tenant-1"}},
+		},
+		{
+			name:     "shared subscription",
+			topic:    TopicConfig{Filter: "$share/otelcol/devices/+", Attributes: []string{"device.id"}},
+			received: "devices/device-1",
+			expected: []topicAttribute{{name: "device.id", value: "device-1"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := parseTopicFilter(tt.topic)
+			require.NoError(t, err)
+			assert.Equal(t, tt.topic.Filter, filter.filter)
+			assert.Equal(t, tt.expected, filter.topicAttributes(tt.received))
+		})
+	}
+}
+
+func TestTopicFilter_invalid(t *testing.T) {
+	tests := []struct {
+		topic       TopicConfig
+		expectedErr string
+	}{
+		{
+			topic:       TopicConfig{},
+			expectedErr: "filter must be specified",
+		},
+		{
+			topic:       TopicConfig{Filter: "devices/#/telemetry"},
+			expectedErr: `filter "devices/#/telemetry" must only have '#' as its last level`,
+		},
+		{
+			topic:       TopicConfig{Filter: "devices/device+/telemetry"},
+			expectedErr: `filter "devices/device+/telemetry" must only have wildcards as entire levels`,
+		},
+		{
+			topic:       TopicConfig{Filter: "devices/telemetry", Attributes: []string{"device.id"}},
+			expectedErr: `filter "devices/telemetry" has 0 wildcards, but 1 attributes are specified`,
+		},
+		{
+			topic:       TopicConfig{Filter: "$share//devices/+"},
+			expectedErr: `filter "$share//devices/+" has an invalid shared subscription group`,
+		},
+		{
+			topic:       TopicConfig{Filter: "$share/otelcol"},
+			expectedErr: `filter "$share/otelcol" has an invalid shared subscription group`,
+		},
+		{
+			topic:       TopicConfig{Filter: "$share/otelcol/"},
+			expectedErr: "filter must be specified",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.topic.Filter, func(t *testing.T) {
+			_, err := parseTopicFilter(tt.topic)
+			assert.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/natsreceiver"
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/nats"
+)
+
+var _ component.Config = (*Config)(nil)
+
+// Config defines configuration for the NATS receiver.
+type Config struct {
+	nats.ClientConfig `mapstructure:",squash"`
+
+	// Logs holds configuration about how logs should be consumed.
+	Logs SignalConfig `mapstructure:"logs"`
+
+	// Metrics holds configuration about how metrics should be consumed.
+	Metrics SignalConfig `mapstructure:"metrics"`
+
+	// Traces holds configuration about how traces should be consumed.
+	Traces SignalConfig `mapstructure:"traces"`
+
+	// Consumer holds configuration shared by the JetStream consumers of
+	// all signals.
+	Consumer ConsumerConfig `mapstructure:"consumer"`
+}
+
+// SignalConfig holds the JetStream consumer configuration of a signal.
+type SignalConfig struct {
+	// Stream holds the name of the JetStream stream to consume from. If
+	// empty, the stream is looked up from the subject.
+	Stream string `mapstructure:"stream"`
+
+	// Subject holds the subject filter of the consumer, which may contain
+	// wildcards.
+	Subject string `mapstructure:"subject"`
+
+	// Durable holds the name of the durable consumer. Receivers using the
+	// same durable consumer share its messages, and it keeps track of the
+	// messages which were acknowledged across restarts.
+	Durable string `mapstructure:"durable"`
+
+	// Encoding holds the encoding of the messages: otlp_proto, otlp_json,
+	// or the ID of an encoding extension.
+	Encoding string `mapstructure:"encoding"`
+}
+
+// ConsumerConfig holds configuration shared by the JetStream consumers.
+type ConsumerConfig struct {
+	// AckWait is how long the server waits for a message to be
+	// acknowledged before redelivering it.
+	AckWait time.Duration `mapstructure:"ack_wait"`
+
+	// MaxDeliver is the maximum number of times a message is delivered,
+	// or -1 for no limit.
+	MaxDeliver int `mapstructure:"max_deliver"`
+
+	// BatchSize is the maximum number of messages pulled from the server
+	// at once.
+	BatchSize int `mapstructure:"batch_size"`
+
+	// RedeliveryDelay is how long the server waits before redelivering a
+	// message which failed to be consumed with a non-permanent error.
+	RedeliveryDelay time.Duration `mapstructure:"redelivery_delay"`
+}
+
+func (c SignalConfig) validate() error {
+	if c.Subject == "" {
+		return errors.New("subject must be specified")
+	}
+	if c.Durable == "" {
+		return errors.New("durable must be specified")
+	}
+	if strings.ContainsAny(c.Durable, ".*> \t\r\n/\\") {
+		return fmt.Errorf("durable %q must not contain '.', '*', '>', whitespace or path separators", c.Durable)
+	}
+	if c.Encoding == "" {
+		return errors.New("encoding must be specified")
+	}
+	return nil
+}
+
+// Validate checks the receiver configuration is valid.
+func (cfg *Config) Validate() error {
+	var errs []error
+	if err := cfg.Logs.validate(); err != nil {
+		errs = append(errs, fmt.Errorf("logs::%w", err))
+	}
+	if err := cfg.Metrics.validate(); err != nil {
+		errs = append(errs, fmt.Errorf("metrics::%w", err))
+	}
+	if err := cfg.Traces.validate(); err != nil {
+		errs = append(errs, fmt.Errorf("traces::%w", err))
+	}
+	if cfg.Consumer.AckWait <= 0 {
+		errs = append(errs, errors.New("consumer::ack_wait must be positive"))
+	}
+	if cfg.Consumer.MaxDeliver == 0 || cfg.Consumer.MaxDeliver < -1 {
+		errs = append(errs, errors.New("consumer::max_deliver must be positive, or -1 for no limit"))
+	}
+	if cfg.Consumer.BatchSize <= 0 {
+		errs = append(errs, errors.New("consumer::batch_size must be positive"))
+	}
+	if cfg.Consumer.RedeliveryDelay < 0 {
+		errs = append(errs, errors.New("consumer::redelivery_delay must not be negative"))
+	}
+	return errors.Join(errs...)
+}
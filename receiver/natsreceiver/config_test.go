@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsreceiver
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/nats"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/natsreceiver/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id          component.ID
+		expected    component.Config
+		expectedErr string
+	}{
+		{
+			id:       component.NewIDWithName(metadata.Type, ""),
+			expected: createDefaultConfig(),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "custom"),
+			expected: &Config{
+				ClientConfig: func() nats.ClientConfig {
+					config := nats.NewDefaultClientConfig()
+					config.URL = "nats://nats-1:4222,nats://nats-2:4222"
+					config.ConnectTimeout = 5 * time.Second
+					config.Auth.Username = "user"
+					config.Auth.Password = "pass"
+					return config
+				}(),
+				Logs: SignalConfig{
+					Stream:   "TELEMETRY",
+					Subject:  "telemetry.logs.>",
+					Durable:  "site_logs",
+					Encoding: "otlp_json",
+				},
+				Metrics: SignalConfig{
+					Subject:  "otlp.metrics",
+					Durable:  "otelcol_metrics",
+					Encoding: "otlp_proto",
+				},
+				Traces: SignalConfig{
+					Subject:  "telemetry.traces.>",
+					Durable:  "otelcol_traces",
+					Encoding: "otlp_proto",
+				},
+				Consumer: ConsumerConfig{
+					AckWait:         time.Minute,
+					MaxDeliver:      10,
+					BatchSize:       500,
+					RedeliveryDelay: 5 * time.Second,
+				},
+			},
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "invalid_durable"),
+			expectedErr: `logs::durable "site.logs" must not contain '.', '*', '>', whitespace or path separators`,
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "invalid_consumer"),
+			expectedErr: "metrics::subject must be specified\n" +
+				"consumer::ack_wait must be positive\n" +
+				"consumer::max_deliver must be positive, or -1 for no limit\n" +
+				"consumer::batch_size must be positive\n" +
+				"consumer::redelivery_delay must not be negative",
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "invalid_auth"),
+			expectedErr: "only one of auth::username, auth::token and auth::credentials_file may be specified",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			t.Parallel()
+
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			err = xconfmap.Validate(cfg)
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/natsreceiver"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/nats"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/natsreceiver/internal/metadata"
+)
+
+const (
+	defaultLogsSubject     = "otlp.logs"
+	defaultLogsDurable     = "otelcol_logs"
+	defaultLogsEncoding    = "otlp_proto"
+	defaultMetricsSubject  = "otlp.metrics"
+	defaultMetricsDurable  = "otelcol_metrics"
+	defaultMetricsEncoding = "otlp_proto"
+	defaultTracesSubject   = "otlp.traces"
+	defaultTracesDurable   = "otelcol_traces"
+	defaultTracesEncoding  = "otlp_proto"
+
+	defaultAckWait         = 30 * time.Second
+	defaultMaxDeliver      = -1
+	defaultBatchSize       = 100
+	defaultRedeliveryDelay = time.Second
+)
+
+// NewFactory creates NATS receiver factory.
+func NewFactory() receiver.Factory {
+	return receiver.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		receiver.WithTraces(createTracesReceiver, metadata.TracesStability),
+		receiver.WithMetrics(createMetricsReceiver, metadata.MetricsStability),
+		receiver.WithLogs(createLogsReceiver, metadata.LogsStability),
+	)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		ClientConfig: nats.NewDefaultClientConfig(),
+		Logs: SignalConfig{
+			Subject:  defaultLogsSubject,
+			Durable:  defaultLogsDurable,
+			Encoding: defaultLogsEncoding,
+		},
+		Metrics: SignalConfig{
+			Subject:  defaultMetricsSubject,
+			Durable:  defaultMetricsDurable,
+			Encoding: defaultMetricsEncoding,
+		},
+		Traces: SignalConfig{
+			Subject:  defaultTracesSubject,
+			Durable:  defaultTracesDurable,
+			Encoding: defaultTracesEncoding,
+		},
+		Consumer: ConsumerConfig{
+			AckWait:         defaultAckWait,
+			MaxDeliver:      defaultMaxDeliver,
+			BatchSize:       defaultBatchSize,
+			RedeliveryDelay: defaultRedeliveryDelay,
+		},
+	}
+}
+
+func createTracesReceiver(
+	_ context.Context,
+	set receiver.Settings,
+	cfg component.Config,
+	nextConsumer consumer.Traces,
+) (receiver.Traces, error) {
+	return newTracesReceiver(cfg.(*Config), set, nextConsumer), nil
+}
+
+func createMetricsReceiver(
+	_ context.Context,
+	set receiver.Settings,
+	cfg component.Config,
+	nextConsumer consumer.Metrics,
+) (receiver.Metrics, error) {
+	return newMetricsReceiver(cfg.(*Config), set, nextConsumer), nil
+}
+
+func createLogsReceiver(
+	_ context.Context,
+	set receiver.Settings,
+	cfg component.Config,
+	nextConsumer consumer.Logs,
+) (receiver.Logs, error) {
+	return newLogsReceiver(cfg.(*Config), set, nextConsumer), nil
+}
@@ -0,0 +1,229 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/natsreceiver"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	natsgo "github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/encodingutil"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/messagehandler"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/nats"
+)
+
+const transport = "nats"
+
+type consumeMessageFunc func(ctx context.Context, msg jetstream.Msg) error
+
+type newConsumeMessageFunc func(host component.Host, obsrecv *receiverhelper.ObsReport) (consumeMessageFunc, error)
+
+// natsReceiver consumes the messages of a signal from a durable JetStream
+// pull consumer. Messages are acknowledged once they were consumed by the
+// next consumer, terminated if consuming them failed with a permanent
+// error, and negatively acknowledged to be redelivered otherwise.
+type natsReceiver struct {
+	config         *Config
+	signal         SignalConfig
+	settings       receiver.Settings
+	newConsumeFunc newConsumeMessageFunc
+
+	conn     *natsgo.Conn
+	messages jetstream.MessagesContext
+	cancel   context.CancelFunc
+	done     chan struct{}
+}
+
+func newLogsReceiver(config *Config, set receiver.Settings, nextConsumer consumer.Logs) *natsReceiver {
+	newConsumeFunc := func(host component.Host, obsrecv *receiverhelper.ObsReport) (consumeMessageFunc, error) {
+		unmarshaler, err := encodingutil.NewLogsUnmarshaler(config.Logs.Encoding, host)
+		if err != nil {
+			return nil, err
+		}
+		handler := messagehandler.NewLogs(config.Logs.Encoding, unmarshaler, obsrecv, nextConsumer)
+		return func(ctx context.Context, msg jetstream.Msg) error {
+			logMessage(set.Logger, msg)
+			return handler.Handle(ctx, msg.Data(), nil)
+		}, nil
+	}
+	return newReceiver(config, config.Logs, set, newConsumeFunc)
+}
+
+func newMetricsReceiver(config *Config, set receiver.Settings, nextConsumer consumer.Metrics) *natsReceiver {
+	newConsumeFunc := func(host component.Host, obsrecv *receiverhelper.ObsReport) (consumeMessageFunc, error) {
+		unmarshaler, err := encodingutil.NewMetricsUnmarshaler(config.Metrics.Encoding, host)
+		if err != nil {
+			return nil, err
+		}
+		handler := messagehandler.NewMetrics(config.Metrics.Encoding, unmarshaler, obsrecv, nextConsumer)
+		return func(ctx context.Context, msg jetstream.Msg) error {
+			logMessage(set.Logger, msg)
+			return handler.Handle(ctx, msg.Data(), nil)
+		}, nil
+	}
+	return newReceiver(config, config.Metrics, set, newConsumeFunc)
+}
+
+func newTracesReceiver(config *Config, set receiver.Settings, nextConsumer consumer.Traces) *natsReceiver {
+	newConsumeFunc := func(host component.Host, obsrecv *receiverhelper.ObsReport) (consumeMessageFunc, error) {
+		unmarshaler, err := encodingutil.NewTracesUnmarshaler(config.Traces.Encoding, host)
+		if err != nil {
+			return nil, err
+		}
+		handler := messagehandler.NewTraces(config.Traces.Encoding, unmarshaler, obsrecv, nextConsumer)
+		return func(ctx context.Context, msg jetstream.Msg) error {
+			logMessage(set.Logger, msg)
+			return handler.Handle(ctx, msg.Data(), nil)
+		}, nil
+	}
+	return newReceiver(config, config.Traces, set, newConsumeFunc)
+}
+
+func newReceiver(config *Config, signal SignalConfig, set receiver.Settings, newConsumeFunc newConsumeMessageFunc) *natsReceiver {
+	return &natsReceiver{
+		config:         config,
+		signal:         signal,
+		settings:       set,
+		newConsumeFunc: newConsumeFunc,
+	}
+}
+
+func (r *natsReceiver) Start(ctx context.Context, host component.Host) error {
+	obsrecv, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
+		ReceiverID:             r.settings.ID,
+		Transport:              transport,
+		ReceiverCreateSettings: r.settings,
+	})
+	if err != nil {
+		return err
+	}
+	consumeMessage, err := r.newConsumeFunc(host, obsrecv)
+	if err != nil {
+		return err
+	}
+
+	conn, err := nats.Connect(ctx, r.config.ClientConfig, r.settings.ID.String(), r.settings.Logger)
+	if err != nil {
+		return err
+	}
+	messages, err := r.subscribe(ctx, conn)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	r.conn = conn
+	r.messages = messages
+
+	consumeCtx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	r.done = make(chan struct{})
+	go r.consumeLoop(consumeCtx, consumeMessage)
+	return nil
+}
+
+// subscribe creates or updates the durable consumer of the signal, and
+// starts pulling its messages.
+func (r *natsReceiver) subscribe(ctx context.Context, conn *natsgo.Conn) (jetstream.MessagesContext, error) {
+	js, err := jetstream.New(conn)
+	if err != nil {
+		return nil, err
+	}
+	stream := r.signal.Stream
+	if stream == "" {
+		if stream, err = js.StreamNameBySubject(ctx, r.signal.Subject); err != nil {
+			return nil, fmt.Errorf("failed to find stream for subject %q: %w", r.signal.Subject, err)
+		}
+	}
+	cons, err := js.CreateOrUpdateConsumer(ctx, stream, jetstream.ConsumerConfig{
+		Durable:       r.signal.Durable,
+		FilterSubject: r.signal.Subject,
+		AckPolicy:     jetstream.AckExplicitPolicy,
+		AckWait:       r.config.Consumer.AckWait,
+		MaxDeliver:    r.config.Consumer.MaxDeliver,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create consumer %q on stream %q: %w", r.signal.Durable, stream, err)
+	}
+	return cons.Messages(jetstream.PullMaxMessages(r.config.Consumer.BatchSize))
+}
+
+func (r *natsReceiver) consumeLoop(ctx context.Context, consumeMessage consumeMessageFunc) {
+	defer close(r.done)
+	for {
+		msg, err := r.messages.Next()
+		if err != nil {
+			if errors.Is(err, jetstream.ErrMsgIteratorClosed) {
+				return
+			}
+			r.settings.Logger.Warn("failed to receive message", zap.Error(err))
+			continue
+		}
+		r.handleMessage(ctx, msg, consumeMessage)
+	}
+}
+
+func (r *natsReceiver) handleMessage(ctx context.Context, msg jetstream.Msg, consumeMessage consumeMessageFunc) {
+	err := consumeMessage(ctx, msg)
+	switch {
+	case err == nil:
+		err = msg.Ack()
+	case consumererror.IsPermanent(err):
+		r.settings.Logger.Error("failed to consume message, dropping it",
+			zap.String("subject", msg.Subject()),
+			zap.Error(err),
+		)
+		err = msg.Term()
+	default:
+		r.settings.Logger.Warn("failed to consume message, it will be redelivered",
+			zap.String("subject", msg.Subject()),
+			zap.Duration("delay", r.config.Consumer.RedeliveryDelay),
+			zap.Error(err),
+		)
+		err = msg.NakWithDelay(r.config.Consumer.RedeliveryDelay)
+	}
+	if err != nil {
+		r.settings.Logger.Error("failed to acknowledge message",
+			zap.String("subject", msg.Subject()),
+			zap.Error(err),
+		)
+	}
+}
+
+func (r *natsReceiver) Shutdown(context.Context) error {
+	if r.conn == nil {
+		return nil
+	}
+	// Stop pulling messages, and cancel the message being consumed, if any.
+	// Messages which were not acknowledged are redelivered after ack_wait.
+	r.messages.Stop()
+	r.cancel()
+	<-r.done
+	r.conn.Close()
+	return nil
+}
+
+// logMessage logs the subject and stream metadata of msg at debug level.
+func logMessage(logger *zap.Logger, msg jetstream.Msg) {
+	if !logger.Core().Enabled(zap.DebugLevel) {
+		return
+	}
+	fields := []zap.Field{zap.String("subject", msg.Subject())}
+	if metadata, err := msg.Metadata(); err == nil {
+		fields = append(fields,
+			zap.String("stream", metadata.Stream),
+			zap.Uint64("stream_sequence", metadata.Sequence.Stream),
+			zap.Uint64("num_delivered", metadata.NumDelivered),
+		)
+	}
+	logger.Debug("nats message received", fields...)
+}
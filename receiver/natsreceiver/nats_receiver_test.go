@@ -0,0 +1,223 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsreceiver
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/nats"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/nats/natstest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/natsreceiver/internal/metadata"
+)
+
+func TestReceiver_logs(t *testing.T) {
+	config, js := newTestConfig(t)
+	sink := &consumertest.LogsSink{}
+	r := newLogsReceiver(config, receivertest.NewNopSettings(metadata.Type), sink)
+	startReceiver(t, r, componenttest.NewNopHost())
+
+	logs := newLogs("log message")
+	payload, err := (&plog.ProtoMarshaler{}).MarshalLogs(logs)
+	require.NoError(t, err)
+	_, err = js.Publish(t.Context(), "otlp.logs", payload)
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool { return sink.LogRecordCount() == 1 }, 10*time.Second, 10*time.Millisecond)
+	assert.Equal(t, logs, sink.AllLogs()[0])
+	assertAcknowledged(t, js, "otelcol_logs", 1)
+}
+
+// TestReceiver_stream consumes from an explicitly configured stream, instead of
+// the stream looked up by subject.
+func TestReceiver_stream(t *testing.T) {
+	config, js := newTestConfig(t)
+	config.Traces.Stream = "OTLP"
+	sink := &consumertest.TracesSink{}
+	r := newTracesReceiver(config, receivertest.NewNopSettings(metadata.Type), sink)
+	startReceiver(t, r, componenttest.NewNopHost())
+
+	traces := ptrace.NewTraces()
+	traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("span")
+	payload, err := (&ptrace.ProtoMarshaler{}).MarshalTraces(traces)
+	require.NoError(t, err)
+	_, err = js.Publish(t.Context(), "otlp.traces", payload)
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool { return sink.SpanCount() == 1 }, 10*time.Second, 10*time.Millisecond)
+	assert.Equal(t, traces, sink.AllTraces()[0])
+	assertAcknowledged(t, js, "otelcol_traces", 1)
+}
+
+func TestReceiver_redeliveryOnError(t *testing.T) {
+	config, js := newTestConfig(t)
+	config.Consumer.RedeliveryDelay = 10 * time.Millisecond
+
+	var attempts atomic.Int64
+	sink := &consumertest.LogsSink{}
+	next, err := consumer.NewLogs(func(ctx context.Context, logs plog.Logs) error {
+		if attempts.Add(1) < 3 {
+			return errors.New("downstream unavailable")
+		}
+		return sink.ConsumeLogs(ctx, logs)
+	})
+	require.NoError(t, err)
+	r := newLogsReceiver(config, receivertest.NewNopSettings(metadata.Type), next)
+	startReceiver(t, r, componenttest.NewNopHost())
+
+	payload, err := (&plog.ProtoMarshaler{}).MarshalLogs(newLogs("log message"))
+	require.NoError(t, err)
+	_, err = js.Publish(t.Context(), "otlp.logs", payload)
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool { return sink.LogRecordCount() == 1 }, 10*time.Second, 10*time.Millisecond)
+	assert.Equal(t, int64(3), attempts.Load())
+	assertAcknowledged(t, js, "otelcol_logs", 1)
+}
+
+func TestReceiver_permanentError(t *testing.T) {
+	config, js := newTestConfig(t)
+	config.Consumer.RedeliveryDelay = 10 * time.Millisecond
+
+	var attempts atomic.Int64
+	next, err := consumer.NewLogs(func(context.Context, plog.Logs) error {
+		attempts.Add(1)
+		return consumererror.NewPermanent(errors.New("invalid data"))
+	})
+	require.NoError(t, err)
+	r := newLogsReceiver(config, receivertest.NewNopSettings(metadata.Type), next)
+	startReceiver(t, r, componenttest.NewNopHost())
+
+	payload, err := (&plog.ProtoMarshaler{}).MarshalLogs(newLogs("log message"))
+	require.NoError(t, err)
+	_, err = js.Publish(t.Context(), "otlp.logs", payload)
+	require.NoError(t, err)
+	// Messages which fail to be unmarshaled are not passed to the next consumer.
+	_, err = js.Publish(t.Context(), "otlp.logs", []byte("invalid"))
+	require.NoError(t, err)
+
+	// Terminated messages are not redelivered.
+	assertAcknowledged(t, js, "otelcol_logs", 2)
+	assert.Equal(t, int64(1), attempts.Load())
+}
+
+func TestReceiver_encodingExtension(t *testing.T) {
+	config, js := newTestConfig(t)
+	config.Logs.Encoding = "text_encoding"
+	sink := &consumertest.LogsSink{}
+	r := newLogsReceiver(config, receivertest.NewNopSettings(metadata.Type), sink)
+	startReceiver(t, r, extensionsHost{
+		component.MustNewID("text_encoding"): textLogsUnmarshalerExtension{},
+	})
+
+	_, err := js.Publish(t.Context(), "otlp.logs", []byte("log message"))
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool { return sink.LogRecordCount() == 1 }, 10*time.Second, 10*time.Millisecond)
+	assert.Equal(t, newLogs("log message"), sink.AllLogs()[0])
+}
+
+func TestReceiver_startErrors(t *testing.T) {
+	t.Run("unknown encoding", func(t *testing.T) {
+		config, _ := newTestConfig(t)
+		config.Logs.Encoding = "unknown"
+		r := newLogsReceiver(config, receivertest.NewNopSettings(metadata.Type), consumertest.NewNop())
+		err := r.Start(t.Context(), componenttest.NewNopHost())
+		assert.EqualError(t, err, `unrecognized logs encoding "unknown"`)
+		assert.NoError(t, r.Shutdown(t.Context()))
+	})
+	t.Run("no stream for subject", func(t *testing.T) {
+		config, _ := newTestConfig(t)
+		config.Logs.Subject = "other.logs"
+		r := newLogsReceiver(config, receivertest.NewNopSettings(metadata.Type), consumertest.NewNop())
+		err := r.Start(t.Context(), componenttest.NewNopHost())
+		assert.ErrorContains(t, err, `failed to find stream for subject "other.logs"`)
+		assert.NoError(t, r.Shutdown(t.Context()))
+	})
+	t.Run("connection refused", func(t *testing.T) {
+		config := createDefaultConfig().(*Config)
+		config.URL = "nats://127.0.0.1:1"
+		r := newLogsReceiver(config, receivertest.NewNopSettings(metadata.Type), consumertest.NewNop())
+		err := r.Start(t.Context(), componenttest.NewNopHost())
+		assert.ErrorContains(t, err, "failed to connect to NATS server")
+		assert.NoError(t, r.Shutdown(t.Context()))
+	})
+}
+
+// newTestConfig starts a NATS server with an "OTLP" stream capturing the
+// "otlp.>" subjects, and returns the default configuration to connect to it.
+func newTestConfig(tb testing.TB) (*Config, jetstream.JetStream) {
+	_, clientConfig := natstest.NewServer(tb)
+	conn, err := nats.Connect(tb.Context(), clientConfig, "test", zap.NewNop())
+	require.NoError(tb, err)
+	tb.Cleanup(conn.Close)
+	js, err := jetstream.New(conn)
+	require.NoError(tb, err)
+	_, err = js.CreateStream(tb.Context(), jetstream.StreamConfig{
+		Name:     "OTLP",
+		Subjects: []string{"otlp.>"},
+	})
+	require.NoError(tb, err)
+
+	config := createDefaultConfig().(*Config)
+	config.ClientConfig = clientConfig
+	return config, js
+}
+
+func startReceiver(tb testing.TB, r component.Component, host component.Host) {
+	require.NoError(tb, r.Start(tb.Context(), host))
+	tb.Cleanup(func() {
+		assert.NoError(tb, r.Shutdown(context.Background()))
+	})
+}
+
+// assertAcknowledged waits for the durable consumer to have acknowledged
+// or terminated n messages, with none pending.
+func assertAcknowledged(tb testing.TB, js jetstream.JetStream, durable string, n uint64) {
+	assert.Eventually(tb, func() bool {
+		cons, err := js.Consumer(tb.Context(), "OTLP", durable)
+		if err != nil {
+			return false
+		}
+		info := cons.CachedInfo()
+		return info.AckFloor.Stream == n && info.NumAckPending == 0 && info.NumPending == 0
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
+func newLogs(body string) plog.Logs {
+	logs := plog.NewLogs()
+	logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr(body)
+	return logs
+}
+
+type textLogsUnmarshalerExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+}
+
+func (textLogsUnmarshalerExtension) UnmarshalLogs(data []byte) (plog.Logs, error) {
+	return newLogs(string(data)), nil
+}
+
+type extensionsHost map[component.ID]component.Component
+
+func (h extensionsHost) GetExtensions() map[component.ID]component.Component {
+	return h
+}
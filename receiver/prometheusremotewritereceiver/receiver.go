@@ -18,12 +18,14 @@ import (
 	promconfig "github.com/prometheus/prometheus/config"
 	"github.com/prometheus/prometheus/model/labels"
 	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/prompb"
 	writev2 "github.com/prometheus/prometheus/prompb/io/prometheus/write/v2"
 	"github.com/prometheus/prometheus/schema"
 	promremote "github.com/prometheus/prometheus/storage/remote"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componentstatus"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/receiver"
@@ -162,7 +164,7 @@ func (prw *prometheusRemoteWriteReceiver) handlePRW(w http.ResponseWriter, req *
 		http.Error(w, err.Error(), http.StatusUnsupportedMediaType)
 		return
 	}
-	if msgType != promconfig.RemoteWriteProtoMsgV2 {
+	if msgType != promconfig.RemoteWriteProtoMsgV1 && msgType != promconfig.RemoteWriteProtoMsgV2 {
 		prw.settings.Logger.Warn("message received with unsupported proto version, rejecting")
 		http.Error(w, "Unsupported proto version", http.StatusUnsupportedMediaType)
 		return
@@ -178,28 +180,59 @@ func (prw *prometheusRemoteWriteReceiver) handlePRW(w http.ResponseWriter, req *
 		return
 	}
 
-	var prw2Req writev2.Request
-	if err = proto.Unmarshal(body, &prw2Req); err != nil {
-		prw.settings.Logger.Warn("Error decoding remote write request", zapcore.Field{Key: "error", Type: zapcore.ErrorType, Interface: err})
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
+	var (
+		m     pmetric.Metrics
+		stats promremote.WriteResponseStats
+	)
+	if msgType == promconfig.RemoteWriteProtoMsgV1 {
+		var prw1Req prompb.WriteRequest
+		if err = proto.Unmarshal(body, &prw1Req); err != nil {
+			prw.settings.Logger.Warn("Error decoding remote write request", zapcore.Field{Key: "error", Type: zapcore.ErrorType, Interface: err})
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		m, stats, err = prw.translateV1(req.Context(), &prw1Req)
+	} else {
+		var prw2Req writev2.Request
+		if err = proto.Unmarshal(body, &prw2Req); err != nil {
+			prw.settings.Logger.Warn("Error decoding remote write request", zapcore.Field{Key: "error", Type: zapcore.ErrorType, Interface: err})
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		m, stats, err = prw.translateV2(req.Context(), &prw2Req)
 	}
-
-	m, stats, err := prw.translateV2(req.Context(), &prw2Req)
-	stats.SetHeaders(w)
 	if err != nil {
+		stats.SetHeaders(w)
 		http.Error(w, err.Error(), http.StatusBadRequest) // Following instructions at https://prometheus.io/docs/specs/remote_write_spec_2_0/#invalid-samples
 		return
 	}
 
-	w.WriteHeader(http.StatusNoContent)
-
+	// The response is only written once the pipeline accepted or refused the metrics, so that the sender
+	// retries the requests refused by the pipeline instead of losing them.
 	obsrecvCtx := prw.obsrecv.StartMetricsOp(req.Context())
 	err = prw.nextConsumer.ConsumeMetrics(req.Context(), m)
+	prw.obsrecv.EndMetricsOp(obsrecvCtx, "prometheusremotewritereceiver", m.ResourceMetrics().Len(), err)
 	if err != nil {
 		prw.settings.Logger.Error("Error consuming metrics", zapcore.Field{Key: "error", Type: zapcore.ErrorType, Interface: err})
+		// Nothing was written, the pipeline accepts or refuses the request as a whole.
+		refused := promremote.WriteResponseStats{Confirmed: true}
+		refused.SetHeaders(w)
+		http.Error(w, err.Error(), consumerErrorStatusCode(err))
+		return
 	}
-	prw.obsrecv.EndMetricsOp(obsrecvCtx, "prometheusremotewritereceiver", m.ResourceMetrics().Len(), err)
+
+	stats.SetHeaders(w)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// consumerErrorStatusCode returns the status code of the response to a request refused by the next consumer.
+// Prometheus retries the requests answered with a 5xx status code, permanent errors are answered with a 4xx
+// status code so that the request is not retried.
+func consumerErrorStatusCode(err error) int {
+	if consumererror.IsPermanent(err) {
+		return http.StatusBadRequest
+	}
+	return http.StatusServiceUnavailable
 }
 
 // parseProto parses the content-type header and returns the version of the remote-write protocol.
@@ -269,6 +302,24 @@ func (prw *prometheusRemoteWriteReceiver) getOrCreateRM(ls labels.Labels, otelMe
 	return rm, hashedLabels
 }
 
+// addTargetInfo adds the labels of a target_info series as attributes of the resource identified by
+// its job/instance labels, and snapshots them into the LRU cache for the next requests.
+func (prw *prometheusRemoteWriteReceiver) addTargetInfo(ls labels.Labels, otelMetrics pmetric.Metrics, reqRM map[uint64]pmetric.ResourceMetrics) {
+	rm, hashed := prw.getOrCreateRM(ls, otelMetrics, reqRM)
+	attrs := rm.Resource().Attributes()
+
+	// Add the remaining labels as resource attributes
+	for labelName, labelValue := range ls.Map() {
+		if labelName != "job" && labelName != "instance" && !schema.IsMetadataLabel(labelName) {
+			attrs.PutStr(labelName, labelValue)
+		}
+	}
+
+	snapshot := pmetric.NewResourceMetrics()
+	attrs.CopyTo(snapshot.Resource().Attributes())
+	prw.rmCache.Add(hashed, snapshot)
+}
+
 // translateV2 translates a v2 remote-write request into OTLP metrics.
 // translate is not feature complete.
 func (prw *prometheusRemoteWriteReceiver) translateV2(_ context.Context, req *writev2.Request) (pmetric.Metrics, promremote.WriteResponseStats, error) {
@@ -309,19 +360,7 @@ func (prw *prometheusRemoteWriteReceiver) translateV2(_ context.Context, req *wr
 		// If the metric name is equal to target_info, we use its labels as attributes of the resource
 		// Ref: https://opentelemetry.io/docs/specs/otel/compatibility/prometheus_and_openmetrics/#resource-attributes-1
 		if metadata.Name == "target_info" {
-			rm, hashed := prw.getOrCreateRM(ls, otelMetrics, modifiedResourceMetric)
-			attrs := rm.Resource().Attributes()
-
-			// Add the remaining labels as resource attributes
-			for labelName, labelValue := range ls.Map() {
-				if labelName != "job" && labelName != "instance" && !schema.IsMetadataLabel(labelName) {
-					attrs.PutStr(labelName, labelValue)
-				}
-			}
-
-			snapshot := pmetric.NewResourceMetrics()
-			attrs.CopyTo(snapshot.Resource().Attributes())
-			prw.rmCache.Add(hashed, snapshot)
+			prw.addTargetInfo(ls, otelMetrics, modifiedResourceMetric)
 			continue
 		}
 
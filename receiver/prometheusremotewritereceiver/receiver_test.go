@@ -6,6 +6,7 @@ package prometheusremotewritereceiver // import "github.com/open-telemetry/opent
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"math"
@@ -25,6 +26,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
@@ -122,9 +124,9 @@ func TestHandlePRWContentTypeNegotiation(t *testing.T) {
 		{
 			name:         "x-protobuf/no proto parameter",
 			contentType:  "application/x-protobuf",
-			expectedCode: http.StatusUnsupportedMediaType,
+			expectedCode: http.StatusNoContent,
 			expectedStats: remote.WriteResponseStats{
-				Confirmed:  false,
+				Confirmed:  true,
 				Samples:    0,
 				Histograms: 0,
 				Exemplars:  0,
@@ -133,9 +135,9 @@ func TestHandlePRWContentTypeNegotiation(t *testing.T) {
 		{
 			name:         "x-protobuf/v1 proto parameter",
 			contentType:  fmt.Sprintf("application/x-protobuf;proto=%s", promconfig.RemoteWriteProtoMsgV1),
-			expectedCode: http.StatusUnsupportedMediaType,
+			expectedCode: http.StatusNoContent,
 			expectedStats: remote.WriteResponseStats{
-				Confirmed:  false,
+				Confirmed:  true,
 				Samples:    0,
 				Histograms: 0,
 				Exemplars:  0,
@@ -182,6 +184,60 @@ func TestHandlePRWContentTypeNegotiation(t *testing.T) {
 	}
 }
 
+func TestHandlePRWConsumerErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name            string
+		consumerErr     error
+		expectedCode    int
+		expectedSamples string
+	}{
+		{
+			name:            "accepted",
+			expectedCode:    http.StatusNoContent,
+			expectedSamples: "1",
+		},
+		{
+			name:            "retryable error",
+			consumerErr:     errors.New("memory limit exceeded"),
+			expectedCode:    http.StatusServiceUnavailable,
+			expectedSamples: "0",
+		},
+		{
+			name:            "permanent error",
+			consumerErr:     consumererror.NewPermanent(errors.New("invalid data")),
+			expectedCode:    http.StatusBadRequest,
+			expectedSamples: "0",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			prwReceiver := setupMetricsReceiver(t)
+			prwReceiver.nextConsumer = consumertest.NewErr(tc.consumerErr)
+
+			body := writev2.Request{
+				Symbols: []string{"", "__name__", "test_metric", "job", "test_job", "instance", "test_instance"},
+				Timeseries: []writev2.TimeSeries{
+					{
+						Metadata:   writev2.Metadata{Type: writev2.Metadata_METRIC_TYPE_GAUGE},
+						LabelsRefs: []uint32{1, 2, 3, 4, 5, 6},
+						Samples:    []writev2.Sample{{Value: 1, Timestamp: 1}},
+					},
+				},
+			}
+			pBuf := proto.NewBuffer(nil)
+			assert.NoError(t, pBuf.Marshal(&body))
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/write", bytes.NewBuffer(pBuf.Bytes()))
+			req.Header.Set("Content-Type", fmt.Sprintf("application/x-protobuf;proto=%s", promconfig.RemoteWriteProtoMsgV2))
+			w := httptest.NewRecorder()
+			prwReceiver.handlePRW(w, req)
+			resp := w.Result()
+
+			assert.Equal(t, tc.expectedCode, resp.StatusCode)
+			assert.Equal(t, tc.expectedSamples, resp.Header.Get("X-Prometheus-Remote-Write-Samples-Written"))
+		})
+	}
+}
+
 func TestTranslateV2(t *testing.T) {
 	prwReceiver := setupMetricsReceiver(t)
 	ctx, cancel := context.WithCancel(t.Context())
@@ -0,0 +1,476 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewritereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusremotewritereceiver"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/prompb"
+	writev2 "github.com/prometheus/prometheus/prompb/io/prometheus/write/v2"
+	promremote "github.com/prometheus/prometheus/storage/remote"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/identity"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheus"
+)
+
+const (
+	bucketLabel   = "le"
+	quantileLabel = "quantile"
+)
+
+// classicPart identifies which part of a classic histogram or summary a v1 series holds.
+type classicPart int
+
+const (
+	notClassic classicPart = iota
+	classicBucket
+	classicQuantile
+	classicSum
+	classicCount
+)
+
+var classicSuffixes = []struct {
+	suffix string
+	part   classicPart
+}{
+	{"_bucket", classicBucket},
+	{"_sum", classicSum},
+	{"_count", classicCount},
+}
+
+// classicDataPoint collects the series of a classic histogram or summary sharing the same labels and
+// timestamp, they are turned into a single OTLP data point once the whole request is processed.
+type classicDataPoint struct {
+	metadata prompb.MetricMetadata
+	// ls are the labels of the series, named after the metric family and without the le/quantile label.
+	ls        labels.Labels
+	timestamp int64
+	buckets   map[float64]float64
+	quantiles map[float64]float64
+	sum       float64
+	count     float64
+	hasSum    bool
+	hasCount  bool
+	stale     bool
+}
+
+type classicDataPointKey struct {
+	labelsHash uint64
+	timestamp  int64
+}
+
+// translateV1 translates a v1 remote-write request into OTLP metrics.
+//
+// Remote-Write 1.0 sends the metadata of the metric families separately from the time series, when
+// the metadata of a series is missing its type is inferred from the other series of the request: the
+// series of classic histograms are recognized by their le label and summaries by their quantile label.
+// The _bucket, _sum and _count series of classic histograms and summaries are grouped back into OTLP
+// histograms and summaries, which requires all the series of a data point to be part of the request.
+func (prw *prometheusRemoteWriteReceiver) translateV1(_ context.Context, req *prompb.WriteRequest) (pmetric.Metrics, promremote.WriteResponseStats, error) {
+	var (
+		badRequestErrors error
+		otelMetrics      = pmetric.NewMetrics()
+		labelsBuilder    = labels.NewScratchBuilder(0)
+		stats            = promremote.WriteResponseStats{
+			Confirmed: true,
+		}
+		metricCache            = make(map[uint64]pmetric.Metric)
+		modifiedResourceMetric = make(map[uint64]pmetric.ResourceMetrics)
+		families               = make(map[string]prompb.MetricMetadata, len(req.Metadata))
+		classicDataPoints      = make(map[classicDataPointKey]*classicDataPoint)
+		// classicOrder keeps the order in which the classic data points were first seen.
+		classicOrder []*classicDataPoint
+	)
+
+	for _, md := range req.Metadata {
+		families[md.MetricFamilyName] = md
+	}
+
+	seriesLabels := make([]labels.Labels, len(req.Timeseries))
+	for i := range req.Timeseries {
+		ls := toLabels(&labelsBuilder, req.Timeseries[i].Labels)
+		seriesLabels[i] = ls
+		// Infer the type of the families without metadata from the le and quantile labels.
+		name := ls.Get(labels.MetricName)
+		if base, ok := strings.CutSuffix(name, "_bucket"); ok && ls.Has(bucketLabel) {
+			if _, found := families[base]; !found {
+				families[base] = prompb.MetricMetadata{Type: prompb.MetricMetadata_HISTOGRAM, MetricFamilyName: base}
+			}
+		} else if ls.Has(quantileLabel) {
+			if _, found := families[name]; !found {
+				families[name] = prompb.MetricMetadata{Type: prompb.MetricMetadata_SUMMARY, MetricFamilyName: name}
+			}
+		}
+	}
+
+	for i := range req.Timeseries {
+		ts := &req.Timeseries[i]
+		ls := seriesLabels[i]
+		metricName := ls.Get(labels.MetricName)
+		if metricName == "" {
+			badRequestErrors = errors.Join(badRequestErrors, errors.New("missing metric name in labels"))
+			continue
+		} else if duplicateLabel, hasDuplicate := ls.HasDuplicateLabelNames(); hasDuplicate {
+			badRequestErrors = errors.Join(badRequestErrors, fmt.Errorf("duplicate label %q in labels", duplicateLabel))
+			continue
+		}
+
+		// If the metric name is equal to target_info, we use its labels as attributes of the resource
+		// Ref: https://opentelemetry.io/docs/specs/otel/compatibility/prometheus_and_openmetrics/#resource-attributes-1
+		if metricName == "target_info" {
+			prw.addTargetInfo(ls, otelMetrics, modifiedResourceMetric)
+			continue
+		}
+
+		scopeName, scopeVersion := prw.extractScopeInfo(ls)
+		metadata, part := classifyV1Series(metricName, ls, families)
+
+		// Native histograms are translated the same way as the ones of v2 requests.
+		if len(ts.Histograms) > 0 {
+			v2TimeSeries := &writev2.TimeSeries{
+				Histograms: make([]writev2.Histogram, 0, len(ts.Histograms)),
+				Metadata:   writev2.Metadata{Type: writev2.Metadata_METRIC_TYPE_HISTOGRAM},
+			}
+			for j := range ts.Histograms {
+				v2TimeSeries.Histograms = append(v2TimeSeries.Histograms, histogramV1ToV2(&ts.Histograms[j]))
+			}
+			prw.processHistogramTimeSeries(otelMetrics, ls, v2TimeSeries, scopeName, scopeVersion, metricName, metadata.Unit, metadata.Help, metricCache, &stats, modifiedResourceMetric)
+			continue
+		}
+
+		if part != notClassic {
+			groupLabels := labels.NewBuilder(ls).Del(bucketLabel, quantileLabel).Set(labels.MetricName, metadata.MetricFamilyName).Labels()
+			var bound float64
+			if part == classicBucket || part == classicQuantile {
+				name := bucketLabel
+				if part == classicQuantile {
+					name = quantileLabel
+				}
+				var err error
+				bound, err = strconv.ParseFloat(ls.Get(name), 64)
+				if err != nil {
+					badRequestErrors = errors.Join(badRequestErrors, fmt.Errorf("invalid %s label for metric %q: %w", name, metricName, err))
+					continue
+				}
+			}
+			labelsHash := groupLabels.Hash()
+			for _, sample := range ts.Samples {
+				key := classicDataPointKey{labelsHash: labelsHash, timestamp: sample.Timestamp}
+				dp, ok := classicDataPoints[key]
+				if !ok {
+					dp = &classicDataPoint{
+						metadata:  metadata,
+						ls:        groupLabels,
+						timestamp: sample.Timestamp,
+						buckets:   make(map[float64]float64),
+						quantiles: make(map[float64]float64),
+					}
+					classicDataPoints[key] = dp
+					classicOrder = append(classicOrder, dp)
+				}
+				dp.add(part, bound, sample.Value)
+			}
+			stats.Samples += len(ts.Samples)
+			continue
+		}
+
+		rm, _ := prw.getOrCreateRM(ls, otelMetrics, modifiedResourceMetric)
+		scope := getOrCreateScope(rm, scopeName, scopeVersion)
+		metricType := writev2.Metadata_METRIC_TYPE_GAUGE
+		switch metadata.Type {
+		case prompb.MetricMetadata_COUNTER:
+			metricType = writev2.Metadata_METRIC_TYPE_COUNTER
+		case prompb.MetricMetadata_UNKNOWN:
+			metricType = writev2.Metadata_METRIC_TYPE_UNSPECIFIED
+		}
+		metricKey := createMetricIdentity(
+			identity.OfResource(rm.Resource()).String(),
+			scopeName,
+			scopeVersion,
+			metricName,
+			metadata.Unit,
+			metricType,
+		).Hash()
+
+		metric, exists := metricCache[metricKey]
+		if !exists {
+			metric = setMetric(scope, metricName, metadata.Unit, metadata.Help)
+			switch metricType {
+			case writev2.Metadata_METRIC_TYPE_COUNTER:
+				sum := metric.SetEmptySum()
+				sum.SetIsMonotonic(true)
+				sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+				metric.Metadata().PutStr(prometheus.MetricMetadataTypeKey, "counter")
+			case writev2.Metadata_METRIC_TYPE_UNSPECIFIED:
+				metric.SetEmptyGauge()
+				metric.Metadata().PutStr(prometheus.MetricMetadataTypeKey, "unknown")
+			default:
+				metric.SetEmptyGauge()
+				metric.Metadata().PutStr(prometheus.MetricMetadataTypeKey, "gauge")
+			}
+			metricCache[metricKey] = metric
+		}
+
+		if metricType == writev2.Metadata_METRIC_TYPE_COUNTER {
+			addV1NumberDatapoints(metric.Sum().DataPoints(), ls, ts.Samples, &stats)
+		} else {
+			addV1NumberDatapoints(metric.Gauge().DataPoints(), ls, ts.Samples, &stats)
+		}
+	}
+
+	for _, dp := range classicOrder {
+		prw.addClassicDataPoint(otelMetrics, dp, metricCache, modifiedResourceMetric)
+	}
+
+	return otelMetrics, stats, badRequestErrors
+}
+
+// classifyV1Series returns the metadata of the family a series belongs to, and which part of a classic
+// histogram or summary it holds.
+func classifyV1Series(name string, ls labels.Labels, families map[string]prompb.MetricMetadata) (prompb.MetricMetadata, classicPart) {
+	if md, ok := families[name]; ok {
+		switch md.Type {
+		case prompb.MetricMetadata_SUMMARY:
+			if ls.Has(quantileLabel) {
+				return md, classicQuantile
+			}
+		case prompb.MetricMetadata_HISTOGRAM:
+		default:
+			return md, notClassic
+		}
+	}
+	for _, s := range classicSuffixes {
+		base, ok := strings.CutSuffix(name, s.suffix)
+		if !ok {
+			continue
+		}
+		md := families[base]
+		switch {
+		case md.Type == prompb.MetricMetadata_HISTOGRAM && (s.part != classicBucket || ls.Has(bucketLabel)):
+			return md, s.part
+		case md.Type == prompb.MetricMetadata_SUMMARY && s.part != classicBucket:
+			return md, s.part
+		}
+	}
+	md, ok := families[name]
+	if !ok {
+		md = prompb.MetricMetadata{MetricFamilyName: name}
+	}
+	return md, notClassic
+}
+
+func (dp *classicDataPoint) add(part classicPart, bound, v float64) {
+	if value.IsStaleNaN(v) {
+		dp.stale = true
+		return
+	}
+	switch part {
+	case classicBucket:
+		dp.buckets[bound] = v
+	case classicQuantile:
+		dp.quantiles[bound] = v
+	case classicSum:
+		dp.sum, dp.hasSum = v, true
+	case classicCount:
+		dp.count, dp.hasCount = v, true
+	}
+}
+
+// addClassicDataPoint adds a classic histogram or summary data point to its metric.
+func (prw *prometheusRemoteWriteReceiver) addClassicDataPoint(
+	otelMetrics pmetric.Metrics,
+	dp *classicDataPoint,
+	metricCache map[uint64]pmetric.Metric,
+	modifiedRM map[uint64]pmetric.ResourceMetrics,
+) {
+	rm, _ := prw.getOrCreateRM(dp.ls, otelMetrics, modifiedRM)
+	scopeName, scopeVersion := prw.extractScopeInfo(dp.ls)
+	scope := getOrCreateScope(rm, scopeName, scopeVersion)
+
+	isHistogram := dp.metadata.Type == prompb.MetricMetadata_HISTOGRAM
+	metricType := writev2.Metadata_METRIC_TYPE_SUMMARY
+	if isHistogram {
+		metricType = writev2.Metadata_METRIC_TYPE_HISTOGRAM
+	}
+	metricName := dp.metadata.MetricFamilyName
+	metricKey := createMetricIdentity(
+		identity.OfResource(rm.Resource()).String(),
+		scopeName,
+		scopeVersion,
+		metricName,
+		dp.metadata.Unit,
+		metricType,
+	).Hash()
+
+	metric, exists := metricCache[metricKey]
+	if !exists {
+		metric = setMetric(scope, metricName, dp.metadata.Unit, dp.metadata.Help)
+		if isHistogram {
+			metric.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+			metric.Metadata().PutStr(prometheus.MetricMetadataTypeKey, "histogram")
+		} else {
+			metric.SetEmptySummary()
+			metric.Metadata().PutStr(prometheus.MetricMetadataTypeKey, "summary")
+		}
+		metricCache[metricKey] = metric
+	}
+
+	timestamp := pcommon.Timestamp(dp.timestamp * int64(time.Millisecond))
+	if isHistogram {
+		hdp := metric.Histogram().DataPoints().AppendEmpty()
+		hdp.SetTimestamp(timestamp)
+		extractAttributes(dp.ls).CopyTo(hdp.Attributes())
+		if dp.stale {
+			hdp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+			return
+		}
+		bounds, counts := classicBucketCounts(dp)
+		hdp.ExplicitBounds().FromRaw(bounds)
+		hdp.BucketCounts().FromRaw(counts)
+		if dp.hasCount {
+			hdp.SetCount(uint64(dp.count))
+		} else {
+			var count uint64
+			for _, c := range counts {
+				count += c
+			}
+			hdp.SetCount(count)
+		}
+		if dp.hasSum {
+			hdp.SetSum(dp.sum)
+		}
+		return
+	}
+
+	sdp := metric.Summary().DataPoints().AppendEmpty()
+	sdp.SetTimestamp(timestamp)
+	extractAttributes(dp.ls).CopyTo(sdp.Attributes())
+	if dp.stale {
+		sdp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+		return
+	}
+	sdp.SetCount(uint64(dp.count))
+	sdp.SetSum(dp.sum)
+	quantiles := make([]float64, 0, len(dp.quantiles))
+	for q := range dp.quantiles {
+		quantiles = append(quantiles, q)
+	}
+	slices.Sort(quantiles)
+	for _, q := range quantiles {
+		qv := sdp.QuantileValues().AppendEmpty()
+		qv.SetQuantile(q)
+		qv.SetValue(dp.quantiles[q])
+	}
+}
+
+// classicBucketCounts turns the cumulative counts of the buckets of a classic histogram into the
+// explicit bounds and bucket counts of an OTLP histogram. The count of the +Inf bucket falls back
+// to the _count series when the +Inf bucket is missing.
+func classicBucketCounts(dp *classicDataPoint) ([]float64, []uint64) {
+	bounds := make([]float64, 0, len(dp.buckets))
+	for le := range dp.buckets {
+		if !math.IsInf(le, 1) {
+			bounds = append(bounds, le)
+		}
+	}
+	slices.Sort(bounds)
+
+	counts := make([]uint64, len(bounds)+1)
+	var previous float64
+	for i, le := range bounds {
+		cumulative := dp.buckets[le]
+		counts[i] = uint64(max(cumulative-previous, 0))
+		previous = max(cumulative, previous)
+	}
+	if inf, ok := dp.buckets[math.Inf(1)]; ok {
+		counts[len(bounds)] = uint64(max(inf-previous, 0))
+	} else if dp.hasCount {
+		counts[len(bounds)] = uint64(max(dp.count-previous, 0))
+	}
+	return bounds, counts
+}
+
+// addV1NumberDatapoints adds the samples of a v1 series as datapoints, with the labels as attributes.
+func addV1NumberDatapoints(datapoints pmetric.NumberDataPointSlice, ls labels.Labels, samples []prompb.Sample, stats *promremote.WriteResponseStats) {
+	for _, sample := range samples {
+		dp := datapoints.AppendEmpty()
+		// Set timestamp in nanoseconds (Prometheus uses milliseconds)
+		dp.SetTimestamp(pcommon.Timestamp(sample.Timestamp * int64(time.Millisecond)))
+		dp.SetDoubleValue(sample.Value)
+		extractAttributes(ls).CopyTo(dp.Attributes())
+	}
+	stats.Samples += len(samples)
+}
+
+// getOrCreateScope returns the scope metrics of rm with the given name and version, creating it if needed.
+func getOrCreateScope(rm pmetric.ResourceMetrics, scopeName, scopeVersion string) pmetric.ScopeMetrics {
+	for i := 0; i < rm.ScopeMetrics().Len(); i++ {
+		s := rm.ScopeMetrics().At(i)
+		if s.Scope().Name() == scopeName && s.Scope().Version() == scopeVersion {
+			return s
+		}
+	}
+	scope := rm.ScopeMetrics().AppendEmpty()
+	scope.Scope().SetName(scopeName)
+	scope.Scope().SetVersion(scopeVersion)
+	return scope
+}
+
+// toLabels converts the labels of a v1 series, sorted by name.
+func toLabels(b *labels.ScratchBuilder, protoLabels []prompb.Label) labels.Labels {
+	b.Reset()
+	for _, l := range protoLabels {
+		b.Add(l.Name, l.Value)
+	}
+	b.Sort()
+	return b.Labels()
+}
+
+// histogramV1ToV2 converts a v1 native histogram into its v2 representation.
+func histogramV1ToV2(h *prompb.Histogram) writev2.Histogram {
+	v2 := writev2.Histogram{
+		Sum:            h.Sum,
+		Schema:         h.Schema,
+		ZeroThreshold:  h.ZeroThreshold,
+		NegativeSpans:  bucketSpansV1ToV2(h.NegativeSpans),
+		NegativeDeltas: h.NegativeDeltas,
+		NegativeCounts: h.NegativeCounts,
+		PositiveSpans:  bucketSpansV1ToV2(h.PositiveSpans),
+		PositiveDeltas: h.PositiveDeltas,
+		PositiveCounts: h.PositiveCounts,
+		ResetHint:      writev2.Histogram_ResetHint(h.ResetHint),
+		Timestamp:      h.Timestamp,
+		CustomValues:   h.CustomValues,
+	}
+	if h.IsFloatHistogram() {
+		v2.Count = &writev2.Histogram_CountFloat{CountFloat: h.GetCountFloat()}
+		v2.ZeroCount = &writev2.Histogram_ZeroCountFloat{ZeroCountFloat: h.GetZeroCountFloat()}
+	} else {
+		v2.Count = &writev2.Histogram_CountInt{CountInt: h.GetCountInt()}
+		v2.ZeroCount = &writev2.Histogram_ZeroCountInt{ZeroCountInt: h.GetZeroCountInt()}
+	}
+	return v2
+}
+
+func bucketSpansV1ToV2(spans []prompb.BucketSpan) []writev2.BucketSpan {
+	if len(spans) == 0 {
+		return nil
+	}
+	v2 := make([]writev2.BucketSpan, len(spans))
+	for i, span := range spans {
+		v2[i] = writev2.BucketSpan{Offset: span.Offset, Length: span.Length}
+	}
+	return v2
+}
@@ -0,0 +1,247 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewritereceiver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/prometheus/prometheus/storage/remote"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatatest/pmetrictest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheus"
+)
+
+func TestTranslateV1(t *testing.T) {
+	prwReceiver := setupMetricsReceiver(t)
+	ctx, cancel := context.WithCancel(t.Context())
+	t.Cleanup(cancel)
+
+	series := func(name string, value float64, extra ...string) prompb.TimeSeries {
+		ls := []prompb.Label{
+			{Name: "__name__", Value: name},
+			{Name: "job", Value: "test_job"},
+			{Name: "instance", Value: "test_instance"},
+		}
+		for i := 0; i+1 < len(extra); i += 2 {
+			ls = append(ls, prompb.Label{Name: extra[i], Value: extra[i+1]})
+		}
+		return prompb.TimeSeries{
+			Labels:  ls,
+			Samples: []prompb.Sample{{Value: value, Timestamp: 1000}},
+		}
+	}
+	newScopeMetrics := func(expected pmetric.Metrics) pmetric.ScopeMetrics {
+		rm := expected.ResourceMetrics().AppendEmpty()
+		rm.Resource().Attributes().PutStr("service.name", "test_job")
+		rm.Resource().Attributes().PutStr("service.instance.id", "test_instance")
+		sm := rm.ScopeMetrics().AppendEmpty()
+		sm.Scope().SetName("OpenTelemetry Collector")
+		sm.Scope().SetVersion("latest")
+		return sm
+	}
+
+	for _, tc := range []struct {
+		name            string
+		request         *prompb.WriteRequest
+		expectError     string
+		expectedMetrics pmetric.Metrics
+		expectedStats   remote.WriteResponseStats
+	}{
+		{
+			name: "missing metric name",
+			request: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{
+					{
+						Labels:  []prompb.Label{{Name: "foo", Value: "bar"}},
+						Samples: []prompb.Sample{{Value: 1, Timestamp: 1}},
+					},
+				},
+			},
+			expectError: "missing metric name in labels",
+		},
+		{
+			name: "counter and gauge with metadata",
+			request: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{
+					series("requests_total", 10, "method", "GET"),
+					series("temperature", 21.5),
+				},
+				Metadata: []prompb.MetricMetadata{
+					{Type: prompb.MetricMetadata_COUNTER, MetricFamilyName: "requests_total", Help: "Requests", Unit: "1"},
+					{Type: prompb.MetricMetadata_GAUGE, MetricFamilyName: "temperature", Help: "Temperature"},
+				},
+			},
+			expectedMetrics: func() pmetric.Metrics {
+				expected := pmetric.NewMetrics()
+				sm := newScopeMetrics(expected)
+
+				counter := sm.Metrics().AppendEmpty()
+				counter.SetName("requests_total")
+				counter.SetUnit("1")
+				counter.SetDescription("Requests")
+				counter.Metadata().PutStr(prometheus.MetricMetadataTypeKey, "counter")
+				sum := counter.SetEmptySum()
+				sum.SetIsMonotonic(true)
+				sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+				dp := sum.DataPoints().AppendEmpty()
+				dp.SetTimestamp(pcommon.Timestamp(1000 * int64(time.Millisecond)))
+				dp.SetDoubleValue(10)
+				dp.Attributes().PutStr("method", "GET")
+
+				gauge := sm.Metrics().AppendEmpty()
+				gauge.SetName("temperature")
+				gauge.SetDescription("Temperature")
+				gauge.Metadata().PutStr(prometheus.MetricMetadataTypeKey, "gauge")
+				dp = gauge.SetEmptyGauge().DataPoints().AppendEmpty()
+				dp.SetTimestamp(pcommon.Timestamp(1000 * int64(time.Millisecond)))
+				dp.SetDoubleValue(21.5)
+				return expected
+			}(),
+			expectedStats: remote.WriteResponseStats{
+				Confirmed: true,
+				Samples:   2,
+			},
+		},
+		{
+			name: "series without metadata are unknown gauges",
+			request: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{
+					series("up", 1),
+				},
+			},
+			expectedMetrics: func() pmetric.Metrics {
+				expected := pmetric.NewMetrics()
+				sm := newScopeMetrics(expected)
+				gauge := sm.Metrics().AppendEmpty()
+				gauge.SetName("up")
+				gauge.Metadata().PutStr(prometheus.MetricMetadataTypeKey, "unknown")
+				dp := gauge.SetEmptyGauge().DataPoints().AppendEmpty()
+				dp.SetTimestamp(pcommon.Timestamp(1000 * int64(time.Millisecond)))
+				dp.SetDoubleValue(1)
+				return expected
+			}(),
+			expectedStats: remote.WriteResponseStats{
+				Confirmed: true,
+				Samples:   1,
+			},
+		},
+		{
+			name: "classic histogram without metadata",
+			request: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{
+					series("request_duration_seconds_bucket", 2, "le", "1", "method", "GET"),
+					series("request_duration_seconds_sum", 10, "method", "GET"),
+					series("request_duration_seconds_bucket", 5, "le", "5", "method", "GET"),
+					series("request_duration_seconds_count", 6, "method", "GET"),
+					series("request_duration_seconds_bucket", 6, "le", "+Inf", "method", "GET"),
+				},
+			},
+			expectedMetrics: func() pmetric.Metrics {
+				expected := pmetric.NewMetrics()
+				sm := newScopeMetrics(expected)
+				metric := sm.Metrics().AppendEmpty()
+				metric.SetName("request_duration_seconds")
+				metric.Metadata().PutStr(prometheus.MetricMetadataTypeKey, "histogram")
+				hist := metric.SetEmptyHistogram()
+				hist.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+				dp := hist.DataPoints().AppendEmpty()
+				dp.SetTimestamp(pcommon.Timestamp(1000 * int64(time.Millisecond)))
+				dp.SetCount(6)
+				dp.SetSum(10)
+				dp.ExplicitBounds().FromRaw([]float64{1, 5})
+				dp.BucketCounts().FromRaw([]uint64{2, 3, 1})
+				dp.Attributes().PutStr("method", "GET")
+				return expected
+			}(),
+			expectedStats: remote.WriteResponseStats{
+				Confirmed: true,
+				Samples:   5,
+			},
+		},
+		{
+			name: "summary with metadata",
+			request: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{
+					series("rpc_duration_seconds", 3, "quantile", "0.9"),
+					series("rpc_duration_seconds", 1, "quantile", "0.5"),
+					series("rpc_duration_seconds_sum", 20),
+					series("rpc_duration_seconds_count", 10),
+				},
+				Metadata: []prompb.MetricMetadata{
+					{Type: prompb.MetricMetadata_SUMMARY, MetricFamilyName: "rpc_duration_seconds", Help: "RPC duration", Unit: "seconds"},
+				},
+			},
+			expectedMetrics: func() pmetric.Metrics {
+				expected := pmetric.NewMetrics()
+				sm := newScopeMetrics(expected)
+				metric := sm.Metrics().AppendEmpty()
+				metric.SetName("rpc_duration_seconds")
+				metric.SetUnit("seconds")
+				metric.SetDescription("RPC duration")
+				metric.Metadata().PutStr(prometheus.MetricMetadataTypeKey, "summary")
+				dp := metric.SetEmptySummary().DataPoints().AppendEmpty()
+				dp.SetTimestamp(pcommon.Timestamp(1000 * int64(time.Millisecond)))
+				dp.SetCount(10)
+				dp.SetSum(20)
+				q := dp.QuantileValues().AppendEmpty()
+				q.SetQuantile(0.5)
+				q.SetValue(1)
+				q = dp.QuantileValues().AppendEmpty()
+				q.SetQuantile(0.9)
+				q.SetValue(3)
+				return expected
+			}(),
+			expectedStats: remote.WriteResponseStats{
+				Confirmed: true,
+				Samples:   4,
+			},
+		},
+		{
+			name: "target_info becomes resource attributes",
+			request: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{
+					series("target_info", 1, "host_name", "server1"),
+					series("up", 1),
+				},
+			},
+			expectedMetrics: func() pmetric.Metrics {
+				expected := pmetric.NewMetrics()
+				sm := newScopeMetrics(expected)
+				expected.ResourceMetrics().At(0).Resource().Attributes().PutStr("host_name", "server1")
+				gauge := sm.Metrics().AppendEmpty()
+				gauge.SetName("up")
+				gauge.Metadata().PutStr(prometheus.MetricMetadataTypeKey, "unknown")
+				dp := gauge.SetEmptyGauge().DataPoints().AppendEmpty()
+				dp.SetTimestamp(pcommon.Timestamp(1000 * int64(time.Millisecond)))
+				dp.SetDoubleValue(1)
+				return expected
+			}(),
+			expectedStats: remote.WriteResponseStats{
+				Confirmed: true,
+				Samples:   1,
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// since we are using the rmCache to store values across requests, we need to clear it after each test, otherwise it will affect the next test
+			prwReceiver.rmCache.Purge()
+			metrics, stats, err := prwReceiver.translateV1(ctx, tc.request)
+			if tc.expectError != "" {
+				assert.ErrorContains(t, err, tc.expectError)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.NoError(t, pmetrictest.CompareMetrics(tc.expectedMetrics, metrics))
+			assert.Equal(t, tc.expectedStats, stats)
+			assert.Equal(t, buildMetaDataMapByID(tc.expectedMetrics), buildMetaDataMapByID(metrics))
+		})
+	}
+}
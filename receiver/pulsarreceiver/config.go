@@ -10,6 +10,8 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/config/configoptional"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/ackextension"
 )
 
 type Config struct {
@@ -20,7 +22,7 @@ type Config struct {
 	Topic string `mapstructure:"topic"`
 	// The Subscription that receiver will be consuming messages from (default "otlp_subscription")
 	Subscription string `mapstructure:"subscription"`
-	// Encoding of the messages (default "otlp_proto")
+	// Encoding of the messages (default "otlp_proto"), or the ID of an encoding extension
 	Encoding string `mapstructure:"encoding"`
 	// Name specifies the consumer name.
 	ConsumerName string `mapstructure:"consumer_name"`
@@ -29,6 +31,17 @@ type Config struct {
 	// Configure whether the Pulsar client accept untrusted TLS certificate from broker (default: false)
 	TLSAllowInsecureConnection bool           `mapstructure:"tls_allow_insecure_connection"`
 	Authentication             Authentication `mapstructure:"auth"`
+	// PropertyExtraction controls adding message properties to the
+	// resources of the received data as attributes.
+	PropertyExtraction PropertyExtraction `mapstructure:"property_extraction"`
+	// EndToEndAck controls deferring acknowledging messages until their data
+	// has been acknowledged at the end of the pipelines.
+	EndToEndAck ackextension.EndToEndConfig `mapstructure:"end_to_end_ack"`
+}
+
+type PropertyExtraction struct {
+	ExtractProperties bool     `mapstructure:"extract_properties"`
+	Properties        []string `mapstructure:"properties"`
 }
 
 type Authentication struct {
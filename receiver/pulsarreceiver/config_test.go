@@ -6,6 +6,7 @@ package pulsarreceiver // import "github.com/open-telemetry/opentelemetry-collec
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -13,6 +14,7 @@ import (
 	"go.opentelemetry.io/collector/config/configoptional"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/ackextension"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/pulsarreceiver/internal/metadata"
 )
 
@@ -26,6 +28,7 @@ func TestLoadConfig(t *testing.T) {
 	require.NoError(t, err)
 	require.NoError(t, sub.Unmarshal(cfg))
 
+	ackExtensionID := component.MustNewID("ack")
 	assert.Equal(t, &Config{
 		Topic:                 "otel-pulsar",
 		Endpoint:              "pulsar://localhost:6500",
@@ -34,6 +37,14 @@ func TestLoadConfig(t *testing.T) {
 		Encoding:              defaultEncoding,
 		TLSTrustCertsFilePath: "ca.pem",
 		Authentication:        Authentication{TLS: configoptional.Some(TLS{CertFile: "cert.pem", KeyFile: "key.pem"})},
+		PropertyExtraction: PropertyExtraction{
+			ExtractProperties: true,
+			Properties:        []string{"tenant"},
+		},
+		EndToEndAck: ackextension.EndToEndConfig{
+			Extension: &ackExtensionID,
+			Timeout:   time.Minute,
+		},
 	},
 		cfg,
 	)
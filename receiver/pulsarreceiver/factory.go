@@ -5,11 +5,13 @@ package pulsarreceiver // import "github.com/open-telemetry/opentelemetry-collec
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/receiver"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/ackextension"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/pulsarreceiver/internal/metadata"
 )
 
@@ -21,6 +23,8 @@ const (
 	defaultConsumerName = ""
 	defaultSubscription = "otlp_subscription"
 	defaultServiceURL   = "pulsar://localhost:6650"
+
+	defaultEndToEndAckTimeout = 30 * time.Second
 )
 
 // FactoryOption applies changes to PulsarExporterFactory.
@@ -135,5 +139,8 @@ func createDefaultConfig() component.Config {
 		ConsumerName: defaultConsumerName,
 		Subscription: defaultSubscription,
 		Endpoint:     defaultServiceURL,
+		EndToEndAck: ackextension.EndToEndConfig{
+			Timeout: defaultEndToEndAckTimeout,
+		},
 	}
 }
@@ -8,11 +8,13 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/receiver/receivertest"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/ackextension"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/pulsarreceiver/internal/metadata"
 )
 
@@ -25,6 +27,7 @@ func TestCreateDefaultConfig(t *testing.T) {
 		Subscription:   defaultSubscription,
 		Endpoint:       defaultServiceURL,
 		Authentication: Authentication{},
+		EndToEndAck:    ackextension.EndToEndConfig{Timeout: defaultEndToEndAckTimeout},
 	}, cfg)
 }
 
@@ -45,8 +48,11 @@ func TestCreateTraces_err_marshallers(t *testing.T) {
 
 	f := pulsarReceiverFactory{tracesUnmarshalers: make(map[string]TracesUnmarshaler)}
 	r, err := f.createTracesReceiver(t.Context(), receivertest.NewNopSettings(metadata.Type), cfg, nil)
-	require.Error(t, err)
-	assert.Nil(t, r)
+	require.NoError(t, err)
+	defer r.(*pulsarTracesConsumer).client.Close()
+
+	err = r.Start(t.Context(), componenttest.NewNopHost())
+	assert.ErrorIs(t, err, errUnrecognizedEncoding)
 }
 
 func Test_CreateTraceReceiver(t *testing.T) {
@@ -93,8 +99,11 @@ func TestCreateMetrics_err_marshallers(t *testing.T) {
 
 	f := pulsarReceiverFactory{metricsUnmarshalers: make(map[string]MetricsUnmarshaler)}
 	r, err := f.createMetricsReceiver(t.Context(), receivertest.NewNopSettings(metadata.Type), cfg, nil)
-	require.Error(t, err)
-	assert.Nil(t, r)
+	require.NoError(t, err)
+	defer r.(*pulsarMetricsConsumer).client.Close()
+
+	err = r.Start(t.Context(), componenttest.NewNopHost())
+	assert.ErrorIs(t, err, errUnrecognizedEncoding)
 }
 
 func Test_CreateMetrics(t *testing.T) {
@@ -142,8 +151,11 @@ func TestCreateLogs_err_marshallers(t *testing.T) {
 
 	f := pulsarReceiverFactory{logsUnmarshalers: make(map[string]LogsUnmarshaler)}
 	r, err := f.createLogsReceiver(t.Context(), receivertest.NewNopSettings(metadata.Type), cfg, nil)
-	require.Error(t, err)
-	assert.Nil(t, r)
+	require.NoError(t, err)
+	defer r.(*pulsarLogsConsumer).client.Close()
+
+	err = r.Start(t.Context(), componenttest.NewNopHost())
+	assert.ErrorIs(t, err, errUnrecognizedEncoding)
 }
 
 func Test_CreateLogs(t *testing.T) {
@@ -12,9 +12,15 @@ import (
 	"github.com/apache/pulsar-client-go/pulsar"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/receiver/receiverhelper"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/ackextension"
 )
 
 var errUnrecognizedEncoding = errors.New("unrecognized encoding")
@@ -24,15 +30,20 @@ const alreadyClosedError = "AlreadyClosedError"
 const transport = "pulsar"
 
 type pulsarTracesConsumer struct {
-	tracesConsumer  consumer.Traces
-	topic           string
-	client          pulsar.Client
-	cancel          context.CancelFunc
-	consumer        pulsar.Consumer
-	unmarshaler     TracesUnmarshaler
-	settings        receiver.Settings
-	consumerOptions pulsar.ConsumerOptions
-	obsrecv         *receiverhelper.ObsReport
+	tracesConsumer     consumer.Traces
+	topic              string
+	client             pulsar.Client
+	cancel             context.CancelFunc
+	consumer           pulsar.Consumer
+	unmarshaler        TracesUnmarshaler
+	settings           receiver.Settings
+	consumerOptions    pulsar.ConsumerOptions
+	obsrecv            *receiverhelper.ObsReport
+	encoding           string
+	unmarshalers       map[string]TracesUnmarshaler
+	propertyExtraction PropertyExtraction
+	endToEndAck        ackextension.EndToEndConfig
+	acks               *ackextension.EndToEnd
 }
 
 func newTracesReceiver(config Config, set receiver.Settings, unmarshalers map[string]TracesUnmarshaler, nextConsumer consumer.Traces) (*pulsarTracesConsumer, error) {
@@ -44,11 +55,6 @@ func newTracesReceiver(config Config, set receiver.Settings, unmarshalers map[st
 	if err != nil {
 		return nil, err
 	}
-	unmarshaler := unmarshalers[config.Encoding]
-	if unmarshaler == nil {
-		return nil, errUnrecognizedEncoding
-	}
-
 	options := config.clientOptions()
 	client, err := pulsar.NewClient(options)
 	if err != nil {
@@ -61,17 +67,32 @@ func newTracesReceiver(config Config, set receiver.Settings, unmarshalers map[st
 	}
 
 	return &pulsarTracesConsumer{
-		obsrecv:         obsrecv,
-		tracesConsumer:  nextConsumer,
-		topic:           config.Topic,
-		unmarshaler:     unmarshaler,
-		settings:        set,
-		client:          client,
-		consumerOptions: consumerOptions,
+		obsrecv:            obsrecv,
+		tracesConsumer:     nextConsumer,
+		topic:              config.Topic,
+		encoding:           config.Encoding,
+		unmarshalers:       unmarshalers,
+		settings:           set,
+		client:             client,
+		consumerOptions:    consumerOptions,
+		propertyExtraction: config.PropertyExtraction,
+		endToEndAck:        config.EndToEndAck,
 	}, nil
 }
 
-func (c *pulsarTracesConsumer) Start(context.Context, component.Host) error {
+func (c *pulsarTracesConsumer) Start(_ context.Context, host component.Host) error {
+	unmarshaler, err := getTracesUnmarshaler(c.encoding, host, c.unmarshalers)
+	if err != nil {
+		return err
+	}
+	c.unmarshaler = unmarshaler
+
+	acks, err := ackextension.NewEndToEnd(host, c.endToEndAck, c.settings.ID)
+	if err != nil {
+		return err
+	}
+	c.acks = acks
+
 	ctx, cancel := context.WithCancel(context.Background())
 	c.cancel = cancel
 
@@ -117,12 +138,54 @@ func consumerTracesLoop(ctx context.Context, c *pulsarTracesConsumer) error {
 			_ = c.consumer.Ack(message)
 			return err
 		}
-		err = traceConsumer.ConsumeTraces(context.Background(), traces)
+		addPropertyResourceAttributes[ptrace.ResourceSpans](c.propertyExtraction, message.Properties(), traces.ResourceSpans())
+		err = c.acks.Consume(context.Background(), func(ctx context.Context) error {
+			ackextension.MarkTraces(ctx, traces)
+			return traceConsumer.ConsumeTraces(ctx, traces)
+		})
 		if err != nil {
 			c.settings.Logger.Error("consume traces failed", zap.Error(err))
 		}
 		c.obsrecv.EndTracesOp(obsCtx, unmarshaler.Encoding(), traces.SpanCount(), err)
-		_ = c.consumer.Ack(message)
+		ackMessage(c.consumer, c.acks, message, err)
+	}
+}
+
+// ackMessage acknowledges a message once its data has been consumed. If
+// end-to-end acknowledgement is enabled and consuming the data failed, the
+// message is negatively acknowledged instead, so that it is redelivered.
+func ackMessage(consumer pulsar.Consumer, acks *ackextension.EndToEnd, message pulsar.Message, err error) {
+	if err != nil && acks != nil {
+		consumer.Nack(message)
+		return
+	}
+	_ = consumer.Ack(message)
+}
+
+type resource interface {
+	Resource() pcommon.Resource
+}
+
+type resourceSlice[T resource] interface {
+	Len() int
+	At(int) T
+}
+
+// addPropertyResourceAttributes adds the message properties listed in the
+// property extraction configuration to all resources as attributes, with the
+// prefix "pulsar.property.".
+func addPropertyResourceAttributes[T resource](cfg PropertyExtraction, properties map[string]string, resources resourceSlice[T]) {
+	if !cfg.ExtractProperties {
+		return
+	}
+	for _, key := range cfg.Properties {
+		value, ok := properties[key]
+		if !ok {
+			continue
+		}
+		for i := 0; i < resources.Len(); i++ {
+			resources.At(i).Resource().Attributes().PutStr("pulsar.property."+key, value)
+		}
 	}
 }
 
@@ -137,15 +200,20 @@ func (c *pulsarTracesConsumer) Shutdown(context.Context) error {
 }
 
 type pulsarMetricsConsumer struct {
-	metricsConsumer consumer.Metrics
-	unmarshaler     MetricsUnmarshaler
-	topic           string
-	client          pulsar.Client
-	consumer        pulsar.Consumer
-	cancel          context.CancelFunc
-	settings        receiver.Settings
-	consumerOptions pulsar.ConsumerOptions
-	obsrecv         *receiverhelper.ObsReport
+	metricsConsumer    consumer.Metrics
+	unmarshaler        MetricsUnmarshaler
+	topic              string
+	client             pulsar.Client
+	consumer           pulsar.Consumer
+	cancel             context.CancelFunc
+	settings           receiver.Settings
+	consumerOptions    pulsar.ConsumerOptions
+	obsrecv            *receiverhelper.ObsReport
+	encoding           string
+	unmarshalers       map[string]MetricsUnmarshaler
+	propertyExtraction PropertyExtraction
+	endToEndAck        ackextension.EndToEndConfig
+	acks               *ackextension.EndToEnd
 }
 
 func newMetricsReceiver(config Config, set receiver.Settings, unmarshalers map[string]MetricsUnmarshaler, nextConsumer consumer.Metrics) (*pulsarMetricsConsumer, error) {
@@ -157,11 +225,6 @@ func newMetricsReceiver(config Config, set receiver.Settings, unmarshalers map[s
 	if err != nil {
 		return nil, err
 	}
-	unmarshaler := unmarshalers[config.Encoding]
-	if unmarshaler == nil {
-		return nil, errUnrecognizedEncoding
-	}
-
 	options := config.clientOptions()
 	client, err := pulsar.NewClient(options)
 	if err != nil {
@@ -174,17 +237,32 @@ func newMetricsReceiver(config Config, set receiver.Settings, unmarshalers map[s
 	}
 
 	return &pulsarMetricsConsumer{
-		obsrecv:         obsrecv,
-		metricsConsumer: nextConsumer,
-		topic:           config.Topic,
-		unmarshaler:     unmarshaler,
-		settings:        set,
-		client:          client,
-		consumerOptions: consumerOptions,
+		obsrecv:            obsrecv,
+		metricsConsumer:    nextConsumer,
+		topic:              config.Topic,
+		encoding:           config.Encoding,
+		unmarshalers:       unmarshalers,
+		settings:           set,
+		client:             client,
+		consumerOptions:    consumerOptions,
+		propertyExtraction: config.PropertyExtraction,
+		endToEndAck:        config.EndToEndAck,
 	}, nil
 }
 
-func (c *pulsarMetricsConsumer) Start(context.Context, component.Host) error {
+func (c *pulsarMetricsConsumer) Start(_ context.Context, host component.Host) error {
+	unmarshaler, err := getMetricsUnmarshaler(c.encoding, host, c.unmarshalers)
+	if err != nil {
+		return err
+	}
+	c.unmarshaler = unmarshaler
+
+	acks, err := ackextension.NewEndToEnd(host, c.endToEndAck, c.settings.ID)
+	if err != nil {
+		return err
+	}
+	c.acks = acks
+
 	ctx, cancel := context.WithCancel(context.Background())
 	c.cancel = cancel
 
@@ -232,13 +310,17 @@ func consumeMetricsLoop(ctx context.Context, c *pulsarMetricsConsumer) error {
 			_ = c.consumer.Ack(message)
 			return err
 		}
-		err = metricsConsumer.ConsumeMetrics(context.Background(), metrics)
+		addPropertyResourceAttributes[pmetric.ResourceMetrics](c.propertyExtraction, message.Properties(), metrics.ResourceMetrics())
+		err = c.acks.Consume(context.Background(), func(ctx context.Context) error {
+			ackextension.MarkMetrics(ctx, metrics)
+			return metricsConsumer.ConsumeMetrics(ctx, metrics)
+		})
 		if err != nil {
 			c.settings.Logger.Error("consume traces failed", zap.Error(err))
 		}
 		c.obsrecv.EndMetricsOp(obsCtx, unmarshaler.Encoding(), metrics.DataPointCount(), err)
 
-		_ = c.consumer.Ack(message)
+		ackMessage(c.consumer, c.acks, message, err)
 	}
 }
 
@@ -253,15 +335,20 @@ func (c *pulsarMetricsConsumer) Shutdown(context.Context) error {
 }
 
 type pulsarLogsConsumer struct {
-	logsConsumer    consumer.Logs
-	unmarshaler     LogsUnmarshaler
-	topic           string
-	client          pulsar.Client
-	consumer        pulsar.Consumer
-	cancel          context.CancelFunc
-	settings        receiver.Settings
-	consumerOptions pulsar.ConsumerOptions
-	obsrecv         *receiverhelper.ObsReport
+	logsConsumer       consumer.Logs
+	unmarshaler        LogsUnmarshaler
+	topic              string
+	client             pulsar.Client
+	consumer           pulsar.Consumer
+	cancel             context.CancelFunc
+	settings           receiver.Settings
+	consumerOptions    pulsar.ConsumerOptions
+	obsrecv            *receiverhelper.ObsReport
+	encoding           string
+	unmarshalers       map[string]LogsUnmarshaler
+	propertyExtraction PropertyExtraction
+	endToEndAck        ackextension.EndToEndConfig
+	acks               *ackextension.EndToEnd
 }
 
 func newLogsReceiver(config Config, set receiver.Settings, unmarshalers map[string]LogsUnmarshaler, nextConsumer consumer.Logs) (*pulsarLogsConsumer, error) {
@@ -273,11 +360,6 @@ func newLogsReceiver(config Config, set receiver.Settings, unmarshalers map[stri
 	if err != nil {
 		return nil, err
 	}
-	unmarshaler := unmarshalers[config.Encoding]
-	if unmarshaler == nil {
-		return nil, errUnrecognizedEncoding
-	}
-
 	options := config.clientOptions()
 	client, err := pulsar.NewClient(options)
 	if err != nil {
@@ -290,18 +372,33 @@ func newLogsReceiver(config Config, set receiver.Settings, unmarshalers map[stri
 	}
 
 	return &pulsarLogsConsumer{
-		obsrecv:         obsrecv,
-		logsConsumer:    nextConsumer,
-		topic:           config.Topic,
-		cancel:          nil,
-		unmarshaler:     unmarshaler,
-		settings:        set,
-		client:          client,
-		consumerOptions: consumerOptions,
+		obsrecv:            obsrecv,
+		logsConsumer:       nextConsumer,
+		topic:              config.Topic,
+		cancel:             nil,
+		encoding:           config.Encoding,
+		unmarshalers:       unmarshalers,
+		settings:           set,
+		client:             client,
+		consumerOptions:    consumerOptions,
+		propertyExtraction: config.PropertyExtraction,
+		endToEndAck:        config.EndToEndAck,
 	}, nil
 }
 
-func (c *pulsarLogsConsumer) Start(context.Context, component.Host) error {
+func (c *pulsarLogsConsumer) Start(_ context.Context, host component.Host) error {
+	unmarshaler, err := getLogsUnmarshaler(c.encoding, host, c.unmarshalers)
+	if err != nil {
+		return err
+	}
+	c.unmarshaler = unmarshaler
+
+	acks, err := ackextension.NewEndToEnd(host, c.endToEndAck, c.settings.ID)
+	if err != nil {
+		return err
+	}
+	c.acks = acks
+
 	ctx, cancel := context.WithCancel(context.Background())
 	c.cancel = cancel
 
@@ -346,12 +443,16 @@ func consumeLogsLoop(ctx context.Context, c *pulsarLogsConsumer) error {
 			_ = c.consumer.Ack(message)
 			return err
 		}
-		err = logsConsumer.ConsumeLogs(context.Background(), logs)
+		addPropertyResourceAttributes[plog.ResourceLogs](c.propertyExtraction, message.Properties(), logs.ResourceLogs())
+		err = c.acks.Consume(context.Background(), func(ctx context.Context) error {
+			ackextension.MarkLogs(ctx, logs)
+			return logsConsumer.ConsumeLogs(ctx, logs)
+		})
 		if err != nil {
 			c.settings.Logger.Error("consume traces failed", zap.Error(err))
 		}
 		c.obsrecv.EndLogsOp(obsCtx, unmarshaler.Encoding(), logs.LogRecordCount(), err)
-		_ = c.consumer.Ack(message)
+		ackMessage(c.consumer, c.acks, message, err)
 	}
 }
 
@@ -4,10 +4,18 @@
 package pulsarreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/pulsarreceiver"
 
 import (
+	"context"
+	"fmt"
 	"testing"
+	"time"
 
+	"github.com/apache/pulsar-client-go/pulsar"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/receiver/receivertest"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/pulsarreceiver/internal/metadata"
@@ -20,3 +28,101 @@ func Test_newTracesReceiver_err(t *testing.T) {
 	_, err := newTracesReceiver(c, receivertest.NewNopSettings(metadata.Type), defaultTracesUnmarshalers(), consumertest.NewNop())
 	assert.Error(t, err)
 }
+
+func Test_newTracesReceiver_missingAckExtension(t *testing.T) {
+	ackExtensionID := component.MustNewID("ack")
+	c := createDefaultConfig().(*Config)
+	c.Topic = defaultTraceTopic
+	c.EndToEndAck.Extension = &ackExtensionID
+	r, err := newTracesReceiver(*c, receivertest.NewNopSettings(metadata.Type), defaultTracesUnmarshalers(), consumertest.NewNop())
+	require.NoError(t, err)
+	defer r.client.Close()
+
+	err = r.Start(t.Context(), componenttest.NewNopHost())
+	assert.EqualError(t, err, `specified ack extension with id "ack" could not be found`)
+}
+
+func Test_consumeTracesLoop_propertyExtraction(t *testing.T) {
+	for _, enabled := range []bool{false, true} {
+		t.Run(fmt.Sprintf("extract_properties=%t", enabled), func(t *testing.T) {
+			c := createDefaultConfig().(*Config)
+			c.Topic = defaultTraceTopic
+			c.PropertyExtraction.ExtractProperties = enabled
+			c.PropertyExtraction.Properties = []string{"extracted", "missing"}
+			sink := &consumertest.TracesSink{}
+			r, err := newTracesReceiver(*c, receivertest.NewNopSettings(metadata.Type), defaultTracesUnmarshalers(), sink)
+			require.NoError(t, err)
+			defer r.client.Close()
+
+			traces := ptrace.NewTraces()
+			traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("span")
+			traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("span")
+			payload, err := (&ptrace.ProtoMarshaler{}).MarshalTraces(traces)
+			require.NoError(t, err)
+
+			mConsumer := &mockConsumer{messages: make(chan pulsar.Message, 1)}
+			mConsumer.messages <- &mockMessage{
+				payload:    payload,
+				properties: map[string]string{"extracted": "value", "ignored": "value"},
+			}
+			r.consumer = mConsumer
+			r.unmarshaler = r.unmarshalers[r.encoding]
+
+			ctx, cancel := context.WithCancel(t.Context())
+			done := make(chan error)
+			go func() { done <- consumerTracesLoop(ctx, r) }()
+			require.Eventually(t, func() bool { return sink.SpanCount() == 2 }, time.Second, 10*time.Millisecond)
+			cancel()
+			require.ErrorIs(t, <-done, context.Canceled)
+			assert.Equal(t, 1, mConsumer.acked)
+
+			resourceSpans := sink.AllTraces()[0].ResourceSpans()
+			for i := 0; i < resourceSpans.Len(); i++ {
+				attrs := resourceSpans.At(i).Resource().Attributes()
+				value, ok := attrs.Get("pulsar.property.extracted")
+				assert.Equal(t, enabled, ok)
+				if enabled {
+					assert.Equal(t, "value", value.Str())
+				}
+				_, ok = attrs.Get("pulsar.property.ignored")
+				assert.False(t, ok)
+				_, ok = attrs.Get("pulsar.property.missing")
+				assert.False(t, ok)
+			}
+		})
+	}
+}
+
+type mockConsumer struct {
+	pulsar.Consumer
+	messages chan pulsar.Message
+	acked    int
+}
+
+func (c *mockConsumer) Receive(ctx context.Context) (pulsar.Message, error) {
+	select {
+	case message := <-c.messages:
+		return message, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (c *mockConsumer) Ack(pulsar.Message) error {
+	c.acked++
+	return nil
+}
+
+type mockMessage struct {
+	pulsar.Message
+	payload    []byte
+	properties map[string]string
+}
+
+func (m *mockMessage) Payload() []byte {
+	return m.payload
+}
+
+func (m *mockMessage) Properties() map[string]string {
+	return m.properties
+}
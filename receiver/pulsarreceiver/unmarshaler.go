@@ -4,10 +4,12 @@
 package pulsarreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/pulsarreceiver"
 
 import (
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/encodingutil"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/zipkin/zipkinv1"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/zipkin/zipkinv2"
 )
@@ -72,3 +74,42 @@ func defaultLogsUnmarshalers() map[string]LogsUnmarshaler {
 		otlpPb.Encoding(): otlpPb,
 	}
 }
+
+func getTracesUnmarshaler(encoding string, host component.Host, unmarshalers map[string]TracesUnmarshaler) (TracesUnmarshaler, error) {
+	// Extensions take precedence.
+	if u, ok, err := encodingutil.Extension[ptrace.Unmarshaler](host, encoding, "traces", "unmarshaler"); err != nil {
+		return nil, err
+	} else if ok {
+		return newPdataTracesUnmarshaler(u, encoding), nil
+	}
+	if u, ok := unmarshalers[encoding]; ok {
+		return u, nil
+	}
+	return nil, errUnrecognizedEncoding
+}
+
+func getMetricsUnmarshaler(encoding string, host component.Host, unmarshalers map[string]MetricsUnmarshaler) (MetricsUnmarshaler, error) {
+	// Extensions take precedence.
+	if u, ok, err := encodingutil.Extension[pmetric.Unmarshaler](host, encoding, "metrics", "unmarshaler"); err != nil {
+		return nil, err
+	} else if ok {
+		return newPdataMetricsUnmarshaler(u, encoding), nil
+	}
+	if u, ok := unmarshalers[encoding]; ok {
+		return u, nil
+	}
+	return nil, errUnrecognizedEncoding
+}
+
+func getLogsUnmarshaler(encoding string, host component.Host, unmarshalers map[string]LogsUnmarshaler) (LogsUnmarshaler, error) {
+	// Extensions take precedence.
+	if u, ok, err := encodingutil.Extension[plog.Unmarshaler](host, encoding, "logs", "unmarshaler"); err != nil {
+		return nil, err
+	} else if ok {
+		return newPdataLogsUnmarshaler(u, encoding), nil
+	}
+	if u, ok := unmarshalers[encoding]; ok {
+		return u, nil
+	}
+	return nil, errUnrecognizedEncoding
+}
@@ -4,10 +4,15 @@
 package pulsarreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/pulsarreceiver"
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 )
 
 // copy from kafka receiver
@@ -60,3 +65,133 @@ func TestDefaultLogsUnMarshaler(t *testing.T) {
 		})
 	}
 }
+
+func TestGetTracesUnmarshalerExtension(t *testing.T) {
+	traces := ptrace.NewTraces()
+	traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("span")
+	extension := ptraceUnmarshalerFuncExtension(func([]byte) (ptrace.Traces, error) {
+		return traces, nil
+	})
+	host := extensionsHost{
+		component.MustNewID("trace_encoding"):                  extension,
+		component.MustNewIDWithName("trace_encoding", "alice"): extension,
+		component.MustNewID("not_traces"):                      nopExtension{},
+	}
+
+	for _, encoding := range []string{"trace_encoding", "trace_encoding/alice"} {
+		u, err := getTracesUnmarshaler(encoding, host, defaultTracesUnmarshalers())
+		require.NoError(t, err)
+		assert.Equal(t, encoding, u.Encoding())
+		got, err := u.Unmarshal(nil)
+		require.NoError(t, err)
+		assert.Equal(t, traces, got)
+	}
+
+	// Built-in encodings are used when no extension is found.
+	u, err := getTracesUnmarshaler("zipkin_json", host, defaultTracesUnmarshalers())
+	require.NoError(t, err)
+	assert.Equal(t, "zipkin_json", u.Encoding())
+
+	_, err = getTracesUnmarshaler("not_traces", host, defaultTracesUnmarshalers())
+	assert.EqualError(t, err, `extension "not_traces" is not a traces unmarshaler`)
+
+	_, err = getTracesUnmarshaler("unknown", host, defaultTracesUnmarshalers())
+	assert.ErrorIs(t, err, errUnrecognizedEncoding)
+
+	_, err = getTracesUnmarshaler("otlp-spans", host, defaultTracesUnmarshalers())
+	assert.ErrorIs(t, err, errUnrecognizedEncoding)
+}
+
+func TestGetMetricsUnmarshalerExtension(t *testing.T) {
+	metrics := pmetric.NewMetrics()
+	metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetName("metric")
+	host := extensionsHost{
+		component.MustNewID("metric_encoding"): pmetricUnmarshalerFuncExtension(func([]byte) (pmetric.Metrics, error) {
+			return metrics, nil
+		}),
+	}
+	u, err := getMetricsUnmarshaler("metric_encoding", host, defaultMetricsUnmarshalers())
+	require.NoError(t, err)
+	got, err := u.Unmarshal(nil)
+	require.NoError(t, err)
+	assert.Equal(t, metrics, got)
+
+	_, err = getMetricsUnmarshaler("otlp_json", host, defaultMetricsUnmarshalers())
+	assert.ErrorIs(t, err, errUnrecognizedEncoding)
+}
+
+func TestGetLogsUnmarshalerExtension(t *testing.T) {
+	logs := plog.NewLogs()
+	logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("log")
+	host := extensionsHost{
+		component.MustNewID("log_encoding"): plogUnmarshalerFuncExtension(func([]byte) (plog.Logs, error) {
+			return logs, nil
+		}),
+	}
+	u, err := getLogsUnmarshaler("log_encoding", host, defaultLogsUnmarshalers())
+	require.NoError(t, err)
+	got, err := u.Unmarshal(nil)
+	require.NoError(t, err)
+	assert.Equal(t, logs, got)
+
+	_, err = getLogsUnmarshaler("otlp_json", host, defaultLogsUnmarshalers())
+	assert.ErrorIs(t, err, errUnrecognizedEncoding)
+}
+
+type extensionsHost map[component.ID]component.Component
+
+func (m extensionsHost) GetExtensions() map[component.ID]component.Component {
+	return m
+}
+
+type nopExtension struct{}
+
+func (nopExtension) Start(context.Context, component.Host) error {
+	return nil
+}
+
+func (nopExtension) Shutdown(context.Context) error {
+	return nil
+}
+
+type ptraceUnmarshalerFuncExtension func([]byte) (ptrace.Traces, error)
+
+func (f ptraceUnmarshalerFuncExtension) UnmarshalTraces(data []byte) (ptrace.Traces, error) {
+	return f(data)
+}
+
+func (ptraceUnmarshalerFuncExtension) Start(context.Context, component.Host) error {
+	return nil
+}
+
+func (ptraceUnmarshalerFuncExtension) Shutdown(context.Context) error {
+	return nil
+}
+
+type pmetricUnmarshalerFuncExtension func([]byte) (pmetric.Metrics, error)
+
+func (f pmetricUnmarshalerFuncExtension) UnmarshalMetrics(data []byte) (pmetric.Metrics, error) {
+	return f(data)
+}
+
+func (pmetricUnmarshalerFuncExtension) Start(context.Context, component.Host) error {
+	return nil
+}
+
+func (pmetricUnmarshalerFuncExtension) Shutdown(context.Context) error {
+	return nil
+}
+
+type plogUnmarshalerFuncExtension func([]byte) (plog.Logs, error)
+
+func (f plogUnmarshalerFuncExtension) UnmarshalLogs(data []byte) (plog.Logs, error) {
+	return f(data)
+}
+
+func (plogUnmarshalerFuncExtension) Start(context.Context, component.Host) error {
+	return nil
+}
+
+func (plogUnmarshalerFuncExtension) Shutdown(context.Context) error {
+	return nil
+}
@@ -0,0 +1,142 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package rabbitmqqueuereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/rabbitmqqueuereceiver"
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/config/configtls"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/ackextension"
+)
+
+var _ component.Config = (*Config)(nil)
+
+// Config defines configuration for the RabbitMQ queue receiver.
+type Config struct {
+	// Connection holds the configuration of the connection to the broker.
+	Connection ConnectionConfig `mapstructure:"connection"`
+
+	// Logs holds configuration about how logs should be consumed.
+	Logs SignalConfig `mapstructure:"logs"`
+
+	// Metrics holds configuration about how metrics should be consumed.
+	Metrics SignalConfig `mapstructure:"metrics"`
+
+	// Traces holds configuration about how traces should be consumed.
+	Traces SignalConfig `mapstructure:"traces"`
+
+	// PrefetchCount is the maximum number of messages of each queue
+	// delivered to the receiver and not yet acknowledged. Zero means no
+	// limit.
+	PrefetchCount int `mapstructure:"prefetch_count"`
+
+	// ErrorBackOff controls the retries of messages for which the next
+	// consumer returned a non-permanent error.
+	ErrorBackOff configretry.BackOffConfig `mapstructure:"error_backoff"`
+
+	// EndToEndAck defers acknowledging messages until their data has been
+	// acknowledged at the end of the pipelines by the ack processor.
+	EndToEndAck ackextension.EndToEndConfig `mapstructure:"end_to_end_ack"`
+}
+
+// ConnectionConfig holds the configuration of the connection to the broker.
+type ConnectionConfig struct {
+	// Endpoint holds the URL of the broker, e.g. amqp://localhost:5672.
+	// The amqp and amqps schemes are supported.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// VHost holds the virtual host to connect to.
+	VHost string `mapstructure:"vhost"`
+
+	// TLSConfig holds the TLS configuration of the connection.
+	TLSConfig *configtls.ClientConfig `mapstructure:"tls"`
+
+	// Auth holds the credentials of the receiver.
+	Auth AuthConfig `mapstructure:"auth"`
+
+	// ConnectionTimeout is the timeout for connecting to the broker.
+	ConnectionTimeout time.Duration `mapstructure:"connection_timeout"`
+
+	// Heartbeat is the interval of the heartbeats of the connection.
+	Heartbeat time.Duration `mapstructure:"heartbeat"`
+
+	// ReconnectInterval is the time to wait before reconnecting after the
+	// connection or a channel was closed.
+	ReconnectInterval time.Duration `mapstructure:"reconnect_interval"`
+
+	// Name holds the name of the connection, shown by the broker.
+	Name string `mapstructure:"name"`
+}
+
+// AuthConfig holds the credentials of the receiver.
+type AuthConfig struct {
+	Plain PlainAuth `mapstructure:"plain"`
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// PlainAuth holds the credentials of the PLAIN authentication mechanism.
+type PlainAuth struct {
+	Username string              `mapstructure:"username"`
+	Password configopaque.String `mapstructure:"password"`
+}
+
+// SignalConfig holds the queue consumed for a signal.
+type SignalConfig struct {
+	// Queue holds the name of the queue to consume from.
+	Queue string `mapstructure:"queue"`
+
+	// Encoding holds the encoding of the messages: otlp_proto, otlp_json,
+	// or the ID of an encoding extension.
+	Encoding string `mapstructure:"encoding"`
+}
+
+func (c SignalConfig) validate() error {
+	if c.Queue == "" {
+		return errors.New("queue must be specified")
+	}
+	if c.Encoding == "" {
+		return errors.New("encoding must be specified")
+	}
+	return nil
+}
+
+// Validate checks the receiver configuration is valid.
+func (cfg *Config) Validate() error {
+	var errs []error
+	if cfg.Connection.Endpoint == "" {
+		errs = append(errs, errors.New("connection::endpoint must be specified"))
+	} else if u, err := url.Parse(cfg.Connection.Endpoint); err != nil {
+		errs = append(errs, fmt.Errorf("connection::endpoint is invalid: %w", err))
+	} else if u.Scheme != "amqp" && u.Scheme != "amqps" {
+		errs = append(errs, fmt.Errorf("connection::endpoint scheme %q must be amqp or amqps", u.Scheme))
+	}
+	// Password-less users are possible so only validate username
+	if cfg.Connection.Auth.Plain.Username == "" {
+		errs = append(errs, errors.New("connection::auth::plain::username must be specified"))
+	}
+	if cfg.Connection.ReconnectInterval <= 0 {
+		errs = append(errs, errors.New("connection::reconnect_interval must be positive"))
+	}
+	if cfg.PrefetchCount < 0 {
+		errs = append(errs, errors.New("prefetch_count must not be negative"))
+	}
+	if err := cfg.Logs.validate(); err != nil {
+		errs = append(errs, fmt.Errorf("logs::%w", err))
+	}
+	if err := cfg.Metrics.validate(); err != nil {
+		errs = append(errs, fmt.Errorf("metrics::%w", err))
+	}
+	if err := cfg.Traces.validate(); err != nil {
+		errs = append(errs, fmt.Errorf("traces::%w", err))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package rabbitmqqueuereceiver
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/ackextension"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/rabbitmqqueuereceiver/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	ackID := component.MustNewID("ack")
+	tests := []struct {
+		id          component.ID
+		expected    component.Config
+		expectedErr string
+	}{
+		{
+			id: component.NewIDWithName(metadata.Type, ""),
+			expected: func() component.Config {
+				cfg := createDefaultConfig().(*Config)
+				cfg.Connection.Auth.Plain.Username = "user"
+				return cfg
+			}(),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "custom"),
+			expected: &Config{
+				Connection: ConnectionConfig{
+					Endpoint: "amqps://broker:5671",
+					VHost:    "otel",
+					TLSConfig: &configtls.ClientConfig{
+						Config: configtls.Config{CAFile: "ca.pem"},
+					},
+					Auth: AuthConfig{
+						Plain: PlainAuth{
+							Username: "user",
+							Password: "pass",
+						},
+					},
+					ConnectionTimeout: 5 * time.Second,
+					Heartbeat:         10 * time.Second,
+					ReconnectInterval: time.Second,
+					Name:              "otelcol-site-1",
+				},
+				Logs: SignalConfig{
+					Queue:    "logs",
+					Encoding: "text_encoding",
+				},
+				Metrics: SignalConfig{
+					Queue:    "metrics",
+					Encoding: "otlp_json",
+				},
+				Traces: SignalConfig{
+					Queue:    "otlp_spans",
+					Encoding: "otlp_proto",
+				},
+				PrefetchCount: 100,
+				ErrorBackOff: func() configretry.BackOffConfig {
+					config := configretry.NewDefaultBackOffConfig()
+					config.Enabled = false
+					return config
+				}(),
+				EndToEndAck: ackextension.EndToEndConfig{
+					Extension: &ackID,
+					Timeout:   time.Minute,
+				},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "invalid_connection"),
+			expectedErr: `connection::endpoint scheme "http" must be amqp or amqps` + "\n" +
+				"connection::auth::plain::username must be specified\n" +
+				"connection::reconnect_interval must be positive\n" +
+				"prefetch_count must not be negative",
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "invalid_queues"),
+			expectedErr: "logs::queue must be specified\n" +
+				"metrics::encoding must be specified",
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "invalid_end_to_end_ack"),
+			expectedErr: "end_to_end_ack: timeout must be positive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			t.Parallel()
+
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			err = xconfmap.Validate(cfg)
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
@@ -0,0 +1,7 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package rabbitmqqueuereceiver receives telemetry from RabbitMQ queues.
+package rabbitmqqueuereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/rabbitmqqueuereceiver"
@@ -0,0 +1,120 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package rabbitmqqueuereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/rabbitmqqueuereceiver"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/ackextension"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/rabbitmq"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/rabbitmqqueuereceiver/internal/metadata"
+)
+
+const (
+	defaultEndpoint          = "amqp://localhost:5672"
+	defaultConnectionTimeout = 10 * time.Second
+	defaultHeartbeat         = 5 * time.Second
+	defaultReconnectInterval = 5 * time.Second
+	defaultConnectionName    = "otel-collector-receiver"
+
+	defaultLogsQueue       = "otlp_logs"
+	defaultLogsEncoding    = "otlp_proto"
+	defaultMetricsQueue    = "otlp_metrics"
+	defaultMetricsEncoding = "otlp_proto"
+	defaultTracesQueue     = "otlp_spans"
+	defaultTracesEncoding  = "otlp_proto"
+
+	defaultPrefetchCount      = 10
+	defaultEndToEndAckTimeout = 30 * time.Second
+)
+
+// NewFactory creates RabbitMQ queue receiver factory.
+func NewFactory() receiver.Factory {
+	return receiver.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		receiver.WithTraces(createTracesReceiver, metadata.TracesStability),
+		receiver.WithMetrics(createMetricsReceiver, metadata.MetricsStability),
+		receiver.WithLogs(createLogsReceiver, metadata.LogsStability),
+	)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		Connection: ConnectionConfig{
+			Endpoint:          defaultEndpoint,
+			ConnectionTimeout: defaultConnectionTimeout,
+			Heartbeat:         defaultHeartbeat,
+			ReconnectInterval: defaultReconnectInterval,
+			Name:              defaultConnectionName,
+		},
+		Logs: SignalConfig{
+			Queue:    defaultLogsQueue,
+			Encoding: defaultLogsEncoding,
+		},
+		Metrics: SignalConfig{
+			Queue:    defaultMetricsQueue,
+			Encoding: defaultMetricsEncoding,
+		},
+		Traces: SignalConfig{
+			Queue:    defaultTracesQueue,
+			Encoding: defaultTracesEncoding,
+		},
+		PrefetchCount: defaultPrefetchCount,
+		ErrorBackOff:  configretry.NewDefaultBackOffConfig(),
+		EndToEndAck: ackextension.EndToEndConfig{
+			Timeout: defaultEndToEndAckTimeout,
+		},
+	}
+}
+
+func createTracesReceiver(
+	_ context.Context,
+	set receiver.Settings,
+	cfg component.Config,
+	nextConsumer consumer.Traces,
+) (receiver.Traces, error) {
+	r := receivers.GetOrAdd(cfg, func() component.Component {
+		return newRabbitMQReceiver(cfg.(*Config), set, rabbitmq.NewAmqpClient(set.Logger))
+	})
+	r.Unwrap().(*rabbitmqReceiver).nextTraces = nextConsumer
+	return r, nil
+}
+
+func createMetricsReceiver(
+	_ context.Context,
+	set receiver.Settings,
+	cfg component.Config,
+	nextConsumer consumer.Metrics,
+) (receiver.Metrics, error) {
+	r := receivers.GetOrAdd(cfg, func() component.Component {
+		return newRabbitMQReceiver(cfg.(*Config), set, rabbitmq.NewAmqpClient(set.Logger))
+	})
+	r.Unwrap().(*rabbitmqReceiver).nextMetrics = nextConsumer
+	return r, nil
+}
+
+func createLogsReceiver(
+	_ context.Context,
+	set receiver.Settings,
+	cfg component.Config,
+	nextConsumer consumer.Logs,
+) (receiver.Logs, error) {
+	r := receivers.GetOrAdd(cfg, func() component.Component {
+		return newRabbitMQReceiver(cfg.(*Config), set, rabbitmq.NewAmqpClient(set.Logger))
+	})
+	r.Unwrap().(*rabbitmqReceiver).nextLogs = nextConsumer
+	return r, nil
+}
+
+// receivers shares a receiver, and so its connection to the broker, across
+// the signals of a configuration.
+var receivers = sharedcomponent.NewSharedComponents()
@@ -0,0 +1,333 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package rabbitmqqueuereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/rabbitmqqueuereceiver"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/ackextension"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/encodingutil"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/messagehandler"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/rabbitmq"
+)
+
+const transport = "amqp"
+
+type consumeMessageFunc func(ctx context.Context, msg amqp.Delivery) error
+
+// queueConsumer consumes the messages of the queue of a signal.
+type queueConsumer struct {
+	queue          string
+	consumeMessage consumeMessageFunc
+}
+
+// rabbitmqReceiver consumes the queues of the signals which have a next
+// consumer, sharing one connection with a channel per queue. Messages are
+// acknowledged once they were consumed by the next consumer, and rejected
+// if consuming them failed with a permanent error, so that they are
+// dead-lettered if the queue has a dead letter exchange. Other messages are
+// returned to the queue.
+type rabbitmqReceiver struct {
+	config   *Config
+	settings receiver.Settings
+	client   rabbitmq.AmqpClient
+
+	nextLogs    consumer.Logs
+	nextMetrics consumer.Metrics
+	nextTraces  consumer.Traces
+
+	connection rabbitmq.Connection
+	consumers  []queueConsumer
+	acks       *ackextension.EndToEnd
+	ctx        context.Context
+	cancel     context.CancelFunc
+	running    sync.WaitGroup
+}
+
+func newRabbitMQReceiver(config *Config, set receiver.Settings, client rabbitmq.AmqpClient) *rabbitmqReceiver {
+	return &rabbitmqReceiver{
+		config:   config,
+		settings: set,
+		client:   client,
+	}
+}
+
+func (r *rabbitmqReceiver) Start(ctx context.Context, host component.Host) error {
+	obsrecv, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
+		ReceiverID:             r.settings.ID,
+		Transport:              transport,
+		ReceiverCreateSettings: r.settings,
+	})
+	if err != nil {
+		return err
+	}
+	r.acks, err = ackextension.NewEndToEnd(host, r.config.EndToEndAck, r.settings.ID)
+	if err != nil {
+		return err
+	}
+	if err = r.addConsumers(host, obsrecv); err != nil {
+		return err
+	}
+
+	dialConfig, err := r.dialConfig(ctx)
+	if err != nil {
+		return err
+	}
+	connection, err := r.client.DialConfig(dialConfig)
+	if err != nil {
+		return fmt.Errorf("failed to connect to RabbitMQ broker: %w", err)
+	}
+	r.connection = connection
+
+	consumeCtx, cancel := context.WithCancel(context.Background())
+	channels := make([]rabbitmq.Channel, len(r.consumers))
+	deliveries := make([]<-chan amqp.Delivery, len(r.consumers))
+	for i, c := range r.consumers {
+		if channels[i], deliveries[i], err = r.consume(consumeCtx, c.queue); err != nil {
+			cancel()
+			return errors.Join(err, connection.Close())
+		}
+	}
+	r.ctx, r.cancel = consumeCtx, cancel
+	for i, c := range r.consumers {
+		r.running.Add(1)
+		go r.run(c, channels[i], deliveries[i])
+	}
+	return nil
+}
+
+func (r *rabbitmqReceiver) addConsumers(host component.Host, obsrecv *receiverhelper.ObsReport) error {
+	if r.nextLogs != nil {
+		unmarshaler, err := encodingutil.NewLogsUnmarshaler(r.config.Logs.Encoding, host)
+		if err != nil {
+			return err
+		}
+		handler := messagehandler.NewLogs(r.config.Logs.Encoding, unmarshaler, obsrecv, r.nextLogs)
+		r.consumers = append(r.consumers, queueConsumer{
+			queue:          r.config.Logs.Queue,
+			consumeMessage: r.consumeMessageFunc(handler.Handle),
+		})
+	}
+	if r.nextMetrics != nil {
+		unmarshaler, err := encodingutil.NewMetricsUnmarshaler(r.config.Metrics.Encoding, host)
+		if err != nil {
+			return err
+		}
+		handler := messagehandler.NewMetrics(r.config.Metrics.Encoding, unmarshaler, obsrecv, r.nextMetrics)
+		r.consumers = append(r.consumers, queueConsumer{
+			queue:          r.config.Metrics.Queue,
+			consumeMessage: r.consumeMessageFunc(handler.Handle),
+		})
+	}
+	if r.nextTraces != nil {
+		unmarshaler, err := encodingutil.NewTracesUnmarshaler(r.config.Traces.Encoding, host)
+		if err != nil {
+			return err
+		}
+		handler := messagehandler.NewTraces(r.config.Traces.Encoding, unmarshaler, obsrecv, r.nextTraces)
+		r.consumers = append(r.consumers, queueConsumer{
+			queue:          r.config.Traces.Queue,
+			consumeMessage: r.consumeMessageFunc(handler.Handle),
+		})
+	}
+	return nil
+}
+
+func (r *rabbitmqReceiver) dialConfig(ctx context.Context) (rabbitmq.DialConfig, error) {
+	dialConfig := rabbitmq.DialConfig{
+		URL:   r.config.Connection.Endpoint,
+		Vhost: r.config.Connection.VHost,
+		Auth: &amqp.PlainAuth{
+			Username: r.config.Connection.Auth.Plain.Username,
+			Password: string(r.config.Connection.Auth.Plain.Password),
+		},
+		ConnectionTimeout: r.config.Connection.ConnectionTimeout,
+		Heartbeat:         r.config.Connection.Heartbeat,
+		ConnectionName:    r.config.Connection.Name,
+	}
+	if r.config.Connection.TLSConfig != nil {
+		tlsConfig, err := r.config.Connection.TLSConfig.LoadTLSConfig(ctx)
+		if err != nil {
+			return rabbitmq.DialConfig{}, fmt.Errorf("failed to load TLS config: %w", err)
+		}
+		dialConfig.TLS = tlsConfig
+	}
+	return dialConfig, nil
+}
+
+// consume opens a channel on the connection, and starts consuming the queue
+// on it. The deliveries are closed when the channel is closed, or when ctx
+// is cancelled.
+func (r *rabbitmqReceiver) consume(ctx context.Context, queue string) (rabbitmq.Channel, <-chan amqp.Delivery, error) {
+	channel, err := r.connection.Channel()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open channel: %w", err)
+	}
+	if err := channel.Qos(r.config.PrefetchCount, 0, false); err != nil {
+		_ = channel.Close()
+		return nil, nil, fmt.Errorf("failed to set prefetch count: %w", err)
+	}
+	deliveries, err := channel.ConsumeWithContext(ctx, queue, "", false, false, false, false, nil)
+	if err != nil {
+		_ = channel.Close()
+		return nil, nil, fmt.Errorf("failed to consume from queue %q: %w", queue, err)
+	}
+	return channel, deliveries, nil
+}
+
+// run handles the deliveries of a queue one at a time, consuming the queue
+// again once the deliveries are closed, until the receiver is shut down.
+func (r *rabbitmqReceiver) run(c queueConsumer, channel rabbitmq.Channel, deliveries <-chan amqp.Delivery) {
+	defer r.running.Done()
+	for {
+		for msg := range deliveries {
+			r.handleMessage(msg, c)
+		}
+		if !channel.IsClosed() {
+			_ = channel.Close()
+		}
+		if channel, deliveries = r.reconsume(c.queue); deliveries == nil {
+			return
+		}
+	}
+}
+
+// reconsume consumes a queue again after its channel was closed, restoring
+// the connection if needed. It returns nil deliveries once the receiver is
+// shut down.
+func (r *rabbitmqReceiver) reconsume(queue string) (rabbitmq.Channel, <-chan amqp.Delivery) {
+	for {
+		select {
+		case <-r.ctx.Done():
+			return nil, nil
+		case <-time.After(r.config.Connection.ReconnectInterval):
+		}
+		r.settings.Logger.Info("consuming from queue was interrupted, resuming", zap.String("queue", queue))
+		if err := r.connection.ReconnectIfUnhealthy(); err != nil {
+			r.settings.Logger.Warn("failed to reconnect to RabbitMQ broker", zap.Error(err))
+			continue
+		}
+		channel, deliveries, err := r.consume(r.ctx, queue)
+		if err != nil {
+			r.settings.Logger.Warn("failed to resume consuming from queue", zap.String("queue", queue), zap.Error(err))
+			continue
+		}
+		return channel, deliveries
+	}
+}
+
+func (r *rabbitmqReceiver) handleMessage(msg amqp.Delivery, c queueConsumer) {
+	if r.ctx.Err() != nil {
+		// The message is returned to the queue when the channel is closed.
+		return
+	}
+	backOff := newExponentialBackOff(r.config.ErrorBackOff)
+	for {
+		err := r.acks.Consume(r.ctx, func(ctx context.Context) error {
+			return c.consumeMessage(ctx, msg)
+		})
+		if err == nil {
+			if err := msg.Ack(false); err != nil {
+				r.settings.Logger.Warn("failed to acknowledge message", zap.String("queue", c.queue), zap.Error(err))
+			}
+			return
+		}
+		if consumererror.IsPermanent(err) {
+			r.settings.Logger.Error("failed to consume message, rejecting it",
+				zap.String("queue", c.queue),
+				zap.Error(err),
+			)
+			if err := msg.Reject(false); err != nil {
+				r.settings.Logger.Warn("failed to reject message", zap.String("queue", c.queue), zap.Error(err))
+			}
+			return
+		}
+		if backOff != nil {
+			backOffDelay := backOff.NextBackOff()
+			if backOffDelay != backoff.Stop {
+				r.settings.Logger.Info("Backing off due to error from the next consumer.",
+					zap.Error(err),
+					zap.Duration("delay", backOffDelay),
+				)
+				select {
+				case <-r.ctx.Done():
+					return
+				case <-time.After(backOffDelay):
+					continue
+				}
+			}
+			r.settings.Logger.Warn("Stop error backoff because the configured max_elapsed_time is reached",
+				zap.Duration("max_elapsed_time", backOff.MaxElapsedTime),
+			)
+		}
+		r.settings.Logger.Error("failed to consume message, returning it to the queue",
+			zap.String("queue", c.queue),
+			zap.Error(err),
+		)
+		if err := msg.Nack(false, true); err != nil {
+			r.settings.Logger.Warn("failed to return message to the queue", zap.String("queue", c.queue), zap.Error(err))
+		}
+		return
+	}
+}
+
+func (r *rabbitmqReceiver) Shutdown(context.Context) error {
+	if r.cancel == nil {
+		return nil
+	}
+	// Cancel the messages being consumed, and wait for their handlers to
+	// return before closing the connection, so the consumed messages are
+	// acknowledged.
+	r.cancel()
+	r.running.Wait()
+	return r.connection.Close()
+}
+
+func newExponentialBackOff(config configretry.BackOffConfig) *backoff.ExponentialBackOff {
+	if !config.Enabled {
+		return nil
+	}
+	backOff := backoff.NewExponentialBackOff()
+	backOff.InitialInterval = config.InitialInterval
+	backOff.RandomizationFactor = config.RandomizationFactor
+	backOff.Multiplier = config.Multiplier
+	backOff.MaxInterval = config.MaxInterval
+	backOff.MaxElapsedTime = config.MaxElapsedTime
+	backOff.Reset()
+	return backOff
+}
+
+// consumeMessageFunc returns a consumeMessageFunc passing the body of the
+// messages to handle, with the ack ID of the context set on its resources for
+// end-to-end acknowledgement.
+func (r *rabbitmqReceiver) consumeMessageFunc(handle func(context.Context, []byte, func(pcommon.Resource)) error) consumeMessageFunc {
+	return func(ctx context.Context, msg amqp.Delivery) error {
+		if r.settings.Logger.Core().Enabled(zap.DebugLevel) {
+			r.settings.Logger.Debug("amqp message received",
+				zap.String("exchange", msg.Exchange),
+				zap.String("routing_key", msg.RoutingKey),
+				zap.Uint64("delivery_tag", msg.DeliveryTag),
+				zap.Bool("redelivered", msg.Redelivered),
+			)
+		}
+		return handle(ctx, msg.Body, func(res pcommon.Resource) {
+			ackextension.MarkResource(ctx, res)
+		})
+	}
+}
@@ -0,0 +1,438 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package rabbitmqqueuereceiver
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/ackextension"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/rabbitmq"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/rabbitmqqueuereceiver/internal/metadata"
+)
+
+func TestReceiver_logs(t *testing.T) {
+	client, config := newTestClient()
+	sink := &consumertest.LogsSink{}
+	r := newRabbitMQReceiver(config, receivertest.NewNopSettings(metadata.Type), client)
+	r.nextLogs = sink
+	startReceiver(t, r, componenttest.NewNopHost())
+
+	logs := newLogs("log message")
+	payload, err := (&plog.ProtoMarshaler{}).MarshalLogs(logs)
+	require.NoError(t, err)
+	acks := client.connection.channel(t, 0).deliver(payload)
+
+	require.Eventually(t, func() bool { return sink.LogRecordCount() == 1 }, 10*time.Second, 10*time.Millisecond)
+	assert.Equal(t, logs, sink.AllLogs()[0])
+	acks.assertOutcome(t, "ack")
+}
+
+func TestReceiver_consume(t *testing.T) {
+	client, config := newTestClient()
+	config.Connection.VHost = "otel"
+	config.Connection.Auth.Plain.Password = "pass"
+	config.PrefetchCount = 42
+	r := newRabbitMQReceiver(config, receivertest.NewNopSettings(metadata.Type), client)
+	r.nextLogs = consumertest.NewNop()
+	r.nextTraces = consumertest.NewNop()
+	startReceiver(t, r, componenttest.NewNopHost())
+
+	assert.Equal(t, rabbitmq.DialConfig{
+		URL:               defaultEndpoint,
+		Vhost:             "otel",
+		Auth:              &amqp.PlainAuth{Username: "user", Password: "pass"},
+		ConnectionTimeout: defaultConnectionTimeout,
+		Heartbeat:         defaultHeartbeat,
+		ConnectionName:    defaultConnectionName,
+	}, client.dialConfig)
+
+	// The signals share the connection, with a channel per queue.
+	logsChannel := client.connection.channel(t, 0)
+	assert.Equal(t, defaultLogsQueue, logsChannel.queue)
+	assert.Equal(t, 42, logsChannel.prefetchCount)
+	assert.False(t, logsChannel.autoAck)
+	tracesChannel := client.connection.channel(t, 1)
+	assert.Equal(t, defaultTracesQueue, tracesChannel.queue)
+	assert.Equal(t, 42, tracesChannel.prefetchCount)
+	assert.False(t, tracesChannel.autoAck)
+}
+
+func TestReceiver_errorBackOff(t *testing.T) {
+	client, config := newTestClient()
+	config.ErrorBackOff.InitialInterval = 10 * time.Millisecond
+	var calls atomic.Int64
+	r := newRabbitMQReceiver(config, receivertest.NewNopSettings(metadata.Type), client)
+	r.nextLogs = newLogsConsumer(func(context.Context, plog.Logs) error {
+		if calls.Add(1) < 3 {
+			return errors.New("temporary error")
+		}
+		return nil
+	})
+	startReceiver(t, r, componenttest.NewNopHost())
+
+	payload, err := (&plog.ProtoMarshaler{}).MarshalLogs(newLogs("log message"))
+	require.NoError(t, err)
+	acks := client.connection.channel(t, 0).deliver(payload)
+
+	acks.assertOutcome(t, "ack")
+	assert.Equal(t, int64(3), calls.Load())
+}
+
+func TestReceiver_errorRequeued(t *testing.T) {
+	client, config := newTestClient()
+	config.ErrorBackOff.Enabled = false
+	r := newRabbitMQReceiver(config, receivertest.NewNopSettings(metadata.Type), client)
+	r.nextLogs = newLogsConsumer(func(context.Context, plog.Logs) error {
+		return errors.New("temporary error")
+	})
+	startReceiver(t, r, componenttest.NewNopHost())
+
+	payload, err := (&plog.ProtoMarshaler{}).MarshalLogs(newLogs("log message"))
+	require.NoError(t, err)
+	acks := client.connection.channel(t, 0).deliver(payload)
+
+	acks.assertOutcome(t, "requeue")
+}
+
+func TestReceiver_permanentError(t *testing.T) {
+	client, config := newTestClient()
+	r := newRabbitMQReceiver(config, receivertest.NewNopSettings(metadata.Type), client)
+	r.nextLogs = newLogsConsumer(func(context.Context, plog.Logs) error {
+		return consumererror.NewPermanent(errors.New("permanent error"))
+	})
+	startReceiver(t, r, componenttest.NewNopHost())
+
+	payload, err := (&plog.ProtoMarshaler{}).MarshalLogs(newLogs("log message"))
+	require.NoError(t, err)
+	channel := client.connection.channel(t, 0)
+	channel.deliver(payload).assertOutcome(t, "reject")
+
+	// Messages which cannot be unmarshaled are rejected too.
+	channel.deliver([]byte("invalid")).assertOutcome(t, "reject")
+}
+
+func TestReceiver_reconsume(t *testing.T) {
+	client, config := newTestClient()
+	config.Connection.ReconnectInterval = 10 * time.Millisecond
+	sink := &consumertest.LogsSink{}
+	r := newRabbitMQReceiver(config, receivertest.NewNopSettings(metadata.Type), client)
+	r.nextLogs = sink
+	startReceiver(t, r, componenttest.NewNopHost())
+
+	// The broker closing the channel interrupts consuming the queue.
+	require.NoError(t, client.connection.channel(t, 0).Close())
+	channel := client.connection.channel(t, 1)
+	assert.Equal(t, defaultLogsQueue, channel.queue)
+	assert.Positive(t, client.connection.reconnects.Load())
+
+	payload, err := (&plog.ProtoMarshaler{}).MarshalLogs(newLogs("log message"))
+	require.NoError(t, err)
+	channel.deliver(payload).assertOutcome(t, "ack")
+	assert.Equal(t, 1, sink.LogRecordCount())
+}
+
+func TestReceiver_endToEndAck(t *testing.T) {
+	factory := ackextension.NewFactory()
+	ext, err := factory.Create(t.Context(), extensiontest.NewNopSettings(factory.Type()), factory.CreateDefaultConfig())
+	require.NoError(t, err)
+	ackExtensionID := component.MustNewID("ack")
+
+	client, config := newTestClient()
+	config.ErrorBackOff.Enabled = false
+	config.EndToEndAck = ackextension.EndToEndConfig{
+		Extension: &ackExtensionID,
+		Timeout:   100 * time.Millisecond,
+	}
+	// The pipeline only acknowledges the data once acknowledge is set.
+	var acknowledge atomic.Bool
+	r := newRabbitMQReceiver(config, receivertest.NewNopSettings(metadata.Type), client)
+	r.nextLogs = newLogsConsumer(func(_ context.Context, ld plog.Logs) error {
+		if acknowledge.Load() {
+			ackextension.AckResources(ext.(ackextension.AckExtension), ackextension.TakeLogsAcks(ld))
+		}
+		return nil
+	})
+	startReceiver(t, r, extensionsHost{ackExtensionID: ext})
+
+	payload, err := (&plog.ProtoMarshaler{}).MarshalLogs(newLogs("log message"))
+	require.NoError(t, err)
+	channel := client.connection.channel(t, 0)
+	channel.deliver(payload).assertOutcome(t, "requeue")
+
+	acknowledge.Store(true)
+	channel.deliver(payload).assertOutcome(t, "ack")
+}
+
+func TestReceiver_startErrors(t *testing.T) {
+	client, config := newTestClient()
+	client.err = errors.New("connection refused")
+	r := newRabbitMQReceiver(config, receivertest.NewNopSettings(metadata.Type), client)
+	r.nextLogs = consumertest.NewNop()
+	err := r.Start(t.Context(), componenttest.NewNopHost())
+	assert.ErrorContains(t, err, "failed to connect to RabbitMQ broker: connection refused")
+	assert.NoError(t, r.Shutdown(t.Context()))
+
+	client, config = newTestClient()
+	client.connection.channelErr = errors.New("channel error")
+	r = newRabbitMQReceiver(config, receivertest.NewNopSettings(metadata.Type), client)
+	r.nextLogs = consumertest.NewNop()
+	err = r.Start(t.Context(), componenttest.NewNopHost())
+	assert.ErrorContains(t, err, "failed to open channel: channel error")
+	assert.True(t, client.connection.IsClosed())
+	assert.NoError(t, r.Shutdown(t.Context()))
+
+	client, config = newTestClient()
+	config.Logs.Encoding = "unknown"
+	r = newRabbitMQReceiver(config, receivertest.NewNopSettings(metadata.Type), client)
+	r.nextLogs = consumertest.NewNop()
+	err = r.Start(t.Context(), componenttest.NewNopHost())
+	assert.EqualError(t, err, `unrecognized logs encoding "unknown"`)
+
+	client, config = newTestClient()
+	ackExtensionID := component.MustNewID("ack")
+	config.EndToEndAck.Extension = &ackExtensionID
+	r = newRabbitMQReceiver(config, receivertest.NewNopSettings(metadata.Type), client)
+	r.nextLogs = consumertest.NewNop()
+	err = r.Start(t.Context(), componenttest.NewNopHost())
+	assert.EqualError(t, err, `specified ack extension with id "ack" could not be found`)
+}
+
+func TestReceiver_shutdownNotStarted(t *testing.T) {
+	client, config := newTestClient()
+	r := newRabbitMQReceiver(config, receivertest.NewNopSettings(metadata.Type), client)
+	assert.NoError(t, r.Shutdown(t.Context()))
+}
+
+func newTestClient() (*fakeClient, *Config) {
+	config := createDefaultConfig().(*Config)
+	config.Connection.Auth.Plain.Username = "user"
+	return &fakeClient{connection: &fakeConnection{}}, config
+}
+
+func startReceiver(tb testing.TB, r component.Component, host component.Host) {
+	require.NoError(tb, r.Start(tb.Context(), host))
+	tb.Cleanup(func() {
+		assert.NoError(tb, r.Shutdown(context.Background()))
+	})
+}
+
+func newLogsConsumer(consume consumer.ConsumeLogsFunc) consumer.Logs {
+	logs, _ := consumer.NewLogs(consume)
+	return logs
+}
+
+func newLogs(body string) plog.Logs {
+	logs := plog.NewLogs()
+	logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr(body)
+	return logs
+}
+
+// fakeClient dials a fakeConnection.
+type fakeClient struct {
+	connection *fakeConnection
+	err        error
+	dialConfig rabbitmq.DialConfig
+}
+
+func (c *fakeClient) DialConfig(config rabbitmq.DialConfig) (rabbitmq.Connection, error) {
+	c.dialConfig = config
+	if c.err != nil {
+		return nil, c.err
+	}
+	return c.connection, nil
+}
+
+// fakeConnection opens fakeChannels.
+type fakeConnection struct {
+	mu         sync.Mutex
+	channels   []*fakeChannel
+	channelErr error
+	closed     bool
+	reconnects atomic.Int64
+}
+
+func (c *fakeConnection) ReconnectIfUnhealthy() error {
+	c.reconnects.Add(1)
+	return nil
+}
+
+func (c *fakeConnection) IsClosed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.closed
+}
+
+func (c *fakeConnection) Channel() (rabbitmq.Channel, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.channelErr != nil {
+		return nil, c.channelErr
+	}
+	channel := &fakeChannel{deliveries: make(chan amqp.Delivery, 10)}
+	c.channels = append(c.channels, channel)
+	return channel, nil
+}
+
+func (*fakeConnection) NotifyClose(receiver chan *amqp.Error) chan *amqp.Error {
+	return receiver
+}
+
+func (c *fakeConnection) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+	for _, channel := range c.channels {
+		_ = channel.Close()
+	}
+	return nil
+}
+
+// channel waits for a queue to be consumed on the i-th channel, and returns
+// the channel.
+func (c *fakeConnection) channel(tb testing.TB, i int) *fakeChannel {
+	require.Eventually(tb, func() bool {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		return len(c.channels) > i && c.channels[i].consuming()
+	}, 10*time.Second, 10*time.Millisecond)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.channels[i]
+}
+
+// fakeChannel delivers the messages passed to deliver once consumed.
+type fakeChannel struct {
+	mu            sync.Mutex
+	queue         string
+	prefetchCount int
+	autoAck       bool
+	deliveries    chan amqp.Delivery
+	closeOnce     sync.Once
+	closed        atomic.Bool
+	deliveryTag   uint64
+}
+
+func (*fakeChannel) Confirm(bool) error {
+	return nil
+}
+
+func (*fakeChannel) PublishWithDeferredConfirmWithContext(context.Context, string, string, bool, bool, amqp.Publishing) (rabbitmq.DeferredConfirmation, error) {
+	return nil, errors.New("not supported")
+}
+
+func (c *fakeChannel) Qos(prefetchCount, _ int, _ bool) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.prefetchCount = prefetchCount
+	return nil
+}
+
+func (c *fakeChannel) ConsumeWithContext(ctx context.Context, queue, _ string, autoAck, _, _, _ bool, _ amqp.Table) (<-chan amqp.Delivery, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.queue = queue
+	c.autoAck = autoAck
+	go func() {
+		<-ctx.Done()
+		c.closeDeliveries()
+	}()
+	return c.deliveries, nil
+}
+
+func (c *fakeChannel) consuming() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.queue != ""
+}
+
+func (c *fakeChannel) IsClosed() bool {
+	return c.closed.Load()
+}
+
+func (c *fakeChannel) Close() error {
+	c.closed.Store(true)
+	c.closeDeliveries()
+	return nil
+}
+
+func (c *fakeChannel) closeDeliveries() {
+	c.closeOnce.Do(func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		close(c.deliveries)
+	})
+}
+
+// deliver delivers a message, and returns the acknowledger recording how it
+// was acknowledged.
+func (c *fakeChannel) deliver(body []byte) *fakeAcknowledger {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.deliveryTag++
+	acks := &fakeAcknowledger{}
+	c.deliveries <- amqp.Delivery{
+		Acknowledger: acks,
+		DeliveryTag:  c.deliveryTag,
+		Body:         body,
+	}
+	return acks
+}
+
+// fakeAcknowledger records the outcome of a delivery: ack, requeue, nack or
+// reject.
+type fakeAcknowledger struct {
+	outcome atomic.Value
+}
+
+func (a *fakeAcknowledger) Ack(uint64, bool) error {
+	a.outcome.Store("ack")
+	return nil
+}
+
+func (a *fakeAcknowledger) Nack(_ uint64, _, requeue bool) error {
+	if requeue {
+		a.outcome.Store("requeue")
+	} else {
+		a.outcome.Store("nack")
+	}
+	return nil
+}
+
+func (a *fakeAcknowledger) Reject(_ uint64, requeue bool) error {
+	if requeue {
+		a.outcome.Store("requeue")
+	} else {
+		a.outcome.Store("reject")
+	}
+	return nil
+}
+
+func (a *fakeAcknowledger) assertOutcome(tb testing.TB, expected string) {
+	assert.Eventually(tb, func() bool {
+		return a.outcome.Load() == expected
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
+type extensionsHost map[component.ID]component.Component
+
+func (h extensionsHost) GetExtensions() map[component.ID]component.Component {
+	return h
+}